@@ -35,6 +35,12 @@ func GenerateSlug(text string) string {
 	return base + "-" + suffix
 }
 
+// SlugBase returns the slug of text without the unique suffix, or "" when text
+// has no usable characters. Texts with equal bases only differ in their suffix.
+func SlugBase(text string) string {
+	return sanitizeToSlugCore(text)
+}
+
 // sanitizeToSlugCore performs normalization & transliteration then builds the core slug (without unique suffix)
 func sanitizeToSlugCore(s string) string {
 	s = strings.TrimSpace(s)
@@ -48,6 +48,10 @@ func main() {
 
 	timeout := time.Duration(env.CtxTSeconds) * time.Second
 
+	// background loops started by the routes stop when the server shuts down
+	appCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
 	// Gin router
 	router := gin.Default()
 	// router.Use(middleware.RequestLogger())
@@ -104,7 +108,7 @@ func main() {
 			c.JSON(http.StatusOK, gin.H{"status": "ok", "db": "skipped"})
 		})
 	} else {
-		routers.Setup(env, timeout, app.Mongo.Database(dbName), router)
+		routers.Setup(appCtx, env, timeout, app.Mongo.Database(dbName), router)
 	}
 
 	srv := &http.Server{
@@ -129,6 +133,7 @@ func main() {
 	<-quit
 
 	logger.Log.Info().Msg("Shutdown Server...")
+	stopBackground()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -0,0 +1,122 @@
+// Command ocr-eval measures how much image preprocessing helps OCR. For every
+// image in a fixture directory that has a transcript next to it (menu.jpg and
+// menu.txt, the layout of OCR_FIXTURE_DIR), it runs tesseract on the original
+// and on the preprocessed image and prints the accuracy of both.
+//
+//	go run ./cmd/ocr-eval -dir ./testdata/ocr
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+)
+
+func main() {
+	dir := flag.String("dir", "./testdata/ocr", "directory of menu images with <name>.txt transcripts")
+	binary := flag.String("tesseract", "", "tesseract binary (default on PATH)")
+	langs := flag.String("langs", "", "tesseract languages (default eng+amh)")
+	crop := flag.Bool("crop", false, "crop margins while preprocessing")
+	timeout := flag.Duration("timeout", 2*time.Minute, "timeout per OCR run")
+	flag.Parse()
+
+	if err := run(*dir, *binary, *langs, *crop, *timeout); err != nil {
+		fmt.Fprintln(os.Stderr, "ocr-eval:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, binary, langs string, crop bool, timeout time.Duration) error {
+	ocr, err := services.NewTesseractOCRService(binary, langs)
+	if err != nil {
+		return err
+	}
+	images, err := fixtureImages(dir)
+	if err != nil {
+		return err
+	}
+	if len(images) == 0 {
+		return fmt.Errorf("no images with transcripts in %s", dir)
+	}
+	tmp, err := os.MkdirTemp("", "ocr-eval-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	read := func(path string) (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		doc, err := ocr.ExtractText(ctx, path)
+		if err != nil {
+			return "", err
+		}
+		return doc.Text, nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "IMAGE\tORIGINAL\tPREPROCESSED\tSKEW\tSIZE")
+	var before, after float64
+	for _, img := range images {
+		truth, err := os.ReadFile(strings.TrimSuffix(img, filepath.Ext(img)) + ".txt")
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(img)
+		if err != nil {
+			return err
+		}
+		processed, report, err := services.PreprocessImage(data, services.PreprocessOptions{Crop: crop})
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(img), err)
+		}
+		processedPath := filepath.Join(tmp, filepath.Base(img)+".png")
+		if err := os.WriteFile(processedPath, processed, 0o600); err != nil {
+			return err
+		}
+		original, err := read(img)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(img), err)
+		}
+		cleaned, err := read(processedPath)
+		if err != nil {
+			return fmt.Errorf("%s (preprocessed): %w", filepath.Base(img), err)
+		}
+		a, b := services.OCRAccuracy(string(truth), original), services.OCRAccuracy(string(truth), cleaned)
+		before += a
+		after += b
+		fmt.Fprintf(w, "%s\t%.1f%%\t%.1f%%\t%.1f°\t%dx%d\n", filepath.Base(img), a*100, b*100, report.SkewDegrees, report.Width, report.Height)
+	}
+	n := float64(len(images))
+	fmt.Fprintf(w, "MEAN\t%.1f%%\t%.1f%%\t\t\n", before/n*100, after/n*100)
+	return w.Flush()
+}
+
+// fixtureImages lists the images of dir that have a transcript.
+func fixtureImages(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var images []string
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if e.IsDir() || (ext != ".jpg" && ext != ".jpeg" && ext != ".png" && ext != ".webp") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if _, err := os.Stat(strings.TrimSuffix(path, filepath.Ext(path)) + ".txt"); err == nil {
+			images = append(images, path)
+		}
+	}
+	sort.Strings(images)
+	return images, nil
+}
@@ -39,6 +39,7 @@ func InitApp() (*Application, error) {
 		RefreshTokenCollection:  env.RefreshTokenCollection,
 		PasswordResetCollection: env.PasswordResetCollection,
 		OtpCollection:           env.OtpCollection,
+		OCRJobCollection:        env.OCRJobCollection,
 	})
 
 	return app, nil
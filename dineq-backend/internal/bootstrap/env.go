@@ -110,6 +110,51 @@ type Env struct {
 	CloudinaryName   string `mapstructure:"CLD_NAME"`
 
 	OCRJobCollection string `mapstructure:"OCR_JOB_COLLECTION"`
+	// parse results awaiting manager review
+	AIParseResultCollection string `mapstructure:"AI_PARSE_RESULT_COLLECTION"`
+	// OCR worker pool: concurrent jobs, lease length and idle polling
+	OCRWorkers             int `mapstructure:"OCR_WORKERS"`
+	OCRLeaseSeconds        int `mapstructure:"OCR_LEASE_SECONDS"`
+	OCRPollIntervalSeconds int `mapstructure:"OCR_POLL_INTERVAL_SECONDS"`
+	OCRMaxAttempts         int `mapstructure:"OCR_MAX_ATTEMPTS"`
+
+	// OCR backend: veryfi (default), tesseract or fixture
+	OCRProvider           string `mapstructure:"OCR_PROVIDER"`
+	OCRTesseractPath      string `mapstructure:"OCR_TESSERACT_PATH"`
+	OCRTesseractLanguages string `mapstructure:"OCR_TESSERACT_LANGS"`
+	OCRFixtureDir         string `mapstructure:"OCR_FIXTURE_DIR"`
+	// multi-page uploads: pdftoppm renders PDF pages; at most OCRMaxPages per job
+	OCRPDFRendererPath string `mapstructure:"OCR_PDFTOPPM_PATH"`
+	OCRMaxPages        int    `mapstructure:"OCR_MAX_PAGES"`
+	// page images are straightened and cleaned up before OCR unless disabled
+	OCRPreprocess     bool `mapstructure:"OCR_PREPROCESS"`
+	OCRPreprocessCrop bool `mapstructure:"OCR_PREPROCESS_CROP"`
+	// days page images of completed / failed or cancelled jobs are kept; 0 keeps them
+	OCRRetentionDays       int `mapstructure:"OCR_RETENTION_DAYS"`
+	OCRFailedRetentionDays int `mapstructure:"OCR_FAILED_RETENTION_DAYS"`
+	// usage ledger and monthly quotas per restaurant and per user; 0 is unlimited
+	UsageCollection                   string `mapstructure:"USAGE_COLLECTION"`
+	UsageQuotaRestaurantOCRPages      int64  `mapstructure:"USAGE_QUOTA_RESTAURANT_OCR_PAGES"`
+	UsageQuotaRestaurantTokens        int64  `mapstructure:"USAGE_QUOTA_RESTAURANT_TOKENS"`
+	UsageQuotaRestaurantImageSearches int64  `mapstructure:"USAGE_QUOTA_RESTAURANT_IMAGE_SEARCHES"`
+	UsageQuotaUserOCRPages            int64  `mapstructure:"USAGE_QUOTA_USER_OCR_PAGES"`
+	UsageQuotaUserTokens              int64  `mapstructure:"USAGE_QUOTA_USER_TOKENS"`
+	UsageQuotaUserImageSearches       int64  `mapstructure:"USAGE_QUOTA_USER_IMAGE_SEARCHES"`
+	// uploads matching a completed job this recent return its result; 0 disables
+	OCRDuplicateWindowDays int `mapstructure:"OCR_DUPLICATE_WINDOW_DAYS"`
+	// restaurant webhooks and their delivery log; 0 takes the defaults
+	WebhookCollection         string `mapstructure:"WEBHOOK_COLLECTION"`
+	WebhookDeliveryCollection string `mapstructure:"WEBHOOK_DELIVERY_COLLECTION"`
+	WebhookMaxAttempts        int    `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookBackoffSeconds     int    `mapstructure:"WEBHOOK_BACKOFF_SECONDS"`
+	WebhookMaxBackoffSeconds  int    `mapstructure:"WEBHOOK_MAX_BACKOFF_SECONDS"`
+	WebhookTimeoutSeconds     int    `mapstructure:"WEBHOOK_TIMEOUT_SECONDS"`
+
+	// menu structuring model: gemini (default), openai (any OpenAI-compatible API) or fake
+	AIProvider string `mapstructure:"AI_PROVIDER"`
+	AIBaseURL  string `mapstructure:"AI_BASE_URL"`
+	AIAPIKey   string `mapstructure:"AI_API_KEY"`
+	AIModel    string `mapstructure:"AI_MODEL"`
 
 	VeryfiClientID     string `mapstructure:"VERIFY_CLIENT_ID"`
 	VeryfiClientSecret string `mapstructure:"VERIFY_CLIENT_SECRET"`
@@ -121,10 +166,18 @@ type Env struct {
 
 	// menu collection
 	MenuCollection string `mapstructure:"MENU_COLLECTION"`
+	// menu version (snapshot) collection
+	MenuVersionCollection string `mapstructure:"MENU_VERSION_COLLECTION"`
+	// how often scheduled menu publish/unpublish transitions are applied
+	MenuSchedulerIntervalSeconds int `mapstructure:"MENU_SCHEDULER_INTERVAL_SECONDS"`
 	// qr code collection
 	QRCodeCollection string `mapstructure:"QR_CODE_COLLECTION"`
 	ItemCollection   string `mapstructure:"ITEM_COLLECTION"`
 	QRCodeContent    string `mapstructure:"QR_CODE_CONTENT"`
+	// scans of QR codes through their short links
+	QRScanCollection string `mapstructure:"QR_SCAN_COLLECTION"`
+	// public base URL of this API; new QR codes encode {base}/q/{id}
+	QRRedirectBaseURL string `mapstructure:"QR_REDIRECT_BASE_URL"`
 
 	// view event collection
 	ViewEventCollection string `mapstructure:"VIEW_EVENT_COLLECTION"`
@@ -196,12 +249,81 @@ func NewEnv() (*Env, error) {
 	env.CloudinarySecret = os.Getenv("CLD_SECRET")
 	env.CloudinaryName = os.Getenv("CLD_NAME")
 	env.OCRJobCollection = os.Getenv("OCR_JOB_COLLECTION")
+	env.AIParseResultCollection = os.Getenv("AI_PARSE_RESULT_COLLECTION")
+	if env.AIParseResultCollection == "" {
+		env.AIParseResultCollection = "ai_parse_results"
+	}
+	// zero values fall back to the worker pool defaults
+	env.OCRWorkers, _ = strconv.Atoi(os.Getenv("OCR_WORKERS"))
+	env.OCRLeaseSeconds, _ = strconv.Atoi(os.Getenv("OCR_LEASE_SECONDS"))
+	env.OCRPollIntervalSeconds, _ = strconv.Atoi(os.Getenv("OCR_POLL_INTERVAL_SECONDS"))
+	env.OCRMaxAttempts, _ = strconv.Atoi(os.Getenv("OCR_MAX_ATTEMPTS"))
+	env.OCRProvider = os.Getenv("OCR_PROVIDER")
+	env.OCRTesseractPath = os.Getenv("OCR_TESSERACT_PATH")
+	env.OCRTesseractLanguages = os.Getenv("OCR_TESSERACT_LANGS")
+	env.OCRFixtureDir = os.Getenv("OCR_FIXTURE_DIR")
+	env.OCRPDFRendererPath = os.Getenv("OCR_PDFTOPPM_PATH")
+	env.OCRMaxPages, _ = strconv.Atoi(os.Getenv("OCR_MAX_PAGES"))
+	if env.OCRMaxPages <= 0 {
+		env.OCRMaxPages = 20
+	}
+	// fixture text is looked up by image name, so the fixture provider reads
+	// the originals unless preprocessing is asked for
+	switch strings.ToLower(os.Getenv("OCR_PREPROCESS")) {
+	case "true":
+		env.OCRPreprocess = true
+	case "false":
+		env.OCRPreprocess = false
+	default:
+		env.OCRPreprocess = env.OCRProvider != "fixture"
+	}
+	env.OCRPreprocessCrop = strings.ToLower(os.Getenv("OCR_PREPROCESS_CROP")) == "true"
+	env.OCRRetentionDays, _ = strconv.Atoi(os.Getenv("OCR_RETENTION_DAYS"))
+	env.OCRFailedRetentionDays, _ = strconv.Atoi(os.Getenv("OCR_FAILED_RETENTION_DAYS"))
+	env.UsageCollection = os.Getenv("USAGE_COLLECTION")
+	if env.UsageCollection == "" {
+		env.UsageCollection = "usage_ledger"
+	}
+	env.UsageQuotaRestaurantOCRPages, _ = strconv.ParseInt(os.Getenv("USAGE_QUOTA_RESTAURANT_OCR_PAGES"), 10, 64)
+	env.UsageQuotaRestaurantTokens, _ = strconv.ParseInt(os.Getenv("USAGE_QUOTA_RESTAURANT_TOKENS"), 10, 64)
+	env.UsageQuotaRestaurantImageSearches, _ = strconv.ParseInt(os.Getenv("USAGE_QUOTA_RESTAURANT_IMAGE_SEARCHES"), 10, 64)
+	env.UsageQuotaUserOCRPages, _ = strconv.ParseInt(os.Getenv("USAGE_QUOTA_USER_OCR_PAGES"), 10, 64)
+	env.UsageQuotaUserTokens, _ = strconv.ParseInt(os.Getenv("USAGE_QUOTA_USER_TOKENS"), 10, 64)
+	env.UsageQuotaUserImageSearches, _ = strconv.ParseInt(os.Getenv("USAGE_QUOTA_USER_IMAGE_SEARCHES"), 10, 64)
+	env.OCRDuplicateWindowDays = 30
+	if days, err := strconv.Atoi(os.Getenv("OCR_DUPLICATE_WINDOW_DAYS")); err == nil {
+		env.OCRDuplicateWindowDays = days
+	}
+	env.WebhookCollection = os.Getenv("WEBHOOK_COLLECTION")
+	if env.WebhookCollection == "" {
+		env.WebhookCollection = "webhooks"
+	}
+	env.WebhookDeliveryCollection = os.Getenv("WEBHOOK_DELIVERY_COLLECTION")
+	if env.WebhookDeliveryCollection == "" {
+		env.WebhookDeliveryCollection = "webhook_deliveries"
+	}
+	env.WebhookMaxAttempts, _ = strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+	env.WebhookBackoffSeconds, _ = strconv.Atoi(os.Getenv("WEBHOOK_BACKOFF_SECONDS"))
+	env.WebhookMaxBackoffSeconds, _ = strconv.Atoi(os.Getenv("WEBHOOK_MAX_BACKOFF_SECONDS"))
+	env.WebhookTimeoutSeconds, _ = strconv.Atoi(os.Getenv("WEBHOOK_TIMEOUT_SECONDS"))
+	env.AIProvider = os.Getenv("AI_PROVIDER")
+	env.AIBaseURL = os.Getenv("AI_BASE_URL")
+	env.AIAPIKey = os.Getenv("AI_API_KEY")
+	env.AIModel = os.Getenv("AI_MODEL")
 	env.VeryfiClientID = os.Getenv("VERIFY_CLIENT_ID")
 	env.VeryfiClientSecret = os.Getenv("VERIFY_CLIENT_SECRET")
 	env.VeryfiAPIKey = os.Getenv("VERIFY_API_KEY")
 	env.VeryfiUsername = os.Getenv("VERIFY_USERNAME")
 	env.NotificationCollection = os.Getenv("NOTIFICATION_COLLECTION")
 	env.MenuCollection = os.Getenv("MENU_COLLECTION")
+	env.MenuVersionCollection = os.Getenv("MENU_VERSION_COLLECTION")
+	if env.MenuVersionCollection == "" {
+		env.MenuVersionCollection = "menu_versions"
+	}
+	env.MenuSchedulerIntervalSeconds, _ = strconv.Atoi(os.Getenv("MENU_SCHEDULER_INTERVAL_SECONDS"))
+	if env.MenuSchedulerIntervalSeconds <= 0 {
+		env.MenuSchedulerIntervalSeconds = 60
+	}
 	env.QRCodeCollection = os.Getenv("QR_CODE_COLLECTION")
 	env.ItemCollection = os.Getenv("ITEM_COLLECTION")
 	env.ViewEventCollection = os.Getenv("VIEW_EVENT_COLLECTION")
@@ -225,6 +347,11 @@ func NewEnv() (*Env, error) {
 		}
 	}
 	env.QRCodeContent = os.Getenv("QR_CODE_CONTENT")
+	env.QRScanCollection = os.Getenv("QR_SCAN_COLLECTION")
+	if env.QRScanCollection == "" {
+		env.QRScanCollection = "qr_scans"
+	}
+	env.QRRedirectBaseURL = os.Getenv("QR_REDIRECT_BASE_URL")
 	env.PasswordResetSessionExpiry, _ = strconv.Atoi(os.Getenv("PASSWORD_RESET_SESSION_EXPIRE_MINUTES"))
 	env.PasswordResetSessionCollection = os.Getenv("PASSWORD_RESET_SESSION_COLLECTION")
 
@@ -5,45 +5,168 @@ import (
 	"time"
 )
 
-type ItemData struct {
-	Name  string  `json:"name"`
-	Price float64 `json:"price"`
+// ParseResultStatus tracks an AI parse result through manager review.
+type ParseResultStatus string
+
+const (
+	ParseResultPendingReview ParseResultStatus = "pending_review"
+	ParseResultApproved      ParseResultStatus = "approved"
+)
+
+// ParsedItemStatus is a manager's decision on one parsed item.
+type ParsedItemStatus string
+
+const (
+	ParsedItemPending  ParsedItemStatus = "pending"
+	ParsedItemAccepted ParsedItemStatus = "accepted"
+	ParsedItemEdited   ParsedItemStatus = "edited"
+	ParsedItemRejected ParsedItemStatus = "rejected"
+)
+
+// Reasons a parsed item gets a lower confidence.
+const (
+	ParseFlagPriceMissing       = "price_missing"
+	ParseFlagPriceNotInSource   = "price_not_in_source"
+	ParseFlagNameNotInSource    = "name_not_in_source"
+	ParseFlagDuplicateName      = "duplicate_name"
+	ParseFlagTranslationMissing = "translation_missing"
+)
+
+// ParsedItem is one menu item read by the model, awaiting review.
+type ParsedItem struct {
+	ID       string
+	Tab      string
+	Category string
+	Item     Item
+	// Original is the item as the model produced it, kept once a manager edits it
+	Original *Item
+	// Confidence is 0..1; Flags say what lowered it
+	Confidence float64
+	Flags      []string
+	Status     ParsedItemStatus
+	ReviewedBy string
+	ReviewedAt *time.Time
+}
+
+// Kept reports whether the item goes into the menu on approval.
+func (p *ParsedItem) Kept() bool {
+	return p.Status == ParsedItemAccepted || p.Status == ParsedItemEdited
 }
 
-type CategoryData struct {
-	Name  string     `json:"name"`
-	Items []ItemData `json:"items"`
+// AIParseResult is the output of an OCR job, held for review until a manager
+// approves it into a draft menu.
+type AIParseResult struct {
+	ID           string
+	OCRJobID     string
+	RestaurantID string
+	UserID       string
+	RawText      string
+	Items        []ParsedItem
+	// ConfidenceScore is the mean item confidence
+	ConfidenceScore float64
+	Status          ParseResultStatus
+	// TargetMenuID, when set, is the existing menu the reviewed items are
+	// merged into on approval instead of creating a new draft
+	TargetMenuID string
+	// MenuID is the draft created, or the menu merged into, on approval
+	MenuID     string
+	ApprovedBy string
+	ApprovedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
-type TabData struct {
-	Name       string         `json:"name"`
-	Categories []CategoryData `json:"categories"`
+// FindItem returns the parsed item with the given ID, or nil.
+func (r *AIParseResult) FindItem(id string) *ParsedItem {
+	for i := range r.Items {
+		if r.Items[i].ID == id {
+			return &r.Items[i]
+		}
+	}
+	return nil
 }
 
-type StructuredData struct {
-	Tabs []TabData `json:"tabs"`
+// PendingItems counts the items not reviewed yet.
+func (r *AIParseResult) PendingItems() int {
+	n := 0
+	for _, it := range r.Items {
+		if it.Status == ParsedItemPending {
+			n++
+		}
+	}
+	return n
 }
 
-type AIParseResult struct {
-	ID              string         `json:"id"`
-	OCRJobID        string         `json:"ocr_job_id"`
-	RawText         string         `json:"raw_text"`
-	StructuredData  StructuredData `json:"structured_data"`
-	ConfidenceScore float64        `json:"confidence_score"`
-	CreatedAt       time.Time      `json:"created_at"`
-	UpdatedAt       time.Time      `json:"updated_at"`
+// ParsedItemReview is a manager's decision on one item. Status is accepted or
+// rejected; an accepted review with Edit replaces the item and marks it edited.
+// Tab and Category, when set, move the item.
+type ParsedItemReview struct {
+	Status   ParsedItemStatus
+	Edit     *Item
+	Tab      string
+	Category string
+}
+
+// How a parsed item was matched to an existing menu item.
+const (
+	MergeMatchSlug   = "slug"
+	MergeMatchName   = "name"
+	MergeMatchNameAm = "name_am"
+	MergeMatchFuzzy  = "fuzzy_name"
+)
+
+// MenuItemChange is one line of a merge change set.
+type MenuItemChange struct {
+	// ParsedItemID is empty for removed items
+	ParsedItemID string `json:"parsed_item_id,omitempty"`
+	// ItemID is the existing menu item; empty for added items
+	ItemID    string  `json:"item_id,omitempty"`
+	Name      string  `json:"name"`
+	OldPrice  float64 `json:"old_price,omitempty"`
+	NewPrice  float64 `json:"new_price,omitempty"`
+	MatchedBy string  `json:"matched_by,omitempty"`
+}
+
+// MenuChangeSet is what merging a parse result into an existing menu does.
+// Existing items matched by a parsed item keep their ID, reviews, ratings and
+// view counts; items no parsed item matched are removed unless kept.
+type MenuChangeSet struct {
+	MenuID       string           `json:"menu_id"`
+	Added        []MenuItemChange `json:"added"`
+	Removed      []MenuItemChange `json:"removed"`
+	PriceChanged []MenuItemChange `json:"price_changed"`
+	Unchanged    int              `json:"unchanged"`
 }
 
 type IAIParseResultUseCase interface {
-	CreateAIParseResult(result *AIParseResult) error
 	GetAIParseResultByID(id string) (*AIParseResult, error)
-	ApproveParseResult(id string) error
+	ReviewItem(id, itemID, userID string, review ParsedItemReview) (*AIParseResult, error)
+	// PreviewMerge returns the change set approval would apply to the target
+	// menu of a result uploaded in merge mode.
+	PreviewMerge(id string) (*MenuChangeSet, error)
+	// ApproveParseResult applies the accepted and edited items once every item
+	// is reviewed. menu carries the restaurant, which must be the one the menu
+	// was read for (ErrForbidden otherwise), the name and author. Without a
+	// target menu a draft is created and menu is filled in as CreateMenu does;
+	// with one the change set is merged into it (keeping unmatched items when
+	// keepRemoved is set), menu is loaded with the result and the applied change
+	// set is returned.
+	ApproveParseResult(id string, menu *Menu, keepRemoved bool) (*MenuChangeSet, error)
 	DeleteAIParseResult(id string) error
-	StructureMenu(result *AIParseResult) (Menu, error)
 }
 
 type IAIParseResultRepository interface {
 	Create(ctx context.Context, result *AIParseResult) error
 	GetByID(ctx context.Context, id string) (*AIParseResult, error)
+	// UpdateItem replaces one item of a result still pending review;
+	// ErrParseResultApproved otherwise.
+	UpdateItem(ctx context.Context, id string, item ParsedItem) error
+	// MarkApproved moves a pending result to approved, or returns
+	// ErrParseResultApproved when another request got there first.
+	MarkApproved(ctx context.Context, id, approvedBy string, at time.Time) error
+	SetMenuID(ctx context.Context, id, menuID string) error
+	// Reopen puts an approved result back to pending review, used when the
+	// menu could not be created.
+	Reopen(ctx context.Context, id string) error
 	Delete(ctx context.Context, id string) error
 }
@@ -64,6 +64,8 @@ var (
 	ErrMenuNotPublished               = errors.New("menu not published")
 	ErrFailedToUpdateQRStatus         = errors.New("failed to update qr status")
 	ErrQRCodeNotFound                 = errors.New("qr code not found")
+	ErrQRCodeInactive                 = errors.New("qr code is inactive")
+	ErrQRCodeExpired                  = errors.New("qr code has expired")
 	ErrFailedToGetQRCode              = errors.New("failed to get qr code")
 	ErrPasswordShortLen               = errors.New("password must be at least 8 characters long")
 	ErrPasswordMustContainUpperLetter = errors.New("password must contain at least one uppercase letter")
@@ -72,6 +74,24 @@ var (
 	ErrPasswordMustContainSpecialChar = errors.New("password must contain at least one special character")
 	ErrFailedToDeleteQRCode           = errors.New("failed to delete qr code")
 	ErrMenuItemNotFound               = errors.New("menu item not found")
+	ErrMenuVersionNotFound            = errors.New("menu version not found")
+	ErrMenuDraftChanged               = errors.New("menu draft changed while publishing")
+	ErrInvalidModifierGroups          = errors.New("invalid modifier groups")
+	ErrUnsupportedImportFormat        = errors.New("unsupported import format")
+	ErrInvalidMenuImport              = errors.New("menu import has invalid rows")
+	ErrTranslationUnavailable         = errors.New("translation service unavailable")
+	ErrOCRJobLeaseLost                = errors.New("ocr job lease lost")
+	ErrOCRJobNotCancellable           = errors.New("ocr job already finished")
+	ErrUsageQuotaExceeded             = errors.New("usage quota exceeded")
+	ErrParseResultNotFound            = errors.New("parse result not found")
+	ErrParseResultApproved            = errors.New("parse result already approved")
+	ErrParseResultNotReviewed         = errors.New("parse result has unreviewed items")
+	ErrWebhookNotFound                = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound        = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL              = errors.New("invalid webhook url")
+	ErrInvalidQRLogo                  = errors.New("qr logo must be an http(s) or data url of an image")
+	ErrUnsupportedQRLogo              = errors.New("svg logos cannot be used in pdf qr codes")
+	ErrNoManagedRestaurant            = errors.New("you do not manage a restaurant")
 )
 
 var (
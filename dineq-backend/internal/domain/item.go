@@ -6,38 +6,50 @@ import (
 )
 
 type Item struct {
-	ID              string           `json:"id"`
-	Name            string           `json:"name"`
-	NameAm          string           `json:"name_am"`
-	Slug            string           `json:"slug"`
-	MenuSlug        string           `json:"menu_slug"`
-	Description     string           `json:"description"`
-	DescriptionAm   string           `json:"description_am"`
-	Image           []string         `json:"image"`
-	ThumbnailImages []string         `json:"thumbnail_images"`
-	Price           float64          `json:"price"`
-	Currency        string           `json:"currency"`
-	Allergies       []string         `json:"allergies"`
-	AllergiesAm     string           `json:"allergies_am"`
-	UserImages      []string         `json:"user_images"`
-	TabTags         []string         `json:"tab_tags"`
-	TabTagsAm       []string         `json:"tab_tags_am"`
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Slug            string   `json:"slug"`
+	MenuSlug        string   `json:"menu_slug"`
+	Description     string   `json:"description"`
+	Image           []string `json:"image"`
+	ThumbnailImages []string `json:"thumbnail_images"`
+	Price           float64  `json:"price"`
+	Currency        string   `json:"currency"`
+	Allergies       []string `json:"allergies"`
+	UserImages      []string `json:"user_images"`
+	TabTags         []string `json:"tab_tags"`
+	// Category names the item's category within its first tab
+	Category        string           `json:"category,omitempty"`
 	Calories        int              `json:"calories"`
 	Protein         int              `json:"protein"`
 	Carbs           int              `json:"carbs"`
 	Fat             int              `json:"fat"`
 	NutritionalInfo *NutritionalInfo `json:"nutritional_info,omitempty"`
 	Ingredients     []string         `json:"ingredients"`
-	IngredientsAm   []string         `json:"ingredients_am"`
 	PreparationTime int              `json:"preparation_time"`
 	HowToEat        string           `json:"how_to_eat"`
-	HowToEatAm      string           `json:"how_to_eat_am"`
 	CreatedAt       time.Time        `json:"created_at"`
 	UpdatedAt       time.Time        `json:"updated_at"`
 	IsDeleted       bool             `json:"is_deleted"`
 	ViewCount       int              `json:"view_count"`
 	AverageRating   float64          `json:"average_rating"`
 	ReviewIds       []string         `json:"review_ids"`
+	ModifierGroups  []ModifierGroup  `json:"modifier_groups,omitempty"`
+	// Translations holds the item's text in languages other than the base one,
+	// keyed by language code (see SupportedLanguages).
+	Translations map[string]ItemTranslation `json:"translations,omitempty"`
+	// Unavailable marks the item as sold out ("86'd"). With UnavailableUntil set
+	// the item becomes available again on its own at that time.
+	Unavailable      bool       `json:"unavailable"`
+	UnavailableUntil *time.Time `json:"unavailable_until,omitempty"`
+}
+
+// IsAvailableAt reports whether the item can be ordered at t.
+func (i *Item) IsAvailableAt(t time.Time) bool {
+	if !i.Unavailable {
+		return true
+	}
+	return i.UnavailableUntil != nil && !t.Before(*i.UnavailableUntil)
 }
 
 type NutritionalInfo struct {
@@ -54,9 +66,15 @@ type IItemRepository interface {
 	UpdateItem(ctx context.Context, id string, item *Item) error
 	DeleteItem(ctx context.Context, id string) error
 	AddReview(ctx context.Context, itemID, reviewID string) error
+	// GetItems, GetPublishedItem and SearchItems serve customers: they read the
+	// published copy of a live menu, never the draft, and find nothing for
+	// menus that are unpublished or were never published.
 	GetItems(ctx context.Context, menuSlug string) ([]Item, error)
+	GetPublishedItem(ctx context.Context, menuSlug, id string) (*Item, error)
 	IncrementItemViewCount(ctx context.Context, id string) error
 	SearchItems(ctx context.Context, filter ItemFilter) ([]Item, int64, error)
+	// SetAvailability sets or clears the sold out flag; until is ignored when available.
+	SetAvailability(ctx context.Context, id string, available bool, until *time.Time) error
 }
 
 type IItemUseCase interface {
@@ -64,6 +82,7 @@ type IItemUseCase interface {
 	UpdateItem(id string, item *Item) error
 	GetItems(menuSlug string) ([]Item, error)
 	GetItemByID(id string) (*Item, error)
+	GetPublishedItem(menuSlug, id string) (*Item, error)
 	AddReview(itemID, reviewID string) error
 	DeleteItem(id string) error
 	IncrementItemViewCount(id string) error
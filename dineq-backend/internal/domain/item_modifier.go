@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ModifierGroup is a set of choices on an item: sizes, a required side such as
+// injera vs rice, or optional add-ons. Customers pick between MinSelect and
+// MaxSelect options; MaxSelect 0 means no upper limit.
+type ModifierGroup struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	MinSelect    int              `json:"min_select"`
+	MaxSelect    int              `json:"max_select"`
+	Options      []ModifierOption `json:"options"`
+	Translations Translations     `json:"translations,omitempty"`
+}
+
+// ModifierOption is one choice in a group. PriceDelta is added to the item's
+// base price when the option is picked and may be negative.
+type ModifierOption struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	PriceDelta   float64      `json:"price_delta"`
+	IsDefault    bool         `json:"is_default"`
+	Translations Translations `json:"translations,omitempty"`
+}
+
+// Required reports whether at least one option must be picked.
+func (g *ModifierGroup) Required() bool {
+	return g.MinSelect > 0
+}
+
+// PriceRange returns the cheapest and most expensive valid configuration of the
+// item. Without modifier groups both equal Price.
+func (i *Item) PriceRange() (low, high float64) {
+	low, high = i.Price, i.Price
+	for g := range i.ModifierGroups {
+		lo, hi := i.ModifierGroups[g].deltaBounds()
+		low += lo
+		high += hi
+	}
+	return low, high
+}
+
+// deltaBounds picks the MinSelect cheapest (or dearest) options and then adds
+// any further discounts (or surcharges) the MaxSelect limit still allows.
+func (g *ModifierGroup) deltaBounds() (lo, hi float64) {
+	deltas := make([]float64, len(g.Options))
+	for i, o := range g.Options {
+		deltas[i] = o.PriceDelta
+	}
+	sort.Float64s(deltas)
+	n := len(deltas)
+	limit := g.MaxSelect
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+	required := g.MinSelect
+	if required > limit {
+		required = limit
+	}
+	for i := 0; i < limit; i++ {
+		if d := deltas[i]; i < required || d < 0 {
+			lo += d
+		}
+		if d := deltas[n-1-i]; i < required || d > 0 {
+			hi += d
+		}
+	}
+	return lo, hi
+}
+
+// ValidateModifierGroups checks selection rules so that every group can be
+// satisfied. Errors wrap ErrInvalidModifierGroups.
+func ValidateModifierGroups(groups []ModifierGroup) error {
+	for i, g := range groups {
+		if strings.TrimSpace(g.Name) == "" && len(g.Translations) == 0 {
+			return fmt.Errorf("%w: modifier_groups[%d]: name or a translated name is required", ErrInvalidModifierGroups, i)
+		}
+		if len(g.Options) == 0 {
+			return fmt.Errorf("%w: modifier_groups[%d]: at least one option is required", ErrInvalidModifierGroups, i)
+		}
+		if g.MinSelect < 0 || g.MaxSelect < 0 {
+			return fmt.Errorf("%w: modifier_groups[%d]: min_select and max_select must not be negative", ErrInvalidModifierGroups, i)
+		}
+		if g.MaxSelect > 0 && g.MaxSelect < g.MinSelect {
+			return fmt.Errorf("%w: modifier_groups[%d]: max_select must be 0 (no limit) or at least min_select", ErrInvalidModifierGroups, i)
+		}
+		if g.MinSelect > len(g.Options) {
+			return fmt.Errorf("%w: modifier_groups[%d]: min_select exceeds the number of options", ErrInvalidModifierGroups, i)
+		}
+		for j, o := range g.Options {
+			if strings.TrimSpace(o.Name) == "" && len(o.Translations) == 0 {
+				return fmt.Errorf("%w: modifier_groups[%d].options[%d]: name or a translated name is required", ErrInvalidModifierGroups, i, j)
+			}
+		}
+	}
+	return nil
+}
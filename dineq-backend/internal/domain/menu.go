@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -23,29 +24,179 @@ type Menu struct {
 	IsDeleted      bool       `json:"is_deleted"`
 	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
 	ViewCount      int        `json:"view_count"`
+	// Published is the frozen copy served to customers. The fields above are the
+	// draft that managers edit; they only reach customers through PublishMenu.
+	Published *PublishedMenu `json:"published,omitempty"`
+	// Schedule limits when the published menu is served; nil means always.
+	Schedule *MenuSchedule `json:"schedule,omitempty"`
+}
+
+// PublishedMenu is the customer-facing copy of a menu promoted from its draft.
+type PublishedMenu struct {
+	Name        string    `json:"name"`
+	Items       []Item    `json:"items"`
+	Version     int       `json:"version"`
+	PublishedAt time.Time `json:"published_at"`
+	PublishedBy string    `json:"published_by"`
+}
+
+// HasUnpublishedChanges reports whether the draft moved on since the last publish.
+func (m *Menu) HasUnpublishedChanges() bool {
+	if m.Published == nil {
+		return true
+	}
+	return m.Version > m.Published.Version
+}
+
+// PublicView returns the menu as customers should see it, or nil when nothing
+// has been published. Menus published before drafts existed have no separate
+// copy and are served as-is.
+func (m *Menu) PublicView() *Menu {
+	if m == nil || m.IsDeleted || !m.IsPublished {
+		return nil
+	}
+	if m.Published == nil {
+		return m
+	}
+	view := *m
+	view.Name = m.Published.Name
+	view.Items = m.Published.Items
+	view.Version = m.Published.Version
+	view.PublishedAt = m.Published.PublishedAt
+	view.Published = nil
+	return &view
+}
+
+// DraftView renders the current draft as if it had just been published, so it
+// can be previewed through the public DTOs.
+func (m *Menu) DraftView() *Menu {
+	if m == nil {
+		return nil
+	}
+	view := *m
+	view.IsPublished = true
+	if view.PublishedAt.IsZero() {
+		view.PublishedAt = time.Now()
+	}
+	view.Published = nil
+	return &view
+}
+
+// Sections returns the menu grouped into tabs and categories. Menus read back
+// from storage keep their structure only on the items, so without a tab tree
+// the tabs are rebuilt from each item's first tab tag and its category; items
+// without a category share an unnamed one. Deleted items are left out.
+func (m *Menu) Sections() []Tab {
+	for _, tab := range m.Tabs {
+		for _, cat := range tab.Categories {
+			if len(cat.Items) > 0 {
+				return m.Tabs
+			}
+		}
+	}
+	var tabs []Tab
+	index := map[string]int{}
+	for _, it := range m.Items {
+		if it.IsDeleted {
+			continue
+		}
+		name := "Menu"
+		var translations, catTranslations Translations
+		if len(it.TabTags) > 0 && strings.TrimSpace(it.TabTags[0]) != "" {
+			name = strings.TrimSpace(it.TabTags[0])
+			for lang, t := range it.Translations {
+				if len(t.TabTags) > 0 {
+					translations = translations.With(lang, t.TabTags[0])
+				}
+			}
+		}
+		catName := strings.TrimSpace(it.Category)
+		if catName != "" {
+			for lang, t := range it.Translations {
+				catTranslations = catTranslations.With(lang, t.Category)
+			}
+		}
+		key := strings.ToLower(name)
+		i, ok := index[key]
+		if !ok {
+			i = len(tabs)
+			index[key] = i
+			tabs = append(tabs, Tab{Name: name, Translations: translations})
+		} else {
+			// later items may carry translations the first one lacked
+			tabs[i].Translations = fillMissing(tabs[i].Translations, translations)
+		}
+		cat := tabs[i].category(catName)
+		cat.Translations = fillMissing(cat.Translations, catTranslations)
+		cat.Items = append(cat.Items, it)
+	}
+	return tabs
+}
+
+// fillMissing adds the languages of from that to lacks.
+func fillMissing(to, from Translations) Translations {
+	for lang, name := range from {
+		if to[lang] == "" {
+			to = to.With(lang, name)
+		}
+	}
+	return to
+}
+
+// category finds or appends the named category of the tab.
+func (t *Tab) category(name string) *Category {
+	for i := range t.Categories {
+		if strings.EqualFold(t.Categories[i].Name, name) {
+			return &t.Categories[i]
+		}
+	}
+	t.Categories = append(t.Categories, Category{Name: name})
+	return &t.Categories[len(t.Categories)-1]
 }
 
 type Tab struct {
-	ID         string     `json:"id"`
-	MenuID     string     `json:"menu_id"`
-	Name       string     `json:"name"`
-	NameAm     string     `json:"name_am"`
-	Categories []Category `json:"categories"`
-	IsDeleted  bool       `json:"is_deleted"`
+	ID           string       `json:"id"`
+	MenuID       string       `json:"menu_id"`
+	Name         string       `json:"name"`
+	Translations Translations `json:"translations,omitempty"`
+	Categories   []Category   `json:"categories"`
+	IsDeleted    bool         `json:"is_deleted"`
 }
 
 type Category struct {
-	ID     string `json:"id"`
-	TabID  string `json:"tab_id"`
-	Name   string `json:"name"`
-	NameAm string `json:"name_am"`
-	Items  []Item `json:"items"`
+	ID           string       `json:"id"`
+	TabID        string       `json:"tab_id"`
+	Name         string       `json:"name"`
+	Translations Translations `json:"translations,omitempty"`
+	Items        []Item       `json:"items"`
 }
 
 type IMenuUseCase interface {
 	CreateMenu(menu *Menu) error
+	// ImportMenu validates a bulk import and creates menu from it unless dryRun
+	// is set. Any invalid row rejects the whole import with ErrInvalidMenuImport.
+	ImportMenu(menu *Menu, data *MenuImport, defaultCurrency string, dryRun bool) (*MenuImportReport, error)
 	UpdateMenu(id string, userId string, menu *Menu) error
+	// MergeMenuItems applies items to the draft with the merge rules of
+	// UpdateMenu and drops the items whose IDs are in removeIDs.
+	MergeMenuItems(id string, userID string, items []Item, removeIDs []string) error
 	PublishMenu(id string, userID string) error
+	PreviewDraft(id string, restaurant *Restaurant) (*Menu, error)
+	ExportMenuPDF(id string, restaurant *Restaurant, opts MenuPDFOptions) ([]byte, error)
+	// TranslateMenu machine-translates every untranslated field of the draft
+	// into langs, keeping the restaurant's glossary terms as written.
+	TranslateMenu(id string, userID string, restaurant *Restaurant, langs []string) (*MenuTranslationReport, error)
+	UnpublishMenu(id string, userID string, restaurant *Restaurant) error
+	SetSchedule(id string, restaurant *Restaurant, schedule *MenuSchedule) error
+	// ApplyScheduledChanges runs every publish/unpublish that is due at now and
+	// returns how many menus changed.
+	ApplyScheduledChanges(now time.Time) (int, error)
+	// SetItemAvailability marks an item sold out (optionally until a time) or
+	// available again on both the draft and the published copy, without a publish.
+	SetItemAvailability(menuID, itemID string, restaurant *Restaurant, available bool, until *time.Time) (*Item, error)
+	// RestoreExpiredAvailability makes items whose sold out period ended at now
+	// available again and returns how many were restored.
+	RestoreExpiredAvailability(now time.Time) (int, error)
 	GetByID(id string) (*Menu, error)
 	GetByRestaurantID(id string) ([]*Menu, error)
 	GenerateQRCode(restaurantID string, menuId string, req *QRCodeRequest) (*QRCode, error)
@@ -53,11 +204,29 @@ type IMenuUseCase interface {
 	MenuItemUpdate(id string, menuItem *Item) error
 	GetMenuItemBySlug(menuSlug string, itemSlug string) (*Item, error)
 	IncrementMenuViewCount(id string) error
+	ListVersions(menuID string, restaurant *Restaurant) ([]*MenuVersion, error)
+	GetVersion(menuID string, restaurant *Restaurant, version int) (*MenuVersion, error)
+	DiffVersions(menuID string, restaurant *Restaurant, from, to int) (*MenuDiff, error)
+	RollbackMenu(menuID string, version int, userID string, restaurant *Restaurant) error
 }
 
 type IMenuRepository interface {
 	Create(ctx context.Context, menu *Menu) error
-	Update(ctx context.Context, id string, menu *Menu) error
+	// Update writes the draft and returns the menu as that write left it, so
+	// the version it was given can be recorded without reading it back.
+	Update(ctx context.Context, id string, menu *Menu) (*Menu, error)
+	// Publish promotes the draft to the published copy, but only if the draft is
+	// still at draftVersion; otherwise ErrMenuDraftChanged is returned. Like
+	// Update, it returns the menu as written.
+	Publish(ctx context.Context, id string, draftVersion int, published *PublishedMenu) (*Menu, error)
+	Unpublish(ctx context.Context, id string, userID string) error
+	SetSchedule(ctx context.Context, id string, schedule *MenuSchedule) error
+	// ClearScheduledTransitions removes the given publish and unpublish times
+	// from the schedule, each only if it is still the stored one.
+	ClearScheduledTransitions(ctx context.Context, id string, publishAt, unpublishAt *time.Time) error
+	FindScheduleDue(ctx context.Context, now time.Time) ([]*Menu, error)
+	SetItemAvailability(ctx context.Context, menuID, itemID string, available bool, until *time.Time) error
+	FindExpiredUnavailable(ctx context.Context, now time.Time) ([]*Menu, error)
 	GetByID(ctx context.Context, id string) (*Menu, error)
 	Delete(ctx context.Context, id string) error
 	GetByRestaurantID(ctx context.Context, restaurantId string) ([]*Menu, error)
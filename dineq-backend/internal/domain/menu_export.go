@@ -0,0 +1,14 @@
+package domain
+
+import "context"
+
+// MenuPDFOptions controls a printable menu export.
+type MenuPDFOptions struct {
+	// IncludeAmharic adds a column with the Amharic name and description next to the English text.
+	IncludeAmharic bool
+}
+
+// IMenuPDFRenderer turns a customer-facing menu into a print-ready PDF.
+type IMenuPDFRenderer interface {
+	RenderMenuPDF(ctx context.Context, menu *Menu, restaurant *Restaurant, opts MenuPDFOptions) ([]byte, error)
+}
@@ -0,0 +1,97 @@
+package domain
+
+import "strings"
+
+// MenuImportFormat is the file format of a bulk menu import.
+type MenuImportFormat string
+
+const (
+	MenuImportCSV  MenuImportFormat = "csv"
+	MenuImportXLSX MenuImportFormat = "xlsx"
+	MenuImportJSON MenuImportFormat = "json"
+)
+
+// SupportedCurrencies lists the ISO codes an imported price may be in.
+var SupportedCurrencies = []string{"ETB", "USD", "EUR", "GBP"}
+
+// IsSupportedCurrency reports whether code (any case) is in SupportedCurrencies.
+func IsSupportedCurrency(code string) bool {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	for _, c := range SupportedCurrencies {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// MenuImport is a parsed import file, flattened to one row per item whatever
+// the source format was.
+type MenuImport struct {
+	Name string
+	Rows []MenuImportRow
+}
+
+// MenuImportRow is a single item as it appeared in the import file. Values are
+// kept as written so that bad input can be reported back verbatim.
+type MenuImportRow struct {
+	// Row is the 1-based line in a spreadsheet (header included) or the 1-based
+	// item position in a JSON document.
+	Row         int
+	Tab         string
+	Category    string
+	Name        string
+	Slug        string
+	Description string
+	Price       string
+	Currency    string
+	Ingredients []string
+	Allergies   []string
+	// Translations holds the translated columns (name_am, tab_om, ...) by language
+	Translations map[string]MenuImportTranslation
+}
+
+// MenuImportTranslation is the translated text of an import row in one language.
+type MenuImportTranslation struct {
+	Tab         string
+	Category    string
+	Name        string
+	Description string
+}
+
+// SetTranslation updates the row's text in lang through fn.
+func (r *MenuImportRow) SetTranslation(lang string, fn func(t *MenuImportTranslation)) {
+	if r.Translations == nil {
+		r.Translations = map[string]MenuImportTranslation{}
+	}
+	t := r.Translations[lang]
+	fn(&t)
+	if t == (MenuImportTranslation{}) {
+		delete(r.Translations, lang)
+		return
+	}
+	r.Translations[lang] = t
+}
+
+// MenuImportIssue is one problem found while validating an import.
+type MenuImportIssue struct {
+	Row     int
+	Field   string
+	Value   string
+	Message string
+}
+
+// MenuImportReport describes the outcome of an import. MenuID is only set when
+// the menu was actually created.
+type MenuImportReport struct {
+	DryRun    bool
+	TotalRows int
+	ValidRows int
+	Issues    []MenuImportIssue
+	MenuID    string
+}
+
+// HasIssues reports whether any row failed validation.
+func (r *MenuImportReport) HasIssues() bool {
+	return len(r.Issues) > 0
+}
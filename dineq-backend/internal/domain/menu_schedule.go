@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // restaurant timezones must resolve on hosts without zoneinfo
+)
+
+// DefaultRestaurantTimezone is used when a restaurant has no (valid) timezone.
+const DefaultRestaurantTimezone = "Africa/Addis_Ababa"
+
+// MenuSchedule controls when a published menu is served.
+//
+// Windows restrict a live menu to certain days, hours and date ranges; a menu
+// without windows is always active. PublishAt and UnpublishAt are one-off
+// transitions applied by the menu scheduler.
+type MenuSchedule struct {
+	Windows     []MenuActivationWindow `json:"windows"`
+	PublishAt   *time.Time             `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time             `json:"unpublish_at,omitempty"`
+}
+
+// MenuActivationWindow matches when every populated constraint matches. Days
+// are weekday names ("monday" or "mon"); times are "HH:MM" in the restaurant
+// timezone and may wrap past midnight; dates are inclusive "YYYY-MM-DD".
+type MenuActivationWindow struct {
+	Days      []string `json:"days,omitempty"`
+	StartTime string   `json:"start_time,omitempty"`
+	EndTime   string   `json:"end_time,omitempty"`
+	StartDate string   `json:"start_date,omitempty"`
+	EndDate   string   `json:"end_date,omitempty"`
+}
+
+// TimeLocation resolves the restaurant timezone, falling back to the default.
+func (r *Restaurant) TimeLocation() *time.Location {
+	if r != nil && r.Timezone != "" {
+		if loc, err := time.LoadLocation(r.Timezone); err == nil {
+			return loc
+		}
+	}
+	loc, err := time.LoadLocation(DefaultRestaurantTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// IsActiveAt reports whether a live menu should be listed at t.
+func (m *Menu) IsActiveAt(t time.Time, loc *time.Location) bool {
+	if m.Schedule == nil {
+		return true
+	}
+	return m.Schedule.ActiveAt(t, loc)
+}
+
+func (s *MenuSchedule) ActiveAt(t time.Time, loc *time.Location) bool {
+	// the scheduler may lag a little; never serve a menu past its unpublish time
+	if s.UnpublishAt != nil && !t.Before(*s.UnpublishAt) {
+		return false
+	}
+	if len(s.Windows) == 0 {
+		return true
+	}
+	local := t.In(loc)
+	for _, w := range s.Windows {
+		if w.matches(local) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w MenuActivationWindow) matches(local time.Time) bool {
+	date := local.Format("2006-01-02")
+	if w.StartDate != "" && date < w.StartDate {
+		return false
+	}
+	if w.EndDate != "" && date > w.EndDate {
+		return false
+	}
+	if len(w.Days) > 0 {
+		today := weekdayKey(local.Weekday().String())
+		found := false
+		for _, d := range w.Days {
+			if weekdayKey(d) == today {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if w.StartTime == "" && w.EndTime == "" {
+		return true
+	}
+	start, _ := parseClock(w.StartTime)
+	end, _ := parseClock(w.EndTime)
+	if w.EndTime == "" {
+		end = 24 * 60
+	}
+	now := local.Hour()*60 + local.Minute()
+	if start == end {
+		return true
+	}
+	if start < end {
+		return now >= start && now < end
+	}
+	// wraps past midnight, e.g. 22:00-02:00
+	return now >= start || now < end
+}
+
+// Validate checks the window formats so bad schedules are rejected on write
+// instead of silently never matching.
+func (s *MenuSchedule) Validate() error {
+	if s.PublishAt != nil && s.UnpublishAt != nil && !s.UnpublishAt.After(*s.PublishAt) {
+		return errors.New("unpublish_at must be after publish_at")
+	}
+	for i, w := range s.Windows {
+		for _, d := range w.Days {
+			if !validWeekdays[weekdayKey(d)] {
+				return fmt.Errorf("windows[%d]: unknown day %q", i, d)
+			}
+		}
+		for _, clock := range []string{w.StartTime, w.EndTime} {
+			if clock == "" {
+				continue
+			}
+			if _, err := parseClock(clock); err != nil {
+				return fmt.Errorf("windows[%d]: %v", i, err)
+			}
+		}
+		for _, date := range []string{w.StartDate, w.EndDate} {
+			if date == "" {
+				continue
+			}
+			if _, err := time.Parse("2006-01-02", date); err != nil {
+				return fmt.Errorf("windows[%d]: invalid date %q, expected YYYY-MM-DD", i, date)
+			}
+		}
+		if w.StartDate != "" && w.EndDate != "" && w.EndDate < w.StartDate {
+			return fmt.Errorf("windows[%d]: end_date before start_date", i)
+		}
+	}
+	return nil
+}
+
+var validWeekdays = map[string]bool{"sun": true, "mon": true, "tue": true, "wed": true, "thu": true, "fri": true, "sat": true}
+
+func weekdayKey(day string) string {
+	day = strings.ToLower(strings.TrimSpace(day))
+	if len(day) > 3 {
+		day = day[:3]
+	}
+	return day
+}
+
+// parseClock converts "HH:MM" into minutes since midnight; "24:00" is allowed
+// as an end of day marker.
+func parseClock(clock string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(clock), ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", clock)
+	}
+	h, err1 := strconv.Atoi(parts[0])
+	m, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || h < 0 || h > 24 || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", clock)
+	}
+	return h*60 + m, nil
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+// ITranslator machine-translates text. TranslateBatch returns one translation
+// per input, in order, into the target language code.
+type ITranslator interface {
+	TranslateBatch(ctx context.Context, texts []string, target string) ([]string, error)
+}
+
+// MenuTranslationReport summarizes a batch translation of a menu.
+type MenuTranslationReport struct {
+	MenuID    string   `json:"menu_id,omitempty"`
+	Languages []string `json:"languages"`
+	// Translated counts the item fields filled in, across all languages
+	Translated int `json:"translated"`
+	// Requests counts the calls made to the translation provider
+	Requests int `json:"requests"`
+	// Failed counts fields left untranslated because the provider's output
+	// could not be used (e.g. a glossary term was lost)
+	Failed int `json:"failed"`
+}
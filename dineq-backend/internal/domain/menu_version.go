@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MenuVersionAction records what produced a menu snapshot.
+type MenuVersionAction string
+
+const (
+	MenuVersionCreate   MenuVersionAction = "create"
+	MenuVersionUpdate   MenuVersionAction = "update"
+	MenuVersionPublish  MenuVersionAction = "publish"
+	MenuVersionRollback MenuVersionAction = "rollback"
+)
+
+// MenuVersion is an immutable snapshot of a menu taken after every write.
+type MenuVersion struct {
+	ID        string            `json:"id"`
+	MenuID    string            `json:"menu_id"`
+	Version   int               `json:"version"`
+	Action    MenuVersionAction `json:"action"`
+	Snapshot  Menu              `json:"snapshot"`
+	CreatedBy string            `json:"created_by"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// MenuDiff describes the changes between two menu versions.
+type MenuDiff struct {
+	MenuID        string            `json:"menu_id"`
+	FromVersion   int               `json:"from_version"`
+	ToVersion     int               `json:"to_version"`
+	NameChanged   bool              `json:"name_changed"`
+	ItemsAdded    []Item            `json:"items_added"`
+	ItemsRemoved  []Item            `json:"items_removed"`
+	ItemsRepriced []ItemPriceChange `json:"items_repriced"`
+	TabsAdded     []string          `json:"tabs_added"`
+	TabsRemoved   []string          `json:"tabs_removed"`
+}
+
+// ItemPriceChange is a single repriced item inside a MenuDiff.
+type ItemPriceChange struct {
+	ItemID   string  `json:"item_id"`
+	Slug     string  `json:"slug"`
+	Name     string  `json:"name"`
+	OldPrice float64 `json:"old_price"`
+	NewPrice float64 `json:"new_price"`
+	Currency string  `json:"currency"`
+}
+
+type IMenuVersionRepository interface {
+	Create(ctx context.Context, version *MenuVersion) error
+	GetByMenuIDAndVersion(ctx context.Context, menuID string, version int) (*MenuVersion, error)
+	ListByMenuID(ctx context.Context, menuID string) ([]*MenuVersion, error)
+}
@@ -13,6 +13,7 @@ type Notification struct {
 	IsRead    bool             // Read status
 	CreatedAt time.Time        // Creation timestamp
 	UpdatedAt time.Time        // Last update timestamp
+	Data      map[string]any   // Optional structured payload for realtime events
 }
 
 type NotificationType string
@@ -25,6 +26,10 @@ const (
 	MenuUpload  NotificationType = "menu_upload"
 	FileUpload  NotificationType = "file_upload"
 	Other       NotificationType = "others"
+	// ItemAvailability is broadcast when an item is sold out or comes back
+	ItemAvailability NotificationType = "item_availability"
+	// OCRProgress carries an OCRJobEvent to the user who uploaded the menu
+	OCRProgress NotificationType = "ocr_progress"
 )
 
 type INotificationUseCase interface {
@@ -32,6 +37,11 @@ type INotificationUseCase interface {
 	GetNotificationsByUserID(ctx context.Context, userID string) ([]Notification, error)
 	MarkAsRead(ctx context.Context, id string) error
 	SendNotificationFromRoute(ctx context.Context, userID, message string, notifType NotificationType) error
+	// Broadcast pushes a transient event to the clients subscribed to a
+	// restaurant without storing it.
+	Broadcast(ctx context.Context, restaurantSlug string, notification *Notification) error
+	// Push sends a transient event to one user's connection without storing it.
+	Push(ctx context.Context, userID string, notification *Notification) error
 }
 
 type INotificationRepository interface {
@@ -2,50 +2,167 @@ package domain
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
 type OCRJob struct {
-	ID                  string
-	RestaurantID        string
-	UserID              string
-	ImageURL            string
-	Status              OCRJobStatus
-	ResultText          string
-	StructuredMenuID    string
+	ID           string
+	RestaurantID string
+	UserID       string
+	// ImageURL is the first page; Pages lists every page of the upload
+	ImageURL string
+	// ProcessedImageURL is the first page after preprocessing, empty when
+	// preprocessing is off or failed and OCR read the original
+	ProcessedImageURL string
+	Pages             []OCRPage
+	Status            OCRJobStatus
+	ResultText        string
+	StructuredMenuID  string
+	// TargetMenuID puts the job in merge mode: the menu read is reviewed as
+	// changes to this existing menu rather than as a new one
+	TargetMenuID        string
 	Error               string
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 	EstimatedCompletion time.Time
 	CompletedAt         *time.Time
 	Results             *OCRJobResult // structured response for polling
-	RawAIJSON           string        // raw Gemini JSON for audit
+	RawAIJSON           string        // raw model JSON for audit
 	Phase               string
 	Progress            int
 	PhaseHistory        []OCRPhase
+	// AICalls records every structuring call made for the job, failed ones included
+	AICalls []AICall
+	// Queue lease: the worker processing the job and until when it holds it.
+	// Only the queue operations of IOCRJobRepository write these.
+	LeaseOwner     string
+	LeaseExpiresAt *time.Time
+	Attempts       int
+	// ImagesPurgedAt is when retention removed the page images from storage
+	ImagesPurgedAt *time.Time
+}
+
+// OCRDocument is the text an OCR provider read from a menu image, whatever
+// the provider.
+type OCRDocument struct {
+	Text string
+	// Provider names the backend that produced the text ("veryfi", "tesseract", ...)
+	Provider string
+}
+
+// AIUsage is what a model provider reports for one request.
+type AIUsage struct {
+	Provider         string `bson:"provider" json:"provider"`
+	Model            string `bson:"model,omitempty" json:"model,omitempty"`
+	PromptTokens     int    `bson:"promptTokens,omitempty" json:"prompt_tokens,omitempty"`
+	CompletionTokens int    `bson:"completionTokens,omitempty" json:"completion_tokens,omitempty"`
+	TotalTokens      int    `bson:"totalTokens,omitempty" json:"total_tokens,omitempty"`
+}
+
+// StructuredMenu is a menu built by a model from OCR text, with the raw
+// response it was parsed from.
+type StructuredMenu struct {
+	Menu    *Menu
+	RawJSON string
+	Usage   AIUsage
+}
+
+// AICall is one model call of an OCR job, kept for cost and latency tracking.
+type AICall struct {
+	AIUsage   `bson:",inline"`
+	Stage     string    `bson:"stage" json:"stage"`
+	Page      int       `bson:"page,omitempty" json:"page,omitempty"`
+	StartedAt time.Time `bson:"startedAt" json:"started_at"`
+	LatencyMS int64     `bson:"latencyMs" json:"latency_ms"`
+	Error     string    `bson:"error,omitempty" json:"error,omitempty"`
 }
 
 // OCRJobResult holds structured result fields returned to clients
 type OCRJobResult struct {
 	ExtractedText    string   `json:"extracted_text,omitempty"`
+	OCRProvider      string   `json:"ocr_provider,omitempty"`
 	PhotoMatches     []string `json:"photo_matches,omitempty"`
 	ConfidenceScore  float64  `json:"confidence_score,omitempty"`
 	StructuredMenuID string   `json:"structured_menu_id,omitempty"`
-	Menu             *Menu    `json:"menu,omitempty"`
-	RawAIJSON        string   `json:"raw_ai_json,omitempty"`
+	// ParseResultID is the AIParseResult awaiting manager review
+	ParseResultID string `json:"parse_result_id,omitempty"`
+	Menu          *Menu  `json:"menu,omitempty"`
+	RawAIJSON     string `json:"raw_ai_json,omitempty"`
 }
 
 // OCRPhase represents a pipeline phase status
 type OCRPhase struct {
 	Name      string     `bson:"name" json:"name"`
-	Status    string     `bson:"status" json:"status"` // pending|running|done|failed
+	Status    string     `bson:"status" json:"status"` // pending|running|done|failed|cancelled
 	StartedAt *time.Time `bson:"startedAt,omitempty" json:"started_at,omitempty"`
 	EndedAt   *time.Time `bson:"endedAt,omitempty" json:"ended_at,omitempty"`
 }
 
+// OCRPage is one page of an upload. Pages are read one by one; a page that is
+// done keeps its text when the job is retried, so only failed pages are read
+// again.
+type OCRPage struct {
+	Number   int    `bson:"number" json:"number"`
+	ImageURL string `bson:"imageUrl" json:"image_url"`
+	// ProcessedImageURL is the cleaned-up copy OCR reads instead of ImageURL
+	ProcessedImageURL string `bson:"processedImageUrl,omitempty" json:"processed_image_url,omitempty"`
+	// storage IDs of the two images, used to delete them once the job expires
+	ImagePublicID     string `bson:"imagePublicId,omitempty" json:"-"`
+	ProcessedPublicID string `bson:"processedPublicId,omitempty" json:"-"`
+	// SHA256 and PerceptualHash fingerprint the uploaded image to spot
+	// re-uploads of the same menu
+	SHA256         string                 `bson:"sha256,omitempty" json:"-"`
+	PerceptualHash string                 `bson:"phash,omitempty" json:"-"`
+	Preprocess     *ImagePreprocessReport `bson:"preprocess,omitempty" json:"preprocess,omitempty"`
+	Status         string                 `bson:"status" json:"status"` // pending|done|failed
+	Text           string                 `bson:"text,omitempty" json:"-"`
+	Provider       string                 `bson:"provider,omitempty" json:"provider,omitempty"`
+	Error          string                 `bson:"error,omitempty" json:"error,omitempty"`
+	Attempts       int                    `bson:"attempts,omitempty" json:"attempts,omitempty"`
+}
+
+// ImagePreprocessReport says what preprocessing did to a page image.
+type ImagePreprocessReport struct {
+	OriginalWidth  int `bson:"originalWidth" json:"original_width"`
+	OriginalHeight int `bson:"originalHeight" json:"original_height"`
+	Width          int `bson:"width" json:"width"`
+	Height         int `bson:"height" json:"height"`
+	// Orientation is the EXIF orientation applied, 1 when the photo was upright
+	Orientation int `bson:"orientation" json:"orientation"`
+	// Scale is the downscaling factor, 0 when the image was small enough
+	Scale float64 `bson:"scale,omitempty" json:"scale,omitempty"`
+	// SkewDegrees is the counter-clockwise rotation that straightened the text
+	SkewDegrees       float64 `bson:"skewDegrees,omitempty" json:"skew_degrees,omitempty"`
+	ContrastStretched bool    `bson:"contrastStretched,omitempty" json:"contrast_stretched,omitempty"`
+	Cropped           bool    `bson:"cropped,omitempty" json:"cropped,omitempty"`
+}
+
+// Page statuses.
+const (
+	OCRPagePending = "pending"
+	OCRPageDone    = "done"
+	OCRPageFailed  = "failed"
+)
+
+// OCRPagePhase names the PhaseHistory entry of page n (1-based).
+func OCRPagePhase(n int) string {
+	return fmt.Sprintf("%s_page_%d", PhaseOCRExtraction, n)
+}
+
+// JobPages returns the pages of a job, treating a job created before
+// multi-page uploads as a single page.
+func (j *OCRJob) JobPages() []OCRPage {
+	if len(j.Pages) == 0 {
+		j.Pages = []OCRPage{{Number: 1, ImageURL: j.ImageURL, Status: OCRPagePending}}
+	}
+	return j.Pages
+}
+
 // Phase names constants
 const (
 	PhaseReceived      = "received"
+	PhasePreprocessing = "preprocessing"
 	PhaseOCRExtraction = "ocr_extraction"
 	PhaseAIStructuring = "ai_structuring"
 	PhaseMenuPersist   = "menu_persist"
@@ -59,23 +176,195 @@ const (
 	OCRProcessing OCRJobStatus = "processing"
 	OCRCompleted  OCRJobStatus = "completed"
 	OCRFailed     OCRJobStatus = "failed"
+	OCRCancelled  OCRJobStatus = "cancelled"
 )
 
+// Finished reports whether a job in this status will not run again unless
+// retried.
+func (s OCRJobStatus) Finished() bool {
+	return s == OCRCompleted || s == OCRFailed || s == OCRCancelled
+}
+
+// OCRJobFilter selects jobs for listing, newest first. Empty fields match
+// every job.
+type OCRJobFilter struct {
+	RestaurantID string
+	UserID       string
+	Statuses     []OCRJobStatus
+	// CreatedAfter, when set, leaves out older jobs
+	CreatedAfter time.Time
+	Page         int
+	PageSize     int
+}
+
+// Normalize defaults to the first page of 20 jobs; page sizes above 100
+// fall back to the default.
+func (f OCRJobFilter) Normalize() OCRJobFilter {
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+	if f.PageSize <= 0 || f.PageSize > 100 {
+		f.PageSize = 20
+	}
+	return f
+}
+
+// OCRDuplicate is an earlier job that read the same menu pages as a new
+// upload. Exact means the files were identical; otherwise Distance is the
+// largest perceptual hash distance between matching pages.
+type OCRDuplicate struct {
+	Job         *OCRJob
+	ParseResult *AIParseResult
+	Exact       bool
+	Distance    int
+}
+
+// OCRJobEvent is published on every update of a job so clients can follow
+// it live instead of polling. The last event of a run is Final, with the
+// parse result (and menu, when there is one) to open next.
+type OCRJobEvent struct {
+	JobID               string       `json:"job_id"`
+	UserID              string       `json:"-"`
+	Status              OCRJobStatus `json:"status"`
+	Phase               string       `json:"phase"`
+	Progress            int          `json:"progress"`
+	EstimatedCompletion time.Time    `json:"estimated_completion_time"`
+	// PhaseChanged is set on the first event of a new phase
+	PhaseChanged  bool      `json:"phase_changed,omitempty"`
+	Pages         []OCRPage `json:"pages,omitempty"`
+	Final         bool      `json:"final,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	ParseResultID string    `json:"parse_result_id,omitempty"`
+	MenuID        string    `json:"menu_id,omitempty"`
+	At            time.Time `json:"at"`
+}
+
+// NewOCRJobEvent describes the current state of job.
+func NewOCRJobEvent(job *OCRJob) OCRJobEvent {
+	ev := OCRJobEvent{
+		JobID:               job.ID,
+		UserID:              job.UserID,
+		Status:              job.Status,
+		Phase:               job.Phase,
+		Progress:            job.Progress,
+		EstimatedCompletion: job.EstimatedCompletion,
+		Final:               job.Status.Finished(),
+		At:                  time.Now(),
+	}
+	if len(job.Pages) > 1 {
+		ev.Pages = append([]OCRPage(nil), job.Pages...)
+	}
+	if job.Status == OCRFailed || job.Status == OCRCancelled {
+		ev.Error = job.Error
+	}
+	if job.Results != nil {
+		ev.ParseResultID = job.Results.ParseResultID
+	}
+	ev.MenuID = job.StructuredMenuID
+	if ev.MenuID == "" {
+		ev.MenuID = job.TargetMenuID
+	}
+	return ev
+}
+
+// OCRWorkerConfig sizes the OCR worker pool.
+type OCRWorkerConfig struct {
+	Workers int
+	// Lease is how long a claimed job stays with its worker without a heartbeat
+	Lease time.Duration
+	// PollInterval is how often idle workers look for jobs enqueued elsewhere
+	PollInterval time.Duration
+	// MaxAttempts fails a job whose worker was lost this many times
+	MaxAttempts int
+	// Retention of page images: completed jobs keep them for Retention and
+	// failed or cancelled ones for FailedRetention; zero keeps them forever.
+	// Expired images are purged every PurgeInterval.
+	Retention       time.Duration
+	FailedRetention time.Duration
+	PurgeInterval   time.Duration
+}
+
+// OCRWorkerStatus is what one worker of the pool is doing.
+type OCRWorkerStatus struct {
+	ID        string     `json:"id"`
+	Busy      bool       `json:"busy"`
+	JobID     string     `json:"job_id,omitempty"`
+	Since     *time.Time `json:"since,omitempty"`
+	Processed int        `json:"processed"`
+}
+
+// OCRQueueStats reports queue depth and worker activity for operators.
+type OCRQueueStats struct {
+	Pending    int64             `json:"pending"`
+	Processing int64             `json:"processing"`
+	Completed  int64             `json:"completed"`
+	Failed     int64             `json:"failed"`
+	Cancelled  int64             `json:"cancelled"`
+	Workers    []OCRWorkerStatus `json:"workers"`
+}
+
 type IOCRJobUseCase interface {
+	// CreateOCRJob stores a new job in the queue for the worker pool.
 	CreateOCRJob(job *OCRJob) error
 	UpdateOCRJobStatus(id string, status OCRJobStatus) error
 	GetOCRJobByID(id string) (*OCRJob, error)
 	ProcessJob(id string)
 	DeleteOCRJob(id string) error
 	RetryJob(id string) (*OCRJob, error)
+	// FindDuplicate looks for a completed job of the same restaurant (or, for
+	// a job without one, of the same user), created since, whose pages match
+	// those of job, which is not created yet. It returns nil when there is none
+	// or its parse result was discarded.
+	FindDuplicate(job *OCRJob, since time.Time) (*OCRDuplicate, error)
+	// ListJobs pages through jobs matching filter and returns the total count.
+	ListJobs(filter OCRJobFilter) ([]*OCRJob, int64, error)
+	// CancelJob stops a pending or running job; ErrOCRJobNotCancellable when
+	// it already finished.
+	CancelJob(id string) (*OCRJob, error)
+	// PurgeExpiredImages deletes from storage the page images of completed
+	// jobs last updated before completedBefore and of failed or cancelled
+	// jobs last updated before failedBefore. A zero time skips those jobs.
+	PurgeExpiredImages(completedBefore, failedBefore time.Time) (int, error)
+	// StartWorkers recovers jobs left behind by a crashed process and runs the
+	// worker pool until ctx is cancelled.
+	StartWorkers(ctx context.Context, cfg OCRWorkerConfig)
+	QueueStats() (*OCRQueueStats, error)
+	// SubscribeJob streams the events of one job published by this instance
+	// until cancel is called.
+	SubscribeJob(id string) (events <-chan OCRJobEvent, cancel func())
 }
 
 type IOCRJobRepository interface {
 	Create(ctx context.Context, job *OCRJob) error
+	// Update writes job. For a job read while leased to a worker it returns
+	// ErrOCRJobLeaseLost once that worker no longer holds the lease.
 	Update(ctx context.Context, id string, job *OCRJob) error
 	UpdateStatus(ctx context.Context, id, status string) error
 	GetByID(ctx context.Context, id string) (*OCRJob, error)
 	Delete(ctx context.Context, id string) error
-	GetPendingJobs(ctx context.Context) ([]*OCRJob, error)
+	// Claim leases the oldest pending job to workerID until leaseUntil, or
+	// returns ErrNotFound when nothing is pending.
+	Claim(ctx context.Context, workerID string, leaseUntil time.Time) (*OCRJob, error)
+	// ExtendLease renews workerID's lease; ErrOCRJobLeaseLost means the job
+	// is no longer processing under that worker.
+	ExtendLease(ctx context.Context, id, workerID string, leaseUntil time.Time) error
+	// RequeueExpired puts processing jobs whose lease ran out at now (or that
+	// never had one) back to pending, and fails those that already used
+	// maxAttempts.
+	RequeueExpired(ctx context.Context, now time.Time, maxAttempts int) (requeued int64, failed int64, err error)
+	CountByStatus(ctx context.Context) (map[OCRJobStatus]int64, error)
+	// UnreadPages counts the pages of pending and processing jobs of the
+	// restaurant or user (the other left empty) that OCR has not read yet.
+	UnreadPages(ctx context.Context, restaurantID, userID string) (int64, error)
+	// List returns one page of jobs matching filter, without the extracted
+	// text and structured menu, and the total matching.
+	List(ctx context.Context, filter OCRJobFilter) ([]*OCRJob, int64, error)
+	// Cancel marks a pending or processing job cancelled and returns it, or
+	// ErrOCRJobNotCancellable. Later pipeline updates leave it cancelled.
+	Cancel(ctx context.Context, id, reason string, at time.Time) (*OCRJob, error)
+	// ListImagesToPurge returns up to limit jobs whose images have expired.
+	ListImagesToPurge(ctx context.Context, completedBefore, failedBefore time.Time, limit int) ([]*OCRJob, error)
+	// MarkImagesPurged clears the image URLs of a job and records when.
+	MarkImagesPurged(ctx context.Context, id string, at time.Time) error
 	GetUserFCMToken(userID string) string
 }
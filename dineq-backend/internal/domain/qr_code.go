@@ -9,22 +9,59 @@ type QRCode struct {
 	ID            string
 	ImageURL      string
 	PublicMenuURL string
-	DownloadURL   string
-	MenuID        string
-	RestaurantID  string
-	IsActive      bool
-	CreatedAt     time.Time
-	ExpiresAt     time.Time
-	IsDeleted     bool
-	DeletedAt     *time.Time
+	// ScanURL is the short link encoded in the image; it redirects to the
+	// menu that is active when scanned. Codes made before it encode
+	// PublicMenuURL directly
+	ScanURL      string
+	DownloadURL  string
+	MenuID       string
+	RestaurantID string
+	// Label names the code for staff, e.g. "Table 12" or "Window"
+	Label string
+	// Table and Location say where the code is displayed; both are carried in
+	// PublicMenuURL so views and orders can be traced back to them
+	Table     string
+	Location  string
+	IsActive  bool
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	IsDeleted bool
+	DeletedAt *time.Time
+	// ScanCount and LastScannedAt count scans through ScanURL
+	ScanCount     int64
+	LastScannedAt *time.Time
+}
+
+// Placement is the table and location a code is displayed at.
+func (q *QRCode) Placement() QRPlacement {
+	return QRPlacement{Label: q.Label, Table: q.Table, Location: q.Location}
+}
+
+// QRPlacement tags a QR code with where it is displayed.
+type QRPlacement struct {
+	Label    string
+	Table    string
+	Location string
 }
 
 type IQRCodeUseCase interface {
 	CreateQRCode(qr *QRCode) error
 	GetQRCodeByRestaurantId(id string) (*QRCode, error)
+	// ChangeQRCodeStatus activates or deactivates every QR code of a restaurant
 	ChangeQRCodeStatus(id string, isActive bool) error
-	// DeleteQRCode deletes a QR code by its restaurant ID
+	// DeleteQRCode deletes every QR code of a restaurant by its restaurant ID
 	DeleteQRCode(id string) error
+
+	GetQRCode(id string) (*QRCode, error)
+	// ListQRCodes lists the codes made under any of the restaurant's slugs,
+	// newest first; an empty menuID lists the codes of every menu
+	ListQRCodes(restaurantSlugs []string, menuID string) ([]*QRCode, error)
+	// UpdateQRCode saves the label and activation of a single code
+	UpdateQRCode(qr *QRCode) error
+	DeleteQRCodeByID(id string) error
+	// ResolveScan finds where a scanned code leads and logs the scan. Inactive
+	// codes fail with ErrQRCodeInactive and expired ones with ErrQRCodeExpired
+	ResolveScan(id string, userAgent string) (*QRScanTarget, error)
 }
 
 // repository
@@ -33,6 +70,12 @@ type IQRCodeRepository interface {
 	GetByRestaurantId(ctx context.Context, id string) (*QRCode, error)
 	UpdateActivation(ctx context.Context, id string, isActive bool) error
 	Delete(ctx context.Context, id string) error
+
+	GetByID(ctx context.Context, id string) (*QRCode, error)
+	List(ctx context.Context, restaurantSlugs []string, menuID string) ([]*QRCode, error)
+	Update(ctx context.Context, qr *QRCode) error
+	DeleteByID(ctx context.Context, id string) error
+	RecordScan(ctx context.Context, id string, at time.Time) error
 }
 
 type QRCodeRequest struct {
@@ -41,6 +84,9 @@ type QRCodeRequest struct {
 	IncludeLabel  bool
 	Quality       int // optional JPEG quality 1-100
 	Customization *QRCodeCustomization
+	// Placement tags the code; its label is printed when IncludeLabel is set
+	// and no LabelText is given
+	Placement QRPlacement
 }
 
 // QRCodeCustomization represents QR code customization options
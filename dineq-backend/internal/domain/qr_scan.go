@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// QRScan logs one scan of a QR code, taken before the scanner is redirected.
+type QRScan struct {
+	ID           string
+	QRCodeID     string
+	RestaurantID string
+	// MenuID is the menu the scan was sent to; empty when no menu of the
+	// restaurant was active and the restaurant page was shown instead
+	MenuID    string
+	Table     string
+	Location  string
+	Device    string // coarse user agent, see CoarseUserAgent
+	ScannedAt time.Time
+}
+
+// QRScanTarget is where a scanned code leads right now.
+type QRScanTarget struct {
+	QRCode         *QRCode
+	RestaurantSlug string
+	// MenuSlug is empty when no menu of the restaurant is active
+	MenuSlug string
+}
+
+type IQRScanRepository interface {
+	Log(ctx context.Context, scan *QRScan) error
+}
+
+// Coarse user agents of a QR scan; the full user agent is not stored.
+const (
+	DeviceIOS     = "ios"
+	DeviceAndroid = "android"
+	DeviceDesktop = "desktop"
+	DeviceBot     = "bot"
+	DeviceOther   = "other"
+)
+
+// CoarseUserAgent reduces a user agent to the kind of device it came from.
+func CoarseUserAgent(ua string) string {
+	ua = strings.ToLower(ua)
+	switch {
+	case ua == "":
+		return DeviceOther
+	case strings.Contains(ua, "bot") || strings.Contains(ua, "crawler") || strings.Contains(ua, "spider") || strings.Contains(ua, "preview"):
+		return DeviceBot
+	case strings.Contains(ua, "iphone") || strings.Contains(ua, "ipad") || strings.Contains(ua, "ipod"):
+		return DeviceIOS
+	case strings.Contains(ua, "android"):
+		return DeviceAndroid
+	case strings.Contains(ua, "windows") || strings.Contains(ua, "macintosh") || strings.Contains(ua, "x11") || strings.Contains(ua, "cros"):
+		return DeviceDesktop
+	default:
+		return DeviceOther
+	}
+}
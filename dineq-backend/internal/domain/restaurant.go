@@ -24,14 +24,25 @@ type Restaurant struct {
 	AccentColor        string
 	DefaultCurrency    string
 	DefaultLanguage    string
-	DefaultVat         float64
-	TaxId              string
-	CoverImage         *string
-	AverageRating      float64
-	ViewCount          int64
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
-	IsDeleted          bool
+	// Translations holds the name and about text in other languages
+	Translations map[string]RestaurantTranslation
+	// Glossary lists dish names ("tibs", "kitfo") machine translation must keep as written
+	Glossary      []string
+	Timezone      string
+	DefaultVat    float64
+	TaxId         string
+	CoverImage    *string
+	AverageRating float64
+	ViewCount     int64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	IsDeleted     bool
+}
+
+// Slugs is the current slug and the ones it replaced. QR codes keep the slug
+// the restaurant had when they were made.
+func (r *Restaurant) Slugs() []string {
+	return append([]string{r.Slug}, r.PreviousSlugs...)
 }
 
 type Address struct {
@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Language codes for translated content. The base fields of items, tabs,
+// categories and restaurants hold the source (English) text; every other
+// language lives in a per-locale translation map keyed by these codes.
+const (
+	LangEnglish  = "en"
+	LangAmharic  = "am"
+	LangOromo    = "om"
+	LangTigrinya = "ti"
+)
+
+var SupportedLanguages = []string{LangEnglish, LangAmharic, LangOromo, LangTigrinya}
+
+// languageNames maps the names restaurants were created with ("English") to codes.
+var languageNames = map[string]string{
+	"english":     LangEnglish,
+	"amharic":     LangAmharic,
+	"አማርኛ":        LangAmharic,
+	"oromo":       LangOromo,
+	"afaan oromo": LangOromo,
+	"afan oromo":  LangOromo,
+	"oromiffa":    LangOromo,
+	"tigrinya":    LangTigrinya,
+	"ትግርኛ":        LangTigrinya,
+}
+
+// LanguageName returns the English name of a supported language code.
+func LanguageName(code string) string {
+	switch code {
+	case LangAmharic:
+		return "Amharic"
+	case LangOromo:
+		return "Afaan Oromo"
+	case LangTigrinya:
+		return "Tigrinya"
+	default:
+		return "English"
+	}
+}
+
+// NormalizeLanguage maps a language tag ("am-ET") or name ("Amharic") to one of
+// SupportedLanguages, or "" when the language is not supported.
+func NormalizeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if code, ok := languageNames[lang]; ok {
+		return code
+	}
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		lang = lang[:i]
+	}
+	for _, code := range SupportedLanguages {
+		if lang == code {
+			return code
+		}
+	}
+	return ""
+}
+
+// NegotiateLanguage picks the language of a public response: the explicit
+// ?lang= value, else the best supported entry of an Accept-Language header,
+// else fallback (the restaurant's default language), else English.
+func NegotiateLanguage(explicit, acceptLanguage, fallback string) string {
+	if lang := NormalizeLanguage(explicit); lang != "" {
+		return lang
+	}
+	type weighted struct {
+		lang string
+		q    float64
+	}
+	var prefs []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if lang := NormalizeLanguage(tag); lang != "" && q > 0 {
+			prefs = append(prefs, weighted{lang, q})
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+	if len(prefs) > 0 {
+		return prefs[0].lang
+	}
+	if lang := NormalizeLanguage(fallback); lang != "" {
+		return lang
+	}
+	return LangEnglish
+}
+
+// Translations maps a language code to the translated name of a tab, category
+// or modifier.
+type Translations map[string]string
+
+// In returns the name in lang, or fallback when it has not been translated.
+func (t Translations) In(lang, fallback string) string {
+	if s := strings.TrimSpace(t[lang]); s != "" {
+		return s
+	}
+	return fallback
+}
+
+// With returns a copy of t with lang set to name; an empty name removes it.
+func (t Translations) With(lang, name string) Translations {
+	out := make(Translations, len(t)+1)
+	for k, v := range t {
+		out[k] = v
+	}
+	if name = strings.TrimSpace(name); name == "" {
+		delete(out, lang)
+	} else {
+		out[lang] = name
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// Translatable item fields, as listed in ItemTranslation.MachineTranslated.
+const (
+	FieldName        = "name"
+	FieldDescription = "description"
+	FieldAllergies   = "allergies"
+	FieldTabTags     = "tab_tags"
+	FieldIngredients = "ingredients"
+	FieldHowToEat    = "how_to_eat"
+)
+
+// ItemTranslation is the text of an item in one language. Allergies is free
+// text ("contains milk and gluten") rather than the base list.
+type ItemTranslation struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Allergies   string   `json:"allergies,omitempty"`
+	TabTags     []string `json:"tab_tags,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Ingredients []string `json:"ingredients,omitempty"`
+	HowToEat    string   `json:"how_to_eat,omitempty"`
+	// MachineTranslated lists the fields filled in by machine translation that
+	// a manager has not reviewed yet.
+	MachineTranslated []string `json:"machine_translated,omitempty"`
+}
+
+// IsZero reports whether nothing has been translated.
+func (t ItemTranslation) IsZero() bool {
+	return t.Name == "" && t.Description == "" && t.Allergies == "" && len(t.TabTags) == 0 && t.Category == "" && len(t.Ingredients) == 0 && t.HowToEat == ""
+}
+
+// IsMachineTranslated reports whether field still holds unreviewed machine output.
+func (t ItemTranslation) IsMachineTranslated(field string) bool {
+	for _, f := range t.MachineTranslated {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkMachineTranslated flags field as machine output awaiting review.
+func (t *ItemTranslation) MarkMachineTranslated(field string) {
+	if !t.IsMachineTranslated(field) {
+		t.MachineTranslated = append(t.MachineTranslated, field)
+	}
+}
+
+// MarkReviewed clears the machine translation flag of field.
+func (t *ItemTranslation) MarkReviewed(field string) {
+	var kept []string
+	for _, f := range t.MachineTranslated {
+		if f != field {
+			kept = append(kept, f)
+		}
+	}
+	t.MachineTranslated = kept
+}
+
+// Translation returns the item's stored text in lang without fallbacks.
+func (i *Item) Translation(lang string) ItemTranslation {
+	return i.Translations[lang]
+}
+
+// SetTranslation stores t as the item's text in lang, dropping the entry when
+// t is empty.
+func (i *Item) SetTranslation(lang string, t ItemTranslation) {
+	if t.IsZero() {
+		delete(i.Translations, lang)
+		return
+	}
+	if i.Translations == nil {
+		i.Translations = map[string]ItemTranslation{}
+	}
+	i.Translations[lang] = t
+}
+
+// Localized returns the item's text in lang, field by field falling back to
+// the base text where no translation exists.
+func (i *Item) Localized(lang string) ItemTranslation {
+	t := i.Translations[lang]
+	if t.Name == "" {
+		t.Name = i.Name
+	}
+	if t.Description == "" {
+		t.Description = i.Description
+	}
+	if t.Allergies == "" {
+		t.Allergies = strings.Join(i.Allergies, ", ")
+	}
+	if len(t.TabTags) == 0 {
+		t.TabTags = i.TabTags
+	}
+	if t.Category == "" {
+		t.Category = i.Category
+	}
+	if len(t.Ingredients) == 0 {
+		t.Ingredients = i.Ingredients
+	}
+	if t.HowToEat == "" {
+		t.HowToEat = i.HowToEat
+	}
+	return t
+}
+
+// DisplayName is the item's name, or its first translated name when the base
+// name is empty (items may be entered in Amharic only).
+func (i *Item) DisplayName() string {
+	if s := strings.TrimSpace(i.Name); s != "" {
+		return s
+	}
+	for _, lang := range SupportedLanguages {
+		if s := strings.TrimSpace(i.Translations[lang].Name); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// RestaurantTranslation is the restaurant's name and about text in one language.
+type RestaurantTranslation struct {
+	Name  string `json:"name,omitempty"`
+	About string `json:"about,omitempty"`
+}
+
+// Language returns the restaurant's default language code, English when unset.
+func (r *Restaurant) Language() string {
+	if lang := NormalizeLanguage(r.DefaultLanguage); lang != "" {
+		return lang
+	}
+	return LangEnglish
+}
+
+// Localized returns the restaurant's name and about text in lang, falling back
+// to the base text.
+func (r *Restaurant) Localized(lang string) RestaurantTranslation {
+	t := r.Translations[lang]
+	if t.Name == "" {
+		t.Name = r.RestaurantName
+	}
+	if t.About == "" && r.About != nil {
+		t.About = *r.About
+	}
+	return t
+}
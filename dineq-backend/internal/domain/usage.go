@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// UsageKind is a unit of paid OCR or AI work.
+type UsageKind string
+
+const (
+	UsageOCRPages      UsageKind = "ocr_pages"
+	UsageTokensIn      UsageKind = "tokens_in"
+	UsageTokensOut     UsageKind = "tokens_out"
+	UsageImageSearches UsageKind = "image_searches"
+	// UsageTokens is tokens in and out together; quotas only, never recorded
+	UsageTokens UsageKind = "tokens"
+)
+
+// UsageEntry is one line of the usage ledger: work done for a restaurant on
+// behalf of a user.
+type UsageEntry struct {
+	ID           string
+	RestaurantID string
+	UserID       string
+	Kind         UsageKind
+	Quantity     int64
+	Provider     string
+	Model        string
+	// OCRJobID is the job the work was done for, empty for image searches
+	OCRJobID  string
+	CreatedAt time.Time
+}
+
+// UsageTotals sums the ledger by kind.
+type UsageTotals struct {
+	OCRPages      int64 `json:"ocr_pages"`
+	TokensIn      int64 `json:"tokens_in"`
+	TokensOut     int64 `json:"tokens_out"`
+	ImageSearches int64 `json:"image_searches"`
+}
+
+// Add counts quantity units of kind.
+func (t *UsageTotals) Add(kind UsageKind, quantity int64) {
+	switch kind {
+	case UsageOCRPages:
+		t.OCRPages += quantity
+	case UsageTokensIn:
+		t.TokensIn += quantity
+	case UsageTokensOut:
+		t.TokensOut += quantity
+	case UsageImageSearches:
+		t.ImageSearches += quantity
+	}
+}
+
+// Get is the total of kind; UsageTokens is tokens in plus out.
+func (t UsageTotals) Get(kind UsageKind) int64 {
+	switch kind {
+	case UsageOCRPages:
+		return t.OCRPages
+	case UsageTokensIn:
+		return t.TokensIn
+	case UsageTokensOut:
+		return t.TokensOut
+	case UsageTokens:
+		return t.TokensIn + t.TokensOut
+	case UsageImageSearches:
+		return t.ImageSearches
+	}
+	return 0
+}
+
+// UsageQuota caps the usage of one restaurant or user per calendar month
+// (UTC). Zero fields are unlimited.
+type UsageQuota struct {
+	OCRPages      int64 `json:"ocr_pages,omitempty"`
+	Tokens        int64 `json:"tokens,omitempty"`
+	ImageSearches int64 `json:"image_searches,omitempty"`
+}
+
+// Limit is the quota of kind, 0 when unlimited.
+func (q UsageQuota) Limit(kind UsageKind) int64 {
+	switch kind {
+	case UsageOCRPages:
+		return q.OCRPages
+	case UsageTokens:
+		return q.Tokens
+	case UsageImageSearches:
+		return q.ImageSearches
+	}
+	return 0
+}
+
+// UsageQuotas are the monthly quotas of every restaurant and every user.
+type UsageQuotas struct {
+	Restaurant UsageQuota `json:"restaurant"`
+	User       UsageQuota `json:"user"`
+}
+
+// QuotaExceededError says which monthly quota a request would go over. It
+// matches ErrUsageQuotaExceeded.
+type QuotaExceededError struct {
+	Scope     string    `json:"scope"` // restaurant|user
+	Kind      UsageKind `json:"kind"`
+	Used      int64     `json:"used"` // OCR pages include those of unfinished jobs
+	Requested int64     `json:"requested,omitempty"`
+	Limit     int64     `json:"limit"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("monthly %s quota of the %s exceeded: %d of %d used", e.Kind, e.Scope, e.Used, e.Limit)
+}
+
+func (e *QuotaExceededError) Unwrap() error { return ErrUsageQuotaExceeded }
+
+// UsageGroup is what a usage report is broken down by.
+type UsageGroup string
+
+const (
+	UsageByRestaurant UsageGroup = "restaurant"
+	UsageByUser       UsageGroup = "user"
+)
+
+// UsageReportFilter selects the ledger entries of a report, from inclusive
+// to exclusive.
+type UsageReportFilter struct {
+	From         time.Time
+	To           time.Time
+	GroupBy      UsageGroup
+	RestaurantID string
+	UserID       string
+}
+
+// UsageReportRow is the usage of one restaurant or user.
+type UsageReportRow struct {
+	RestaurantID string `json:"restaurant_id,omitempty"`
+	UserID       string `json:"user_id,omitempty"`
+	UsageTotals
+}
+
+// UsageReport is the usage of a period per restaurant or user, most OCR
+// pages first.
+type UsageReport struct {
+	From    time.Time        `json:"from"`
+	To      time.Time        `json:"to"`
+	GroupBy UsageGroup       `json:"group_by"`
+	Rows    []UsageReportRow `json:"rows"`
+	Total   UsageTotals      `json:"total"`
+	Quotas  UsageQuotas      `json:"quotas"`
+}
+
+type IUsageUseCase interface {
+	// Record appends entries to the ledger. It is best-effort: failures are
+	// logged, never returned, so paid work already done is not lost to them.
+	Record(entries ...UsageEntry)
+	// CheckQuota returns a *QuotaExceededError when requested more units of
+	// kind would go over the monthly quota of the restaurant or the user.
+	// OCR pages of queued and running jobs count as used.
+	// With requested 0 it checks the quota is not used up yet. Empty IDs
+	// are not checked.
+	CheckQuota(restaurantID, userID string, kind UsageKind, requested int64) error
+	Report(filter UsageReportFilter) (*UsageReport, error)
+}
+
+type IUsageRepository interface {
+	Insert(ctx context.Context, entries []UsageEntry) error
+	// Totals sums the entries of the restaurant or user (the other left
+	// empty) since the given time.
+	Totals(ctx context.Context, restaurantID, userID string, since time.Time) (UsageTotals, error)
+	Report(ctx context.Context, filter UsageReportFilter) ([]UsageReportRow, error)
+}
@@ -12,6 +12,10 @@ type ViewEvent struct {
 	Timestamp  time.Time `json:"timestamp" bson:"timestamp"`
 	IP         string    `json:"ip,omitempty" bson:"ip,omitempty"`
 	UserAgent  string    `json:"user_agent,omitempty" bson:"userAgent,omitempty"`
+	// QRCodeID, Table and Location are set for views that came from a scanned QR code
+	QRCodeID string `json:"qr_code_id,omitempty" bson:"qrCodeId,omitempty"`
+	Table    string `json:"table,omitempty" bson:"table,omitempty"`
+	Location string `json:"location,omitempty" bson:"location,omitempty"`
 }
 
 type IViewEventRepository interface {
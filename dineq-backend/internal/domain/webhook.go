@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// WebhookEvent names something that happened to a restaurant that outside
+// systems, such as a POS, can subscribe to.
+type WebhookEvent string
+
+const (
+	WebhookOCRCompleted  WebhookEvent = "ocr.completed"
+	WebhookOCRFailed     WebhookEvent = "ocr.failed"
+	WebhookMenuPublished WebhookEvent = "menu.published"
+	WebhookMenuUpdated   WebhookEvent = "menu.updated"
+)
+
+// WebhookEvents are the events a subscription may ask for.
+var WebhookEvents = []WebhookEvent{WebhookOCRCompleted, WebhookOCRFailed, WebhookMenuPublished, WebhookMenuUpdated}
+
+func (e WebhookEvent) Valid() bool {
+	for _, known := range WebhookEvents {
+		if e == known {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookSubscription sends the chosen events of a restaurant to URL. Every
+// request is signed with Secret, which is only shown when it is created.
+type WebhookSubscription struct {
+	ID           string         `json:"id"`
+	RestaurantID string         `json:"restaurant_id"`
+	URL          string         `json:"url"`
+	Secret       string         `json:"-"`
+	Events       []WebhookEvent `json:"events"`
+	Active       bool           `json:"active"`
+	CreatedBy    string         `json:"created_by,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// Wants reports whether the subscription is active and asked for event.
+func (s *WebhookSubscription) Wants(event WebhookEvent) bool {
+	if !s.Active {
+		return false
+	}
+	for _, e := range s.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookPending   WebhookDeliveryStatus = "pending"
+	WebhookDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookFailed deliveries ran out of attempts; they are only sent again
+	// by a manual redelivery
+	WebhookFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookAttempt is one request made for a delivery.
+type WebhookAttempt struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+// WebhookDelivery is one event on its way to one subscription, and the log
+// of the attempts made to send it.
+type WebhookDelivery struct {
+	ID             string       `json:"id"`
+	SubscriptionID string       `json:"subscription_id"`
+	RestaurantID   string       `json:"restaurant_id"`
+	Event          WebhookEvent `json:"event"`
+	// EventID stays the same across redeliveries so receivers can drop
+	// events they already handled
+	EventID string `json:"event_id"`
+	// Payload is the JSON body sent, byte for byte on every attempt
+	Payload       string                `json:"payload"`
+	Status        WebhookDeliveryStatus `json:"status"`
+	Attempts      []WebhookAttempt      `json:"attempts"`
+	NextAttemptAt *time.Time            `json:"next_attempt_at,omitempty"`
+	DeliveredAt   *time.Time            `json:"delivered_at,omitempty"`
+	// RedeliveryOf is the delivery a manual redelivery copies
+	RedeliveryOf string    `json:"redelivery_of,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// WebhookPayload is the JSON body of every webhook request.
+type WebhookPayload struct {
+	ID           string       `json:"id"`
+	Event        WebhookEvent `json:"event"`
+	RestaurantID string       `json:"restaurant_id"`
+	CreatedAt    time.Time    `json:"created_at"`
+	Data         any          `json:"data"`
+}
+
+// WebhookConfig tunes the delivery of webhooks. Zero fields take defaults.
+type WebhookConfig struct {
+	// MaxAttempts is how many times a delivery is tried before it fails
+	MaxAttempts int
+	// Backoff is the wait after the first failed attempt; it doubles after
+	// each further one up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Timeout bounds each request to a receiver
+	Timeout      time.Duration
+	PollInterval time.Duration
+}
+
+// IWebhookPublisher raises events for the webhooks of a restaurant. Publish
+// never fails the caller: events that cannot be queued are logged.
+type IWebhookPublisher interface {
+	Publish(restaurantID string, event WebhookEvent, data any)
+}
+
+type IWebhookUseCase interface {
+	IWebhookPublisher
+	// CreateSubscription generates the secret of the subscription.
+	CreateSubscription(sub *WebhookSubscription) error
+	GetSubscription(id string) (*WebhookSubscription, error)
+	ListSubscriptions(restaurantID string) ([]*WebhookSubscription, error)
+	// UpdateSubscription saves the URL, events and active flag of sub.
+	UpdateSubscription(sub *WebhookSubscription) error
+	DeleteSubscription(id string) error
+	// ListDeliveries pages through the deliveries of a subscription, newest
+	// first.
+	ListDeliveries(subscriptionID string, page, pageSize int) ([]*WebhookDelivery, int64, error)
+	GetDelivery(id string) (*WebhookDelivery, error)
+	// Redeliver queues a copy of a delivery to be sent right away.
+	Redeliver(id string) (*WebhookDelivery, error)
+	// StartDispatcher sends queued deliveries in the background until ctx
+	// ends.
+	StartDispatcher(ctx context.Context, cfg WebhookConfig)
+}
+
+type IWebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *WebhookSubscription) error
+	GetSubscription(ctx context.Context, id string) (*WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, restaurantID string) ([]*WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, sub *WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id string) error
+
+	CreateDelivery(ctx context.Context, d *WebhookDelivery) error
+	GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID string, page, pageSize int) ([]*WebhookDelivery, int64, error)
+	// ClaimDelivery takes the pending delivery due the longest and moves its
+	// next attempt to leaseUntil, so no other dispatcher sends it meanwhile.
+	// It returns ErrNotFound when nothing is due.
+	ClaimDelivery(ctx context.Context, now, leaseUntil time.Time) (*WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, d *WebhookDelivery) error
+}
@@ -191,6 +191,30 @@ func CreatePasswordResetTokenIndexes(db Database, collection string) error {
 	return nil
 }
 
+// CreateOCRJobIndexes ensures indexes for the OCR job queue: claiming the
+// oldest pending job and finding expired leases.
+func CreateOCRJobIndexes(db Database, collection string) error {
+	mdb, ok := db.(*mongoDatabase)
+	if !ok {
+		return errors.New("unsupported database implementation for index creation")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	idxView := mdb.db.Collection(collection).Indexes()
+	models := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: 1}}, Options: options.Index().SetName("ix_status_createdAt")},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "leaseExpiresAt", Value: 1}}, Options: options.Index().SetName("ix_status_leaseExpiresAt")},
+	}
+	names, err := idxView.CreateMany(ctx, models)
+	if err != nil {
+		log.Debug().Err(err).Str("collection", collection).Msg("ocr job index creation (some may already exist)")
+		return err
+	}
+	log.Info().Strs("indexes", names).Str("collection", collection).Msg("ocr job indexes ensured")
+	return nil
+}
+
 // IndexConfig defines collection names for unified index orchestration.
 type IndexConfig struct {
 	RestaurantCollection    string
@@ -198,6 +222,7 @@ type IndexConfig struct {
 	RefreshTokenCollection  string
 	PasswordResetCollection string
 	OtpCollection           string
+	OCRJobCollection        string
 }
 
 // EnsureIndexes centralizes creation of all indexes using the given configuration.
@@ -236,4 +261,9 @@ func EnsureIndexes(client Client, dbName string, cfg IndexConfig) {
 			log.Warn().Err(err).Str("collection", cfg.OtpCollection).Msg("ensure otp indexes failed")
 		}
 	}
+	if cfg.OCRJobCollection != "" {
+		if err := CreateOCRJobIndexes(db, cfg.OCRJobCollection); err != nil {
+			log.Warn().Err(err).Str("collection", cfg.OCRJobCollection).Msg("ensure ocr job indexes failed")
+		}
+	}
 }
@@ -0,0 +1,115 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type AIParseResultDB struct {
+	ID              bson.ObjectID  `bson:"_id,omitempty"`
+	OCRJobID        string         `bson:"ocrJobId"`
+	RestaurantID    string         `bson:"restaurantId"`
+	UserID          string         `bson:"userId"`
+	RawText         string         `bson:"rawText"`
+	Items           []ParsedItemDB `bson:"items"`
+	ConfidenceScore float64        `bson:"confidenceScore"`
+	Status          string         `bson:"status"`
+	TargetMenuID    string         `bson:"targetMenuId,omitempty"`
+	MenuID          string         `bson:"menuId,omitempty"`
+	ApprovedBy      string         `bson:"approvedBy,omitempty"`
+	ApprovedAt      *time.Time     `bson:"approvedAt,omitempty"`
+	CreatedAt       time.Time      `bson:"createdAt"`
+	UpdatedAt       time.Time      `bson:"updatedAt"`
+}
+
+type ParsedItemDB struct {
+	ID         string     `bson:"id"`
+	Tab        string     `bson:"tab"`
+	Category   string     `bson:"category"`
+	Item       ItemDB     `bson:"item"`
+	Original   *ItemDB    `bson:"original,omitempty"`
+	Confidence float64    `bson:"confidence"`
+	Flags      []string   `bson:"flags,omitempty"`
+	Status     string     `bson:"status"`
+	ReviewedBy string     `bson:"reviewedBy,omitempty"`
+	ReviewedAt *time.Time `bson:"reviewedAt,omitempty"`
+}
+
+func FromDomainAIParseResult(r *domain.AIParseResult) *AIParseResultDB {
+	items := make([]ParsedItemDB, len(r.Items))
+	for i := range r.Items {
+		items[i] = *FromDomainParsedItem(&r.Items[i])
+	}
+	return &AIParseResultDB{
+		ID:              idempotentID(r.ID),
+		OCRJobID:        r.OCRJobID,
+		RestaurantID:    r.RestaurantID,
+		UserID:          r.UserID,
+		RawText:         r.RawText,
+		Items:           items,
+		ConfidenceScore: r.ConfidenceScore,
+		Status:          string(r.Status),
+		TargetMenuID:    r.TargetMenuID,
+		MenuID:          r.MenuID,
+		ApprovedBy:      r.ApprovedBy,
+		ApprovedAt:      r.ApprovedAt,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+}
+
+func FromDomainParsedItem(p *domain.ParsedItem) *ParsedItemDB {
+	db := &ParsedItemDB{
+		ID:         p.ID,
+		Tab:        p.Tab,
+		Category:   p.Category,
+		Item:       *NewItemDBFromDomain(&p.Item),
+		Confidence: p.Confidence,
+		Flags:      p.Flags,
+		Status:     string(p.Status),
+		ReviewedBy: p.ReviewedBy,
+		ReviewedAt: p.ReviewedAt,
+	}
+	if p.Original != nil {
+		db.Original = NewItemDBFromDomain(p.Original)
+	}
+	return db
+}
+
+func ToDomainAIParseResult(db *AIParseResultDB) *domain.AIParseResult {
+	items := make([]domain.ParsedItem, len(db.Items))
+	for i, it := range db.Items {
+		items[i] = domain.ParsedItem{
+			ID:         it.ID,
+			Tab:        it.Tab,
+			Category:   it.Category,
+			Item:       *ToDomainItem(&it.Item),
+			Confidence: it.Confidence,
+			Flags:      it.Flags,
+			Status:     domain.ParsedItemStatus(it.Status),
+			ReviewedBy: it.ReviewedBy,
+			ReviewedAt: it.ReviewedAt,
+		}
+		if it.Original != nil {
+			items[i].Original = ToDomainItem(it.Original)
+		}
+	}
+	return &domain.AIParseResult{
+		ID:              db.ID.Hex(),
+		OCRJobID:        db.OCRJobID,
+		RestaurantID:    db.RestaurantID,
+		UserID:          db.UserID,
+		RawText:         db.RawText,
+		Items:           items,
+		ConfidenceScore: db.ConfidenceScore,
+		Status:          domain.ParseResultStatus(db.Status),
+		TargetMenuID:    db.TargetMenuID,
+		MenuID:          db.MenuID,
+		ApprovedBy:      db.ApprovedBy,
+		ApprovedAt:      db.ApprovedAt,
+		CreatedAt:       db.CreatedAt,
+		UpdatedAt:       db.UpdatedAt,
+	}
+}
@@ -10,16 +10,13 @@ import (
 type ItemDB struct {
 	ID              bson.ObjectID           `bson:"_id,omitempty"`
 	Name            string                  `bson:"name"`
-	NameAm          string                  `bson:"nameAm"`
 	Slug            string                  `bson:"slug"`
 	MenuSlug        string                  `bson:"menuSlug"`
 	Description     string                  `bson:"description"`
-	DescriptionAm   string                  `bson:"descriptionAm"`
 	Image           []string                `bson:"image"`
 	Price           float64                 `bson:"price"`
 	Currency        string                  `bson:"currency"`
 	Allergies       []string                `bson:"allergies"`
-	AllergiesAm     string                  `bson:"allergiesAm"`
 	UserImages      []string                `bson:"userImages"`
 	Calories        int                     `bson:"calories"`
 	Protein         int                     `bson:"protein"`
@@ -27,12 +24,10 @@ type ItemDB struct {
 	Fat             int                     `bson:"fat"`
 	NutritionalInfo *domain.NutritionalInfo `bson:"nutritionalInfo,omitempty"`
 	TabTags         []string                `bson:"tabTags"`
-	TabTagsAm       []string                `bson:"tabTagsAm"`
+	Category        string                  `bson:"category,omitempty"`
 	Ingredients     []string                `bson:"ingredients"`
-	IngredientsAm   []string                `bson:"ingredientsAm"`
 	PreparationTime int                     `bson:"preparationTime"`
 	HowToEat        string                  `bson:"howToEat"`
-	HowToEatAm      string                  `bson:"howToEatAm"`
 	CreatedAt       time.Time               `bson:"createdAt"`
 	UpdatedAt       time.Time               `bson:"updatedAt"`
 	IsDeleted       bool                    `bson:"isDeleted"`
@@ -40,6 +35,132 @@ type ItemDB struct {
 	AverageRating   float64                 `bson:"averageRating"`
 	ReviewIDs       []string                `bson:"reviewIds"`
 	DeletedAt       *time.Time              `bson:"deletedAt,omitempty"`
+	// omitempty keeps MergeItemUpdate from clearing availability on partial updates
+	Unavailable      bool       `bson:"unavailable,omitempty"`
+	UnavailableUntil *time.Time `bson:"unavailableUntil,omitempty"`
+	// ModifierGroups carries sizes/choices/add-ons; PriceMin and PriceMax are the
+	// resulting price range, stored so search can filter on it
+	ModifierGroups []ModifierGroupDB `bson:"modifierGroups,omitempty"`
+	PriceMin       *float64          `bson:"priceMin,omitempty"`
+	PriceMax       *float64          `bson:"priceMax,omitempty"`
+	// Translations is always written, so an empty map means "no translations"
+	// rather than a document from before per-language storage
+	Translations map[string]ItemTranslationDB `bson:"translations"`
+	// Legacy Amharic fields, only read to migrate older documents
+	NameAm        string   `bson:"nameAm,omitempty"`
+	DescriptionAm string   `bson:"descriptionAm,omitempty"`
+	AllergiesAm   string   `bson:"allergiesAm,omitempty"`
+	TabTagsAm     []string `bson:"tabTagsAm,omitempty"`
+	IngredientsAm []string `bson:"ingredientsAm,omitempty"`
+	HowToEatAm    string   `bson:"howToEatAm,omitempty"`
+}
+
+type ItemTranslationDB struct {
+	Name        string   `bson:"name,omitempty"`
+	Description string   `bson:"description,omitempty"`
+	Allergies   string   `bson:"allergies,omitempty"`
+	TabTags     []string `bson:"tabTags,omitempty"`
+	Category    string   `bson:"category,omitempty"`
+	Ingredients []string `bson:"ingredients,omitempty"`
+	HowToEat    string   `bson:"howToEat,omitempty"`
+	// fields still awaiting review after machine translation
+	MachineTranslated []string `bson:"machineTranslated,omitempty"`
+}
+
+type ModifierGroupDB struct {
+	ID           string             `bson:"id"`
+	Name         string             `bson:"name"`
+	MinSelect    int                `bson:"minSelect"`
+	MaxSelect    int                `bson:"maxSelect"`
+	Options      []ModifierOptionDB `bson:"options"`
+	Translations map[string]string  `bson:"translations,omitempty"`
+	NameAm       string             `bson:"nameAm,omitempty"` // legacy, read only
+}
+
+type ModifierOptionDB struct {
+	ID           string            `bson:"id"`
+	Name         string            `bson:"name"`
+	PriceDelta   float64           `bson:"priceDelta"`
+	IsDefault    bool              `bson:"isDefault,omitempty"`
+	Translations map[string]string `bson:"translations,omitempty"`
+	NameAm       string            `bson:"nameAm,omitempty"` // legacy, read only
+}
+
+// setModifiers copies the modifier groups of item onto db together with the
+// derived price range.
+func (db *ItemDB) setModifiers(item *domain.Item) {
+	if len(item.ModifierGroups) == 0 {
+		return
+	}
+	db.ModifierGroups = make([]ModifierGroupDB, len(item.ModifierGroups))
+	for i, g := range item.ModifierGroups {
+		options := make([]ModifierOptionDB, len(g.Options))
+		for j, o := range g.Options {
+			options[j] = ModifierOptionDB{ID: o.ID, Name: o.Name, PriceDelta: o.PriceDelta, IsDefault: o.IsDefault, Translations: o.Translations}
+		}
+		db.ModifierGroups[i] = ModifierGroupDB{ID: g.ID, Name: g.Name, MinSelect: g.MinSelect, MaxSelect: g.MaxSelect, Options: options, Translations: g.Translations}
+	}
+	low, high := item.PriceRange()
+	db.PriceMin = &low
+	db.PriceMax = &high
+}
+
+func toDomainModifierGroups(groups []ModifierGroupDB) []domain.ModifierGroup {
+	if len(groups) == 0 {
+		return nil
+	}
+	out := make([]domain.ModifierGroup, len(groups))
+	for i, g := range groups {
+		options := make([]domain.ModifierOption, len(g.Options))
+		for j, o := range g.Options {
+			options[j] = domain.ModifierOption{ID: o.ID, Name: o.Name, PriceDelta: o.PriceDelta, IsDefault: o.IsDefault, Translations: legacyTranslations(o.Translations, o.NameAm)}
+		}
+		out[i] = domain.ModifierGroup{ID: g.ID, Name: g.Name, MinSelect: g.MinSelect, MaxSelect: g.MaxSelect, Options: options, Translations: legacyTranslations(g.Translations, g.NameAm)}
+	}
+	return out
+}
+
+func itemTranslationsToDB(translations map[string]domain.ItemTranslation) map[string]ItemTranslationDB {
+	out := make(map[string]ItemTranslationDB, len(translations))
+	for lang, t := range translations {
+		out[lang] = ItemTranslationDB(t)
+	}
+	return out
+}
+
+// domainTranslations returns the stored translations, or for documents written
+// before per-language storage, the legacy Amharic fields.
+func (db *ItemDB) domainTranslations() map[string]domain.ItemTranslation {
+	if db.Translations == nil {
+		am := domain.ItemTranslation{
+			Name:        db.NameAm,
+			Description: db.DescriptionAm,
+			Allergies:   db.AllergiesAm,
+			TabTags:     db.TabTagsAm,
+			Ingredients: db.IngredientsAm,
+			HowToEat:    db.HowToEatAm,
+		}
+		if am.IsZero() {
+			return nil
+		}
+		return map[string]domain.ItemTranslation{domain.LangAmharic: am}
+	}
+	if len(db.Translations) == 0 {
+		return nil
+	}
+	out := make(map[string]domain.ItemTranslation, len(db.Translations))
+	for lang, t := range db.Translations {
+		out[lang] = domain.ItemTranslation(t)
+	}
+	return out
+}
+
+// legacyTranslations folds a legacy Amharic name into a name translation map.
+func legacyTranslations(t map[string]string, nameAm string) domain.Translations {
+	if t == nil && nameAm != "" {
+		return domain.Translations{domain.LangAmharic: nameAm}
+	}
+	return t
 }
 
 // ---------- Creation ----------
@@ -49,72 +170,72 @@ func NewItemDBFromDomain(item *domain.Item) *ItemDB {
 	itemId := idempotentID(item.ID)
 	item.ID = itemId.Hex()
 
-	return &ItemDB{
-		ID:              itemId,
-		Name:            item.Name,
-		NameAm:          item.NameAm,
-		Slug:            item.Slug,
-		MenuSlug:        item.MenuSlug,
-		Description:     item.Description,
-		DescriptionAm:   item.DescriptionAm,
-		Image:           item.Image,
-		Price:           item.Price,
-		Currency:        item.Currency,
-		Allergies:       item.Allergies,
-		AllergiesAm:     item.AllergiesAm,
-		UserImages:      item.UserImages,
-		Calories:        item.Calories,
-		Protein:         item.Protein,
-		Carbs:           item.Carbs,
-		Fat:             item.Fat,
-		NutritionalInfo: item.NutritionalInfo,
-		TabTags:         item.TabTags,
-		TabTagsAm:       item.TabTagsAm,
-		Ingredients:     item.Ingredients,
-		IngredientsAm:   item.IngredientsAm,
-		PreparationTime: item.PreparationTime,
-		HowToEat:        item.HowToEat,
-		HowToEatAm:      item.HowToEatAm,
-		CreatedAt:       now,
-		UpdatedAt:       now,
-		IsDeleted:       false,
-		ViewCount:       0,
-		AverageRating:   0,
-		ReviewIDs:       []string{},
-		DeletedAt:       nil,
+	db := &ItemDB{
+		ID:               itemId,
+		Name:             item.Name,
+		Slug:             item.Slug,
+		MenuSlug:         item.MenuSlug,
+		Description:      item.Description,
+		Image:            item.Image,
+		Price:            item.Price,
+		Currency:         item.Currency,
+		Allergies:        item.Allergies,
+		UserImages:       item.UserImages,
+		Calories:         item.Calories,
+		Protein:          item.Protein,
+		Carbs:            item.Carbs,
+		Fat:              item.Fat,
+		NutritionalInfo:  item.NutritionalInfo,
+		TabTags:          item.TabTags,
+		Category:         item.Category,
+		Ingredients:      item.Ingredients,
+		PreparationTime:  item.PreparationTime,
+		HowToEat:         item.HowToEat,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		IsDeleted:        false,
+		ViewCount:        0,
+		AverageRating:    0,
+		ReviewIDs:        []string{},
+		DeletedAt:        nil,
+		Translations:     itemTranslationsToDB(item.Translations),
+		Unavailable:      item.Unavailable,
+		UnavailableUntil: item.UnavailableUntil,
 	}
+	db.setModifiers(item)
+	return db
 }
 
 // ---------- Update ----------
 
 func MergeItemUpdate(updated *domain.Item) *ItemDB {
-	return &ItemDB{
+	db := &ItemDB{
 		ID:              idempotentID(updated.ID),
 		Name:            updated.Name,
-		NameAm:          updated.NameAm,
 		Slug:            updated.Slug,
 		MenuSlug:        updated.MenuSlug,
 		Description:     updated.Description,
-		DescriptionAm:   updated.DescriptionAm,
 		Image:           updated.Image,
 		Price:           updated.Price,
 		TabTags:         updated.TabTags,
+		Category:        updated.Category,
 		Currency:        updated.Currency,
 		Allergies:       updated.Allergies,
 		UserImages:      updated.UserImages,
 		Calories:        updated.Calories,
 		Ingredients:     updated.Ingredients,
-		IngredientsAm:   updated.IngredientsAm,
 		PreparationTime: updated.PreparationTime,
 		HowToEat:        updated.HowToEat,
-		HowToEatAm:      updated.HowToEatAm,
 		CreatedAt:       time.Now().UTC(),
 		UpdatedAt:       time.Now().UTC(),
 		IsDeleted:       updated.IsDeleted,
 		ViewCount:       updated.ViewCount,
 		AverageRating:   updated.AverageRating,
 		ReviewIDs:       updated.ReviewIds,
+		Translations:    itemTranslationsToDB(updated.Translations),
 	}
+	db.setModifiers(updated)
+	return db
 }
 
 func ToItemDBForUpdate(it *domain.Item) *ItemDB {
@@ -129,75 +250,75 @@ func ToItemDBForUpdate(it *domain.Item) *ItemDB {
 	if updatedAt.IsZero() { // only set now if not already provided
 		updatedAt = time.Now().UTC()
 	}
-	return &ItemDB{
-		ID:              idempotentID(it.ID),
-		Name:            it.Name,
-		NameAm:          it.NameAm,
-		Slug:            it.Slug,
-		MenuSlug:        it.MenuSlug,
-		Description:     it.Description,
-		DescriptionAm:   it.DescriptionAm,
-		Image:           it.Image,
-		Price:           it.Price,
-		Currency:        it.Currency,
-		Allergies:       it.Allergies,
-		AllergiesAm:     it.AllergiesAm,
-		UserImages:      it.UserImages,
-		Calories:        it.Calories,
-		Protein:         it.Protein,
-		Carbs:           it.Carbs,
-		Fat:             it.Fat,
-		NutritionalInfo: it.NutritionalInfo,
-		TabTags:         it.TabTags,
-		TabTagsAm:       it.TabTagsAm,
-		Ingredients:     it.Ingredients,
-		IngredientsAm:   it.IngredientsAm,
-		PreparationTime: it.PreparationTime,
-		HowToEat:        it.HowToEat,
-		HowToEatAm:      it.HowToEatAm,
-		CreatedAt:       createdAt,
-		UpdatedAt:       updatedAt,
-		IsDeleted:       it.IsDeleted,
-		ViewCount:       it.ViewCount,
-		AverageRating:   it.AverageRating,
-		ReviewIDs:       it.ReviewIds,
+	db := &ItemDB{
+		ID:               idempotentID(it.ID),
+		Name:             it.Name,
+		Slug:             it.Slug,
+		MenuSlug:         it.MenuSlug,
+		Description:      it.Description,
+		Image:            it.Image,
+		Price:            it.Price,
+		Currency:         it.Currency,
+		Allergies:        it.Allergies,
+		UserImages:       it.UserImages,
+		Calories:         it.Calories,
+		Protein:          it.Protein,
+		Carbs:            it.Carbs,
+		Fat:              it.Fat,
+		NutritionalInfo:  it.NutritionalInfo,
+		TabTags:          it.TabTags,
+		Category:         it.Category,
+		Ingredients:      it.Ingredients,
+		PreparationTime:  it.PreparationTime,
+		HowToEat:         it.HowToEat,
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+		IsDeleted:        it.IsDeleted,
+		ViewCount:        it.ViewCount,
+		AverageRating:    it.AverageRating,
+		ReviewIDs:        it.ReviewIds,
+		Translations:     itemTranslationsToDB(it.Translations),
+		Unavailable:      it.Unavailable,
+		UnavailableUntil: it.UnavailableUntil,
 	}
+	db.setModifiers(it)
+	return db
 }
 
 // ---------- Conversion ----------
 
 func ToDomainItem(item *ItemDB) *domain.Item {
 	return &domain.Item{
-		ID:              item.ID.Hex(),
-		Name:            item.Name,
-		NameAm:          item.NameAm,
-		Slug:            item.Slug,
-		Description:     item.Description,
-		DescriptionAm:   item.DescriptionAm,
-		Image:           item.Image,
-		Price:           item.Price,
-		Currency:        item.Currency,
-		TabTags:         item.TabTags,
-		Allergies:       item.Allergies,
-		AllergiesAm:     item.AllergiesAm,
-		UserImages:      item.UserImages,
-		Calories:        item.Calories,
-		Protein:         item.Protein,
-		Carbs:           item.Carbs,
-		Fat:             item.Fat,
-		NutritionalInfo: item.NutritionalInfo,
-		TabTagsAm:       item.TabTagsAm,
-		Ingredients:     item.Ingredients,
-		IngredientsAm:   item.IngredientsAm,
-		PreparationTime: item.PreparationTime,
-		HowToEat:        item.HowToEat,
-		HowToEatAm:      item.HowToEatAm,
-		CreatedAt:       item.CreatedAt,
-		UpdatedAt:       item.UpdatedAt,
-		IsDeleted:       item.IsDeleted,
-		ViewCount:       item.ViewCount,
-		AverageRating:   item.AverageRating,
-		ReviewIds:       item.ReviewIDs,
+		ID:               item.ID.Hex(),
+		Name:             item.Name,
+		Slug:             item.Slug,
+		MenuSlug:         item.MenuSlug,
+		Description:      item.Description,
+		Image:            item.Image,
+		Price:            item.Price,
+		Currency:         item.Currency,
+		TabTags:          item.TabTags,
+		Category:         item.Category,
+		Allergies:        item.Allergies,
+		UserImages:       item.UserImages,
+		Calories:         item.Calories,
+		Protein:          item.Protein,
+		Carbs:            item.Carbs,
+		Fat:              item.Fat,
+		NutritionalInfo:  item.NutritionalInfo,
+		Ingredients:      item.Ingredients,
+		PreparationTime:  item.PreparationTime,
+		HowToEat:         item.HowToEat,
+		CreatedAt:        item.CreatedAt,
+		UpdatedAt:        item.UpdatedAt,
+		IsDeleted:        item.IsDeleted,
+		ViewCount:        item.ViewCount,
+		AverageRating:    item.AverageRating,
+		ReviewIds:        item.ReviewIDs,
+		Unavailable:      item.Unavailable,
+		UnavailableUntil: item.UnavailableUntil,
+		ModifierGroups:   toDomainModifierGroups(item.ModifierGroups),
+		Translations:     item.domainTranslations(),
 	}
 }
 
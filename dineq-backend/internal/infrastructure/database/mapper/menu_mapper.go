@@ -8,22 +8,47 @@ import (
 )
 
 type MenuDB struct {
-	ID             bson.ObjectID `bson:"_id,omitempty"`
-	Name           string        `bson:"name"`
-	RestaurantID   string        `bson:"restaurantId"`
-	RestaurantSlug string        `bson:"RestaurantSlug"`
-	Slug           string        `bson:"slug"`
-	Version        int           `bson:"version"`
-	IsPublished    bool          `bson:"isPublished"`
-	PublishedAt    time.Time     `bson:"publishedAt"`
-	Items          []ItemDB      `bson:"items"`
-	CreatedAt      time.Time     `bson:"createdAt"`
-	UpdatedAt      time.Time     `bson:"updatedAt"`
-	CreatedBy      string        `bson:"createdBy"`
-	UpdatedBy      string        `bson:"updatedBy"`
-	IsDeleted      bool          `bson:"isDeleted"`
-	DeletedAt      *time.Time    `bson:"deletedAt,omitempty"`
-	ViewCount      int           `bson:"viewCount"`
+	ID             bson.ObjectID    `bson:"_id,omitempty"`
+	Name           string           `bson:"name"`
+	RestaurantID   string           `bson:"restaurantId"`
+	RestaurantSlug string           `bson:"RestaurantSlug"`
+	Slug           string           `bson:"slug"`
+	Version        int              `bson:"version"`
+	IsPublished    bool             `bson:"isPublished"`
+	PublishedAt    time.Time        `bson:"publishedAt"`
+	Items          []ItemDB         `bson:"items"`
+	CreatedAt      time.Time        `bson:"createdAt"`
+	UpdatedAt      time.Time        `bson:"updatedAt"`
+	CreatedBy      string           `bson:"createdBy"`
+	UpdatedBy      string           `bson:"updatedBy"`
+	IsDeleted      bool             `bson:"isDeleted"`
+	DeletedAt      *time.Time       `bson:"deletedAt,omitempty"`
+	ViewCount      int              `bson:"viewCount"`
+	Published      *PublishedMenuDB `bson:"published,omitempty"`
+	Schedule       *MenuScheduleDB  `bson:"schedule,omitempty"`
+}
+
+type MenuScheduleDB struct {
+	Windows     []MenuActivationWindowDB `bson:"windows"`
+	PublishAt   *time.Time               `bson:"publishAt,omitempty"`
+	UnpublishAt *time.Time               `bson:"unpublishAt,omitempty"`
+}
+
+type MenuActivationWindowDB struct {
+	Days      []string `bson:"days,omitempty"`
+	StartTime string   `bson:"startTime,omitempty"`
+	EndTime   string   `bson:"endTime,omitempty"`
+	StartDate string   `bson:"startDate,omitempty"`
+	EndDate   string   `bson:"endDate,omitempty"`
+}
+
+// PublishedMenuDB is the customer-facing copy embedded in the menu document.
+type PublishedMenuDB struct {
+	Name        string    `bson:"name"`
+	Items       []ItemDB  `bson:"items"`
+	Version     int       `bson:"version"`
+	PublishedAt time.Time `bson:"publishedAt"`
+	PublishedBy string    `bson:"publishedBy"`
 }
 
 // ---------- Creation ----------
@@ -56,6 +81,8 @@ func NewMenuDBFromDomain(menu *domain.Menu) *MenuDB {
 		UpdatedBy:      menu.UpdatedBy,
 		IsDeleted:      false,
 		ViewCount:      0,
+		Published:      ToPublishedMenuDB(menu.Published),
+		Schedule:       ToMenuScheduleDB(menu.Schedule),
 	}
 }
 
@@ -113,6 +140,78 @@ func ToDomainMenu(menu *MenuDB) *domain.Menu {
 		IsDeleted:      menu.IsDeleted,
 		ViewCount:      menu.ViewCount,
 		DeletedAt:      menu.DeletedAt,
+		Published:      toDomainPublishedMenu(menu.Published),
+		Schedule:       toDomainMenuSchedule(menu.Schedule),
+	}
+}
+
+func ToMenuScheduleDB(s *domain.MenuSchedule) *MenuScheduleDB {
+	if s == nil {
+		return nil
+	}
+	windows := make([]MenuActivationWindowDB, len(s.Windows))
+	for i, w := range s.Windows {
+		windows[i] = MenuActivationWindowDB{
+			Days:      w.Days,
+			StartTime: w.StartTime,
+			EndTime:   w.EndTime,
+			StartDate: w.StartDate,
+			EndDate:   w.EndDate,
+		}
+	}
+	return &MenuScheduleDB{Windows: windows, PublishAt: s.PublishAt, UnpublishAt: s.UnpublishAt}
+}
+
+func toDomainMenuSchedule(s *MenuScheduleDB) *domain.MenuSchedule {
+	if s == nil {
+		return nil
+	}
+	windows := make([]domain.MenuActivationWindow, len(s.Windows))
+	for i, w := range s.Windows {
+		windows[i] = domain.MenuActivationWindow{
+			Days:      w.Days,
+			StartTime: w.StartTime,
+			EndTime:   w.EndTime,
+			StartDate: w.StartDate,
+			EndDate:   w.EndDate,
+		}
+	}
+	return &domain.MenuSchedule{Windows: windows, PublishAt: s.PublishAt, UnpublishAt: s.UnpublishAt}
+}
+
+// ToPublishedMenuDB keeps item ids and timestamps so published items stay
+// addressable by the same id as their draft counterparts.
+func ToPublishedMenuDB(p *domain.PublishedMenu) *PublishedMenuDB {
+	if p == nil {
+		return nil
+	}
+	var items []ItemDB
+	for i := range p.Items {
+		items = append(items, *ToItemDBForUpdate(&p.Items[i]))
+	}
+	return &PublishedMenuDB{
+		Name:        p.Name,
+		Items:       items,
+		Version:     p.Version,
+		PublishedAt: p.PublishedAt,
+		PublishedBy: p.PublishedBy,
+	}
+}
+
+func toDomainPublishedMenu(p *PublishedMenuDB) *domain.PublishedMenu {
+	if p == nil {
+		return nil
+	}
+	var items []domain.Item
+	for _, item := range p.Items {
+		items = append(items, *ToDomainItem(&item))
+	}
+	return &domain.PublishedMenu{
+		Name:        p.Name,
+		Items:       items,
+		Version:     p.Version,
+		PublishedAt: p.PublishedAt,
+		PublishedBy: p.PublishedBy,
 	}
 }
 
@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type MenuVersionDB struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	MenuID    string        `bson:"menuId"`
+	Version   int           `bson:"version"`
+	Action    string        `bson:"action"`
+	Snapshot  MenuDB        `bson:"snapshot"`
+	CreatedBy string        `bson:"createdBy"`
+	CreatedAt time.Time     `bson:"createdAt"`
+}
+
+// NewMenuVersionDBFromDomain freezes the given menu as a snapshot document.
+// Unlike NewMenuDBFromDomain it keeps ids, timestamps and version untouched.
+func NewMenuVersionDBFromDomain(v *domain.MenuVersion) *MenuVersionDB {
+	menu := &v.Snapshot
+	var items []ItemDB
+	for i := range menu.Items {
+		items = append(items, *ToItemDBForUpdate(&menu.Items[i]))
+	}
+	menuID, _ := bson.ObjectIDFromHex(menu.ID)
+
+	return &MenuVersionDB{
+		MenuID:  v.MenuID,
+		Version: v.Version,
+		Action:  string(v.Action),
+		Snapshot: MenuDB{
+			ID:             menuID,
+			Name:           menu.Name,
+			RestaurantID:   menu.RestaurantID,
+			RestaurantSlug: menu.RestaurantSlug,
+			Slug:           menu.Slug,
+			Version:        menu.Version,
+			IsPublished:    menu.IsPublished,
+			PublishedAt:    menu.PublishedAt,
+			Items:          items,
+			CreatedAt:      menu.CreatedAt,
+			UpdatedAt:      menu.UpdatedAt,
+			CreatedBy:      menu.CreatedBy,
+			UpdatedBy:      menu.UpdatedBy,
+			IsDeleted:      menu.IsDeleted,
+			DeletedAt:      menu.DeletedAt,
+			ViewCount:      menu.ViewCount,
+		},
+		CreatedBy: v.CreatedBy,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+func ToDomainMenuVersion(v *MenuVersionDB) *domain.MenuVersion {
+	return &domain.MenuVersion{
+		ID:        v.ID.Hex(),
+		MenuID:    v.MenuID,
+		Version:   v.Version,
+		Action:    domain.MenuVersionAction(v.Action),
+		Snapshot:  *ToDomainMenu(&v.Snapshot),
+		CreatedBy: v.CreatedBy,
+		CreatedAt: v.CreatedAt,
+	}
+}
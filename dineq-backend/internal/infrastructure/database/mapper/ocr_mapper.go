@@ -11,10 +11,13 @@ type OCRJobDB struct {
 	ID                  bson.ObjectID        `bson:"_id,omitempty"`
 	RestaurantID        string               `bson:"restaurantId"`
 	ImageURL            string               `bson:"imageUrl"`
+	ProcessedImageURL   string               `bson:"processedImageUrl,omitempty"`
+	Pages               []domain.OCRPage     `bson:"pages,omitempty"`
 	UserID              string               `bson:"userId"`
 	Status              string               `bson:"status"`
 	ResultText          string               `bson:"resultText"`
 	StructuredMenuID    string               `bson:"structuredMenuId"`
+	TargetMenuID        string               `bson:"targetMenuId,omitempty"`
 	Error               string               `bson:"error"`
 	CreatedAt           time.Time            `bson:"createdAt"`
 	UpdatedAt           time.Time            `bson:"updatedAt"`
@@ -25,6 +28,13 @@ type OCRJobDB struct {
 	Phase               string               `bson:"phase,omitempty"`
 	Progress            int                  `bson:"progress,omitempty"`
 	PhaseHistory        []domain.OCRPhase    `bson:"phaseHistory,omitempty"`
+	AICalls             []domain.AICall      `bson:"aiCalls,omitempty"`
+	ImagesPurgedAt      *time.Time           `bson:"imagesPurgedAt,omitempty"`
+	// lease fields are set by the queue operations only; FromDomainOCRJob
+	// leaves them empty so a full update never overwrites a renewed lease
+	LeaseOwner     string     `bson:"leaseOwner,omitempty"`
+	LeaseExpiresAt *time.Time `bson:"leaseExpiresAt,omitempty"`
+	Attempts       int        `bson:"attempts,omitempty"`
 }
 
 func ToDomainOCRJob(m *OCRJobDB) *domain.OCRJob {
@@ -33,9 +43,12 @@ func ToDomainOCRJob(m *OCRJobDB) *domain.OCRJob {
 		RestaurantID:        m.RestaurantID,
 		UserID:              m.UserID,
 		ImageURL:            m.ImageURL,
+		ProcessedImageURL:   m.ProcessedImageURL,
+		Pages:               m.Pages,
 		Status:              domain.OCRJobStatus(m.Status),
 		ResultText:          m.ResultText,
 		StructuredMenuID:    m.StructuredMenuID,
+		TargetMenuID:        m.TargetMenuID,
 		Error:               m.Error,
 		CreatedAt:           m.CreatedAt,
 		UpdatedAt:           m.UpdatedAt,
@@ -46,6 +59,11 @@ func ToDomainOCRJob(m *OCRJobDB) *domain.OCRJob {
 		Phase:               m.Phase,
 		Progress:            m.Progress,
 		PhaseHistory:        m.PhaseHistory,
+		AICalls:             m.AICalls,
+		ImagesPurgedAt:      m.ImagesPurgedAt,
+		LeaseOwner:          m.LeaseOwner,
+		LeaseExpiresAt:      m.LeaseExpiresAt,
+		Attempts:            m.Attempts,
 	}
 }
 
@@ -61,9 +79,12 @@ func FromDomainOCRJob(d *domain.OCRJob) *OCRJobDB {
 		RestaurantID:        d.RestaurantID,
 		UserID:              d.UserID,
 		ImageURL:            d.ImageURL,
+		ProcessedImageURL:   d.ProcessedImageURL,
+		Pages:               d.Pages,
 		Status:              string(d.Status),
 		ResultText:          d.ResultText,
 		StructuredMenuID:    d.StructuredMenuID,
+		TargetMenuID:        d.TargetMenuID,
 		Error:               d.Error,
 		CreatedAt:           d.CreatedAt,
 		UpdatedAt:           d.UpdatedAt,
@@ -74,5 +95,7 @@ func FromDomainOCRJob(d *domain.OCRJob) *OCRJobDB {
 		Phase:               d.Phase,
 		Progress:            d.Progress,
 		PhaseHistory:        d.PhaseHistory,
+		AICalls:             d.AICalls,
+		ImagesPurgedAt:      d.ImagesPurgedAt,
 	}
 }
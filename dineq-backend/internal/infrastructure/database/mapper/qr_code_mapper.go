@@ -11,14 +11,31 @@ type QRCodeModel struct {
 	ID            bson.ObjectID `bson:"_id,omitempty"`
 	ImageURL      string        `bson:"imageUrl"`
 	PublicMenuURL string        `bson:"publicMenuUrl"`
+	ScanURL       string        `bson:"scanUrl,omitempty"`
 	DownloadURL   string        `bson:"downloadUrl"`
 	MenuID        string        `bson:"menuId"`
 	RestaurantID  string        `bson:"restaurantId"`
+	Label         string        `bson:"label,omitempty"`
+	Table         string        `bson:"table,omitempty"`
+	Location      string        `bson:"location,omitempty"`
 	IsActive      bool          `bson:"isActive"`
 	CreatedAt     time.Time     `bson:"createdAt"`
 	ExpiresAt     time.Time     `bson:"expiresAt"`
 	IsDeleted     bool          `bson:"isDeleted"`
 	DeletedAt     *time.Time    `bson:"deletedAt"`
+	ScanCount     int64         `bson:"scanCount"`
+	LastScannedAt *time.Time    `bson:"lastScannedAt,omitempty"`
+}
+
+type QRScanModel struct {
+	ID           bson.ObjectID `bson:"_id,omitempty"`
+	QRCodeID     string        `bson:"qrCodeId"`
+	RestaurantID string        `bson:"restaurantId"`
+	MenuID       string        `bson:"menuId,omitempty"`
+	Table        string        `bson:"table,omitempty"`
+	Location     string        `bson:"location,omitempty"`
+	Device       string        `bson:"device"`
+	ScannedAt    time.Time     `bson:"scannedAt"`
 }
 
 // mapper
@@ -27,28 +44,56 @@ func ToDomainQRCode(m *QRCodeModel) *domain.QRCode {
 		ID:            m.ID.Hex(),
 		ImageURL:      m.ImageURL,
 		PublicMenuURL: m.PublicMenuURL,
+		ScanURL:       m.ScanURL,
 		DownloadURL:   m.DownloadURL,
 		MenuID:        m.MenuID,
 		RestaurantID:  m.RestaurantID,
+		Label:         m.Label,
+		Table:         m.Table,
+		Location:      m.Location,
 		IsActive:      m.IsActive,
 		CreatedAt:     m.CreatedAt,
 		ExpiresAt:     m.ExpiresAt,
 		IsDeleted:     m.IsDeleted,
 		DeletedAt:     m.DeletedAt,
+		ScanCount:     m.ScanCount,
+		LastScannedAt: m.LastScannedAt,
 	}
 }
 
+// ToModelQRCode keeps an ID generated ahead of the insert, so it matches the
+// one encoded in the code's public menu URL.
 func ToModelQRCode(d *domain.QRCode) *QRCodeModel {
+	id, _ := bson.ObjectIDFromHex(d.ID)
 	return &QRCodeModel{
+		ID:            id,
 		ImageURL:      d.ImageURL,
 		PublicMenuURL: d.PublicMenuURL,
+		ScanURL:       d.ScanURL,
 		DownloadURL:   d.DownloadURL,
 		MenuID:        d.MenuID,
 		RestaurantID:  d.RestaurantID,
+		Label:         d.Label,
+		Table:         d.Table,
+		Location:      d.Location,
 		IsActive:      d.IsActive,
 		CreatedAt:     d.CreatedAt,
 		ExpiresAt:     d.ExpiresAt,
 		IsDeleted:     d.IsDeleted,
 		DeletedAt:     d.DeletedAt,
+		ScanCount:     d.ScanCount,
+		LastScannedAt: d.LastScannedAt,
+	}
+}
+
+func ToModelQRScan(d *domain.QRScan) *QRScanModel {
+	return &QRScanModel{
+		QRCodeID:     d.QRCodeID,
+		RestaurantID: d.RestaurantID,
+		MenuID:       d.MenuID,
+		Table:        d.Table,
+		Location:     d.Location,
+		Device:       d.Device,
+		ScannedAt:    d.ScannedAt,
 	}
 }
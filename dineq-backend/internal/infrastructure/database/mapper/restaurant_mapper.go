@@ -7,32 +7,35 @@ import (
 
 // RestaurantModel represents the MongoDB model for a restaurant
 type RestaurantModel struct {
-	ID                 bson.ObjectID       `bson:"_id"`
-	Slug               string              `bson:"slug"`
-	PreviousSlugs      []string            `bson:"previousSlugs"`
-	Name               string              `bson:"name"`
-	ManagerID          bson.ObjectID       `bson:"managerId"`
-	Phone              string              `bson:"phone"`
-	Location           *domain.Address     `bson:"location"`
-	About              *string             `bson:"about"`
-	LogoImage          *string             `bson:"logoImage"`
-	VerificationStatus string              `bson:"verificationStatus"`
-	VerificationDocs   *string             `bson:"verificationDocs"`
-	Schedule           []domain.Schedule   `bson:"schedule"`
-	SpecialDays        []domain.SpecialDay `bson:"specialDays"`
-	DefaultCurrency    string              `bson:"defaultCurrency"`
-	DefaultLanguage    string              `bson:"defaultLanguage"`
-	DefaultVat         float64             `bson:"defaultVat"`
-	TaxId              string              `bson:"taxId"`
-	Tags               []string            `bson:"tags"`
-	PrimaryColor       string              `bson:"primaryColor"`
-	AccentColor        string              `bson:"accentColor"`
-	CoverImage         *string             `bson:"coverImage"`
-	AverageRating      float64             `bson:"averageRating"`
-	ViewCount          int64               `bson:"viewCount"`
-	CreatedAt          bson.DateTime       `bson:"createdAt"`
-	UpdatedAt          bson.DateTime       `bson:"updatedAt"`
-	IsDeleted          bool                `bson:"isDeleted"`
+	ID                 bson.ObjectID                           `bson:"_id"`
+	Slug               string                                  `bson:"slug"`
+	PreviousSlugs      []string                                `bson:"previousSlugs"`
+	Name               string                                  `bson:"name"`
+	ManagerID          bson.ObjectID                           `bson:"managerId"`
+	Phone              string                                  `bson:"phone"`
+	Location           *domain.Address                         `bson:"location"`
+	About              *string                                 `bson:"about"`
+	LogoImage          *string                                 `bson:"logoImage"`
+	VerificationStatus string                                  `bson:"verificationStatus"`
+	VerificationDocs   *string                                 `bson:"verificationDocs"`
+	Schedule           []domain.Schedule                       `bson:"schedule"`
+	SpecialDays        []domain.SpecialDay                     `bson:"specialDays"`
+	DefaultCurrency    string                                  `bson:"defaultCurrency"`
+	DefaultLanguage    string                                  `bson:"defaultLanguage"`
+	Translations       map[string]domain.RestaurantTranslation `bson:"translations,omitempty"`
+	Glossary           []string                                `bson:"glossary,omitempty"`
+	Timezone           string                                  `bson:"timezone,omitempty"`
+	DefaultVat         float64                                 `bson:"defaultVat"`
+	TaxId              string                                  `bson:"taxId"`
+	Tags               []string                                `bson:"tags"`
+	PrimaryColor       string                                  `bson:"primaryColor"`
+	AccentColor        string                                  `bson:"accentColor"`
+	CoverImage         *string                                 `bson:"coverImage"`
+	AverageRating      float64                                 `bson:"averageRating"`
+	ViewCount          int64                                   `bson:"viewCount"`
+	CreatedAt          bson.DateTime                           `bson:"createdAt"`
+	UpdatedAt          bson.DateTime                           `bson:"updatedAt"`
+	IsDeleted          bool                                    `bson:"isDeleted"`
 }
 
 // Parse converts domain.Restaurant → RestaurantModel
@@ -53,6 +56,9 @@ func (m *RestaurantModel) Parse(r *domain.Restaurant) error {
 	m.Phone = r.RestaurantPhone
 	m.DefaultCurrency = r.DefaultCurrency
 	m.DefaultLanguage = r.DefaultLanguage
+	m.Translations = r.Translations
+	m.Glossary = r.Glossary
+	m.Timezone = r.Timezone
 	m.DefaultVat = r.DefaultVat
 	m.TaxId = r.TaxId
 	m.Tags = r.Tags
@@ -106,6 +112,9 @@ func (m *RestaurantModel) ToDomain() *domain.Restaurant {
 		SpecialDays:        m.SpecialDays,
 		DefaultCurrency:    m.DefaultCurrency,
 		DefaultLanguage:    m.DefaultLanguage,
+		Translations:       m.Translations,
+		Glossary:           m.Glossary,
+		Timezone:           m.Timezone,
 		DefaultVat:         m.DefaultVat,
 		TaxId:              m.TaxId,
 		Tags:               m.Tags,
@@ -136,32 +145,35 @@ func (m *RestaurantModel) ToDomain() *domain.Restaurant {
 
 // FacetRestaurant represents a restaurant returned in a $facet query
 type FacetRestaurant struct {
-	ID                 bson.ObjectID       `bson:"_id"`
-	Slug               string              `bson:"slug"`
-	PreviousSlugs      []string            `bson:"previousSlugs"`
-	Name               string              `bson:"name"`
-	ManagerID          bson.ObjectID       `bson:"managerId"`
-	Phone              string              `bson:"phone"`
-	Location           domain.Address      `bson:"location"`
-	About              *string             `bson:"about"`
-	LogoImage          *string             `bson:"logoImage"`
-	VerificationStatus string              `bson:"verificationStatus"`
-	VerificationDocs   *string             `bson:"verificationDocs"`
-	Tags               []string            `bson:"tags"`
-	Schedule           []domain.Schedule   `bson:"schedule"`
-	SpecialDays        []domain.SpecialDay `bson:"specialDays"`
-	DefaultCurrency    string              `bson:"defaultCurrency"`
-	DefaultLanguage    string              `bson:"defaultLanguage"`
-	DefaultVat         float64             `bson:"defaultVat"`
-	TaxId              string              `bson:"taxId"`
-	PrimaryColor       string              `bson:"primaryColor"`
-	AccentColor        string              `bson:"accentColor"`
-	CoverImage         *string             `bson:"coverImage"`
-	AverageRating      float64             `bson:"averageRating"`
-	ViewCount          int64               `bson:"viewCount"`
-	CreatedAt          bson.DateTime       `bson:"createdAt"`
-	UpdatedAt          bson.DateTime       `bson:"updatedAt"`
-	IsDeleted          bool                `bson:"isDeleted"`
+	ID                 bson.ObjectID                           `bson:"_id"`
+	Slug               string                                  `bson:"slug"`
+	PreviousSlugs      []string                                `bson:"previousSlugs"`
+	Name               string                                  `bson:"name"`
+	ManagerID          bson.ObjectID                           `bson:"managerId"`
+	Phone              string                                  `bson:"phone"`
+	Location           domain.Address                          `bson:"location"`
+	About              *string                                 `bson:"about"`
+	LogoImage          *string                                 `bson:"logoImage"`
+	VerificationStatus string                                  `bson:"verificationStatus"`
+	VerificationDocs   *string                                 `bson:"verificationDocs"`
+	Tags               []string                                `bson:"tags"`
+	Schedule           []domain.Schedule                       `bson:"schedule"`
+	SpecialDays        []domain.SpecialDay                     `bson:"specialDays"`
+	DefaultCurrency    string                                  `bson:"defaultCurrency"`
+	DefaultLanguage    string                                  `bson:"defaultLanguage"`
+	Translations       map[string]domain.RestaurantTranslation `bson:"translations,omitempty"`
+	Glossary           []string                                `bson:"glossary,omitempty"`
+	Timezone           string                                  `bson:"timezone,omitempty"`
+	DefaultVat         float64                                 `bson:"defaultVat"`
+	TaxId              string                                  `bson:"taxId"`
+	PrimaryColor       string                                  `bson:"primaryColor"`
+	AccentColor        string                                  `bson:"accentColor"`
+	CoverImage         *string                                 `bson:"coverImage"`
+	AverageRating      float64                                 `bson:"averageRating"`
+	ViewCount          int64                                   `bson:"viewCount"`
+	CreatedAt          bson.DateTime                           `bson:"createdAt"`
+	UpdatedAt          bson.DateTime                           `bson:"updatedAt"`
+	IsDeleted          bool                                    `bson:"isDeleted"`
 }
 
 // Parse converts FacetRestaurant → domain.Restaurant
@@ -181,6 +193,9 @@ func (f *FacetRestaurant) ToDomain() *domain.Restaurant {
 		VerificationDocs:   f.VerificationDocs,
 		DefaultCurrency:    f.DefaultCurrency,
 		DefaultLanguage:    f.DefaultLanguage,
+		Translations:       f.Translations,
+		Glossary:           f.Glossary,
+		Timezone:           f.Timezone,
 		DefaultVat:         f.DefaultVat,
 		Schedule:           f.Schedule,
 		SpecialDays:        f.SpecialDays,
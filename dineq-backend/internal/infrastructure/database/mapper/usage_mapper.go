@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type UsageEntryDB struct {
+	ID           bson.ObjectID `bson:"_id,omitempty"`
+	RestaurantID string        `bson:"restaurantId,omitempty"`
+	UserID       string        `bson:"userId,omitempty"`
+	Kind         string        `bson:"kind"`
+	Quantity     int64         `bson:"quantity"`
+	Provider     string        `bson:"provider,omitempty"`
+	Model        string        `bson:"model,omitempty"`
+	OCRJobID     string        `bson:"ocrJobId,omitempty"`
+	CreatedAt    time.Time     `bson:"createdAt"`
+}
+
+func FromDomainUsageEntry(e *domain.UsageEntry) *UsageEntryDB {
+	return &UsageEntryDB{
+		ID:           idempotentID(e.ID),
+		RestaurantID: e.RestaurantID,
+		UserID:       e.UserID,
+		Kind:         string(e.Kind),
+		Quantity:     e.Quantity,
+		Provider:     e.Provider,
+		Model:        e.Model,
+		OCRJobID:     e.OCRJobID,
+		CreatedAt:    e.CreatedAt,
+	}
+}
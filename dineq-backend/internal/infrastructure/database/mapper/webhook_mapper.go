@@ -0,0 +1,123 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type WebhookSubscriptionDB struct {
+	ID           bson.ObjectID `bson:"_id,omitempty"`
+	RestaurantID string        `bson:"restaurantId"`
+	URL          string        `bson:"url"`
+	Secret       string        `bson:"secret"`
+	Events       []string      `bson:"events"`
+	Active       bool          `bson:"active"`
+	CreatedBy    string        `bson:"createdBy,omitempty"`
+	CreatedAt    time.Time     `bson:"createdAt"`
+	UpdatedAt    time.Time     `bson:"updatedAt"`
+}
+
+type WebhookAttemptDB struct {
+	At         time.Time `bson:"at"`
+	StatusCode int       `bson:"statusCode,omitempty"`
+	Error      string    `bson:"error,omitempty"`
+	DurationMS int64     `bson:"durationMs"`
+}
+
+type WebhookDeliveryDB struct {
+	ID             bson.ObjectID      `bson:"_id,omitempty"`
+	SubscriptionID string             `bson:"subscriptionId"`
+	RestaurantID   string             `bson:"restaurantId"`
+	Event          string             `bson:"event"`
+	EventID        string             `bson:"eventId"`
+	Payload        string             `bson:"payload"`
+	Status         string             `bson:"status"`
+	Attempts       []WebhookAttemptDB `bson:"attempts"`
+	NextAttemptAt  *time.Time         `bson:"nextAttemptAt,omitempty"`
+	DeliveredAt    *time.Time         `bson:"deliveredAt,omitempty"`
+	RedeliveryOf   string             `bson:"redeliveryOf,omitempty"`
+	CreatedAt      time.Time          `bson:"createdAt"`
+	UpdatedAt      time.Time          `bson:"updatedAt"`
+}
+
+func FromDomainWebhookSubscription(s *domain.WebhookSubscription) *WebhookSubscriptionDB {
+	events := make([]string, len(s.Events))
+	for i, e := range s.Events {
+		events[i] = string(e)
+	}
+	return &WebhookSubscriptionDB{
+		ID:           idempotentID(s.ID),
+		RestaurantID: s.RestaurantID,
+		URL:          s.URL,
+		Secret:       s.Secret,
+		Events:       events,
+		Active:       s.Active,
+		CreatedBy:    s.CreatedBy,
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
+
+func ToDomainWebhookSubscription(s *WebhookSubscriptionDB) *domain.WebhookSubscription {
+	events := make([]domain.WebhookEvent, len(s.Events))
+	for i, e := range s.Events {
+		events[i] = domain.WebhookEvent(e)
+	}
+	return &domain.WebhookSubscription{
+		ID:           s.ID.Hex(),
+		RestaurantID: s.RestaurantID,
+		URL:          s.URL,
+		Secret:       s.Secret,
+		Events:       events,
+		Active:       s.Active,
+		CreatedBy:    s.CreatedBy,
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
+
+func FromDomainWebhookDelivery(d *domain.WebhookDelivery) *WebhookDeliveryDB {
+	attempts := make([]WebhookAttemptDB, len(d.Attempts))
+	for i, a := range d.Attempts {
+		attempts[i] = WebhookAttemptDB{At: a.At, StatusCode: a.StatusCode, Error: a.Error, DurationMS: a.DurationMS}
+	}
+	return &WebhookDeliveryDB{
+		ID:             idempotentID(d.ID),
+		SubscriptionID: d.SubscriptionID,
+		RestaurantID:   d.RestaurantID,
+		Event:          string(d.Event),
+		EventID:        d.EventID,
+		Payload:        d.Payload,
+		Status:         string(d.Status),
+		Attempts:       attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+		RedeliveryOf:   d.RedeliveryOf,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
+
+func ToDomainWebhookDelivery(d *WebhookDeliveryDB) *domain.WebhookDelivery {
+	attempts := make([]domain.WebhookAttempt, len(d.Attempts))
+	for i, a := range d.Attempts {
+		attempts[i] = domain.WebhookAttempt{At: a.At, StatusCode: a.StatusCode, Error: a.Error, DurationMS: a.DurationMS}
+	}
+	return &domain.WebhookDelivery{
+		ID:             d.ID.Hex(),
+		SubscriptionID: d.SubscriptionID,
+		RestaurantID:   d.RestaurantID,
+		Event:          domain.WebhookEvent(d.Event),
+		EventID:        d.EventID,
+		Payload:        d.Payload,
+		Status:         domain.WebhookDeliveryStatus(d.Status),
+		Attempts:       attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+		RedeliveryOf:   d.RedeliveryOf,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
@@ -34,6 +34,7 @@ type Collection interface {
 
 	UpdateOne(ctx context.Context, filter, update any, opts ...options.Lister[options.UpdateOneOptions]) (*mongo.UpdateResult, error)
 	UpdateMany(ctx context.Context, filter, update any, opts ...options.Lister[options.UpdateManyOptions]) (*mongo.UpdateResult, error)
+	FindOneAndUpdate(ctx context.Context, filter, update any, opts ...options.Lister[options.FindOneAndUpdateOptions]) SingleResult
 }
 
 type Client interface {
@@ -210,6 +211,10 @@ func (mc *mongoCollection) UpdateMany(ctx context.Context, filter, update any, o
 	return mc.coll.UpdateMany(ctx, filter, update, opts...)
 }
 
+func (mc *mongoCollection) FindOneAndUpdate(ctx context.Context, filter, update any, opts ...options.Lister[options.FindOneAndUpdateOptions]) SingleResult {
+	return &mongoSingleResult{sr: mc.coll.FindOneAndUpdate(ctx, filter, update, opts...)}
+}
+
 func (mc *mongoCollection) Indexes() IndexView {
 	return &mongoIndexView{coll: mc.coll}
 }
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	mongo "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database/mapper"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type AIParseResultRepository struct {
+	database mongo.Database
+	coll     string
+}
+
+func NewAIParseResultRepository(db mongo.Database, collection string) domain.IAIParseResultRepository {
+	repo := &AIParseResultRepository{
+		database: db,
+		coll:     collection,
+	}
+	repo.createIndexes(context.Background())
+	return repo
+}
+
+func (r *AIParseResultRepository) createIndexes(ctx context.Context) {
+	// one parse result per OCR job
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "ocrJobId", Value: 1}},
+		Options: options.Index().SetName("ux_ocr_job").SetUnique(true),
+	}
+	if _, err := r.database.Collection(r.coll).Indexes().CreateOne(ctx, indexModel); err != nil {
+		fmt.Printf("Failed to create parse result index: %v\n", err)
+	}
+}
+
+func (r *AIParseResultRepository) Create(ctx context.Context, result *domain.AIParseResult) error {
+	dbResult := mapper.FromDomainAIParseResult(result)
+	res, err := r.database.Collection(r.coll).InsertOne(ctx, dbResult)
+	if err != nil {
+		return err
+	}
+	if res.InsertedID == nil {
+		return errors.New("failed to insert parse result")
+	}
+	result.ID = res.InsertedID.(bson.ObjectID).Hex()
+	return nil
+}
+
+func (r *AIParseResultRepository) GetByID(ctx context.Context, id string) (*domain.AIParseResult, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrParseResultNotFound
+	}
+	var dbResult mapper.AIParseResultDB
+	if err := r.database.Collection(r.coll).FindOne(ctx, bson.M{"_id": oid}).Decode(&dbResult); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments()) {
+			return nil, domain.ErrParseResultNotFound
+		}
+		return nil, err
+	}
+	return mapper.ToDomainAIParseResult(&dbResult), nil
+}
+
+func (r *AIParseResultRepository) UpdateItem(ctx context.Context, id string, item domain.ParsedItem) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrParseResultNotFound
+	}
+	filter := bson.M{"_id": oid, "status": string(domain.ParseResultPendingReview), "items.id": item.ID}
+	update := bson.M{"$set": bson.M{"items.$": mapper.FromDomainParsedItem(&item), "updatedAt": time.Now().UTC()}}
+	res, err := r.database.Collection(r.coll).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return r.missingOrApproved(ctx, id)
+	}
+	return nil
+}
+
+func (r *AIParseResultRepository) MarkApproved(ctx context.Context, id, approvedBy string, at time.Time) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrParseResultNotFound
+	}
+	filter := bson.M{"_id": oid, "status": string(domain.ParseResultPendingReview)}
+	update := bson.M{"$set": bson.M{"status": string(domain.ParseResultApproved), "approvedBy": approvedBy, "approvedAt": at, "updatedAt": at}}
+	res, err := r.database.Collection(r.coll).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return r.missingOrApproved(ctx, id)
+	}
+	return nil
+}
+
+func (r *AIParseResultRepository) SetMenuID(ctx context.Context, id, menuID string) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrParseResultNotFound
+	}
+	_, err = r.database.Collection(r.coll).UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"menuId": menuID, "updatedAt": time.Now().UTC()}})
+	return err
+}
+
+func (r *AIParseResultRepository) Reopen(ctx context.Context, id string) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrParseResultNotFound
+	}
+	update := bson.M{
+		"$set":   bson.M{"status": string(domain.ParseResultPendingReview), "updatedAt": time.Now().UTC()},
+		"$unset": bson.M{"approvedBy": "", "approvedAt": "", "menuId": ""},
+	}
+	_, err = r.database.Collection(r.coll).UpdateOne(ctx, bson.M{"_id": oid, "status": string(domain.ParseResultApproved)}, update)
+	return err
+}
+
+func (r *AIParseResultRepository) Delete(ctx context.Context, id string) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrParseResultNotFound
+	}
+	deleted, err := r.database.Collection(r.coll).DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrParseResultNotFound
+	}
+	return nil
+}
+
+// missingOrApproved explains why a conditional update matched nothing.
+func (r *AIParseResultRepository) missingOrApproved(ctx context.Context, id string) error {
+	result, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if result.Status != domain.ParseResultPendingReview {
+		return domain.ErrParseResultApproved
+	}
+	return domain.ErrNotFound
+}
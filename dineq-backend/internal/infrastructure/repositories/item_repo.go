@@ -108,7 +108,11 @@ func (r *ItemRepository) UpdateItem(ctx context.Context, id string, item *domain
 	update := mapper.MergeItemUpdate(item)
 	fmt.Println("Update data:", update) // Debug log
 
-	result, err := r.database.Collection(r.coll).UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": update})
+	fields := bson.M{"$set": update}
+	if item.ModifierGroups != nil && len(item.ModifierGroups) == 0 {
+		fields["$unset"] = bson.M{"modifierGroups": "", "priceMin": "", "priceMax": ""}
+	}
+	result, err := r.database.Collection(r.coll).UpdateOne(ctx, bson.M{"_id": oid}, fields)
 	if err != nil {
 		return err
 	}
@@ -177,33 +181,94 @@ func (r *ItemRepository) AddReview(ctx context.Context, itemID, reviewID string)
 	return nil
 }
 
+// GetItems returns the items of the published copy of a live menu.
 func (r *ItemRepository) GetItems(ctx context.Context, menuSlug string) ([]domain.Item, error) {
-	log.Println("Fetching items for menu slug:", menuSlug) // Debug log
+	pipeline := append(r.publishedItemsPipeline(menuSlug),
+		bson.D{{Key: "$match", Value: bson.M{"isDeleted": false}}},
+	)
+	cursor, err := r.menus().AggregatePipeline(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
 	var itemsDB []mapper.ItemDB
-	filter := bson.M{
-		"menuSlug":  menuSlug,
-		"isDeleted": false,
+	if err := cursor.All(ctx, &itemsDB); err != nil {
+		return nil, err
 	}
-	cursor, err := r.database.Collection(r.coll).Find(ctx, filter)
+	return mapper.ItemDBToDomainList(itemsDB), nil
+}
+
+// GetPublishedItem returns one item of the published copy of a live menu.
+func (r *ItemRepository) GetPublishedItem(ctx context.Context, menuSlug, id string) (*domain.Item, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	pipeline := append(r.publishedItemsPipeline(menuSlug),
+		bson.D{{Key: "$match", Value: bson.M{"_id": oid, "isDeleted": false}}},
+		bson.D{{Key: "$limit", Value: 1}},
+	)
+	cursor, err := r.menus().AggregatePipeline(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	for cursor.Next(ctx) {
-		var itemDB mapper.ItemDB
-		if err := cursor.Decode(&itemDB); err != nil {
-			return nil, err
-		}
-		itemsDB = append(itemsDB, itemDB)
+	var itemsDB []mapper.ItemDB
+	if err := cursor.All(ctx, &itemsDB); err != nil {
+		return nil, err
+	}
+	if len(itemsDB) == 0 {
+		return nil, domain.ErrNotFound
 	}
+	return mapper.ToDomainItem(&itemsDB[0]), nil
+}
 
-	if err := cursor.Err(); err != nil {
-		return nil, err
+// menus is the menus collection, whose published copies back the public item reads.
+func (r *ItemRepository) menus() mongo.Collection {
+	return r.database.Collection(menuCollectionName())
+}
+
+func menuCollectionName() string {
+	if name := os.Getenv("MENU_COLLECTION"); name != "" {
+		return name
 	}
-	log.Println("Successfully fetched items for menu slug:", itemsDB)
+	return "menus"
+}
 
-	return mapper.ItemDBToDomainList(itemsDB), nil
+// publishedItemsPipeline turns a live menu into one document per item. The
+// published copy is read when there is one; menus published before drafts
+// existed have none and, like Menu.PublicView, serve their items as stored.
+// Standalone items created for the menu in the item collection are added
+// while the menu is live. A menu that is unpublished or was never published
+// yields no items.
+func (r *ItemRepository) publishedItemsPipeline(menuSlug string) []bson.D {
+	live := bson.M{"slug": menuSlug, "isDeleted": false, "isPublished": true}
+	return []bson.D{
+		{{Key: "$match", Value: live}},
+		{{Key: "$project", Value: bson.M{
+			"items":    bson.M{"$ifNull": bson.A{"$published.items", "$items"}},
+			"menuSlug": "$slug",
+		}}},
+		{{Key: "$unwind", Value: "$items"}},
+		{{Key: "$replaceRoot", Value: bson.M{
+			"newRoot": bson.M{"$mergeObjects": bson.A{"$items", bson.M{"menuSlug": "$menuSlug"}}},
+		}}},
+		{{Key: "$unionWith", Value: bson.M{
+			"coll": r.coll,
+			"pipeline": bson.A{
+				bson.D{{Key: "$match", Value: bson.M{"menuSlug": menuSlug, "isDeleted": false}}},
+				bson.D{{Key: "$lookup", Value: bson.M{
+					"from":     menuCollectionName(),
+					"pipeline": bson.A{bson.D{{Key: "$match", Value: live}}, bson.D{{Key: "$limit", Value: 1}}},
+					"as":       "liveMenu",
+				}}},
+				bson.D{{Key: "$match", Value: bson.M{"liveMenu": bson.M{"$ne": bson.A{}}}}},
+				bson.D{{Key: "$project", Value: bson.M{"liveMenu": 0}}},
+			},
+		}}},
+	}
 }
 
 // IncrementItemViewCount increments the view count for an item by 1
@@ -223,14 +288,32 @@ func (r *ItemRepository) IncrementItemViewCount(ctx context.Context, id string)
 	return nil
 }
 
-// SearchItems returns items for a given menu using advanced filters with pagination and sorting
-func (r *ItemRepository) SearchItems(ctx context.Context, filter domain.ItemFilter) ([]domain.Item, int64, error) {
-	// Search inside menus collection's embedded items array
-	menuCollName := os.Getenv("MENU_COLLECTION")
-	if menuCollName == "" {
-		menuCollName = "menus"
+// SetAvailability sets or clears the sold out flag of an item
+func (r *ItemRepository) SetAvailability(ctx context.Context, id string, available bool, until *time.Time) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
 	}
-	coll := r.database.Collection(menuCollName)
+	update := bson.M{
+		"$set":   bson.M{"unavailable": !available, "updatedAt": time.Now()},
+		"$unset": bson.M{"unavailableUntil": ""},
+	}
+	if !available && until != nil {
+		update = bson.M{"$set": bson.M{"unavailable": true, "unavailableUntil": until, "updatedAt": time.Now()}}
+	}
+	result, err := r.database.Collection(r.coll).UpdateOne(ctx, bson.M{"_id": oid, "isDeleted": false}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// SearchItems returns items of the published copy of a live menu using
+// advanced filters with pagination and sorting
+func (r *ItemRepository) SearchItems(ctx context.Context, filter domain.ItemFilter) ([]domain.Item, int64, error) {
 
 	// Validate menu slug
 	if filter.MenuSlug == "" {
@@ -254,7 +337,7 @@ func (r *ItemRepository) SearchItems(ctx context.Context, filter domain.ItemFilt
 	sortField := "createdAt"
 	switch filter.SortBy {
 	case "price":
-		sortField = "price"
+		sortField = "priceLow"
 	case "rating":
 		sortField = "averageRating"
 	case "popularity":
@@ -272,15 +355,12 @@ func (r *ItemRepository) SearchItems(ctx context.Context, filter domain.ItemFilt
 	if len(filter.Tags) > 0 {
 		itemMatch["tabTags"] = bson.M{"$in": filter.Tags}
 	}
-	if filter.MinPrice != nil || filter.MaxPrice != nil {
-		pr := bson.M{}
-		if filter.MinPrice != nil {
-			pr["$gte"] = *filter.MinPrice
-		}
-		if filter.MaxPrice != nil {
-			pr["$lte"] = *filter.MaxPrice
-		}
-		itemMatch["price"] = pr
+	// items with modifiers match when any configuration falls in the range
+	if filter.MinPrice != nil {
+		itemMatch["priceHigh"] = bson.M{"$gte": *filter.MinPrice}
+	}
+	if filter.MaxPrice != nil {
+		itemMatch["priceLow"] = bson.M{"$lte": *filter.MaxPrice}
 	}
 	if filter.MinRating != nil {
 		itemMatch["averageRating"] = bson.M{"$gte": *filter.MinRating}
@@ -289,14 +369,11 @@ func (r *ItemRepository) SearchItems(ctx context.Context, filter domain.ItemFilt
 		itemMatch["name"] = bson.M{"$regex": filter.Query, "$options": "i"}
 	}
 
-	// Aggregation pipeline over menus -> items
-	matchMenu := bson.M{"slug": filter.MenuSlug, "isDeleted": false}
-	pipeline := []bson.D{
-		bson.D{{Key: "$match", Value: matchMenu}},
-		bson.D{{Key: "$project", Value: bson.M{"items": 1, "menuSlug": "$slug"}}},
-		bson.D{{Key: "$unwind", Value: "$items"}},
-		bson.D{{Key: "$replaceRoot", Value: bson.M{
-			"newRoot": bson.M{"$mergeObjects": bson.A{"$items", bson.M{"menuSlug": "$menuSlug"}}},
+	// Aggregation pipeline over the published menu -> items
+	pipeline := append(r.publishedItemsPipeline(filter.MenuSlug),
+		bson.D{{Key: "$addFields", Value: bson.M{
+			"priceLow":  bson.M{"$ifNull": bson.A{"$priceMin", "$price"}},
+			"priceHigh": bson.M{"$ifNull": bson.A{"$priceMax", "$price"}},
 		}}},
 		bson.D{{Key: "$match", Value: itemMatch}},
 		bson.D{{Key: "$sort", Value: bson.D{{Key: sortField, Value: order}, {Key: "_id", Value: 1}}}},
@@ -309,9 +386,9 @@ func (r *ItemRepository) SearchItems(ctx context.Context, filter domain.ItemFilt
 				bson.D{{Key: "$count", Value: "count"}},
 			},
 		}}},
-	}
+	)
 
-	cur, err := coll.Aggregate(ctx, pipeline)
+	cur, err := r.menus().AggregatePipeline(ctx, pipeline)
 	if err != nil {
 		return nil, 0, err
 	}
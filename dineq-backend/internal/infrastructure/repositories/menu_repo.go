@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -55,6 +56,26 @@ func (r *MenuRepository) createTTLIndex(ctx context.Context) {
 	if _, err = r.database.Collection(r.coll).Indexes().CreateOne(ctx, slugIndex); err != nil {
 		fmt.Printf("Failed to create slug index: %v\n", err)
 	}
+
+	// Scheduler lookups for due publish/unpublish transitions
+	for _, field := range []string{"schedule.publishAt", "schedule.unpublishAt"} {
+		scheduleIndex := mongo.IndexModel{
+			Keys:    bson.M{field: 1},
+			Options: options.Index().SetSparse(true),
+		}
+		if _, err = r.database.Collection(r.coll).Indexes().CreateOne(ctx, scheduleIndex); err != nil {
+			fmt.Printf("Failed to create %s index: %v\n", field, err)
+		}
+	}
+
+	// Finds sold out items due to come back
+	availabilityIndex := mongo.IndexModel{
+		Keys:    bson.M{"items.unavailableUntil": 1},
+		Options: options.Index().SetSparse(true),
+	}
+	if _, err = r.database.Collection(r.coll).Indexes().CreateOne(ctx, availabilityIndex); err != nil {
+		fmt.Printf("Failed to create items.unavailableUntil index: %v\n", err)
+	}
 }
 
 func (r *MenuRepository) Create(ctx context.Context, menu *domain.Menu) error {
@@ -67,6 +88,7 @@ func (r *MenuRepository) Create(ctx context.Context, menu *domain.Menu) error {
 		return err
 	}
 	menu.ID = res.InsertedID.(bson.ObjectID).Hex()
+	menu.Version = dbMenu.Version
 	return nil
 }
 
@@ -133,10 +155,10 @@ func (r *MenuRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *MenuRepository) Update(ctx context.Context, id string, menu *domain.Menu) error {
+func (r *MenuRepository) Update(ctx context.Context, id string, menu *domain.Menu) (*domain.Menu, error) {
 	oid, err := bson.ObjectIDFromHex(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Build dynamic update: allow changing name, items, and publish state.
@@ -156,9 +178,10 @@ func (r *MenuRepository) Update(ctx context.Context, id string, menu *domain.Men
 		setFields["publishedAt"] = menu.PublishedAt
 	}
 
-	// If items slice provided, map to DB representations (regenerating slugs left to upstream if desired)
-	if len(menu.Items) > 0 {
-		var dbItems []mapper.ItemDB
+	// If items slice provided, map to DB representations (regenerating slugs left to upstream if desired).
+	// An empty, non-nil slice clears the items, e.g. when rolling back to an empty menu.
+	if menu.Items != nil {
+		dbItems := make([]mapper.ItemDB, 0, len(menu.Items))
 		for i := range menu.Items {
 			dbItems = append(dbItems, *mapper.ToItemDBForUpdate(&menu.Items[i]))
 		}
@@ -168,7 +191,86 @@ func (r *MenuRepository) Update(ctx context.Context, id string, menu *domain.Men
 	update := bson.M{"$set": setFields, "$inc": bson.M{"version": 1}}
 	filter := bson.M{"_id": oid, "isDeleted": false}
 
-	result, err := r.database.Collection(r.coll).UpdateOne(ctx, filter, update)
+	return r.updateAndGet(ctx, filter, update)
+}
+
+// updateAndGet applies update to the menu matching filter and returns the
+// document as this update left it, or mongo.ErrNoDocuments.
+func (r *MenuRepository) updateAndGet(ctx context.Context, filter, update bson.M) (*domain.Menu, error) {
+	var dbMenu mapper.MenuDB
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := r.database.Collection(r.coll).FindOneAndUpdate(ctx, filter, update, opts).Decode(&dbMenu); err != nil {
+		return nil, err
+	}
+	return mapper.ToDomainMenu(&dbMenu), nil
+}
+
+// Publish copies the draft into the embedded published copy in a single document
+// update. Matching on version makes the promotion atomic with respect to
+// concurrent draft edits: if the draft moved on, nothing is written.
+func (r *MenuRepository) Publish(ctx context.Context, id string, draftVersion int, published *domain.PublishedMenu) (*domain.Menu, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"published":   mapper.ToPublishedMenuDB(published),
+			"isPublished": true,
+			"publishedAt": published.PublishedAt,
+			"updatedAt":   time.Now().UTC(),
+			"updatedBy":   published.PublishedBy,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	filter := bson.M{"_id": oid, "isDeleted": false, "version": draftVersion}
+
+	menu, err := r.updateAndGet(ctx, filter, update)
+	if errors.Is(err, mongo.ErrNoDocuments()) {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrMenuDraftChanged
+	}
+	return menu, err
+}
+
+// Unpublish takes the menu off the public endpoints. The published copy is kept
+// so a later publish without draft edits restores the same content.
+func (r *MenuRepository) Unpublish(ctx context.Context, id string, userID string) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{
+		"isPublished": false,
+		"updatedAt":   time.Now().UTC(),
+		"updatedBy":   userID,
+	}}
+	result, err := r.database.Collection(r.coll).UpdateOne(ctx, bson.M{"_id": oid, "isDeleted": false}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments()
+	}
+	return nil
+}
+
+// SetSchedule replaces the menu schedule; a nil schedule removes it.
+func (r *MenuRepository) SetSchedule(ctx context.Context, id string, schedule *domain.MenuSchedule) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	var update bson.M
+	if schedule == nil {
+		update = bson.M{"$unset": bson.M{"schedule": ""}}
+	} else {
+		update = bson.M{"$set": bson.M{"schedule": mapper.ToMenuScheduleDB(schedule)}}
+	}
+	result, err := r.database.Collection(r.coll).UpdateOne(ctx, bson.M{"_id": oid, "isDeleted": false}, update)
 	if err != nil {
 		return err
 	}
@@ -178,6 +280,136 @@ func (r *MenuRepository) Update(ctx context.Context, id string, menu *domain.Men
 	return nil
 }
 
+// ClearScheduledTransitions removes the scheduled publish and unpublish times
+// that have been carried out. Each is only removed while it still holds the
+// given time, so a schedule edited meanwhile is kept; nil leaves a time alone.
+// A schedule left with nothing in it is removed.
+func (r *MenuRepository) ClearScheduledTransitions(ctx context.Context, id string, publishAt, unpublishAt *time.Time) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	coll := r.database.Collection(r.coll)
+	for field, at := range map[string]*time.Time{"schedule.publishAt": publishAt, "schedule.unpublishAt": unpublishAt} {
+		if at == nil {
+			continue
+		}
+		if _, err := coll.UpdateOne(ctx, bson.M{"_id": oid, field: *at}, bson.M{"$unset": bson.M{field: ""}}); err != nil {
+			return err
+		}
+	}
+	empty := bson.M{
+		"_id":                  oid,
+		"schedule":             bson.M{"$type": "object"},
+		"schedule.publishAt":   nil,
+		"schedule.unpublishAt": nil,
+		"schedule.windows.0":   bson.M{"$exists": false},
+	}
+	_, err = coll.UpdateOne(ctx, empty, bson.M{"$unset": bson.M{"schedule": ""}})
+	return err
+}
+
+// FindScheduleDue returns menus with a publish or unpublish time at or before now.
+func (r *MenuRepository) FindScheduleDue(ctx context.Context, now time.Time) ([]*domain.Menu, error) {
+	filter := bson.M{
+		"isDeleted": false,
+		"$or": bson.A{
+			bson.M{"schedule.publishAt": bson.M{"$lte": now}},
+			bson.M{"schedule.unpublishAt": bson.M{"$lte": now}},
+		},
+	}
+	cursor, err := r.database.Collection(r.coll).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var menus []*domain.Menu
+	for cursor.Next(ctx) {
+		var dbMenu mapper.MenuDB
+		if err := cursor.Decode(&dbMenu); err != nil {
+			return nil, err
+		}
+		menus = append(menus, mapper.ToDomainMenu(&dbMenu))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return menus, nil
+}
+
+// SetItemAvailability updates the sold out flag of one item in the draft and,
+// when present, in the published copy. The version is left alone: availability
+// is operational state and does not need a publish.
+func (r *MenuRepository) SetItemAvailability(ctx context.Context, menuID, itemID string, available bool, until *time.Time) error {
+	oid, err := bson.ObjectIDFromHex(menuID)
+	if err != nil {
+		return err
+	}
+	itemOID, err := bson.ObjectIDFromHex(itemID)
+	if err != nil {
+		return domain.ErrMenuItemNotFound
+	}
+
+	matched := false
+	for _, prefix := range []string{"items", "published.items"} {
+		var update bson.M
+		if available || until == nil {
+			update = bson.M{
+				"$set":   bson.M{prefix + ".$.unavailable": !available},
+				"$unset": bson.M{prefix + ".$.unavailableUntil": ""},
+			}
+		} else {
+			update = bson.M{"$set": bson.M{
+				prefix + ".$.unavailable":      true,
+				prefix + ".$.unavailableUntil": until,
+			}}
+		}
+		filter := bson.M{"_id": oid, "isDeleted": false, prefix + "._id": itemOID}
+		result, err := r.database.Collection(r.coll).UpdateOne(ctx, filter, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount > 0 {
+			matched = true
+		}
+	}
+	if !matched {
+		return domain.ErrMenuItemNotFound
+	}
+	return nil
+}
+
+// FindExpiredUnavailable returns menus holding a sold out item whose
+// unavailableUntil is at or before now.
+func (r *MenuRepository) FindExpiredUnavailable(ctx context.Context, now time.Time) ([]*domain.Menu, error) {
+	filter := bson.M{
+		"isDeleted": false,
+		"$or": bson.A{
+			bson.M{"items.unavailableUntil": bson.M{"$lte": now}},
+			bson.M{"published.items.unavailableUntil": bson.M{"$lte": now}},
+		},
+	}
+	cursor, err := r.database.Collection(r.coll).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var menus []*domain.Menu
+	for cursor.Next(ctx) {
+		var dbMenu mapper.MenuDB
+		if err := cursor.Decode(&dbMenu); err != nil {
+			return nil, err
+		}
+		menus = append(menus, mapper.ToDomainMenu(&dbMenu))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return menus, nil
+}
+
 func (r *MenuRepository) IncrementViewCount(ctx context.Context, id string) error {
 	oid, err := bson.ObjectIDFromHex(id)
 	if err != nil {
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	mongo "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database/mapper"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type MenuVersionRepository struct {
+	database mongo.Database
+	coll     string
+}
+
+func NewMenuVersionRepository(db mongo.Database, collection string) domain.IMenuVersionRepository {
+	repo := &MenuVersionRepository{
+		database: db,
+		coll:     collection,
+	}
+	repo.createIndexes(context.Background())
+	return repo
+}
+
+func (r *MenuVersionRepository) createIndexes(ctx context.Context) {
+	// one snapshot per (menu, version); also serves "latest first" listing
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "menuId", Value: 1}, {Key: "version", Value: -1}},
+		Options: options.Index().SetName("ux_menu_version").SetUnique(true),
+	}
+	if _, err := r.database.Collection(r.coll).Indexes().CreateOne(ctx, indexModel); err != nil {
+		fmt.Printf("Failed to create menu version index: %v\n", err)
+	}
+}
+
+func (r *MenuVersionRepository) Create(ctx context.Context, version *domain.MenuVersion) error {
+	dbVersion := mapper.NewMenuVersionDBFromDomain(version)
+	res, err := r.database.Collection(r.coll).InsertOne(ctx, dbVersion)
+	if err != nil {
+		return err
+	}
+	if res.InsertedID == nil {
+		return errors.New("failed to insert menu version")
+	}
+	version.ID = res.InsertedID.(bson.ObjectID).Hex()
+	return nil
+}
+
+func (r *MenuVersionRepository) GetByMenuIDAndVersion(ctx context.Context, menuID string, version int) (*domain.MenuVersion, error) {
+	var dbVersion mapper.MenuVersionDB
+	err := r.database.Collection(r.coll).FindOne(ctx, bson.M{"menuId": menuID, "version": version}).Decode(&dbVersion)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments()) {
+			return nil, domain.ErrMenuVersionNotFound
+		}
+		return nil, err
+	}
+	return mapper.ToDomainMenuVersion(&dbVersion), nil
+}
+
+func (r *MenuVersionRepository) ListByMenuID(ctx context.Context, menuID string) ([]*domain.MenuVersion, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	cursor, err := r.database.Collection(r.coll).Find(ctx, bson.M{"menuId": menuID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var versions []*domain.MenuVersion
+	for cursor.Next(ctx) {
+		var dbVersion mapper.MenuVersionDB
+		if err := cursor.Decode(&dbVersion); err != nil {
+			return nil, err
+		}
+		versions = append(versions, mapper.ToDomainMenuVersion(&dbVersion))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
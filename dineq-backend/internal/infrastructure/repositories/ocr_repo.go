@@ -8,6 +8,7 @@ import (
 	mongo "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database"
 	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database/mapper"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 type OCRRepository struct {
@@ -32,7 +33,7 @@ func (r *OCRRepository) Create(ctx context.Context, job *domain.OCRJob) error {
 		dbocr.UpdatedAt = time.Now()
 	}
 	if dbocr.Status == "" {
-		dbocr.Status = string(domain.OCRProcessing)
+		dbocr.Status = string(domain.OCRPending)
 	}
 	res, err := r.db.Collection(r.ocrCl).InsertOne(ctx, dbocr)
 	if err != nil {
@@ -47,7 +48,8 @@ func (r *OCRRepository) Create(ctx context.Context, job *domain.OCRJob) error {
 	return nil
 }
 
-// update
+// update. A job read under a worker's lease is written only while that worker
+// still holds it; otherwise ErrOCRJobLeaseLost is returned.
 func (r *OCRRepository) Update(ctx context.Context, id string, job *domain.OCRJob) error {
 	dbocr := mapper.FromDomainOCRJob(job)
 	dbocr.UpdatedAt = time.Now()
@@ -56,8 +58,30 @@ func (r *OCRRepository) Update(ctx context.Context, id string, job *domain.OCRJo
 		return err
 	}
 	update := bson.M{"$set": dbocr}
-	_, err = r.db.Collection(r.ocrCl).UpdateOne(ctx, bson.M{"_id": oid}, update)
-	return err
+	if job.Status == domain.OCRPending {
+		// back in the queue (e.g. a retry): drop the old lease and attempt count
+		update["$unset"] = bson.M{"leaseOwner": "", "leaseExpiresAt": "", "attempts": ""}
+	}
+	filter := bson.M{"_id": oid}
+	leased := false
+	if job.Status != domain.OCRPending && job.Status != domain.OCRCancelled {
+		// a job cancelled while a worker runs it stays cancelled; only a retry
+		// brings it back
+		filter["status"] = bson.M{"$ne": string(domain.OCRCancelled)}
+		// a requeued job may already run under another worker
+		if job.LeaseOwner != "" {
+			filter["leaseOwner"] = job.LeaseOwner
+			leased = true
+		}
+	}
+	res, err := r.db.Collection(r.ocrCl).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if leased && res.MatchedCount == 0 {
+		return domain.ErrOCRJobLeaseLost
+	}
+	return nil
 }
 
 // getbyid
@@ -100,29 +124,295 @@ func (r *OCRRepository) UpdateStatus(ctx context.Context, id, status string) err
 	return err
 }
 
-// get pending jobs
-func (r *OCRRepository) GetPendingJobs(ctx context.Context) ([]*domain.OCRJob, error) {
-	filter := bson.M{"status": string(domain.OCRProcessing)}
-	cursor, err := r.db.Collection(r.ocrCl).Find(ctx, filter)
+// claimCandidates is how many pending jobs Claim tries per call; other workers
+// may win the race for some of them.
+const claimCandidates = 5
+
+// Claim leases the oldest pending job. Jobs are claimed with a conditional
+// update on status, so two workers racing for the same job cannot both win.
+func (r *OCRRepository) Claim(ctx context.Context, workerID string, leaseUntil time.Time) (*domain.OCRJob, error) {
+	coll := r.db.Collection(r.ocrCl)
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}).SetLimit(claimCandidates).SetProjection(bson.M{"_id": 1})
+	cursor, err := coll.Find(ctx, bson.M{"status": string(domain.OCRPending)}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []struct {
+		ID bson.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return nil, err
+	}
+	for _, cand := range candidates {
+		res, err := coll.UpdateOne(ctx,
+			bson.M{"_id": cand.ID, "status": string(domain.OCRPending)},
+			bson.M{
+				"$set": bson.M{
+					"status":         string(domain.OCRProcessing),
+					"leaseOwner":     workerID,
+					"leaseExpiresAt": leaseUntil,
+					"updatedAt":      time.Now(),
+				},
+				"$inc": bson.M{"attempts": 1},
+			})
+		if err != nil {
+			return nil, err
+		}
+		if res.ModifiedCount == 1 {
+			return r.GetByID(ctx, cand.ID.Hex())
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// ExtendLease renews the lease while the job is still processing under workerID.
+func (r *OCRRepository) ExtendLease(ctx context.Context, id, workerID string, leaseUntil time.Time) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	res, err := r.db.Collection(r.ocrCl).UpdateOne(ctx,
+		bson.M{"_id": oid, "status": string(domain.OCRProcessing), "leaseOwner": workerID},
+		bson.M{"$set": bson.M{"leaseExpiresAt": leaseUntil}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrOCRJobLeaseLost
+	}
+	return nil
+}
+
+// RequeueExpired recovers processing jobs whose worker stopped renewing its
+// lease. Jobs without a lease were started before the queue existed.
+func (r *OCRRepository) RequeueExpired(ctx context.Context, now time.Time, maxAttempts int) (int64, int64, error) {
+	coll := r.db.Collection(r.ocrCl)
+	expired := bson.A{
+		bson.M{"leaseExpiresAt": bson.M{"$lt": now}},
+		bson.M{"leaseExpiresAt": bson.M{"$exists": false}},
+		bson.M{"leaseExpiresAt": nil},
+	}
+	failed, err := coll.UpdateMany(ctx,
+		bson.M{"status": string(domain.OCRProcessing), "$or": expired, "attempts": bson.M{"$gte": maxAttempts}},
+		bson.M{"$set": bson.M{
+			"status":    string(domain.OCRFailed),
+			"error":     "processing was interrupted too many times",
+			"updatedAt": now,
+		}, "$unset": bson.M{"leaseOwner": "", "leaseExpiresAt": ""}})
+	if err != nil {
+		return 0, 0, err
+	}
+	requeued, err := coll.UpdateMany(ctx,
+		bson.M{"status": string(domain.OCRProcessing), "$or": expired},
+		bson.M{"$set": bson.M{
+			"status":    string(domain.OCRPending),
+			"updatedAt": now,
+		}, "$unset": bson.M{"leaseOwner": "", "leaseExpiresAt": ""}})
+	if err != nil {
+		return 0, failed.ModifiedCount, err
+	}
+	return requeued.ModifiedCount, failed.ModifiedCount, nil
+}
+
+// CountByStatus counts jobs per status.
+func (r *OCRRepository) CountByStatus(ctx context.Context) (map[domain.OCRJobStatus]int64, error) {
+	cursor, err := r.db.Collection(r.ocrCl).AggregatePipeline(ctx, []bson.D{
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$status"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
+	var rows []struct {
+		Status string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	counts := make(map[domain.OCRJobStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[domain.OCRJobStatus(row.Status)] = row.Count
+	}
+	return counts, nil
+}
+
+// UnreadPages sums the pages not read yet of queued and running jobs. A job
+// from before multi-page uploads counts as one page.
+func (r *OCRRepository) UnreadPages(ctx context.Context, restaurantID, userID string) (int64, error) {
+	match := bson.M{"status": bson.M{"$in": bson.A{string(domain.OCRPending), string(domain.OCRProcessing)}}}
+	if restaurantID != "" {
+		match["restaurantId"] = restaurantID
+	}
+	if userID != "" {
+		match["userId"] = userID
+	}
+	pages := bson.M{"$ifNull": bson.A{"$pages", bson.A{}}}
+	unread := bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{bson.M{"$size": pages}, 0}},
+		bson.M{"$size": bson.M{"$filter": bson.M{"input": pages, "cond": bson.M{"$ne": bson.A{"$$this.status", domain.OCRPageDone}}}}},
+		1,
+	}}
+	cursor, err := r.db.Collection(r.ocrCl).AggregatePipeline(ctx, []bson.D{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: nil}, {Key: "pages", Value: bson.D{{Key: "$sum", Value: unread}}}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	var rows []struct {
+		Pages int64 `bson:"pages"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[0].Pages, nil
+}
+
+// ocrListProjection leaves the bulky fields out of listings.
+var ocrListProjection = bson.M{
+	"rawAiJson":             0,
+	"resultText":            0,
+	"results.menu":          0,
+	"results.rawaijson":     0,
+	"results.extractedtext": 0,
+	"pages.text":            0,
+	"aiCalls":               0,
+	"phaseHistory":          0,
+}
+
+// List pages through jobs, newest first.
+func (r *OCRRepository) List(ctx context.Context, f domain.OCRJobFilter) ([]*domain.OCRJob, int64, error) {
+	filter := bson.M{}
+	if f.RestaurantID != "" {
+		filter["restaurantId"] = f.RestaurantID
+	}
+	if f.UserID != "" {
+		filter["userId"] = f.UserID
+	}
+	if len(f.Statuses) > 0 {
+		statuses := make(bson.A, len(f.Statuses))
+		for i, st := range f.Statuses {
+			statuses[i] = string(st)
+		}
+		filter["status"] = bson.M{"$in": statuses}
+	}
+	if !f.CreatedAfter.IsZero() {
+		filter["createdAt"] = bson.M{"$gte": f.CreatedAfter}
+	}
+	f = f.Normalize()
+	page, size := f.Page, f.PageSize
+	coll := r.db.Collection(r.ocrCl)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip(int64((page - 1) * size)).
+		SetLimit(int64(size)).
+		SetProjection(ocrListProjection)
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	var rows []mapper.OCRJobDB
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, 0, err
+	}
+	total, err := coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	jobs := make([]*domain.OCRJob, len(rows))
+	for i := range rows {
+		jobs[i] = mapper.ToDomainOCRJob(&rows[i])
+	}
+	return jobs, total, nil
+}
 
-	var jobs []*domain.OCRJob
-	for cursor.Next(ctx) {
-		var dbocr mapper.OCRJobDB
-		if err := cursor.Decode(&dbocr); err != nil {
+// Cancel moves a pending or processing job to cancelled in one conditional
+// update, so a job finishing at the same time is either cancelled or left
+// finished, never both.
+func (r *OCRRepository) Cancel(ctx context.Context, id, reason string, at time.Time) (*domain.OCRJob, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.db.Collection(r.ocrCl).UpdateOne(ctx,
+		bson.M{"_id": oid, "status": bson.M{"$in": bson.A{string(domain.OCRPending), string(domain.OCRProcessing)}}},
+		bson.M{"$set": bson.M{
+			"status":      string(domain.OCRCancelled),
+			"error":       reason,
+			"updatedAt":   at,
+			"completedAt": at,
+		}, "$unset": bson.M{"leaseOwner": "", "leaseExpiresAt": ""}})
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		if _, err := r.GetByID(ctx, id); err != nil {
 			return nil, err
 		}
-		jobs = append(jobs, mapper.ToDomainOCRJob(&dbocr))
+		return nil, domain.ErrOCRJobNotCancellable
+	}
+	return r.GetByID(ctx, id)
+}
+
+// ListImagesToPurge finds finished jobs past their retention whose images
+// are still stored, oldest first.
+func (r *OCRRepository) ListImagesToPurge(ctx context.Context, completedBefore, failedBefore time.Time, limit int) ([]*domain.OCRJob, error) {
+	var expired bson.A
+	if !completedBefore.IsZero() {
+		expired = append(expired, bson.M{"status": string(domain.OCRCompleted), "updatedAt": bson.M{"$lt": completedBefore}})
+	}
+	if !failedBefore.IsZero() {
+		expired = append(expired, bson.M{
+			"status":    bson.M{"$in": bson.A{string(domain.OCRFailed), string(domain.OCRCancelled)}},
+			"updatedAt": bson.M{"$lt": failedBefore},
+		})
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+	filter := bson.M{"$or": expired, "imagesPurgedAt": bson.M{"$exists": false}}
+	opts := options.Find().SetSort(bson.D{{Key: "updatedAt", Value: 1}}).SetLimit(int64(limit)).SetProjection(ocrListProjection)
+	cursor, err := r.db.Collection(r.ocrCl).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
 	}
-	if err := cursor.Err(); err != nil {
+	var rows []mapper.OCRJobDB
+	if err := cursor.All(ctx, &rows); err != nil {
 		return nil, err
 	}
+	jobs := make([]*domain.OCRJob, len(rows))
+	for i := range rows {
+		jobs[i] = mapper.ToDomainOCRJob(&rows[i])
+	}
 	return jobs, nil
 }
 
+// MarkImagesPurged records the purge and drops the dead image links.
+func (r *OCRRepository) MarkImagesPurged(ctx context.Context, id string, at time.Time) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	coll := r.db.Collection(r.ocrCl)
+	_, err = coll.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{
+		"imagesPurgedAt":    at,
+		"imageUrl":          "",
+		"processedImageUrl": "",
+	}})
+	if err != nil {
+		return err
+	}
+	// jobs from before multi-page uploads have no pages array to update
+	_, err = coll.UpdateOne(ctx, bson.M{"_id": oid, "pages.0": bson.M{"$exists": true}}, bson.M{
+		"$set":   bson.M{"pages.$[].imageUrl": ""},
+		"$unset": bson.M{"pages.$[].processedImageUrl": "", "pages.$[].imagePublicId": "", "pages.$[].processedPublicId": ""},
+	})
+	return err
+}
+
 // getFCM Token
 func (r *OCRRepository) GetUserFCMToken(userID string) string {
 	var user struct {
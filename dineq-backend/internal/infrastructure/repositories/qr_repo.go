@@ -36,6 +36,14 @@ func (r *qrRepository) createTTLIndex(ctx context.Context) {
 	if err != nil {
 		fmt.Printf("Failed to create TTL index: %v\n", err)
 	}
+	// a restaurant has a code per table and location, listed per menu
+	listIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "restaurantId", Value: 1}, {Key: "menuId", Value: 1}, {Key: "createdAt", Value: -1}},
+		Options: options.Index().SetName("restaurant_menu_created"),
+	}
+	if _, err := r.db.Collection(r.qrCollection).Indexes().CreateOne(ctx, listIndex); err != nil {
+		fmt.Printf("Failed to create QR code list index: %v\n", err)
+	}
 }
 
 // create
@@ -63,14 +71,133 @@ func (r *qrRepository) GetByRestaurantId(ctx context.Context, id string) (*domai
 	return mapper.ToDomainQRCode(&qr), nil
 }
 
-// updateactivation
+// updateactivation, for every code of the restaurant
 func (r *qrRepository) UpdateActivation(ctx context.Context, id string, isActive bool) error {
-	_, err := r.db.Collection(r.qrCollection).UpdateOne(ctx, bson.M{"restaurantId": id}, bson.M{"$set": bson.M{"isActive": isActive}})
+	_, err := r.db.Collection(r.qrCollection).UpdateMany(ctx, bson.M{"restaurantId": id, "isDeleted": false}, bson.M{"$set": bson.M{"isActive": isActive}})
 	return err
 }
 
-// delete
+// delete every code of the restaurant
 func (r *qrRepository) Delete(ctx context.Context, id string) error {
-	_, err := r.db.Collection(r.qrCollection).UpdateOne(ctx, bson.M{"restaurantId": id}, bson.M{"$set": bson.M{"isDeleted": true, "deletedAt": time.Now().AddDate(0, 2, 0)}})
+	_, err := r.db.Collection(r.qrCollection).UpdateMany(ctx, bson.M{"restaurantId": id, "isDeleted": false}, bson.M{"$set": bson.M{"isDeleted": true, "deletedAt": time.Now().AddDate(0, 2, 0)}})
+	return err
+}
+
+func (r *qrRepository) GetByID(ctx context.Context, id string) (*domain.QRCode, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrQRCodeNotFound
+	}
+	var qr mapper.QRCodeModel
+	if err := r.db.Collection(r.qrCollection).FindOne(ctx, bson.M{"_id": oid, "isDeleted": false}).Decode(&qr); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments()) {
+			return nil, domain.ErrQRCodeNotFound
+		}
+		return nil, err
+	}
+	return mapper.ToDomainQRCode(&qr), nil
+}
+
+func (r *qrRepository) List(ctx context.Context, restaurantSlugs []string, menuID string) ([]*domain.QRCode, error) {
+	filter := bson.M{"restaurantId": bson.M{"$in": restaurantSlugs}, "isDeleted": false}
+	if menuID != "" {
+		filter["menuId"] = menuID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.db.Collection(r.qrCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var models []mapper.QRCodeModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+	codes := make([]*domain.QRCode, len(models))
+	for i := range models {
+		codes[i] = mapper.ToDomainQRCode(&models[i])
+	}
+	return codes, nil
+}
+
+// Update saves the label and activation of a code; its table and location
+// are encoded in the printed image and stay as created.
+func (r *qrRepository) Update(ctx context.Context, qr *domain.QRCode) error {
+	oid, err := bson.ObjectIDFromHex(qr.ID)
+	if err != nil {
+		return domain.ErrQRCodeNotFound
+	}
+	res, err := r.db.Collection(r.qrCollection).UpdateOne(ctx, bson.M{"_id": oid, "isDeleted": false}, bson.M{"$set": bson.M{
+		"label":    qr.Label,
+		"isActive": qr.IsActive,
+	}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrQRCodeNotFound
+	}
+	return nil
+}
+
+func (r *qrRepository) DeleteByID(ctx context.Context, id string) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrQRCodeNotFound
+	}
+	res, err := r.db.Collection(r.qrCollection).UpdateOne(ctx, bson.M{"_id": oid, "isDeleted": false}, bson.M{"$set": bson.M{"isDeleted": true, "deletedAt": time.Now().AddDate(0, 2, 0)}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrQRCodeNotFound
+	}
+	return nil
+}
+
+// RecordScan counts a scan of the code.
+func (r *qrRepository) RecordScan(ctx context.Context, id string, at time.Time) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrQRCodeNotFound
+	}
+	_, err = r.db.Collection(r.qrCollection).UpdateOne(ctx, bson.M{"_id": oid}, bson.M{
+		"$inc": bson.M{"scanCount": 1},
+		"$max": bson.M{"lastScannedAt": at},
+	})
 	return err
 }
+
+type qrScanRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewQRScanRepository(db mongo.Database, collection string) domain.IQRScanRepository {
+	repo := &qrScanRepository{db: db, collection: collection}
+	repo.createIndexes(context.Background())
+	return repo
+}
+
+func (r *qrScanRepository) createIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "qrCodeId", Value: 1}, {Key: "scannedAt", Value: -1}}, Options: options.Index().SetName("qr_code_scanned")},
+		{Keys: bson.D{{Key: "restaurantId", Value: 1}, {Key: "scannedAt", Value: -1}}, Options: options.Index().SetName("restaurant_scanned")},
+	}
+	for _, idx := range indexes {
+		if _, err := r.db.Collection(r.collection).Indexes().CreateOne(ctx, idx); err != nil {
+			fmt.Printf("Failed to create QR scan index: %v\n", err)
+		}
+	}
+}
+
+func (r *qrScanRepository) Log(ctx context.Context, scan *domain.QRScan) error {
+	model := mapper.ToModelQRScan(scan)
+	res, err := r.db.Collection(r.collection).InsertOne(ctx, model)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		scan.ID = oid.Hex()
+	}
+	return nil
+}
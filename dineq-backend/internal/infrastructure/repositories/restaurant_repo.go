@@ -116,6 +116,9 @@ func (repo *RestaurantRepo) Update(ctx context.Context, r *domain.Restaurant) er
 		"accentColor":        model.AccentColor,
 		"defaultCurrency":    model.DefaultCurrency,
 		"defaultLanguage":    model.DefaultLanguage,
+		"translations":       model.Translations,
+		"glossary":           model.Glossary,
+		"timezone":           model.Timezone,
 		"defaultVat":         model.DefaultVat,
 		"taxId":              model.TaxId,
 		"coverImage":         model.CoverImage,
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	mongo "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database/mapper"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type UsageRepository struct {
+	database mongo.Database
+	coll     string
+}
+
+func NewUsageRepository(db mongo.Database, collection string) domain.IUsageRepository {
+	repo := &UsageRepository{database: db, coll: collection}
+	repo.createIndexes(context.Background())
+	return repo
+}
+
+func (r *UsageRepository) createIndexes(ctx context.Context) {
+	// quota checks sum one restaurant's or user's entries of the month
+	for _, model := range []mongo.IndexModel{
+		{Keys: bson.D{{Key: "restaurantId", Value: 1}, {Key: "createdAt", Value: 1}}, Options: options.Index().SetName("restaurant_created")},
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: 1}}, Options: options.Index().SetName("user_created")},
+	} {
+		if _, err := r.database.Collection(r.coll).Indexes().CreateOne(ctx, model); err != nil {
+			fmt.Printf("Failed to create usage ledger index: %v\n", err)
+		}
+	}
+}
+
+func (r *UsageRepository) Insert(ctx context.Context, entries []domain.UsageEntry) error {
+	docs := make([]any, len(entries))
+	for i := range entries {
+		docs[i] = mapper.FromDomainUsageEntry(&entries[i])
+	}
+	_, err := r.database.Collection(r.coll).InsertMany(ctx, docs)
+	return err
+}
+
+func (r *UsageRepository) Totals(ctx context.Context, restaurantID, userID string, since time.Time) (domain.UsageTotals, error) {
+	match := bson.M{"createdAt": bson.M{"$gte": since}}
+	if restaurantID != "" {
+		match["restaurantId"] = restaurantID
+	}
+	if userID != "" {
+		match["userId"] = userID
+	}
+	rows, err := r.sum(ctx, match, "")
+	if err != nil || len(rows) == 0 {
+		return domain.UsageTotals{}, err
+	}
+	return rows[0].UsageTotals, nil
+}
+
+func (r *UsageRepository) Report(ctx context.Context, f domain.UsageReportFilter) ([]domain.UsageReportRow, error) {
+	match := bson.M{"createdAt": bson.M{"$gte": f.From, "$lt": f.To}}
+	if f.RestaurantID != "" {
+		match["restaurantId"] = f.RestaurantID
+	}
+	if f.UserID != "" {
+		match["userId"] = f.UserID
+	}
+	key := "$restaurantId"
+	if f.GroupBy == domain.UsageByUser {
+		key = "$userId"
+	}
+	rows, err := r.sum(ctx, match, key)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		if f.GroupBy == domain.UsageByUser {
+			rows[i].UserID, rows[i].RestaurantID = rows[i].RestaurantID, ""
+		}
+	}
+	return rows, nil
+}
+
+// sum totals the matching entries per kind and per value of key (one row
+// when key is empty). The key is returned as RestaurantID.
+func (r *UsageRepository) sum(ctx context.Context, match bson.M, key string) ([]domain.UsageReportRow, error) {
+	var groupKey any = ""
+	if key != "" {
+		groupKey = key
+	}
+	pipeline := []bson.D{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      bson.M{"key": groupKey, "kind": "$kind"},
+			"quantity": bson.M{"$sum": "$quantity"},
+		}}},
+	}
+	cursor, err := r.database.Collection(r.coll).AggregatePipeline(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var groups []struct {
+		ID struct {
+			Key  string `bson:"key"`
+			Kind string `bson:"kind"`
+		} `bson:"_id"`
+		Quantity int64 `bson:"quantity"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	var rows []domain.UsageReportRow
+	index := map[string]int{}
+	for _, g := range groups {
+		i, ok := index[g.ID.Key]
+		if !ok {
+			i = len(rows)
+			index[g.ID.Key] = i
+			rows = append(rows, domain.UsageReportRow{RestaurantID: g.ID.Key})
+		}
+		rows[i].Add(domain.UsageKind(g.ID.Kind), g.Quantity)
+	}
+	return rows, nil
+}
@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	mongo "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database/mapper"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type WebhookRepository struct {
+	database      mongo.Database
+	subscriptions string
+	deliveries    string
+}
+
+func NewWebhookRepository(db mongo.Database, subscriptions, deliveries string) domain.IWebhookRepository {
+	repo := &WebhookRepository{database: db, subscriptions: subscriptions, deliveries: deliveries}
+	repo.createIndexes(context.Background())
+	return repo
+}
+
+func (r *WebhookRepository) createIndexes(ctx context.Context) {
+	indexes := []struct {
+		coll  string
+		model mongo.IndexModel
+	}{
+		{r.subscriptions, mongo.IndexModel{Keys: bson.D{{Key: "restaurantId", Value: 1}}, Options: options.Index().SetName("restaurant")}},
+		// the dispatcher polls for pending deliveries that are due
+		{r.deliveries, mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}}, Options: options.Index().SetName("status_next_attempt")}},
+		{r.deliveries, mongo.IndexModel{Keys: bson.D{{Key: "subscriptionId", Value: 1}, {Key: "createdAt", Value: -1}}, Options: options.Index().SetName("subscription_created")}},
+	}
+	for _, idx := range indexes {
+		if _, err := r.database.Collection(idx.coll).Indexes().CreateOne(ctx, idx.model); err != nil {
+			fmt.Printf("Failed to create webhook index: %v\n", err)
+		}
+	}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	doc := mapper.FromDomainWebhookSubscription(sub)
+	if _, err := r.database.Collection(r.subscriptions).InsertOne(ctx, doc); err != nil {
+		return err
+	}
+	sub.ID = doc.ID.Hex()
+	return nil
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrWebhookNotFound
+	}
+	var doc mapper.WebhookSubscriptionDB
+	if err := r.database.Collection(r.subscriptions).FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments()) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return mapper.ToDomainWebhookSubscription(&doc), nil
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context, restaurantID string) ([]*domain.WebhookSubscription, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := r.database.Collection(r.subscriptions).Find(ctx, bson.M{"restaurantId": restaurantID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []mapper.WebhookSubscriptionDB
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	subs := make([]*domain.WebhookSubscription, len(docs))
+	for i := range docs {
+		subs[i] = mapper.ToDomainWebhookSubscription(&docs[i])
+	}
+	return subs, nil
+}
+
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	oid, err := bson.ObjectIDFromHex(sub.ID)
+	if err != nil {
+		return domain.ErrWebhookNotFound
+	}
+	doc := mapper.FromDomainWebhookSubscription(sub)
+	res, err := r.database.Collection(r.subscriptions).UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{
+		"url":       doc.URL,
+		"events":    doc.Events,
+		"active":    doc.Active,
+		"updatedAt": doc.UpdatedAt,
+	}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id string) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrWebhookNotFound
+	}
+	n, err := r.database.Collection(r.subscriptions).DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	doc := mapper.FromDomainWebhookDelivery(d)
+	if _, err := r.database.Collection(r.deliveries).InsertOne(ctx, doc); err != nil {
+		return err
+	}
+	d.ID = doc.ID.Hex()
+	return nil
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+	var doc mapper.WebhookDeliveryDB
+	if err := r.database.Collection(r.deliveries).FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments()) {
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return mapper.ToDomainWebhookDelivery(&doc), nil
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionID string, page, pageSize int) ([]*domain.WebhookDelivery, int64, error) {
+	coll := r.database.Collection(r.deliveries)
+	filter := bson.M{"subscriptionId": subscriptionID}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize))
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	var docs []mapper.WebhookDeliveryDB
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, 0, err
+	}
+	total, err := coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	deliveries := make([]*domain.WebhookDelivery, len(docs))
+	for i := range docs {
+		deliveries[i] = mapper.ToDomainWebhookDelivery(&docs[i])
+	}
+	return deliveries, total, nil
+}
+
+// ClaimDelivery moves the next attempt of a due delivery forward with a
+// conditional update on its old value, so two dispatchers racing for the same
+// delivery cannot both win.
+func (r *WebhookRepository) ClaimDelivery(ctx context.Context, now, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+	coll := r.database.Collection(r.deliveries)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}).
+		SetLimit(claimCandidates).
+		SetProjection(bson.M{"_id": 1, "nextAttemptAt": 1})
+	cursor, err := coll.Find(ctx, bson.M{"status": string(domain.WebhookPending), "nextAttemptAt": bson.M{"$lte": now}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []struct {
+		ID            bson.ObjectID `bson:"_id"`
+		NextAttemptAt time.Time     `bson:"nextAttemptAt"`
+	}
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return nil, err
+	}
+	for _, cand := range candidates {
+		res, err := coll.UpdateOne(ctx,
+			bson.M{"_id": cand.ID, "status": string(domain.WebhookPending), "nextAttemptAt": cand.NextAttemptAt},
+			bson.M{"$set": bson.M{"nextAttemptAt": leaseUntil, "updatedAt": now}})
+		if err != nil {
+			return nil, err
+		}
+		if res.ModifiedCount == 1 {
+			return r.GetDelivery(ctx, cand.ID.Hex())
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	oid, err := bson.ObjectIDFromHex(d.ID)
+	if err != nil {
+		return domain.ErrWebhookDeliveryNotFound
+	}
+	doc := mapper.FromDomainWebhookDelivery(d)
+	set := bson.M{
+		"status":      doc.Status,
+		"attempts":    doc.Attempts,
+		"deliveredAt": doc.DeliveredAt,
+		"updatedAt":   doc.UpdatedAt,
+	}
+	update := bson.M{"$set": set}
+	if doc.NextAttemptAt != nil {
+		set["nextAttemptAt"] = doc.NextAttemptAt
+	} else {
+		update["$unset"] = bson.M{"nextAttemptAt": ""}
+	}
+	_, err = r.database.Collection(r.deliveries).UpdateOne(ctx, bson.M{"_id": oid}, update)
+	return err
+}
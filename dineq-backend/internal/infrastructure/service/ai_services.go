@@ -16,17 +16,18 @@ import (
 	"google.golang.org/genai"
 )
 
-// Interface kept for existing callers
+// IAIService covers the translation and classification helpers; menu
+// structuring goes through IMenuStructurer.
 type IAIService interface {
-	StructureWithGemini(ctx context.Context, ocrText string) (*domain.Menu, error)
 	TranslateAIBit(text, target string) (string, error)
+	// TranslateBatch translates many strings in one call; see domain.ITranslator.
+	TranslateBatch(ctx context.Context, texts []string, target string) ([]string, error)
 	IsEthiopianFood(ctx context.Context, item string) (bool, error)
 }
 
 type GeminiService struct {
-	client  *genai.Client
-	model   string
-	lastRaw string
+	client *genai.Client
+	model  string
 }
 
 func NewAIService(ctx context.Context, apiKey string, model string, _ IImageSearchService) (IAIService, error) {
@@ -45,32 +46,46 @@ type menuProcessingResults struct {
 	MenuItems []menuItemResult `json:"menuItems"`
 }
 type menuItemResult struct {
-	ID                   string   `json:"id"`
-	Name                 string   `json:"name"`
-	NameAmharic          string   `json:"nameAmharic"`
-	Description          string   `json:"description"`
-	DescriptionAmharic   string   `json:"descriptionAmharic"`
-	Price                float64  `json:"price"`
-	Currency             string   `json:"currency"`
-	Tab                  string   `json:"tab"`
-	TabTags              []string `json:"tab_tags"`
-	TabTagsAm            []string `json:"tab_tags_am"`
-	Ingredients          any      `json:"ingredients"`
-	NutritionalInfo      any      `json:"nutritionalInfo"`
-	Allergens            []string `json:"allergens"`
-	Allergies            string   `json:"allergies"`
-	AllergiesAm          string   `json:"allergies_am"`
-	EatingInstructions   string   `json:"eatingInstructions"`
-	EatingInstructionsAm string   `json:"eatingInstructionsAm"`
-	IsAvailable          bool     `json:"isAvailable"`
-	PreparationTime      int      `json:"preparationTime"`
+	ID                   string                `json:"id"`
+	Name                 string                `json:"name"`
+	NameAmharic          string                `json:"nameAmharic"`
+	Description          string                `json:"description"`
+	DescriptionAmharic   string                `json:"descriptionAmharic"`
+	Price                float64               `json:"price"`
+	Currency             string                `json:"currency"`
+	Tab                  string                `json:"tab"`
+	TabTags              []string              `json:"tab_tags"`
+	TabTagsAm            []string              `json:"tab_tags_am"`
+	Ingredients          any                   `json:"ingredients"`
+	NutritionalInfo      any                   `json:"nutritionalInfo"`
+	Allergens            []string              `json:"allergens"`
+	Allergies            string                `json:"allergies"`
+	AllergiesAm          string                `json:"allergies_am"`
+	EatingInstructions   string                `json:"eatingInstructions"`
+	EatingInstructionsAm string                `json:"eatingInstructionsAm"`
+	IsAvailable          *bool                 `json:"isAvailable"`
+	PreparationTime      int                   `json:"preparationTime"`
+	ModifierGroups       []modifierGroupResult `json:"modifierGroups"`
+}
+type modifierGroupResult struct {
+	Name        string                 `json:"name"`
+	NameAmharic string                 `json:"nameAmharic"`
+	MinSelect   int                    `json:"minSelect"`
+	MaxSelect   int                    `json:"maxSelect"`
+	Options     []modifierOptionResult `json:"options"`
+}
+type modifierOptionResult struct {
+	Name        string  `json:"name"`
+	NameAmharic string  `json:"nameAmharic"`
+	PriceDelta  float64 `json:"priceDelta"`
 }
 
-// StructureWithGemini adapts ProcessOCRText style to existing domain.Menu output
-func (gs *GeminiService) StructureWithGemini(ctx context.Context, ocrText string) (*domain.Menu, error) {
-	prompt := gs.createMenuStructuringPrompt(ocrText, "")
+// StructureMenu turns OCR text into a menu with Gemini. Token counts add up
+// over the retries made for transient errors and unparsable replies.
+func (gs *GeminiService) StructureMenu(ctx context.Context, ocrText string) (*domain.StructuredMenu, error) {
+	prompt := menuStructuringPrompt(ocrText, "")
+	usage := domain.AIUsage{Provider: AIProviderGemini, Model: gs.model}
 	var lastErr error
-	var raw string
 	// exponential backoff for transient errors (429/503) only
 	for attempt := 1; attempt <= 3; attempt++ {
 		resp, err := gs.client.Models.GenerateContent(ctx, gs.model, genai.Text(prompt), nil)
@@ -83,7 +98,12 @@ func (gs *GeminiService) StructureWithGemini(ctx context.Context, ocrText string
 			}
 			return nil, fmt.Errorf("failed to generate content: %w", err)
 		}
-		if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
+		if m := resp.UsageMetadata; m != nil {
+			usage.PromptTokens += int(m.PromptTokenCount)
+			usage.CompletionTokens += int(m.CandidatesTokenCount)
+			usage.TotalTokens += int(m.TotalTokenCount)
+		}
+		if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil || len(resp.Candidates[0].Content.Parts) == 0 {
 			lastErr = errors.New("no response from Gemini")
 			continue
 		}
@@ -91,9 +111,8 @@ func (gs *GeminiService) StructureWithGemini(ctx context.Context, ocrText string
 		for _, p := range resp.Candidates[0].Content.Parts {
 			buf.WriteString(fmt.Sprintf("%v", p))
 		}
-		raw = buf.String()
-		gs.lastRaw = raw
-		results, err := gs.parseGeminiResponse(raw)
+		raw := buf.String()
+		results, err := parseMenuResponse(raw)
 		if err != nil {
 			lastErr = fmt.Errorf("failed to parse Gemini response: %w", err)
 			if attempt < 3 {
@@ -102,105 +121,110 @@ func (gs *GeminiService) StructureWithGemini(ctx context.Context, ocrText string
 			}
 			return nil, lastErr
 		}
-		// Build menu
-		menu := &domain.Menu{ID: bson.NewObjectID().Hex(), RestaurantSlug: bson.NewObjectID().Hex(), Version: 1, CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}
-		tabIndex := map[string]*domain.Tab{}
-		for _, mi := range results.MenuItems {
-			if strings.TrimSpace(mi.Name) == "" {
-				continue
-			}
-			// bound prep time 1..60 (default 15 if zero)
-			prep := mi.PreparationTime
-			if prep <= 0 {
-				prep = 15
-			}
-			if prep < 1 {
-				prep = 1
-			}
-			if prep > 60 {
-				prep = 60
-			}
-			// derive tab from tags
-			var firstTab string
-			if len(mi.TabTags) > 0 {
-				firstTab = mi.TabTags[0]
-			}
-			tabName := firstNonEmpty(mi.Tab, firstTab, "General")
-			t, ok := tabIndex[tabName]
-			if !ok {
-				// attempt Amharic name mapping for tab
-				amTab := amharicScriptForLabel(tabName)
-				t = &domain.Tab{ID: bson.NewObjectID().Hex(), MenuID: menu.ID, Name: tabName, NameAm: amTab}
-				tabIndex[tabName] = t
+		return &domain.StructuredMenu{Menu: buildStructuredMenu(results), RawJSON: raw, Usage: usage}, nil
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, errors.New("AI structuring failed with unknown error")
+}
+
+// buildStructuredMenu groups parsed items into tabs and categories, keeping
+// tabs in the order the model first used them.
+func buildStructuredMenu(results *menuProcessingResults) *domain.Menu {
+	menu := &domain.Menu{ID: bson.NewObjectID().Hex(), RestaurantSlug: bson.NewObjectID().Hex(), Version: 1, CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}
+	tabIndex := map[string]*domain.Tab{}
+	var tabOrder []string
+	for _, mi := range results.MenuItems {
+		if strings.TrimSpace(mi.Name) == "" {
+			continue
+		}
+		// bound prep time 1..60 (default 15 if zero)
+		prep := mi.PreparationTime
+		if prep <= 0 {
+			prep = 15
+		}
+		if prep < 1 {
+			prep = 1
+		}
+		if prep > 60 {
+			prep = 60
+		}
+		// derive tab from tags
+		var firstTab string
+		if len(mi.TabTags) > 0 {
+			firstTab = mi.TabTags[0]
+		}
+		tabName := firstNonEmpty(mi.Tab, firstTab, "General")
+		t, ok := tabIndex[tabName]
+		if !ok {
+			// attempt Amharic name mapping for tab
+			amTab := amharicScriptForLabel(tabName)
+			t = &domain.Tab{ID: bson.NewObjectID().Hex(), MenuID: menu.ID, Name: tabName, Translations: domain.Translations{}.With(domain.LangAmharic, amTab)}
+			tabIndex[tabName] = t
+			tabOrder = append(tabOrder, tabName)
+		}
+		// classify category heuristically
+		catName := classifyCategory(mi.Name, mi.Description)
+		amCat := amharicScriptForLabel(catName)
+		var cat *domain.Category
+		for i := range t.Categories {
+			if t.Categories[i].Name == catName {
+				cat = &t.Categories[i]
+				break
 			}
-			// classify category heuristically
-			catName := classifyCategory(mi.Name, mi.Description)
-			amCat := amharicScriptForLabel(catName)
-			var cat *domain.Category
-			for i := range t.Categories {
-				if t.Categories[i].Name == catName {
-					cat = &t.Categories[i]
-					break
+		}
+		if cat == nil {
+			t.Categories = append(t.Categories, domain.Category{ID: bson.NewObjectID().Hex(), TabID: t.ID, Name: catName, Translations: domain.Translations{}.With(domain.LangAmharic, amCat)})
+			cat = &t.Categories[len(t.Categories)-1]
+		}
+		// Combine legacy array 'Allergens' with new scalar 'Allergies'
+		allergySlice := mi.Allergens
+		if len(allergySlice) == 0 && strings.TrimSpace(mi.Allergies) != "" {
+			// Create a single entry slice from full sentence; could be improved to parse ingredients list
+			allergySlice = []string{mi.Allergies}
+		}
+		// Extract nutritional info if object
+		var calories, protein, carbs, fat int
+		if m, ok := mi.NutritionalInfo.(map[string]any); ok {
+			if v, ok2 := m["calories"]; ok2 {
+				if f, ok3 := toInt(v); ok3 {
+					calories = f
 				}
 			}
-			if cat == nil {
-				t.Categories = append(t.Categories, domain.Category{ID: bson.NewObjectID().Hex(), TabID: t.ID, Name: catName, NameAm: amCat})
-				cat = &t.Categories[len(t.Categories)-1]
-			}
-			// Combine legacy array 'Allergens' with new scalar 'Allergies'
-			allergySlice := mi.Allergens
-			if len(allergySlice) == 0 && strings.TrimSpace(mi.Allergies) != "" {
-				// Create a single entry slice from full sentence; could be improved to parse ingredients list
-				allergySlice = []string{mi.Allergies}
-			}
-			// Extract nutritional info if object
-			var calories, protein, carbs, fat int
-			if m, ok := mi.NutritionalInfo.(map[string]any); ok {
-				if v, ok2 := m["calories"]; ok2 {
-					if f, ok3 := toInt(v); ok3 {
-						calories = f
-					}
-				}
-				if v, ok2 := m["protein"]; ok2 {
-					if f, ok3 := toInt(v); ok3 {
-						protein = f
-					}
-				}
-				if v, ok2 := m["carbs"]; ok2 {
-					if f, ok3 := toInt(v); ok3 {
-						carbs = f
-					}
+			if v, ok2 := m["protein"]; ok2 {
+				if f, ok3 := toInt(v); ok3 {
+					protein = f
 				}
-				if v, ok2 := m["fat"]; ok2 {
-					if f, ok3 := toInt(v); ok3 {
-						fat = f
-					}
+			}
+			if v, ok2 := m["carbs"]; ok2 {
+				if f, ok3 := toInt(v); ok3 {
+					carbs = f
 				}
 			}
-			var nutri *domain.NutritionalInfo
-			if calories > 0 || protein > 0 || carbs > 0 || fat > 0 {
-				nutri = &domain.NutritionalInfo{Calories: calories, Protein: protein, Carbs: carbs, Fat: fat}
+			if v, ok2 := m["fat"]; ok2 {
+				if f, ok3 := toInt(v); ok3 {
+					fat = f
+				}
 			}
-			item := domain.Item{ID: bson.NewObjectID().Hex(), Name: mi.Name, NameAm: mi.NameAmharic, Description: mi.Description, DescriptionAm: mi.DescriptionAmharic, Price: mi.Price, Currency: firstNonEmpty(mi.Currency, "ETB"), PreparationTime: prep, Allergies: allergySlice, AllergiesAm: mi.AllergiesAm, HowToEat: mi.EatingInstructions, HowToEatAm: mi.EatingInstructionsAm, Calories: calories, Protein: protein, Carbs: carbs, Fat: fat, NutritionalInfo: nutri, TabTags: mi.TabTags, TabTagsAm: mi.TabTagsAm, IsDeleted: false}
-			cat.Items = append(cat.Items, item)
 		}
-		for _, t := range tabIndex {
-			menu.Tabs = append(menu.Tabs, *t)
+		var nutri *domain.NutritionalInfo
+		if calories > 0 || protein > 0 || carbs > 0 || fat > 0 {
+			nutri = &domain.NutritionalInfo{Calories: calories, Protein: protein, Carbs: carbs, Fat: fat}
 		}
-		// attach raw JSON to menu in a placeholder way (could be stored elsewhere via caller)
-		// The caller (usecase) will capture raw JSON via job.Results.RawAIJSON if integrated.
-		_ = raw
-		return menu, nil
+		item := domain.Item{ID: bson.NewObjectID().Hex(), Name: mi.Name, Description: mi.Description, Price: mi.Price, Currency: firstNonEmpty(mi.Currency, "ETB"), PreparationTime: prep, Allergies: allergySlice, HowToEat: mi.EatingInstructions, Calories: calories, Protein: protein, Carbs: carbs, Fat: fat, NutritionalInfo: nutri, TabTags: mi.TabTags, IsDeleted: false}
+		item.SetTranslation(domain.LangAmharic, domain.ItemTranslation{Name: mi.NameAmharic, Description: mi.DescriptionAmharic, Allergies: mi.AllergiesAm, HowToEat: mi.EatingInstructionsAm, TabTags: mi.TabTagsAm})
+		// only an explicit "sold out" marking on the source menu makes an item unavailable
+		item.Unavailable = mi.IsAvailable != nil && !*mi.IsAvailable
+		item.ModifierGroups = toModifierGroups(mi.ModifierGroups)
+		cat.Items = append(cat.Items, item)
 	}
-	if lastErr != nil {
-		return nil, lastErr
+	for _, name := range tabOrder {
+		menu.Tabs = append(menu.Tabs, *tabIndex[name])
 	}
-	return nil, errors.New("AI structuring failed with unknown error")
+	return menu
 }
 
-// RawLastAIJSON returns the last raw AI JSON (best-effort) produced by StructureWithGemini
-func (gs *GeminiService) RawLastAIJSON() string { return gs.lastRaw }
-
 // IsEthiopianFood asks the Gemini model a short yes/no question whether the
 // provided item is an Ethiopian food (origin/or commonly eaten there).
 // It expects the model to reply with exactly 'yes' or 'no' (case-insensitive)
@@ -240,7 +264,7 @@ func (gs *GeminiService) IsEthiopianFood(ctx context.Context, item string) (bool
 	return false, fmt.Errorf("unexpected gemini reply: %s", out)
 }
 
-func (gs *GeminiService) createMenuStructuringPrompt(ocrText, restaurantName string) string {
+func menuStructuringPrompt(ocrText, restaurantName string) string {
 	return fmt.Sprintf(`You are an expert Ethiopian menu structuring AI. Produce ONLY one valid JSON object. No markdown, no commentary.
 
 SCHEMA (exact field names):
@@ -262,7 +286,14 @@ SCHEMA (exact field names):
 			"nutritional_info": {"calories": 320, "protein": 15, "carbs": 28, "fat": 14},
 			"preparation_time": 25,
 			"how_to_eat": "Tear injera, scoop stew, eat by hand.",
-			"how_to_eat_am": "እንጀራ ቁርጠው ወጡን ይውሰዱ በእጅ ይበሉ።"
+			"how_to_eat_am": "እንጀራ ቁርጠው ወጡን ይውሰዱ በእጅ ይበሉ።",
+			"isAvailable": true,
+			"modifierGroups": [
+				{"name": "Size", "nameAmharic": "መጠን", "minSelect": 1, "maxSelect": 1, "options": [
+					{"name": "Regular", "nameAmharic": "መደበኛ", "priceDelta": 0},
+					{"name": "Large", "nameAmharic": "ትልቅ", "priceDelta": 30.0}
+				]}
+			]
 		}
 	]
 }
@@ -286,6 +317,13 @@ Estimate realistic positive integers: calories 80–1200; macros consistent (pro
 OTHER RULES:
 - preparation_time integer 1–60.
 - currency: ETB default if absent.
+- isAvailable: true unless the menu marks the dish as sold out, unavailable or crossed out.
+
+MODIFIER RULES:
+- One item per dish. Sizes (small/large, half/full), required choices (e.g. injera or rice) and optional add-ons become modifierGroups on that item, never separate items.
+- price is the cheapest required configuration; priceDelta is the extra cost of each option over it (0 for the base size, negative only if the menu shows a cheaper choice).
+- Sizes and required choices: minSelect 1, maxSelect 1. Optional add-ons: minSelect 0, maxSelect 0 (no limit) unless the menu states a limit.
+- Use "modifierGroups": [] when the dish has no options.
 - Deduplicate identical (name+price) items by merging ingredients.
 - NO images if not present in text -> set image arrays empty (but still output fields as empty arrays if schema demands—they are not in this reduced schema so omit).
 - Absolutely NO null, markdown fences, or commentary. Output only JSON.
@@ -297,10 +335,12 @@ OCR TEXT:
 %s`, restaurantName, ocrText)
 }
 
-func (gs *GeminiService) parseGeminiResponse(response string) (*menuProcessingResults, error) {
+// parseMenuResponse extracts the menuItems JSON from a model reply, tolerating
+// code fences and surrounding text.
+func parseMenuResponse(response string) (*menuProcessingResults, error) {
 	response = strings.TrimSpace(response)
 	// Preserve newlines initially for better pattern detection, then clean.
-	log.Printf("[DEBUG] Raw Gemini response (original): %.500s", response)
+	log.Printf("[DEBUG] Raw AI response (original): %.500s", response)
 
 	// If response contains a Go fmt of struct (&{... ```json{ ... }```}) we try to isolate the JSON code block
 	// Remove any leading Go struct prefix up to first code fence or '{'
@@ -336,7 +376,7 @@ func (gs *GeminiService) parseGeminiResponse(response string) (*menuProcessingRe
 		}
 	}
 
-	log.Printf("[DEBUG] Gemini JSON candidate: %.500s", response)
+	log.Printf("[DEBUG] AI JSON candidate: %.500s", response)
 	// Normalize field aliases (snake_case to camelCase expected by struct tags)
 	normalized := normalizeAIJSONAliases(response)
 	var results menuProcessingResults
@@ -358,6 +398,26 @@ func (gs *GeminiService) parseGeminiResponse(response string) (*menuProcessingRe
 	return &results, nil
 }
 
+// toModifierGroups keeps the AI groups that can be satisfied; a bad group is
+// dropped rather than failing the whole menu.
+func toModifierGroups(results []modifierGroupResult) []domain.ModifierGroup {
+	var groups []domain.ModifierGroup
+	for _, g := range results {
+		group := domain.ModifierGroup{ID: bson.NewObjectID().Hex(), Name: g.Name, MinSelect: g.MinSelect, MaxSelect: g.MaxSelect, Translations: domain.Translations{}.With(domain.LangAmharic, g.NameAmharic)}
+		for _, o := range g.Options {
+			if strings.TrimSpace(o.Name) == "" && strings.TrimSpace(o.NameAmharic) == "" {
+				continue
+			}
+			group.Options = append(group.Options, domain.ModifierOption{ID: bson.NewObjectID().Hex(), Name: o.Name, PriceDelta: o.PriceDelta, Translations: domain.Translations{}.With(domain.LangAmharic, o.NameAmharic)})
+		}
+		if domain.ValidateModifierGroups([]domain.ModifierGroup{group}) != nil {
+			continue
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
 // normalizeAIJSONAliases replaces snake_case keys produced by prompt with the camelCase keys expected by the parser structs.
 func normalizeAIJSONAliases(s string) string {
 	replacer := strings.NewReplacer(
@@ -425,6 +485,67 @@ func (gs *GeminiService) TranslateAIBit(text, target string) (string, error) {
 	return strings.TrimSpace(out), nil
 }
 
+// TranslateBatch translates texts in a single request, sent and returned as a
+// JSON array so the model cannot merge or reorder entries. Placeholders such
+// as {{0}} mark glossary terms and must come back unchanged.
+func (gs *GeminiService) TranslateBatch(ctx context.Context, texts []string, target string) ([]string, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	prompt, err := translateBatchPrompt(texts, target)
+	if err != nil {
+		return nil, err
+	}
+	config := &genai.GenerateContentConfig{ResponseMIMEType: "application/json"}
+	var lastErr error
+	for attempt := 1; attempt <= 3; attempt++ {
+		resp, err := gs.client.Models.GenerateContent(ctx, gs.model, genai.Text(prompt), config)
+		if err != nil {
+			if transient(err) && attempt < 3 {
+				time.Sleep(time.Duration(math.Pow(2, float64(attempt-1))) * 500 * time.Millisecond)
+				lastErr = err
+				continue
+			}
+			return nil, fmt.Errorf("failed to translate batch: %w", err)
+		}
+		out, err := parseTranslateBatch(resp.Text(), len(texts))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return out, nil
+	}
+	return nil, lastErr
+}
+
+// translateBatchPrompt asks for a JSON array of translations of texts.
+func translateBatchPrompt(texts []string, target string) (string, error) {
+	in, err := json.Marshal(texts)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`You translate restaurant menu text into %s.
+Translate every string of the JSON array below and reply with a JSON array of exactly %d strings, in the same order.
+Keep placeholders like {{0}} exactly as written; they stand for dish names that must not be translated.
+Keep prices, numbers and units unchanged. Reply with the JSON array only.
+
+%s`, domain.LanguageName(target), len(texts), in), nil
+}
+
+// parseTranslateBatch decodes the reply to translateBatchPrompt.
+func parseTranslateBatch(raw string, n int) ([]string, error) {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(raw, "```json"), "```"), "```")
+	var out []string
+	if err := json.Unmarshal([]byte(strings.TrimSpace(raw)), &out); err != nil {
+		return nil, fmt.Errorf("invalid translation response: %w", err)
+	}
+	if len(out) != n {
+		return nil, fmt.Errorf("translation response has %d entries, want %d", len(out), n)
+	}
+	return out, nil
+}
+
 func firstNonEmpty(vals ...string) string {
 	for _, v := range vals {
 		if strings.TrimSpace(v) != "" {
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/disintegration/imaging"
+)
+
+// SimilarImageBits is how many of the 256 perceptual hash bits two images
+// may differ in and still count as the same photo. Resizing and heavy JPEG
+// compression stay well below it; a different menu page is far above.
+const SimilarImageBits = 24
+
+// ImageHashes fingerprints a menu image: the SHA-256 of its bytes, which only
+// matches the very same file, and a 256-bit perceptual hash, which stays close
+// when the photo is re-encoded, resized or recompressed by a messaging app.
+// Both are hex encoded.
+func ImageHashes(data []byte) (sha, phash string, err error) {
+	sum := sha256.Sum256(data)
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", "", fmt.Errorf("decoding menu image: %w", err)
+	}
+	img = applyOrientation(img, exifOrientation(data))
+	hash := perceptualHash(img)
+	return hex.EncodeToString(sum[:]), hex.EncodeToString(hash[:]), nil
+}
+
+// PerceptualHashDistance counts the bits two perceptual hashes differ in, or
+// -1 when either is missing or malformed.
+func PerceptualHashDistance(a, b string) int {
+	x, errA := hex.DecodeString(a)
+	y, errB := hex.DecodeString(b)
+	if errA != nil || errB != nil || len(x) == 0 || len(x) != len(y) {
+		return -1
+	}
+	d := 0
+	for i := range x {
+		d += bits.OnesCount8(x[i] ^ y[i])
+	}
+	return d
+}
+
+// perceptualHash is the DCT hash: the image is shrunk to 64x64 gray, and the
+// 16x16 lowest frequencies of its cosine transform, which carry the layout of
+// the page rather than its detail, are compared to their median. Menu pages
+// all look alike at low resolution, hence more bits than the usual 64.
+func perceptualHash(img image.Image) [32]byte {
+	const size, low = 64, 16
+	small := imaging.Grayscale(imaging.Resize(img, size, size, imaging.Box))
+	var px [size][size]float64
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			px[y][x] = float64(small.Pix[y*small.Stride+x*4])
+		}
+	}
+	var cos [low][size]float64
+	for u := 0; u < low; u++ {
+		for x := 0; x < size; x++ {
+			cos[u][x] = math.Cos(float64((2*x+1)*u) * math.Pi / (2 * size))
+		}
+	}
+	// rows first, then columns, keeping only the low frequencies
+	var rows [size][low]float64
+	for y := 0; y < size; y++ {
+		for u := 0; u < low; u++ {
+			for x := 0; x < size; x++ {
+				rows[y][u] += px[y][x] * cos[u][x]
+			}
+		}
+	}
+	var coeffs [low * low]float64
+	for v := 0; v < low; v++ {
+		for u := 0; u < low; u++ {
+			var s float64
+			for y := 0; y < size; y++ {
+				s += rows[y][u] * cos[v][y]
+			}
+			coeffs[v*low+u] = s
+		}
+	}
+	// the first coefficient is the mean brightness and would skew the median
+	sorted := append([]float64(nil), coeffs[1:]...)
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	var hash [32]byte
+	for i, c := range coeffs {
+		if c > median {
+			hash[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return hash
+}
@@ -0,0 +1,348 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // decoders for uploaded menu photos
+	_ "image/png"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/disintegration/imaging"
+	_ "golang.org/x/image/webp"
+)
+
+// PreprocessOptions tunes how menu photos are cleaned up before OCR.
+type PreprocessOptions struct {
+	// MaxDimension is the longest side after downscaling, 2000px by default
+	MaxDimension int
+	// MaxSkewDegrees bounds the deskew search, 10° by default; negative disables deskew
+	MaxSkewDegrees float64
+	// Crop trims the margins around the menu text
+	Crop bool
+}
+
+func (o PreprocessOptions) withDefaults() PreprocessOptions {
+	if o.MaxDimension <= 0 {
+		o.MaxDimension = 2000
+	}
+	if o.MaxSkewDegrees == 0 {
+		o.MaxSkewDegrees = 10
+	}
+	return o
+}
+
+// IImagePreprocessor prepares a menu image for OCR and stores the result,
+// returning the URL and storage ID of the processed image.
+type IImagePreprocessor interface {
+	Preprocess(ctx context.Context, imageURL string) (url, publicID string, report *domain.ImagePreprocessReport, err error)
+}
+
+// ImagePreprocessor runs PreprocessImage on stored menu images and uploads
+// the processed copy next to the original.
+type ImagePreprocessor struct {
+	storage StorageService
+	client  *http.Client
+	opts    PreprocessOptions
+}
+
+func NewImagePreprocessor(storage StorageService, opts PreprocessOptions) *ImagePreprocessor {
+	return &ImagePreprocessor{storage: storage, client: &http.Client{Timeout: 30 * time.Second}, opts: opts}
+}
+
+func (p *ImagePreprocessor) Preprocess(ctx context.Context, imageURL string) (string, string, *domain.ImagePreprocessReport, error) {
+	data, err := readOCRImage(ctx, p.client, imageURL)
+	if err != nil {
+		return "", "", nil, err
+	}
+	processed, report, err := PreprocessImage(data, p.opts)
+	if err != nil {
+		return "", "", nil, err
+	}
+	url, publicID, err := p.storage.UploadFile(ctx, imageBaseName(imageURL)+"-processed.png", processed, "menus/processed")
+	if err != nil {
+		return "", "", nil, err
+	}
+	return url, publicID, report, nil
+}
+
+// PreprocessImage makes a phone photo of a menu easier to read: it applies
+// the EXIF orientation, downscales, converts to grayscale, stretches the
+// contrast, straightens skewed text and optionally crops the margins. The
+// result is a PNG. Everything runs in pure Go.
+func PreprocessImage(data []byte, opts PreprocessOptions) ([]byte, *domain.ImagePreprocessReport, error) {
+	opts = opts.withDefaults()
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding menu image: %w", err)
+	}
+	report := &domain.ImagePreprocessReport{
+		OriginalWidth:  img.Bounds().Dx(),
+		OriginalHeight: img.Bounds().Dy(),
+		Orientation:    exifOrientation(data),
+	}
+	out := applyOrientation(img, report.Orientation)
+
+	if b := out.Bounds(); max(b.Dx(), b.Dy()) > opts.MaxDimension {
+		if b.Dx() >= b.Dy() {
+			out = imaging.Resize(out, opts.MaxDimension, 0, imaging.Lanczos)
+		} else {
+			out = imaging.Resize(out, 0, opts.MaxDimension, imaging.Lanczos)
+		}
+		report.Scale = math.Round(float64(out.Bounds().Dx())/float64(b.Dx())*1000) / 1000
+	}
+
+	gray := imaging.Grayscale(out)
+	report.ContrastStretched = stretchContrast(gray)
+
+	if opts.MaxSkewDegrees > 0 {
+		if angle := detectSkew(gray, opts.MaxSkewDegrees); math.Abs(angle) >= 0.25 {
+			gray = imaging.Rotate(gray, angle, color.White)
+			report.SkewDegrees = angle
+		}
+	}
+	if opts.Crop {
+		if box, ok := contentBounds(gray); ok {
+			gray = imaging.Crop(gray, box)
+			report.Cropped = true
+		}
+	}
+	report.Width, report.Height = gray.Bounds().Dx(), gray.Bounds().Dy()
+
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, gray, imaging.PNG); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), report, nil
+}
+
+// applyOrientation turns an image the way its EXIF orientation tag says the
+// camera held it.
+func applyOrientation(img image.Image, orientation int) *image.NRGBA {
+	switch orientation {
+	case 2:
+		return imaging.FlipH(img)
+	case 3:
+		return imaging.Rotate180(img)
+	case 4:
+		return imaging.FlipV(img)
+	case 5:
+		return imaging.Transpose(img)
+	case 6:
+		return imaging.Rotate270(img)
+	case 7:
+		return imaging.Transverse(img)
+	case 8:
+		return imaging.Rotate90(img)
+	default:
+		return imaging.Clone(img)
+	}
+}
+
+// exifOrientation reads the orientation tag of a JPEG, 1 (upright) when the
+// image has none.
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		if marker == 0xDA || size < 2 || i+2+size > len(data) {
+			return 1 // start of scan: no EXIF segment before the image data
+		}
+		seg := data[i+4 : i+2+size]
+		if marker == 0xE1 && len(seg) > 14 && string(seg[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(seg[6:])
+		}
+		i += 2 + size
+	}
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for e := 0; e < entries; e++ {
+		off := ifd + 2 + e*12
+		if off+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[off:]) == 0x0112 {
+			if v := int(order.Uint16(tiff[off+8:])); v >= 1 && v <= 8 {
+				return v
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// stretchContrast maps the 1st..99th percentile of luminance onto the full
+// range, which lifts dim photos and washes out paper texture. It reports
+// whether anything changed.
+func stretchContrast(img *image.NRGBA) bool {
+	var hist [256]int
+	n := 0
+	for i := 0; i < len(img.Pix); i += 4 {
+		hist[img.Pix[i]]++
+		n++
+	}
+	lo, hi := percentile(hist, n, 0.01), percentile(hist, n, 0.99)
+	if hi-lo < 16 || (lo <= 5 && hi >= 250) {
+		return false
+	}
+	var lut [256]uint8
+	for v := range lut {
+		scaled := (float64(v) - float64(lo)) * 255 / float64(hi-lo)
+		lut[v] = uint8(math.Max(0, math.Min(255, math.Round(scaled))))
+	}
+	for i := 0; i < len(img.Pix); i += 4 {
+		v := lut[img.Pix[i]]
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2] = v, v, v
+	}
+	return true
+}
+
+func percentile(hist [256]int, n int, p float64) int {
+	target := int(float64(n) * p)
+	sum := 0
+	for v, c := range hist {
+		sum += c
+		if sum > target {
+			return v
+		}
+	}
+	return 255
+}
+
+// otsuThreshold picks the gray level that best separates ink from paper;
+// levels at or below it are ink.
+func otsuThreshold(img *image.NRGBA) uint8 {
+	var hist [256]int
+	n := 0
+	for i := 0; i < len(img.Pix); i += 4 {
+		hist[img.Pix[i]]++
+		n++
+	}
+	var total float64
+	for v, c := range hist {
+		total += float64(v * c)
+	}
+	var sumB, wB float64
+	best, bestVar := 0, -1.0
+	for t := 0; t < 256; t++ {
+		wB += float64(hist[t])
+		if wB == 0 {
+			continue
+		}
+		wF := float64(n) - wB
+		if wF == 0 {
+			break
+		}
+		sumB += float64(t * hist[t])
+		mB, mF := sumB/wB, (total-sumB)/wF
+		if between := wB * wF * (mB - mF) * (mB - mF); between > bestVar {
+			best, bestVar = t, between
+		}
+	}
+	return uint8(best)
+}
+
+// detectSkew finds the rotation, in degrees counter-clockwise, that makes
+// the text lines horizontal. For each candidate angle the dark pixels are
+// projected onto the rotated vertical axis; lines of text line up into
+// sharp peaks at the right angle, which maximizes the sum of squared bins.
+func detectSkew(img *image.NRGBA, maxDegrees float64) float64 {
+	small := img
+	if b := img.Bounds(); max(b.Dx(), b.Dy()) > 800 {
+		small = imaging.Fit(img, 800, 800, imaging.Box)
+	}
+	threshold := otsuThreshold(small)
+	w, h := small.Bounds().Dx(), small.Bounds().Dy()
+	type point struct{ x, y float64 }
+	var ink []point
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if small.Pix[y*small.Stride+x*4] <= threshold {
+				ink = append(ink, point{float64(x), float64(y)})
+			}
+		}
+	}
+	// nothing to align on, or a photo rather than text
+	if len(ink) < 50 || len(ink) > w*h/2 {
+		return 0
+	}
+	score := func(deg float64) float64 {
+		sin, cos := math.Sincos(deg * math.Pi / 180)
+		offset := float64(w + h)
+		bins := make([]float64, 2*(w+h)+1)
+		for _, p := range ink {
+			// image y grows downwards, so a counter-clockwise turn by deg
+			// moves a point to y' = y*cos - x*sin
+			bins[int(p.y*cos-p.x*sin+offset)]++
+		}
+		var s float64
+		for _, b := range bins {
+			s += b * b
+		}
+		return s
+	}
+	best, bestScore := 0.0, score(0)
+	search := func(from, to, step float64) {
+		for deg := from; deg <= to+1e-9; deg += step {
+			if s := score(deg); s > bestScore {
+				best, bestScore = deg, s
+			}
+		}
+	}
+	search(-maxDegrees, maxDegrees, 0.5)
+	search(best-0.5, best+0.5, 0.1)
+	return math.Round(best*10) / 10
+}
+
+// contentBounds is the box around the ink plus a small margin, or false when
+// the ink already fills most of the image.
+func contentBounds(img *image.NRGBA) (image.Rectangle, bool) {
+	threshold := otsuThreshold(img)
+	b := img.Bounds()
+	minX, minY, maxX, maxY := b.Dx(), b.Dy(), -1, -1
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			if img.Pix[y*img.Stride+x*4] <= threshold {
+				minX, maxX = min(minX, x), max(maxX, x)
+				minY, maxY = min(minY, y), max(maxY, y)
+			}
+		}
+	}
+	if maxX < 0 {
+		return image.Rectangle{}, false
+	}
+	margin := max(b.Dx(), b.Dy()) / 50
+	box := image.Rect(minX-margin, minY-margin, maxX+margin+1, maxY+margin+1).Intersect(image.Rect(0, 0, b.Dx(), b.Dy()))
+	if box.Dx()*box.Dy() > b.Dx()*b.Dy()*95/100 {
+		return image.Rectangle{}, false
+	}
+	return box, true
+}
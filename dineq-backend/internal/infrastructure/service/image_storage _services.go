@@ -19,6 +19,13 @@ type StorageService interface {
 	UploadFile(ctx context.Context, fileName string, fileData []byte, folder string) (string, string, error)
 	DeleteFile(ctx context.Context, publicID string) error
 }
+
+// DocumentStorage stores files that are not images, such as SVG and PDF
+// prints, byte for byte.
+type DocumentStorage interface {
+	UploadDocument(ctx context.Context, fileName string, fileData []byte, folder string) (string, string, error)
+}
+
 type CloudinaryStorage struct {
 	client *cloudinary.Cloudinary
 }
@@ -61,6 +68,26 @@ func (c *CloudinaryStorage) UploadFile(ctx context.Context, fileName string, fil
 	return uploadResult.SecureURL, uploadResult.PublicID, nil
 }
 
+// UploadDocument implements DocumentStorage. The file is kept as a raw
+// resource under its own name, extension included.
+func (c *CloudinaryStorage) UploadDocument(ctx context.Context, fileName string, fileData []byte, folder string) (string, string, error) {
+	if len(fileData) == 0 {
+		return "", "", domain.ErrInvalidFile
+	}
+	folderDir := fmt.Sprintf("dineQ/%s", folder)
+
+	uploadResult, err := c.client.Upload.Upload(ctx, bytes.NewReader(fileData), uploader.UploadParams{
+		PublicID:     fileName,
+		Folder:       folderDir,
+		ResourceType: "raw",
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return uploadResult.SecureURL, uploadResult.PublicID, nil
+}
+
 // DeleteFile deletes a file from Cloudinary using its public ID.
 func (c *CloudinaryStorage) DeleteFile(ctx context.Context, publicID string) error {
 	destroyResult, err := c.client.Upload.Destroy(ctx, uploader.DestroyParams{
@@ -0,0 +1,452 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+// menuImportColumns maps accepted spreadsheet headers to row fields. Headers are
+// matched case-insensitively with spaces treated as underscores.
+var menuImportColumns = map[string]func(r *domain.MenuImportRow, v string){
+	"tab":         func(r *domain.MenuImportRow, v string) { r.Tab = v },
+	"category":    func(r *domain.MenuImportRow, v string) { r.Category = v },
+	"name":        func(r *domain.MenuImportRow, v string) { r.Name = v },
+	"slug":        func(r *domain.MenuImportRow, v string) { r.Slug = v },
+	"description": func(r *domain.MenuImportRow, v string) { r.Description = v },
+	"price":       func(r *domain.MenuImportRow, v string) { r.Price = v },
+	"currency":    func(r *domain.MenuImportRow, v string) { r.Currency = v },
+	"ingredients": func(r *domain.MenuImportRow, v string) { r.Ingredients = splitImportList(v) },
+	"allergies":   func(r *domain.MenuImportRow, v string) { r.Allergies = splitImportList(v) },
+}
+
+// translated columns are the text column name plus a language suffix, e.g. name_am or tab_om
+func init() {
+	fields := map[string]func(t *domain.MenuImportTranslation, v string){
+		"tab":         func(t *domain.MenuImportTranslation, v string) { t.Tab = v },
+		"category":    func(t *domain.MenuImportTranslation, v string) { t.Category = v },
+		"name":        func(t *domain.MenuImportTranslation, v string) { t.Name = v },
+		"description": func(t *domain.MenuImportTranslation, v string) { t.Description = v },
+	}
+	for _, lang := range domain.SupportedLanguages {
+		if lang == domain.LangEnglish {
+			continue
+		}
+		for field, set := range fields {
+			menuImportColumns[field+"_"+lang] = func(r *domain.MenuImportRow, v string) {
+				r.SetTranslation(lang, func(t *domain.MenuImportTranslation) { set(t, v) })
+			}
+		}
+	}
+}
+
+// DetectMenuImportFormat picks the import format from an explicit value, the
+// uploaded file name or its content type, in that order.
+func DetectMenuImportFormat(explicit, filename, contentType string) (domain.MenuImportFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(explicit)) {
+	case "csv":
+		return domain.MenuImportCSV, nil
+	case "xlsx":
+		return domain.MenuImportXLSX, nil
+	case "json":
+		return domain.MenuImportJSON, nil
+	case "":
+	default:
+		return "", domain.ErrUnsupportedImportFormat
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return domain.MenuImportCSV, nil
+	case ".xlsx":
+		return domain.MenuImportXLSX, nil
+	case ".json":
+		return domain.MenuImportJSON, nil
+	}
+	ct := strings.ToLower(contentType)
+	switch {
+	case strings.Contains(ct, "csv"):
+		return domain.MenuImportCSV, nil
+	case strings.Contains(ct, "spreadsheetml"):
+		return domain.MenuImportXLSX, nil
+	case strings.Contains(ct, "json"):
+		return domain.MenuImportJSON, nil
+	}
+	return "", domain.ErrUnsupportedImportFormat
+}
+
+// ParseMenuImport decodes an import file into one row per item. It only fails
+// when the file itself cannot be read; bad values are left for validation.
+func ParseMenuImport(format domain.MenuImportFormat, data []byte) (*domain.MenuImport, error) {
+	switch format {
+	case domain.MenuImportCSV:
+		data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+		r := csv.NewReader(bytes.NewReader(data))
+		r.FieldsPerRecord = -1
+		r.TrimLeadingSpace = true
+		var records [][]string
+		var lines []int
+		for {
+			rec, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("read csv: %w", err)
+			}
+			line, _ := r.FieldPos(0)
+			records = append(records, rec)
+			lines = append(lines, line)
+		}
+		return menuImportFromTable(records, lines)
+	case domain.MenuImportXLSX:
+		records, lines, err := readXLSXFirstSheet(data)
+		if err != nil {
+			return nil, fmt.Errorf("read xlsx: %w", err)
+		}
+		return menuImportFromTable(records, lines)
+	case domain.MenuImportJSON:
+		return menuImportFromJSON(data)
+	}
+	return nil, domain.ErrUnsupportedImportFormat
+}
+
+// menuImportFromTable maps a header row plus data rows onto import rows. lines
+// holds the source line of each record when it differs from its position.
+func menuImportFromTable(records [][]string, lines []int) (*domain.MenuImport, error) {
+	if len(records) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	setters := make([]func(r *domain.MenuImportRow, v string), len(records[0]))
+	seen := map[string]bool{}
+	for i, h := range records[0] {
+		key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(h)), " ", "_")
+		if set, ok := menuImportColumns[key]; ok {
+			setters[i] = set
+			seen[key] = true
+		}
+	}
+	for _, required := range []string{"name", "price"} {
+		if !seen[required] {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
+	imp := &domain.MenuImport{}
+	for i, rec := range records[1:] {
+		row := domain.MenuImportRow{Row: i + 2}
+		if lines != nil {
+			row.Row = lines[i+1]
+		}
+		blank := true
+		for j, v := range rec {
+			v = strings.TrimSpace(v)
+			if j >= len(setters) || setters[j] == nil || v == "" {
+				continue
+			}
+			setters[j](&row, v)
+			blank = false
+		}
+		if !blank {
+			imp.Rows = append(imp.Rows, row)
+		}
+	}
+	return imp, nil
+}
+
+func splitImportList(v string) []string {
+	parts := strings.FieldsFunc(v, func(r rune) bool { return r == ';' || r == '|' })
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// menuImportDocument is the documented JSON import schema. name_am is
+// shorthand for translations.am.name.
+type menuImportDocument struct {
+	Name string `json:"name"`
+	Tabs []struct {
+		Name         string            `json:"name"`
+		NameAm       string            `json:"name_am"`
+		Translations map[string]string `json:"translations"`
+		Categories   []struct {
+			Name         string            `json:"name"`
+			NameAm       string            `json:"name_am"`
+			Translations map[string]string `json:"translations"`
+			Items        []struct {
+				Name          string   `json:"name"`
+				NameAm        string   `json:"name_am"`
+				Slug          string   `json:"slug"`
+				Description   string   `json:"description"`
+				DescriptionAm string   `json:"description_am"`
+				Price         any      `json:"price"`
+				Currency      string   `json:"currency"`
+				Ingredients   []string `json:"ingredients"`
+				Allergies     []string `json:"allergies"`
+				Translations  map[string]struct {
+					Name        string `json:"name"`
+					Description string `json:"description"`
+				} `json:"translations"`
+			} `json:"items"`
+		} `json:"categories"`
+	} `json:"tabs"`
+}
+
+func menuImportFromJSON(data []byte) (*domain.MenuImport, error) {
+	var doc menuImportDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("read json: %w", err)
+	}
+	imp := &domain.MenuImport{Name: strings.TrimSpace(doc.Name)}
+	n := 0
+	for _, tab := range doc.Tabs {
+		for _, cat := range tab.Categories {
+			for _, it := range cat.Items {
+				n++
+				row := domain.MenuImportRow{
+					Row:         n,
+					Tab:         strings.TrimSpace(tab.Name),
+					Category:    strings.TrimSpace(cat.Name),
+					Name:        strings.TrimSpace(it.Name),
+					Slug:        strings.TrimSpace(it.Slug),
+					Description: strings.TrimSpace(it.Description),
+					Price:       jsonImportPrice(it.Price),
+					Currency:    strings.TrimSpace(it.Currency),
+					Ingredients: it.Ingredients,
+					Allergies:   it.Allergies,
+				}
+				for key, v := range tab.Translations {
+					setImportTranslation(&row, key, func(t *domain.MenuImportTranslation) { t.Tab = strings.TrimSpace(v) })
+				}
+				for key, v := range cat.Translations {
+					setImportTranslation(&row, key, func(t *domain.MenuImportTranslation) { t.Category = strings.TrimSpace(v) })
+				}
+				for key, v := range it.Translations {
+					setImportTranslation(&row, key, func(t *domain.MenuImportTranslation) {
+						t.Name, t.Description = strings.TrimSpace(v.Name), strings.TrimSpace(v.Description)
+					})
+				}
+				setImportTranslation(&row, domain.LangAmharic, func(t *domain.MenuImportTranslation) {
+					t.Tab = firstNonEmpty(strings.TrimSpace(tab.NameAm), t.Tab)
+					t.Category = firstNonEmpty(strings.TrimSpace(cat.NameAm), t.Category)
+					t.Name = firstNonEmpty(strings.TrimSpace(it.NameAm), t.Name)
+					t.Description = firstNonEmpty(strings.TrimSpace(it.DescriptionAm), t.Description)
+				})
+				imp.Rows = append(imp.Rows, row)
+			}
+		}
+	}
+	return imp, nil
+}
+
+// setImportTranslation applies fn to the row's text in the language named by
+// key; unsupported languages are ignored.
+func setImportTranslation(row *domain.MenuImportRow, key string, fn func(t *domain.MenuImportTranslation)) {
+	if lang := domain.NormalizeLanguage(key); lang != "" {
+		row.SetTranslation(lang, fn)
+	}
+}
+
+// jsonImportPrice renders a JSON price (number or string) for validation.
+func jsonImportPrice(v any) string {
+	switch p := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(p, 'f', -1, 64)
+	case string:
+		return strings.TrimSpace(p)
+	default:
+		return fmt.Sprint(p)
+	}
+}
+
+// readXLSXFirstSheet returns the cell text of the first worksheet of an XLSX
+// workbook together with the sheet row number of each record; empty rows are
+// not stored in the sheet, so the two can differ. Only what a menu spreadsheet
+// needs is supported: shared, inline and literal strings plus numbers, without
+// evaluating formulas.
+func readXLSXFirstSheet(data []byte) ([][]string, []int, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, err
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := xlsxFirstSheetPath(files)
+	if err != nil {
+		return nil, nil, err
+	}
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst struct {
+			Items []xlsxText `xml:"si"`
+		}
+		if err := decodeZipXML(f, &sst); err != nil {
+			return nil, nil, err
+		}
+		for _, si := range sst.Items {
+			shared = append(shared, si.String())
+		}
+	}
+
+	sheet, ok := files[sheetPath]
+	if !ok {
+		return nil, nil, fmt.Errorf("worksheet %s not found", sheetPath)
+	}
+	var ws struct {
+		Rows []struct {
+			Ref   int `xml:"r,attr"`
+			Cells []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeZipXML(sheet, &ws); err != nil {
+		return nil, nil, err
+	}
+	records := make([][]string, 0, len(ws.Rows))
+	lines := make([]int, 0, len(ws.Rows))
+	for _, row := range ws.Rows {
+		line := row.Ref
+		if line <= 0 { // r is optional: the row follows the previous one
+			line = 1
+			if len(lines) > 0 {
+				line = lines[len(lines)-1] + 1
+			}
+		}
+		var rec []string
+		for i, c := range row.Cells {
+			col := xlsxColumnIndex(c.Ref)
+			if col < 0 {
+				col = i
+			}
+			if col >= xlsxMaxColumns {
+				return nil, nil, fmt.Errorf("cell %s: column beyond %d", c.Ref, xlsxMaxColumns)
+			}
+			for len(rec) <= col {
+				rec = append(rec, "")
+			}
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(c.Value)
+				if err != nil || idx < 0 || idx >= len(shared) {
+					return nil, nil, fmt.Errorf("cell %s: bad shared string index", c.Ref)
+				}
+				rec[col] = shared[idx]
+			case "inlineStr":
+				rec[col] = c.Inline.String()
+			default:
+				rec[col] = c.Value
+			}
+		}
+		records = append(records, rec)
+		lines = append(lines, line)
+	}
+	return records, lines, nil
+}
+
+// xlsxText is a string item that is either plain (<t>) or rich text (<r><t>).
+type xlsxText struct {
+	Text string   `xml:"t"`
+	Runs []string `xml:"r>t"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) > 0 {
+		return strings.Join(t.Runs, "")
+	}
+	return t.Text
+}
+
+// xlsxFirstSheetPath resolves the first sheet of the workbook to its part name.
+func xlsxFirstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+	wbFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", errors.New("not an xlsx workbook")
+	}
+	var wb struct {
+		Sheets []struct {
+			RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeZipXML(wbFile, &wb); err != nil {
+		return "", err
+	}
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if len(wb.Sheets) == 0 || !ok {
+		return fallback, nil
+	}
+	var rels struct {
+		Items []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeZipXML(relsFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Items {
+		if rel.ID != wb.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+// xlsxMaxColumns is the column limit of an Excel sheet (XFD).
+const xlsxMaxColumns = 16384
+
+// xlsxColumnIndex turns a cell reference such as "C12" into a 0-based column.
+// References past the sheet limit return xlsxMaxColumns.
+func xlsxColumnIndex(ref string) int {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		if col > xlsxMaxColumns {
+			return xlsxMaxColumns
+		}
+		n++
+	}
+	if n == 0 {
+		return -1
+	}
+	return col - 1
+}
+
+func decodeZipXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(io.LimitReader(rc, 64<<20)).Decode(v)
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
+)
+
+// A4 portrait in points.
+const (
+	menuPDFWidth   = 595.28
+	menuPDFHeight  = 841.89
+	menuPDFMargin  = 40.0
+	menuPDFFooter  = 28.0
+	menuPDFHeader  = 104.0
+	menuPDFQRSize  = 84.0
+	menuPDFLogo    = 64.0
+	menuPDFPriceW  = 90.0
+	menuPDFColumnG = 14.0
+)
+
+var (
+	menuPDFDefaultPrimary = "#89643E"
+	menuPDFDefaultAccent  = "#DD3424"
+	menuPDFText           = color.RGBA{R: 0x1f, G: 0x29, B: 0x37, A: 0xff}
+	menuPDFMuted          = color.RGBA{R: 0x6b, G: 0x72, B: 0x80, A: 0xff}
+)
+
+// MenuPDFService renders published menus to print-ready PDFs. Everything it
+// needs is compiled in (fonts, QR encoder) except the restaurant logo, which is
+// skipped when it cannot be loaded.
+type MenuPDFService struct {
+	qrService *QRService
+}
+
+func NewMenuPDFService(qrService *QRService) *MenuPDFService {
+	return &MenuPDFService{qrService: qrService}
+}
+
+type menuPDFLayout struct {
+	doc             *pdfDocument
+	regular, bold   pdfFontSet
+	primary, accent color.Color
+	amharic         bool
+	y               float64
+}
+
+func (s *MenuPDFService) RenderMenuPDF(ctx context.Context, menu *domain.Menu, rest *domain.Restaurant, opts domain.MenuPDFOptions) ([]byte, error) {
+	doc := newPDFDocument(menuPDFWidth, menuPDFHeight)
+	regularFiles, boldFiles := pdfFontFiles()
+	l := &menuPDFLayout{doc: doc, amharic: opts.IncludeAmharic}
+	for _, data := range regularFiles {
+		f, err := doc.addFont(data)
+		if err != nil {
+			return nil, fmt.Errorf("load font: %w", err)
+		}
+		l.regular = append(l.regular, f)
+	}
+	for _, data := range boldFiles {
+		f, err := doc.addFont(data)
+		if err != nil {
+			return nil, fmt.Errorf("load font: %w", err)
+		}
+		l.bold = append(l.bold, f)
+	}
+	if l.amharic {
+		if err := CheckPDFFonts(); err != nil {
+			return nil, err
+		}
+	}
+	l.primary = colorOrDefault(rest.PrimaryColor, menuPDFDefaultPrimary)
+	l.accent = colorOrDefault(rest.AccentColor, menuPDFDefaultAccent)
+
+	doc.addPage()
+	if err := s.drawHeader(ctx, l, menu, rest); err != nil {
+		return nil, err
+	}
+	currency := rest.DefaultCurrency
+	if currency == "" {
+		currency = "ETB"
+	}
+	for _, tab := range menu.Sections() {
+		l.drawTab(tab, currency)
+	}
+
+	footer := rest.RestaurantName
+	for i := range doc.pages {
+		doc.setPage(i)
+		y := menuPDFHeight - menuPDFFooter + 8
+		doc.line(menuPDFMargin, y-10, menuPDFWidth-menuPDFMargin, y-10, 0.5, menuPDFMuted)
+		doc.text(l.regular, 8, menuPDFMargin, y, menuPDFMuted, footer)
+		pageLabel := fmt.Sprintf("Page %d of %d", i+1, len(doc.pages))
+		doc.text(l.regular, 8, menuPDFWidth-menuPDFMargin-l.regular.width(pageLabel, 8), y, menuPDFMuted, pageLabel)
+	}
+	return doc.bytes()
+}
+
+// drawHeader paints the branded band with logo, names and the menu QR code.
+func (s *MenuPDFService) drawHeader(ctx context.Context, l *menuPDFLayout, menu *domain.Menu, rest *domain.Restaurant) error {
+	doc := l.doc
+	doc.fillRect(0, 0, menuPDFWidth, menuPDFHeader, l.primary)
+
+	textX := menuPDFMargin
+	if rest.LogoImage != nil && *rest.LogoImage != "" && ctx.Err() == nil {
+		if img, err := fetchLogoImage(*rest.LogoImage); err != nil {
+			logger.Log.Warn().Err(err).Str("restaurant", rest.Slug).Msg("menu PDF: skipping logo")
+		} else if logo, err := doc.addImage(img); err == nil {
+			b := img.Bounds()
+			w, h := menuPDFLogo, menuPDFLogo
+			if b.Dx() > b.Dy() {
+				h = menuPDFLogo * float64(b.Dy()) / float64(b.Dx())
+			} else if b.Dy() > b.Dx() {
+				w = menuPDFLogo * float64(b.Dx()) / float64(b.Dy())
+			}
+			doc.fillRect(menuPDFMargin-4, (menuPDFHeader-menuPDFLogo)/2-4, menuPDFLogo+8, menuPDFLogo+8, color.White)
+			doc.drawImage(logo, menuPDFMargin+(menuPDFLogo-w)/2, (menuPDFHeader-h)/2, w, h)
+			textX += menuPDFLogo + 16
+		}
+	}
+
+	qrX := menuPDFWidth - menuPDFMargin - menuPDFQRSize
+	qrY := (menuPDFHeader - menuPDFQRSize) / 2
+	if s.qrService != nil {
+		modules, _, err := s.qrService.MenuQRMatrix(rest.Slug, menu.Slug)
+		if err != nil {
+			return err
+		}
+		doc.fillRect(qrX, qrY, menuPDFQRSize, menuPDFQRSize, color.White)
+		cell := menuPDFQRSize / float64(len(modules))
+		for row, cols := range modules {
+			// draw horizontal runs of dark modules as one rectangle each
+			for col := 0; col < len(cols); {
+				if !cols[col] {
+					col++
+					continue
+				}
+				start := col
+				for col < len(cols) && cols[col] {
+					col++
+				}
+				doc.fillRect(qrX+float64(start)*cell, qrY+float64(row)*cell, float64(col-start)*cell, cell, color.Black)
+			}
+		}
+	}
+
+	maxW := qrX - textX - menuPDFColumnG
+	name := firstLine(l.bold.wrap(rest.RestaurantName, 22, maxW))
+	doc.text(l.bold, 22, textX, 48, color.White, name)
+	doc.text(l.regular, 12, textX, 70, color.White, firstLine(l.regular.wrap(menu.Name, 12, maxW)))
+	l.y = menuPDFHeader + 28
+	return nil
+}
+
+func (l *menuPDFLayout) drawTab(tab domain.Tab, currency string) {
+	doc := l.doc
+	l.ensure(60)
+	doc.text(l.bold, 17, menuPDFMargin, l.y, l.primary, tab.Name)
+	if am := tab.Translations[domain.LangAmharic]; l.amharic && am != "" {
+		doc.text(l.bold, 17, l.amharicX(), l.y, l.primary, am)
+	}
+	l.y += 7
+	doc.line(menuPDFMargin, l.y, menuPDFWidth-menuPDFMargin, l.y, 1.5, l.accent)
+	l.y += 18
+	for _, cat := range tab.Categories {
+		if cat.Name != "" {
+			l.ensure(44)
+			doc.text(l.bold, 12, menuPDFMargin, l.y, l.accent, strings.ToUpper(cat.Name))
+			if am := cat.Translations[domain.LangAmharic]; l.amharic && am != "" {
+				doc.text(l.bold, 12, l.amharicX(), l.y, l.accent, am)
+			}
+			l.y += 18
+		}
+		for i := range cat.Items {
+			l.drawItem(&cat.Items[i], currency)
+		}
+	}
+	l.y += 10
+}
+
+func (l *menuPDFLayout) drawItem(item *domain.Item, currency string) {
+	doc := l.doc
+	enW := l.englishWidth()
+	nameLines := l.bold.wrap(item.Name, 11, enW)
+	descLines := l.regular.wrap(item.Description, 9, enW)
+	height := float64(len(nameLines))*14 + float64(len(descLines))*11.5
+	var amNameLines, amDescLines []string
+	if l.amharic {
+		amW := l.amharicWidth()
+		am := item.Translation(domain.LangAmharic)
+		amNameLines = l.bold.wrap(am.Name, 11, amW)
+		amDescLines = l.regular.wrap(am.Description, 9, amW)
+		height = max(height, float64(len(amNameLines))*14+float64(len(amDescLines))*11.5)
+	}
+	l.ensure(height + 10)
+
+	top := l.y
+	drawLines := func(x float64, names, descs []string) {
+		y := top
+		for _, s := range names {
+			doc.text(l.bold, 11, x, y, menuPDFText, s)
+			y += 14
+		}
+		for _, s := range descs {
+			doc.text(l.regular, 9, x, y, menuPDFMuted, s)
+			y += 11.5
+		}
+	}
+	drawLines(menuPDFMargin, nameLines, descLines)
+	if l.amharic {
+		drawLines(l.amharicX(), amNameLines, amDescLines)
+	}
+	price := formatMenuPDFPrice(item, currency)
+	doc.text(l.bold, 11, menuPDFWidth-menuPDFMargin-l.bold.width(price, 11), top, l.primary, price)
+	l.y = top + height + 10
+}
+
+// ensure starts a new page when less than h points are left on this one.
+func (l *menuPDFLayout) ensure(h float64) {
+	if l.y+h <= menuPDFHeight-menuPDFMargin-menuPDFFooter {
+		return
+	}
+	l.doc.addPage()
+	l.doc.fillRect(0, 0, menuPDFWidth, 8, l.primary)
+	l.y = menuPDFMargin + 12
+}
+
+func (l *menuPDFLayout) textWidth() float64 {
+	return menuPDFWidth - 2*menuPDFMargin - menuPDFPriceW
+}
+
+func (l *menuPDFLayout) englishWidth() float64 {
+	if l.amharic {
+		return (l.textWidth() - menuPDFColumnG) / 2
+	}
+	return l.textWidth()
+}
+
+func (l *menuPDFLayout) amharicX() float64 {
+	return menuPDFMargin + l.englishWidth() + menuPDFColumnG
+}
+
+func (l *menuPDFLayout) amharicWidth() float64 {
+	return l.englishWidth()
+}
+
+// formatMenuPDFPrice prints the base price, or the price range when modifiers
+// change it. Items without a currency use the restaurant default.
+func formatMenuPDFPrice(item *domain.Item, currency string) string {
+	if item.Currency != "" {
+		currency = item.Currency
+	}
+	low, high := item.PriceRange()
+	if high > low {
+		return fmt.Sprintf("%.2f – %.2f %s", low, high, currency)
+	}
+	return fmt.Sprintf("%.2f %s", low, currency)
+}
+
+func colorOrDefault(hex, fallback string) color.Color {
+	if c, err := parseHexColor(hex); err == nil {
+		return c
+	}
+	c, _ := parseHexColor(fallback)
+	return c
+}
+
+func firstLine(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return lines[0]
+}
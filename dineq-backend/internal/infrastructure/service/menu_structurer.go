@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+// IMenuStructurer turns OCR text into a menu. Implementations are selected by
+// AI_PROVIDER; see NewMenuStructurer.
+type IMenuStructurer interface {
+	// Name is the provider name recorded when a call fails before any usage is known.
+	Name() string
+	StructureMenu(ctx context.Context, ocrText string) (*domain.StructuredMenu, error)
+}
+
+// AI provider names accepted by NewMenuStructurer.
+const (
+	AIProviderGemini = "gemini"
+	AIProviderOpenAI = "openai"
+	AIProviderFake   = "fake"
+)
+
+// AIProviderConfig holds the settings of every provider; only the selected
+// provider's fields are used.
+type AIProviderConfig struct {
+	Provider string
+	// Model overrides the provider's default model
+	Model string
+
+	GeminiAPIKey string
+
+	// BaseURL of an OpenAI-compatible API, e.g. http://localhost:11434/v1
+	BaseURL string
+	// APIKey is sent as a bearer token when set; local servers often need none
+	APIKey string
+}
+
+// NewMenuStructurer builds the structuring backend named by cfg.Provider,
+// Gemini when empty. The Gemini and OpenAI-compatible backends also
+// implement domain.ITranslator.
+func NewMenuStructurer(ctx context.Context, cfg AIProviderConfig) (IMenuStructurer, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case "", AIProviderGemini:
+		if cfg.GeminiAPIKey == "" {
+			return nil, errors.New("gemini provider needs GEMINI_API_KEY")
+		}
+		svc, err := NewAIService(ctx, cfg.GeminiAPIKey, cfg.Model, nil)
+		if err != nil {
+			return nil, err
+		}
+		return svc.(*GeminiService), nil
+	case AIProviderOpenAI:
+		svc, err := NewOpenAICompatService(cfg.BaseURL, cfg.APIKey, cfg.Model)
+		if err != nil {
+			return nil, err
+		}
+		return svc, nil
+	case AIProviderFake:
+		return FakeMenuStructurer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider %q", cfg.Provider)
+	}
+}
+
+func (gs *GeminiService) Name() string { return AIProviderGemini }
+
+// FakeMenuStructurer builds menus without a model, for tests and offline
+// demos. A line ending in a price becomes an item; a line without digits
+// starts a new tab. The same text always gives the same menu content. Word
+// counts stand in for token counts.
+type FakeMenuStructurer struct{}
+
+var fakeMenuLine = regexp.MustCompile(`^(.*?)[\s.:\-–]*(\d+(?:[.,]\d{1,2})?)\s*(?i:etb|birr|br)?\.?$`)
+
+func (FakeMenuStructurer) Name() string { return AIProviderFake }
+
+func (FakeMenuStructurer) StructureMenu(_ context.Context, ocrText string) (*domain.StructuredMenu, error) {
+	results := &menuProcessingResults{MenuItems: []menuItemResult{}}
+	tab := ""
+	for _, line := range strings.Split(ocrText, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		m := fakeMenuLine.FindStringSubmatch(line)
+		if m == nil || strings.TrimSpace(m[1]) == "" {
+			if !strings.ContainsAny(line, "0123456789") {
+				tab = line
+			}
+			continue
+		}
+		price, err := strconv.ParseFloat(strings.ReplaceAll(m[2], ",", "."), 64)
+		if err != nil {
+			continue
+		}
+		results.MenuItems = append(results.MenuItems, menuItemResult{Name: strings.TrimSpace(m[1]), Price: price, Currency: "ETB", Tab: tab})
+	}
+	if len(results.MenuItems) == 0 {
+		return nil, errors.New("no priced menu lines in OCR text")
+	}
+	raw, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+	prompt, completion := len(strings.Fields(ocrText)), len(strings.Fields(string(raw)))
+	return &domain.StructuredMenu{
+		Menu:    buildStructuredMenu(results),
+		RawJSON: string(raw),
+		Usage:   domain.AIUsage{Provider: AIProviderFake, Model: AIProviderFake, PromptTokens: prompt, CompletionTokens: completion, TotalTokens: prompt + completion},
+	}, nil
+}
@@ -13,15 +13,24 @@ import (
 
 type NotificationService interface {
 	SendNotification(ctx context.Context, userID string, notification *domain.Notification) error
-	RegisterClient(userID string, conn *websocket.Conn)
+	// RegisterClient registers the user's connection; restaurantSlugs are the
+	// restaurants whose broadcasts it receives.
+	RegisterClient(userID string, conn *websocket.Conn, restaurantSlugs ...string)
 	UnregisterClient(userID string)
 	StartPing(conn *websocket.Conn, userID string)
+	// Broadcast sends a notification to the connections subscribed to a restaurant.
+	Broadcast(ctx context.Context, restaurantSlug string, notification *domain.Notification) error
+	// Push sends a notification to the user's open connection, if any, without
+	// queueing it for later.
+	Push(ctx context.Context, userID string, notification *domain.Notification) error
 }
 
 type notificationService struct {
-	clients sync.Map                          // Use sync.Map for active connections (read-heavy)
-	queues  map[string][]*domain.Notification // Use map for queued notifications
-	mutex   sync.Mutex                        // Mutex for queues
+	clients       sync.Map                          // Use sync.Map for active connections (read-heavy)
+	writers       sync.Map                          // *websocket.Conn -> *sync.Mutex; a connection takes one writer at a time
+	subscriptions sync.Map                          // userID -> map[string]bool of restaurant slugs
+	queues        map[string][]*domain.Notification // Use map for queued notifications
+	mutex         sync.Mutex                        // Mutex for queues
 }
 
 func NewNotificationService() NotificationService {
@@ -41,10 +50,10 @@ func (s *notificationService) SendNotification(ctx context.Context, userID strin
 	if conn, ok := s.clients.Load(userID); ok {
 		if conn != nil {
 			fmt.Println("Sending notification to", userID)
-			if err := conn.(*websocket.Conn).WriteMessage(websocket.TextMessage, data); err != nil {
+			if err := s.write(conn.(*websocket.Conn), websocket.TextMessage, data); err != nil {
 				fmt.Println("Write error:", err)
-				s.clients.Delete(userID)                  // Remove invalid connection
-				s.queueNotification(userID, notification) // Queue on failure
+				s.dropClient(userID, conn.(*websocket.Conn)) // Remove invalid connection
+				s.queueNotification(userID, notification)    // Queue on failure
 				return err
 			}
 			return nil
@@ -75,8 +84,17 @@ func (s *notificationService) queueNotification(userID string, notification *dom
 }
 
 // RegisterClient registers a WebSocket connection and delivers queued notifications
-func (s *notificationService) RegisterClient(userID string, conn *websocket.Conn) {
-	s.clients.Store(userID, conn)
+func (s *notificationService) RegisterClient(userID string, conn *websocket.Conn, restaurantSlugs ...string) {
+	subscribed := make(map[string]bool, len(restaurantSlugs))
+	for _, slug := range restaurantSlugs {
+		if slug != "" {
+			subscribed[slug] = true
+		}
+	}
+	s.subscriptions.Store(userID, subscribed)
+	if previous, loaded := s.clients.Swap(userID, conn); loaded && previous != conn {
+		s.writers.Delete(previous)
+	}
 	fmt.Println("Client registered in service:", userID)
 	// Deliver queued notifications
 	s.mutex.Lock()
@@ -89,9 +107,88 @@ func (s *notificationService) RegisterClient(userID string, conn *websocket.Conn
 	}
 }
 
+// Broadcast sends a notification to the connected clients subscribed to the
+// restaurant. Offline users are skipped rather than queued since broadcasts
+// describe live state.
+func (s *notificationService) Broadcast(ctx context.Context, restaurantSlug string, notification *domain.Notification) error {
+	if restaurantSlug == "" {
+		return nil
+	}
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	s.subscriptions.Range(func(key, value any) bool {
+		if !value.(map[string]bool)[restaurantSlug] {
+			return true
+		}
+		userID := key.(string)
+		stored, ok := s.clients.Load(userID)
+		if !ok {
+			return true
+		}
+		conn, ok := stored.(*websocket.Conn)
+		if !ok || conn == nil {
+			return true
+		}
+		if err := s.write(conn, websocket.TextMessage, data); err != nil {
+			fmt.Println("Broadcast write error:", userID, err)
+			s.dropClient(userID, conn)
+		}
+		return true
+	})
+	return nil
+}
+
+// Push sends a transient event, such as OCR job progress, to a connected
+// user. Events for offline users are dropped: they are stale by the time the
+// user reconnects.
+func (s *notificationService) Push(ctx context.Context, userID string, notification *domain.Notification) error {
+	value, ok := s.clients.Load(userID)
+	if !ok {
+		return nil
+	}
+	conn, ok := value.(*websocket.Conn)
+	if !ok || conn == nil {
+		return nil
+	}
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	if err := s.write(conn, websocket.TextMessage, data); err != nil {
+		s.dropClient(userID, conn)
+		return err
+	}
+	return nil
+}
+
+// write serializes writes to a connection; gorilla/websocket allows only one
+// concurrent writer, and pings, notifications and job events come from
+// different goroutines.
+func (s *notificationService) write(conn *websocket.Conn, messageType int, data []byte) error {
+	mu, _ := s.writers.LoadOrStore(conn, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+	return conn.WriteMessage(messageType, data)
+}
+
 // UnregisterClient removes a WebSocket connection
 func (s *notificationService) UnregisterClient(userID string) {
-	s.clients.Delete(userID)
+	if conn, ok := s.clients.LoadAndDelete(userID); ok {
+		s.writers.Delete(conn)
+	}
+	s.subscriptions.Delete(userID)
+}
+
+// dropClient forgets a connection whose write failed. The user may have
+// reconnected meanwhile, so a newer connection is left registered.
+func (s *notificationService) dropClient(userID string, conn *websocket.Conn) {
+	s.writers.Delete(conn)
+	if s.clients.CompareAndDelete(userID, conn) {
+		s.subscriptions.Delete(userID)
+	}
+	_ = conn.Close()
 }
 
 // startPing keeps the connection alive
@@ -99,9 +196,9 @@ func (s *notificationService) StartPing(conn *websocket.Conn, userID string) {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
 	for range ticker.C {
-		if err := conn.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
+		if err := s.write(conn, websocket.PingMessage, []byte{}); err != nil {
 			fmt.Println("Ping failed:", userID, err)
-			s.UnregisterClient(userID)
+			s.dropClient(userID, conn)
 			return
 		}
 	}
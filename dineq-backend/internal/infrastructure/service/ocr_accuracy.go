@@ -0,0 +1,47 @@
+package services
+
+import "strings"
+
+// OCRAccuracy scores OCR text against a hand-typed transcript as 1 minus the
+// character error rate, after lowercasing and collapsing whitespace. 1 is a
+// perfect read; the score is clamped at 0 for text that is mostly wrong.
+func OCRAccuracy(truth, got string) float64 {
+	want := []rune(normalizeOCRText(truth))
+	have := []rune(normalizeOCRText(got))
+	if len(want) == 0 {
+		if len(have) == 0 {
+			return 1
+		}
+		return 0
+	}
+	cer := float64(editDistance(want, have)) / float64(len(want))
+	if cer > 1 {
+		return 0
+	}
+	return 1 - cer
+}
+
+func normalizeOCRText(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// editDistance is the Levenshtein distance between two rune slices.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
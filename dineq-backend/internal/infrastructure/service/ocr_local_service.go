@@ -0,0 +1,130 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+// maxOCRImageBytes bounds what the local providers download.
+const maxOCRImageBytes = 20 << 20
+
+// TesseractOCRService reads menus offline with the tesseract CLI. Images are
+// piped through stdin, so nothing is written to disk.
+type TesseractOCRService struct {
+	binary    string
+	languages string
+	client    *http.Client
+}
+
+// NewTesseractOCRService checks that the tesseract binary can be found.
+func NewTesseractOCRService(binary, languages string) (*TesseractOCRService, error) {
+	if binary == "" {
+		binary = "tesseract"
+	}
+	if languages == "" {
+		languages = "eng+amh"
+	}
+	resolved, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("tesseract not available: %w", err)
+	}
+	return &TesseractOCRService{binary: resolved, languages: languages, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+func (s *TesseractOCRService) ExtractText(ctx context.Context, imageURL string) (*domain.OCRDocument, error) {
+	img, err := readOCRImage(ctx, s.client, imageURL)
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.binary, "stdin", "stdout", "-l", s.languages)
+	cmd.Stdin = bytes.NewReader(img)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("tesseract failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return &domain.OCRDocument{Text: strings.TrimSpace(stdout.String()), Provider: OCRProviderTesseract}, nil
+}
+
+// FixtureOCRService returns canned text instead of reading the image, for
+// tests and demos without network access. The text for an image is
+// <Dir>/<image base name without extension>.txt, else <Dir>/default.txt;
+// Texts, keyed the same way, is consulted first.
+type FixtureOCRService struct {
+	Dir   string
+	Texts map[string]string
+}
+
+func (s *FixtureOCRService) ExtractText(_ context.Context, imageURL string) (*domain.OCRDocument, error) {
+	name := imageBaseName(imageURL)
+	for _, key := range []string{name, "default"} {
+		if text, ok := s.Texts[key]; ok {
+			return &domain.OCRDocument{Text: text, Provider: OCRProviderFixture}, nil
+		}
+		if s.Dir == "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.Dir, key+".txt"))
+		if err == nil {
+			return &domain.OCRDocument{Text: string(data), Provider: OCRProviderFixture}, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("no OCR fixture for %q", name)
+}
+
+// imageBaseName is the file name of an image URL or path without its extension.
+func imageBaseName(imageURL string) string {
+	p := imageURL
+	if u, err := url.Parse(imageURL); err == nil && u.Path != "" {
+		p = u.Path
+	}
+	base := path.Base(filepath.ToSlash(p))
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// readOCRImage loads an image from an http(s) URL, a file:// URL or a local path.
+func readOCRImage(ctx context.Context, client *http.Client, imageURL string) ([]byte, error) {
+	u, err := url.Parse(imageURL)
+	if err != nil || u.Scheme == "" || u.Scheme == "file" {
+		p := imageURL
+		if err == nil && u.Scheme == "file" {
+			p = u.Path
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return io.ReadAll(io.LimitReader(f, maxOCRImageBytes))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching menu image: %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxOCRImageBytes))
+}
@@ -2,21 +2,71 @@ package services
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"strings"
 
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
 	"github.com/veryfi/veryfi-go/veryfi"
 	"github.com/veryfi/veryfi-go/veryfi/scheme"
 )
 
+// IOCRService reads the text of a menu image. Implementations are selected
+// by OCR_PROVIDER; see NewOCRProvider.
 type IOCRService interface {
-	ProcessDocumentURL(ctx context.Context, url string) (*scheme.Document, error)
+	ExtractText(ctx context.Context, url string) (*domain.OCRDocument, error)
 }
 
+// OCR provider names accepted by NewOCRProvider.
+const (
+	OCRProviderVeryfi    = "veryfi"
+	OCRProviderTesseract = "tesseract"
+	OCRProviderFixture   = "fixture"
+)
+
+// OCRProviderConfig holds the settings of every provider; only the selected
+// provider's fields are used.
+type OCRProviderConfig struct {
+	Provider string
+
+	Veryfi veryfi.Options
+
+	// TesseractPath is the tesseract binary, "tesseract" on PATH by default
+	TesseractPath string
+	// TesseractLanguages is passed to tesseract -l, "eng+amh" by default
+	TesseractLanguages string
+
+	// FixtureDir holds <image name>.txt files for the fixture provider
+	FixtureDir string
+}
+
+// NewOCRProvider builds the OCR backend named by cfg.Provider, Veryfi when empty.
+func NewOCRProvider(cfg OCRProviderConfig) (IOCRService, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case "", OCRProviderVeryfi:
+		return NewOCRService(&cfg.Veryfi)
+	case OCRProviderTesseract:
+		svc, err := NewTesseractOCRService(cfg.TesseractPath, cfg.TesseractLanguages)
+		if err != nil {
+			return nil, err
+		}
+		return svc, nil
+	case OCRProviderFixture:
+		if cfg.FixtureDir == "" {
+			return nil, errors.New("fixture OCR provider needs OCR_FIXTURE_DIR")
+		}
+		return &FixtureOCRService{Dir: cfg.FixtureDir}, nil
+	default:
+		return nil, fmt.Errorf("unknown OCR provider %q", cfg.Provider)
+	}
+}
+
+// OcrService reads menus with the Veryfi document API.
 type OcrService struct {
 	client *veryfi.Client
 }
 
-func NewOCRService(options *veryfi.Options) IOCRService {
+func NewOCRService(options *veryfi.Options) (IOCRService, error) {
 	client, err := veryfi.NewClientV8(&veryfi.Options{
 		ClientID:     options.ClientID,
 		ClientSecret: options.ClientSecret,
@@ -24,17 +74,17 @@ func NewOCRService(options *veryfi.Options) IOCRService {
 		Username:     options.Username,
 	})
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to create Veryfi client: %w", err)
 	}
-	return &OcrService{client: client}
+	return &OcrService{client: client}, nil
 }
 
-func (s *OcrService) ProcessDocumentURL(ctx context.Context, url string) (*scheme.Document, error) {
+func (s *OcrService) ExtractText(ctx context.Context, url string) (*domain.OCRDocument, error) {
 	resp, err := s.client.ProcessDocumentURL(scheme.DocumentURLOptions{
 		FileURL: url,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+	return &domain.OCRDocument{Text: resp.OCRText, Provider: OCRProviderVeryfi}, nil
 }
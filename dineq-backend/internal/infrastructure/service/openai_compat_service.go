@@ -0,0 +1,146 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+// OpenAICompatService talks to any server implementing the OpenAI chat
+// completions API, such as Ollama, vLLM or llama.cpp, so menus can be
+// structured by a self-hosted model.
+type OpenAICompatService struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+func NewOpenAICompatService(baseURL, apiKey, model string) (*OpenAICompatService, error) {
+	if baseURL == "" {
+		return nil, errors.New("openai provider needs AI_BASE_URL")
+	}
+	if model == "" {
+		return nil, errors.New("openai provider needs AI_MODEL")
+	}
+	// requests are bounded by the caller's context
+	return &OpenAICompatService{baseURL: strings.TrimRight(baseURL, "/"), apiKey: apiKey, model: model, client: &http.Client{}}, nil
+}
+
+func (s *OpenAICompatService) Name() string { return AIProviderOpenAI }
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string            `json:"model"`
+	Messages       []chatMessage     `json:"messages"`
+	Temperature    float64           `json:"temperature"`
+	ResponseFormat map[string]string `json:"response_format,omitempty"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// complete sends one chat completion, retrying transient errors.
+func (s *OpenAICompatService) complete(ctx context.Context, prompt string, jsonObject bool) (string, domain.AIUsage, error) {
+	usage := domain.AIUsage{Provider: AIProviderOpenAI, Model: s.model}
+	req := chatRequest{Model: s.model, Messages: []chatMessage{{Role: "user", Content: prompt}}}
+	if jsonObject {
+		req.ResponseFormat = map[string]string{"type": "json_object"}
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", usage, err
+	}
+	var lastErr error
+	for attempt := 1; attempt <= 3; attempt++ {
+		if attempt > 1 {
+			time.Sleep(time.Duration(math.Pow(2, float64(attempt-2))) * 500 * time.Millisecond)
+		}
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/chat/completions", bytes.NewReader(body))
+		if err != nil {
+			return "", usage, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		if s.apiKey != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+s.apiKey)
+		}
+		resp, err := s.client.Do(httpReq)
+		if err != nil {
+			return "", usage, fmt.Errorf("chat completion failed: %w", err)
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+		resp.Body.Close()
+		if err != nil {
+			return "", usage, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			lastErr = fmt.Errorf("chat completion failed: %s: %.300s", resp.Status, data)
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+				continue
+			}
+			return "", usage, lastErr
+		}
+		var out chatResponse
+		if err := json.Unmarshal(data, &out); err != nil {
+			return "", usage, fmt.Errorf("invalid chat completion response: %w", err)
+		}
+		usage.PromptTokens += out.Usage.PromptTokens
+		usage.CompletionTokens += out.Usage.CompletionTokens
+		usage.TotalTokens += out.Usage.TotalTokens
+		if len(out.Choices) == 0 {
+			return "", usage, errors.New("chat completion returned no choices")
+		}
+		return out.Choices[0].Message.Content, usage, nil
+	}
+	return "", usage, lastErr
+}
+
+func (s *OpenAICompatService) StructureMenu(ctx context.Context, ocrText string) (*domain.StructuredMenu, error) {
+	raw, usage, err := s.complete(ctx, menuStructuringPrompt(ocrText, ""), true)
+	if err != nil {
+		return nil, err
+	}
+	results, err := parseMenuResponse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse model response: %w", err)
+	}
+	return &domain.StructuredMenu{Menu: buildStructuredMenu(results), RawJSON: raw, Usage: usage}, nil
+}
+
+// TranslateBatch implements domain.ITranslator. JSON mode is left off since
+// it only allows objects and the reply is an array.
+func (s *OpenAICompatService) TranslateBatch(ctx context.Context, texts []string, target string) ([]string, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	prompt, err := translateBatchPrompt(texts, target)
+	if err != nil {
+		return nil, err
+	}
+	raw, _, err := s.complete(ctx, prompt, false)
+	if err != nil {
+		return nil, err
+	}
+	return parseTranslateBatch(raw, len(texts))
+}
@@ -0,0 +1,491 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"sort"
+	"strings"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/math/fixed"
+)
+
+// pdfDocument is a small PDF 1.4 writer covering what printable menus need:
+// pages, filled shapes, gradients, embedded TrueType text and images.
+// Coordinates are in points with the origin at the top-left corner of the page.
+type pdfDocument struct {
+	width, height float64
+	pages         []*bytes.Buffer
+	current       int
+	fonts         []*pdfFont
+	images        []*pdfImage
+	shadings      []*pdfShading
+}
+
+// pdfFont is an embedded TrueType font addressed by glyph id (Identity-H), so
+// any script the font covers can be written without a code page.
+type pdfFont struct {
+	name string
+	data []byte
+	ttf  *truetype.Font
+	used map[truetype.Index]rune
+}
+
+// pdfImage holds either JPEG data or, for lossless images, raw RGB samples
+// with an optional alpha channel.
+type pdfImage struct {
+	name          string
+	width, height int
+	jpeg          []byte
+	rgb, alpha    []byte
+}
+
+// pdfShading is a two-colour axial gradient in page coordinates.
+type pdfShading struct {
+	name           string
+	x0, y0, x1, y1 float64
+	from, to       color.Color
+}
+
+// pdfRect is an axis-aligned rectangle with its top-left corner at x, y.
+type pdfRect struct {
+	x, y, w, h float64
+}
+
+// pdfFontSet is a fallback chain: each rune is drawn with the first font that
+// has a glyph for it.
+type pdfFontSet []*pdfFont
+
+func newPDFDocument(width, height float64) *pdfDocument {
+	return &pdfDocument{width: width, height: height}
+}
+
+// addFont registers a TrueType font for embedding.
+func (d *pdfDocument) addFont(data []byte) (*pdfFont, error) {
+	f, err := parsePDFFont(fmt.Sprintf("F%d", len(d.fonts)+1), data)
+	if err != nil {
+		return nil, err
+	}
+	d.fonts = append(d.fonts, f)
+	return f, nil
+}
+
+// parsePDFFont parses a TrueType font without registering it, for measuring
+// text outside a document.
+func parsePDFFont(name string, data []byte) (*pdfFont, error) {
+	ttf, err := truetype.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return &pdfFont{name: name, data: data, ttf: ttf, used: map[truetype.Index]rune{}}, nil
+}
+
+// addImage registers a raster image; it is flattened onto white and stored as JPEG.
+func (d *pdfDocument) addImage(img image.Image) (*pdfImage, error) {
+	b := img.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, b.Min, draw.Over)
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, err
+	}
+	im := &pdfImage{name: fmt.Sprintf("Im%d", len(d.images)+1), width: b.Dx(), height: b.Dy(), jpeg: buf.Bytes()}
+	d.images = append(d.images, im)
+	return im, nil
+}
+
+// addLosslessImage registers a raster image without recompressing it, keeping
+// its transparency as a soft mask. Used where JPEG artefacts would show, such
+// as logos on printed QR codes.
+func (d *pdfDocument) addLosslessImage(img image.Image) *pdfImage {
+	b := img.Bounds()
+	nrgba := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(nrgba, nrgba.Bounds(), img, b.Min, draw.Src)
+	n := b.Dx() * b.Dy()
+	rgb := make([]byte, 0, 3*n)
+	alpha := make([]byte, 0, n)
+	opaque := true
+	for i := 0; i < len(nrgba.Pix); i += 4 {
+		rgb = append(rgb, nrgba.Pix[i], nrgba.Pix[i+1], nrgba.Pix[i+2])
+		alpha = append(alpha, nrgba.Pix[i+3])
+		opaque = opaque && nrgba.Pix[i+3] == 0xff
+	}
+	if opaque {
+		alpha = nil
+	}
+	im := &pdfImage{name: fmt.Sprintf("Im%d", len(d.images)+1), width: b.Dx(), height: b.Dy(), rgb: rgb, alpha: alpha}
+	d.images = append(d.images, im)
+	return im
+}
+
+// addShading registers an axial gradient running from (x0, y0) to (x1, y1).
+func (d *pdfDocument) addShading(x0, y0, x1, y1 float64, from, to color.Color) *pdfShading {
+	sh := &pdfShading{name: fmt.Sprintf("Sh%d", len(d.shadings)+1), x0: x0, y0: d.height - y0, x1: x1, y1: d.height - y1, from: from, to: to}
+	d.shadings = append(d.shadings, sh)
+	return sh
+}
+
+// addPage starts a new page and makes it the drawing target.
+func (d *pdfDocument) addPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.current = len(d.pages) - 1
+}
+
+// setPage makes an earlier page the drawing target again, e.g. for footers
+// that need the final page count.
+func (d *pdfDocument) setPage(i int) {
+	d.current = i
+}
+
+func (d *pdfDocument) page() *bytes.Buffer {
+	return d.pages[d.current]
+}
+
+func (d *pdfDocument) fillRect(x, y, w, h float64, c color.Color) {
+	fmt.Fprintf(d.page(), "%s %s %s %s %s re f\n", pdfColor(c, "rg"), pdfNum(x), pdfNum(d.height-y-h), pdfNum(w), pdfNum(h))
+}
+
+// fillRects fills many rectangles as one path.
+func (d *pdfDocument) fillRects(rects []pdfRect, c color.Color) {
+	if len(rects) == 0 {
+		return
+	}
+	page := d.page()
+	page.WriteString(pdfColor(c, "rg") + "\n")
+	d.rects(rects)
+	page.WriteString("f\n")
+}
+
+// shadeRects paints sh through the union of rects.
+func (d *pdfDocument) shadeRects(rects []pdfRect, sh *pdfShading) {
+	if len(rects) == 0 {
+		return
+	}
+	page := d.page()
+	page.WriteString("q\n")
+	d.rects(rects)
+	fmt.Fprintf(page, "W n /%s sh Q\n", sh.name)
+}
+
+func (d *pdfDocument) rects(rects []pdfRect) {
+	page := d.page()
+	for _, r := range rects {
+		fmt.Fprintf(page, "%s %s %s %s re\n", pdfNum(r.x), pdfNum(d.height-r.y-r.h), pdfNum(r.w), pdfNum(r.h))
+	}
+}
+
+func (d *pdfDocument) line(x1, y1, x2, y2, width float64, c color.Color) {
+	fmt.Fprintf(d.page(), "%s %s w %s %s m %s %s l S\n", pdfColor(c, "RG"), pdfNum(width), pdfNum(x1), pdfNum(d.height-y1), pdfNum(x2), pdfNum(d.height-y2))
+}
+
+// drawImage places img with its top-left corner at x, y.
+func (d *pdfDocument) drawImage(img *pdfImage, x, y, w, h float64) {
+	fmt.Fprintf(d.page(), "q %s 0 0 %s %s %s cm /%s Do Q\n", pdfNum(w), pdfNum(h), pdfNum(x), pdfNum(d.height-y-h), img.name)
+}
+
+// text draws s with its baseline at y.
+func (d *pdfDocument) text(fs pdfFontSet, size, x, y float64, c color.Color, s string) {
+	if s == "" {
+		return
+	}
+	page := d.page()
+	fmt.Fprintf(page, "BT %s %s %s Td\n", pdfColor(c, "rg"), pdfNum(x), pdfNum(d.height-y))
+	for _, run := range fs.runs(s) {
+		fmt.Fprintf(page, "/%s %s Tf <", run.font.name, pdfNum(size))
+		for _, r := range run.text {
+			idx := run.font.ttf.Index(r)
+			run.font.used[idx] = r
+			fmt.Fprintf(page, "%04X", uint16(idx))
+		}
+		page.WriteString("> Tj\n")
+	}
+	page.WriteString("ET\n")
+}
+
+type pdfTextRun struct {
+	font *pdfFont
+	text []rune
+}
+
+// runs splits s into consecutive pieces that share a font.
+func (fs pdfFontSet) runs(s string) []pdfTextRun {
+	var out []pdfTextRun
+	for _, r := range s {
+		f := fs.fontFor(r)
+		if n := len(out); n > 0 && out[n-1].font == f {
+			out[n-1].text = append(out[n-1].text, r)
+			continue
+		}
+		out = append(out, pdfTextRun{font: f, text: []rune{r}})
+	}
+	return out
+}
+
+func (fs pdfFontSet) fontFor(r rune) *pdfFont {
+	for _, f := range fs {
+		if f.ttf.Index(r) != 0 {
+			return f
+		}
+	}
+	return fs[0]
+}
+
+// covers reports whether every rune in s has a glyph in the set.
+func (fs pdfFontSet) covers(s string) bool {
+	for _, r := range s {
+		if r != ' ' && fs.fontFor(r).ttf.Index(r) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// width returns the advance width of s in points at size.
+func (fs pdfFontSet) width(s string, size float64) float64 {
+	var w float64
+	for _, r := range s {
+		f := fs.fontFor(r)
+		w += float64(f.advance(f.ttf.Index(r))) * size / float64(f.ttf.FUnitsPerEm())
+	}
+	return w
+}
+
+// wrap breaks s into lines no wider than maxWidth, splitting on spaces and
+// falling back to rune boundaries for words longer than a line.
+func (fs pdfFontSet) wrap(s string, size, maxWidth float64) []string {
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if fs.width(candidate, size) <= maxWidth {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = ""
+			for fs.width(word, size) > maxWidth {
+				cut := 1
+				rs := []rune(word)
+				for cut < len(rs) && fs.width(string(rs[:cut+1]), size) <= maxWidth {
+					cut++
+				}
+				lines = append(lines, string(rs[:cut]))
+				word = string(rs[cut:])
+			}
+			line = word
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func (f *pdfFont) advance(idx truetype.Index) int32 {
+	upem := f.ttf.FUnitsPerEm()
+	return int32(f.ttf.HMetric(fixed.Int26_6(upem), idx).AdvanceWidth)
+}
+
+// bytes serializes the document.
+func (d *pdfDocument) bytes() ([]byte, error) {
+	var objs [][]byte
+	reserve := func() int {
+		objs = append(objs, nil)
+		return len(objs)
+	}
+	set := func(id int, body string) { objs[id-1] = []byte(body) }
+	setStream := func(id int, dict string, data []byte, compress bool) error {
+		if compress {
+			var z bytes.Buffer
+			zw := zlib.NewWriter(&z)
+			if _, err := zw.Write(data); err != nil {
+				return err
+			}
+			if err := zw.Close(); err != nil {
+				return err
+			}
+			data = z.Bytes()
+			dict += " /Filter /FlateDecode"
+		}
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "<< %s /Length %d >>\nstream\n", dict, len(data))
+		b.Write(data)
+		b.WriteString("\nendstream")
+		objs[id-1] = b.Bytes()
+		return nil
+	}
+
+	catalog := reserve()
+	pagesID := reserve()
+
+	var resources strings.Builder
+	resources.WriteString("<< /Font <<")
+	for _, f := range d.fonts {
+		if len(f.used) == 0 {
+			continue
+		}
+		id, err := d.writeFont(f, reserve, set, setStream)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&resources, " /%s %d 0 R", f.name, id)
+	}
+	resources.WriteString(" >> /XObject <<")
+	for _, im := range d.images {
+		id := reserve()
+		dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8", im.width, im.height)
+		var err error
+		if im.jpeg != nil {
+			err = setStream(id, dict+" /Filter /DCTDecode", im.jpeg, false)
+		} else {
+			if im.alpha != nil {
+				maskID := reserve()
+				maskDict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8", im.width, im.height)
+				if err := setStream(maskID, maskDict, im.alpha, true); err != nil {
+					return nil, err
+				}
+				dict += fmt.Sprintf(" /SMask %d 0 R", maskID)
+			}
+			err = setStream(id, dict, im.rgb, true)
+		}
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&resources, " /%s %d 0 R", im.name, id)
+	}
+	resources.WriteString(" >> /Shading <<")
+	for _, sh := range d.shadings {
+		id := reserve()
+		set(id, fmt.Sprintf("<< /ShadingType 2 /ColorSpace /DeviceRGB /Coords [%s %s %s %s] /Function << /FunctionType 2 /Domain [0 1] /C0 [%s] /C1 [%s] /N 1 >> /Extend [true true] >>",
+			pdfNum(sh.x0), pdfNum(sh.y0), pdfNum(sh.x1), pdfNum(sh.y1), pdfRGB(sh.from), pdfRGB(sh.to)))
+		fmt.Fprintf(&resources, " /%s %d 0 R", sh.name, id)
+	}
+	resources.WriteString(" >> >>")
+
+	var kids []string
+	for _, p := range d.pages {
+		contentID := reserve()
+		if err := setStream(contentID, "", p.Bytes(), true); err != nil {
+			return nil, err
+		}
+		pageID := reserve()
+		set(pageID, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R >>",
+			pagesID, pdfNum(d.width), pdfNum(d.height), resources.String(), contentID))
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageID))
+	}
+	set(pagesID, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+	set(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesID))
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objs))
+	for i, body := range objs {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n", i+1)
+		out.Write(body)
+		out.WriteString("\nendobj\n")
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objs)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objs)+1, catalog, xref)
+	return out.Bytes(), nil
+}
+
+// writeFont emits the Type0 font, its CID descendant, descriptor, font file
+// and ToUnicode map, returning the Type0 object id.
+func (d *pdfDocument) writeFont(f *pdfFont, reserve func() int, set func(int, string), setStream func(int, string, []byte, bool) error) (int, error) {
+	upem := float64(f.ttf.FUnitsPerEm())
+	scale := func(v int32) int { return int(float64(v) * 1000 / upem) }
+	bounds := f.ttf.Bounds(fixed.Int26_6(f.ttf.FUnitsPerEm()))
+	baseFont := strings.ReplaceAll(f.ttf.Name(truetype.NameIDPostscriptName), " ", "")
+	if baseFont == "" {
+		baseFont = f.name
+	}
+
+	glyphs := make([]int, 0, len(f.used))
+	for idx := range f.used {
+		glyphs = append(glyphs, int(idx))
+	}
+	sort.Ints(glyphs)
+
+	var widths, cmap strings.Builder
+	for _, g := range glyphs {
+		fmt.Fprintf(&widths, "%d [%d] ", g, scale(f.advance(truetype.Index(g))))
+	}
+	cmap.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	cmap.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	cmap.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	cmap.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	for start := 0; start < len(glyphs); start += 100 {
+		end := min(start+100, len(glyphs))
+		fmt.Fprintf(&cmap, "%d beginbfchar\n", end-start)
+		for _, g := range glyphs[start:end] {
+			fmt.Fprintf(&cmap, "<%04X> <%s>\n", g, utf16Hex(f.used[truetype.Index(g)]))
+		}
+		cmap.WriteString("endbfchar\n")
+	}
+	cmap.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend")
+
+	fileID := reserve()
+	if err := setStream(fileID, fmt.Sprintf("/Length1 %d", len(f.data)), f.data, true); err != nil {
+		return 0, err
+	}
+	descID := reserve()
+	set(descID, fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		baseFont, scale(int32(bounds.Min.X)), scale(int32(bounds.Min.Y)), scale(int32(bounds.Max.X)), scale(int32(bounds.Max.Y)),
+		scale(int32(bounds.Max.Y)), scale(int32(bounds.Min.Y)), scale(int32(bounds.Max.Y)), fileID))
+	cidID := reserve()
+	set(cidID, fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /CIDToGIDMap /Identity /W [%s] >>",
+		baseFont, descID, widths.String()))
+	cmapID := reserve()
+	if err := setStream(cmapID, "", []byte(cmap.String()), true); err != nil {
+		return 0, err
+	}
+	fontID := reserve()
+	set(fontID, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		baseFont, cidID, cmapID))
+	return fontID, nil
+}
+
+func utf16Hex(r rune) string {
+	if r < 0x10000 {
+		return fmt.Sprintf("%04X", r)
+	}
+	r -= 0x10000
+	return fmt.Sprintf("%04X%04X", 0xD800+(r>>10), 0xDC00+(r&0x3FF))
+}
+
+func pdfColor(c color.Color, op string) string {
+	return pdfRGB(c) + " " + op
+}
+
+func pdfRGB(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("%s %s %s", pdfNum(float64(r)/0xffff), pdfNum(float64(g)/0xffff), pdfNum(float64(b)/0xffff))
+}
+
+// pdfNum formats a coordinate compactly with three decimals at most, enough
+// for adjacent shapes such as QR modules to meet without visible seams.
+func pdfNum(v float64) string {
+	s := fmt.Sprintf("%.3f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-0" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// bundledFonts holds the extra TrueType fonts compiled into the binary, most
+// importantly the Ethiopic fonts used for Amharic text. See fonts/README.md.
+//
+//go:embed fonts
+var bundledFonts embed.FS
+
+// EthiopicFont is the bundled Noto Sans Ethiopic face Amharic text is drawn
+// with. A bold cut is used when one is bundled as well.
+const EthiopicFont = "NotoSansEthiopic-Regular.ttf"
+
+// CheckPDFFonts returns an error when the Ethiopic font is missing from the
+// binary or has no Amharic glyphs. Exports that include Amharic fail with it
+// rather than leaving the Amharic column out.
+func CheckPDFFonts() error {
+	data, err := bundledFonts.ReadFile("fonts/" + EthiopicFont)
+	if err == nil {
+		if f, err := truetype.Parse(data); err == nil && f.Index('ም') != 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("PDF font %s is not bundled; add it to the service fonts directory (see fonts/README.md)", EthiopicFont)
+}
+
+// pdfFontFiles lists the regular and bold font files used for PDF text, each
+// in fallback order: the Go fonts first, then every bundled font.
+func pdfFontFiles() (regular, bold [][]byte) {
+	regular = [][]byte{goregular.TTF}
+	bold = [][]byte{gobold.TTF}
+	var bundledRegular [][]byte
+	_ = fs.WalkDir(bundledFonts, "fonts", func(p string, e fs.DirEntry, err error) error {
+		if err != nil || e.IsDir() || !strings.EqualFold(path.Ext(p), ".ttf") {
+			return nil
+		}
+		data, err := bundledFonts.ReadFile(p)
+		if err != nil {
+			return nil
+		}
+		if strings.HasSuffix(strings.ToLower(p), "-bold.ttf") {
+			bold = append(bold, data)
+		} else {
+			bundledRegular = append(bundledRegular, data)
+		}
+		return nil
+	})
+	regular = append(regular, bundledRegular...)
+	// bold text falls back to regular faces for scripts without a bold cut
+	bold = append(bold, bundledRegular...)
+	return regular, bold
+}
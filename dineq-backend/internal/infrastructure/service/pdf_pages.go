@@ -0,0 +1,114 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrPDFTooManyPages is returned when a PDF has more pages than allowed.
+var ErrPDFTooManyPages = errors.New("pdf has too many pages")
+
+// IPDFPageRenderer turns a PDF into one PNG image per page so each page can
+// be read by the OCR providers, which only take images.
+type IPDFPageRenderer interface {
+	RenderPages(ctx context.Context, pdf []byte, maxPages int) ([][]byte, error)
+}
+
+// PopplerPDFRenderer renders pages with poppler's pdftoppm.
+type PopplerPDFRenderer struct {
+	pdftoppm string
+	pdfinfo  string
+	dpi      int
+}
+
+// NewPDFPageRenderer checks that pdftoppm (and pdfinfo next to it, used to
+// count pages) can be found. binary defaults to "pdftoppm" on PATH.
+func NewPDFPageRenderer(binary string) (*PopplerPDFRenderer, error) {
+	if binary == "" {
+		binary = "pdftoppm"
+	}
+	resolved, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("pdftoppm not available: %w", err)
+	}
+	info, err := exec.LookPath(filepath.Join(filepath.Dir(resolved), "pdfinfo"))
+	if err != nil {
+		return nil, fmt.Errorf("pdfinfo not available: %w", err)
+	}
+	return &PopplerPDFRenderer{pdftoppm: resolved, pdfinfo: info, dpi: 200}, nil
+}
+
+func (r *PopplerPDFRenderer) RenderPages(ctx context.Context, pdf []byte, maxPages int) ([][]byte, error) {
+	dir, err := os.MkdirTemp("", "menu-pdf-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "menu.pdf")
+	if err := os.WriteFile(src, pdf, 0o600); err != nil {
+		return nil, err
+	}
+
+	pages, err := r.pageCount(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	if maxPages > 0 && pages > maxPages {
+		return nil, fmt.Errorf("%w: %d, at most %d", ErrPDFTooManyPages, pages, maxPages)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.pdftoppm, "-png", "-r", strconv.Itoa(r.dpi), src, filepath.Join(dir, "page"))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("pdftoppm failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	// pdftoppm names pages page-1.png, page-01.png, ... depending on the count
+	files, err := filepath.Glob(filepath.Join(dir, "page-*.png"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return pageNumber(files[i]) < pageNumber(files[j]) })
+	images := make([][]byte, 0, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, data)
+	}
+	if len(images) == 0 {
+		return nil, errors.New("pdf has no pages")
+	}
+	return images, nil
+}
+
+func (r *PopplerPDFRenderer) pageCount(ctx context.Context, src string) (int, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.pdfinfo, src)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return 0, fmt.Errorf("pdfinfo failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if rest, ok := strings.CutPrefix(line, "Pages:"); ok {
+			return strconv.Atoi(strings.TrimSpace(rest))
+		}
+	}
+	return 0, errors.New("pdfinfo reported no page count")
+}
+
+func pageNumber(file string) int {
+	base := strings.TrimSuffix(filepath.Base(file), ".png")
+	n, _ := strconv.Atoi(base[strings.LastIndexByte(base, '-')+1:])
+	return n
+}
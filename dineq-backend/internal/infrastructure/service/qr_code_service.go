@@ -3,6 +3,7 @@ package services
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"image"
@@ -14,6 +15,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,13 +29,16 @@ import (
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
 	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
 	"github.com/disintegration/imaging"
-	"github.com/google/uuid"
 	"github.com/skip2/go-qrcode"
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 type QRService struct {
 	qrDir   string
 	baseURL string
+	// redirectBaseURL is the public address of the API serving /q/:id; without
+	// it codes encode the menu URL directly
+	redirectBaseURL string
 }
 
 func NewQRService() *QRService {
@@ -43,37 +48,111 @@ func NewQRService() *QRService {
 	if baseURL == "" {
 		baseURL = "https://dineqmenumate.vercel.app"
 	}
-	return &QRService{qrDir: qrDir, baseURL: baseURL}
+	return &QRService{qrDir: qrDir, baseURL: baseURL, redirectBaseURL: os.Getenv("QR_REDIRECT_BASE_URL")}
 }
 
 func (qs *QRService) GenerateQRCode(restaurantSlug string, menuSlug string, request *domain.QRCodeRequest) (*dto.QRCodeResponse, error) {
-	qrCodeID := uuid.New().String()
-	frontendURL := os.Getenv("FRONTEND_URL")
-	if frontendURL == "" {
-		frontendURL = qs.baseURL
+	// the ID is chosen here, before the code is stored, so scans can name it
+	qrCodeID := bson.NewObjectID().Hex()
+	publicMenuURL := QRMenuURL(qs.PublicMenuURL(restaurantSlug, menuSlug), qrCodeID, request.Placement)
+
+	// encode the short link when there is one, so the code survives slug and
+	// menu changes and its scans are counted
+	scanURL := ""
+	content := publicMenuURL
+	if qs.redirectBaseURL != "" {
+		scanURL = QRScanURL(qs.redirectBaseURL, qrCodeID)
+		content = scanURL
+	}
+
+	data, labelFontApplied, err := RenderQRCode(content, request)
+	if err != nil {
+		return nil, err
+	}
+	filename := fmt.Sprintf("%s.%s", qrCodeID, request.Format)
+
+	cloudName := os.Getenv("CLOUDINARY_CLOUD_NAME")
+	apiKey := os.Getenv("CLOUDINARY_API_KEY")
+	apiSecret := os.Getenv("CLOUDINARY_API_SECRET")
+
+	if cloudName == "" {
+		cloudName = os.Getenv("CLD_NAME")
+	}
+	if apiKey == "" {
+		apiKey = os.Getenv("CLD_API_KEY")
+	}
+	if apiSecret == "" {
+		apiSecret = os.Getenv("CLD_SECRET")
+	}
+	if cloudName == "" || apiKey == "" || apiSecret == "" {
+		if raw := os.Getenv("CLOUDINARY_URL"); raw != "" {
+			parts := strings.SplitN(raw, "@", 2)
+			if len(parts) == 2 {
+				cred := strings.TrimPrefix(parts[0], "cloudinary://")
+				cParts := strings.SplitN(cred, ":", 2)
+				if len(cParts) == 2 {
+					apiKey = cParts[0]
+					apiSecret = cParts[1]
+					cloudName = parts[1]
+					cloudName = strings.TrimSuffix(cloudName, "/")
+				}
+			}
+		}
+	}
+	if cloudName == "" || apiKey == "" || apiSecret == "" {
+		return nil, fmt.Errorf("cloudinary env vars missing")
+	}
+	storage := NewCloudinaryStorage(cloudName, apiKey, apiSecret)
+	var url string
+	if doc, ok := storage.(DocumentStorage); ok && (request.Format == "svg" || request.Format == "pdf") {
+		// vector files are stored byte for byte, not as images
+		url, _, err = doc.UploadDocument(context.Background(), filename, data, "qr_codes")
+	} else {
+		url, _, err = storage.UploadFile(context.Background(), filename, data, "qr_codes")
 	}
-	// Build new public URL format: {FRONTEND}/user/{restaurant_slug}/{menu_slug}
-	publicMenuURL := fmt.Sprintf("%s/user/%s/%s", strings.TrimRight(frontendURL, "/"), restaurantSlug, menuSlug)
+	if err != nil {
+		return nil, fmt.Errorf("cloudinary upload failed: %w", err)
+	}
+	resp := &dto.QRCodeResponse{
+		QRCodeID:         qrCodeID,
+		ImageURL:         url,
+		CloudImageURL:    url,
+		PublicMenuURL:    publicMenuURL,
+		ScanURL:          scanURL,
+		DownloadURL:      url,
+		IsActive:         true,
+		ExpiresAt:        time.Now().Add(365 * 24 * time.Hour),
+		LabelFontApplied: labelFontApplied,
+		CreatedAt:        time.Now(),
+	}
+	return resp, nil
+}
+
+// RenderQRCode draws content as a QR code in request.Format (png, jpg, gif,
+// svg or pdf) with the request's customization, filling in defaults. It
+// reports whether a custom label font was applied.
+func RenderQRCode(content string, request *domain.QRCodeRequest) ([]byte, bool, error) {
 	if request.Size <= 0 {
 		request.Size = 256
 	}
-	if request.Format == "" {
-		request.Format = "png"
-	}
-	if request.Format != "png" && request.Format != "jpg" && request.Format != "jpeg" && request.Format != "gif" {
+	request.Format = strings.ToLower(request.Format)
+	switch request.Format {
+	case "png", "jpg", "jpeg", "gif", "svg", "pdf":
+	default:
 		request.Format = "png"
 	}
 	if request.Quality <= 0 || request.Quality > 100 {
 		request.Quality = 90
 	}
-
-	filename := fmt.Sprintf("%s.%s", qrCodeID, request.Format)
+	if c := request.Customization; c != nil && c.Logo != "" && !isLogoRef(c.Logo) {
+		return nil, false, domain.ErrInvalidQRLogo
+	}
 
 	// Determine error correction level
 	level := qrcode.Medium
-	qrCode, err := qrcode.New(publicMenuURL, level)
+	qrCode, err := qrcode.New(content, level)
 	if err != nil {
-		return nil, fmt.Errorf("init qr: %w", err)
+		return nil, false, fmt.Errorf("init qr: %w", err)
 	}
 
 	var fgCol, bgCol color.Color
@@ -110,6 +189,15 @@ func (qs *QRService) GenerateQRCode(restaurantSlug string, menuSlug string, requ
 		bgCol = color.White
 	}
 
+	if request.Format == "svg" || request.Format == "pdf" {
+		var gradient *qrGradient
+		if haveGradient {
+			gradient = &qrGradient{from: gradientFrom, to: gradientTo, vertical: strings.ToLower(request.Customization.GradientDirection) == "vertical"}
+		}
+		data, err := renderVectorQRCode(qrCode.Bitmap(), level, request, fgCol, bgCol, gradient)
+		return data, false, err
+	}
+
 	img := qrCode.Image(request.Size)
 	// Optional margin padding
 	if request.Customization != nil && request.Customization.Margin > 0 {
@@ -133,51 +221,15 @@ func (qs *QRService) GenerateQRCode(restaurantSlug string, menuSlug string, requ
 
 	if request.Customization != nil && request.Customization.Logo != "" {
 		logoImg, err := fetchLogoImage(request.Customization.Logo)
+		if errors.Is(err, domain.ErrInvalidQRLogo) {
+			return nil, false, err
+		}
 		if err != nil {
 			log.Printf("Failed to fetch logo '%s': %v", request.Customization.Logo, err)
-			// Optionally return error: return nil, fmt.Errorf("fetch logo: %w", err)
+			// Optionally return error: return nil, false, fmt.Errorf("fetch logo: %w", err)
 		} else {
 			log.Printf("Logo loaded: %dx%d", logoImg.Bounds().Dx(), logoImg.Bounds().Dy())
-			// Configurable logo size (default: 25% of QR code size)
-			logoSizePercent := 0.25
-			if request.Customization.LogoSizePercent > 0 {
-				logoSizePercent = request.Customization.LogoSizePercent
-			}
-			// Safety caps by error correction level (approx recommended)
-			// H: up to ~30% data restoration => allow <=0.30, M: <=0.20, L: <=0.15
-			maxAllowed := 0.25
-			switch level {
-			case qrcode.High:
-				maxAllowed = 0.30
-			case qrcode.Medium:
-				maxAllowed = 0.20
-			case qrcode.Low:
-				maxAllowed = 0.15
-			default:
-				maxAllowed = 0.22
-			}
-			if logoSizePercent > maxAllowed {
-				logoSizePercent = maxAllowed
-			}
-			if logoSizePercent < 0.05 {
-				logoSizePercent = 0.05
-			}
-			// Additionally limit absolute pixel size so modules around finder patterns remain
-			maxSide := int(float64(request.Size) * logoSizePercent)
-			// Ensure at least 6 modules clearance (rough) from each edge of logo to outer code edge
-			modules := qrCode.Bitmap()
-			qrModules := len(modules)
-			if qrModules > 0 {
-				moduleSize := request.Size / qrModules
-				minClearance := moduleSize * 6
-				maxLogical := request.Size - 2*minClearance
-				if maxLogical < maxSide {
-					maxSide = maxLogical
-				}
-				if maxSide < moduleSize*8 {
-					maxSide = moduleSize * 8
-				} // keep logo legible but not tiny
-			}
+			maxSide := qrLogoMaxSide(request.Size, request.Customization.LogoSizePercent, level, len(qrCode.Bitmap()))
 
 			// Resize logo with high-quality scaling
 			lb := logoImg.Bounds()
@@ -207,11 +259,7 @@ func (qs *QRService) GenerateQRCode(restaurantSlug string, menuSlug string, requ
 
 	// Handle include_label / label_text (improved baseline & clipping avoidance)
 	labelFontApplied := false
-	if request.IncludeLabel || (request.Customization != nil && request.Customization.LabelText != "") {
-		label := "Scan Me"
-		if request.Customization != nil && request.Customization.LabelText != "" {
-			label = request.Customization.LabelText
-		}
+	if label, ok := qrLabel(request); ok {
 		var labelColor color.Color = color.Black
 		if request.Customization != nil && request.Customization.LabelColor != "" {
 			if c, err := parseHexColor(request.Customization.LabelColor); err == nil {
@@ -265,66 +313,152 @@ func (qs *QRService) GenerateQRCode(restaurantSlug string, menuSlug string, requ
 	switch request.Format {
 	case "jpg", "jpeg":
 		if err := jpeg.Encode(&encodedBuf, img, &jpeg.Options{Quality: request.Quality}); err != nil {
-			return nil, fmt.Errorf("jpeg encode: %w", err)
+			return nil, false, fmt.Errorf("jpeg encode: %w", err)
 		}
 	case "gif":
 		if err := gif.Encode(&encodedBuf, img, nil); err != nil {
-			return nil, fmt.Errorf("gif encode: %w", err)
+			return nil, false, fmt.Errorf("gif encode: %w", err)
 		}
 	default:
 		if err := png.Encode(&encodedBuf, img); err != nil {
-			return nil, fmt.Errorf("png encode: %w", err)
+			return nil, false, fmt.Errorf("png encode: %w", err)
 		}
 	}
 
-	cloudName := os.Getenv("CLOUDINARY_CLOUD_NAME")
-	apiKey := os.Getenv("CLOUDINARY_API_KEY")
-	apiSecret := os.Getenv("CLOUDINARY_API_SECRET")
+	return encodedBuf.Bytes(), labelFontApplied, nil
+}
 
-	if cloudName == "" {
-		cloudName = os.Getenv("CLD_NAME")
+// PublicMenuURL is the customer-facing address a menu QR code points to:
+// {FRONTEND}/user/{restaurant_slug}/{menu_slug}.
+func (qs *QRService) PublicMenuURL(restaurantSlug string, menuSlug string) string {
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = qs.baseURL
 	}
-	if apiKey == "" {
-		apiKey = os.Getenv("CLD_API_KEY")
+	return fmt.Sprintf("%s/user/%s/%s", strings.TrimRight(frontendURL, "/"), restaurantSlug, menuSlug)
+}
+
+// QRMenuURL tags a public menu URL with the QR code it was scanned from and
+// the code's table and location: ?qr={id}&table={table}&location={location}.
+func QRMenuURL(menuURL string, qrCodeID string, placement domain.QRPlacement) string {
+	q := url.Values{}
+	if qrCodeID != "" {
+		q.Set("qr", qrCodeID)
 	}
-	if apiSecret == "" {
-		apiSecret = os.Getenv("CLD_SECRET")
+	if placement.Table != "" {
+		q.Set("table", placement.Table)
 	}
-	if cloudName == "" || apiKey == "" || apiSecret == "" {
-		if raw := os.Getenv("CLOUDINARY_URL"); raw != "" {
-			parts := strings.SplitN(raw, "@", 2)
-			if len(parts) == 2 {
-				cred := strings.TrimPrefix(parts[0], "cloudinary://")
-				cParts := strings.SplitN(cred, ":", 2)
-				if len(cParts) == 2 {
-					apiKey = cParts[0]
-					apiSecret = cParts[1]
-					cloudName = parts[1]
-					cloudName = strings.TrimSuffix(cloudName, "/")
-				}
-			}
-		}
+	if placement.Location != "" {
+		q.Set("location", placement.Location)
 	}
-	if cloudName == "" || apiKey == "" || apiSecret == "" {
-		return nil, fmt.Errorf("cloudinary env vars missing")
+	if len(q) == 0 {
+		return menuURL
 	}
-	storage := NewCloudinaryStorage(cloudName, apiKey, apiSecret)
-	url, _, err := storage.UploadFile(context.Background(), filename, encodedBuf.Bytes(), "qr_codes")
+	return menuURL + "?" + q.Encode()
+}
+
+// QRScanURL is the short link of a QR code: {base}/q/{id}.
+func QRScanURL(redirectBaseURL string, qrCodeID string) string {
+	return fmt.Sprintf("%s/q/%s", strings.TrimRight(redirectBaseURL, "/"), qrCodeID)
+}
+
+// QRScanRedirectURL is where a scan is sent: the active menu, tagged with the
+// code and its placement, or the restaurant page when no menu is active.
+func QRScanRedirectURL(target *domain.QRScanTarget) string {
+	if target.MenuSlug == "" {
+		return QRMenuURL(PublicRestaurantURL(target.RestaurantSlug), target.QRCode.ID, target.QRCode.Placement())
+	}
+	return QRMenuURL(PublicMenuURL(target.RestaurantSlug, target.MenuSlug), target.QRCode.ID, target.QRCode.Placement())
+}
+
+// PublicRestaurantURL is the customer-facing page of a restaurant, next to
+// the menu pages built by QRService.PublicMenuURL.
+func PublicRestaurantURL(restaurantSlug string) string {
+	return fmt.Sprintf("%s/user/%s", publicFrontendURL(), restaurantSlug)
+}
+
+// PublicMenuURL is QRService.PublicMenuURL for callers without a QRService.
+func PublicMenuURL(restaurantSlug string, menuSlug string) string {
+	return fmt.Sprintf("%s/%s", PublicRestaurantURL(restaurantSlug), menuSlug)
+}
+
+func publicFrontendURL() string {
+	for _, key := range []string{"FRONTEND_URL", "FRONTEND_BASE_URL"} {
+		if v := os.Getenv(key); v != "" {
+			return strings.TrimRight(v, "/")
+		}
+	}
+	return "https://dineqmenumate.vercel.app"
+}
+
+// MenuQRMatrix returns the QR modules (true = dark, quiet zone included) for a
+// menu's public URL, for callers that draw the code themselves.
+func (qs *QRService) MenuQRMatrix(restaurantSlug string, menuSlug string) ([][]bool, string, error) {
+	url := qs.PublicMenuURL(restaurantSlug, menuSlug)
+	qr, err := qrcode.New(url, qrcode.Medium)
 	if err != nil {
-		return nil, fmt.Errorf("cloudinary upload failed: %w", err)
+		return nil, "", fmt.Errorf("init qr: %w", err)
 	}
-	resp := &dto.QRCodeResponse{
-		QRCodeID:         qrCodeID,
-		ImageURL:         url,
-		CloudImageURL:    url,
-		PublicMenuURL:    publicMenuURL,
-		DownloadURL:      url,
-		IsActive:         true,
-		ExpiresAt:        time.Now().Add(365 * 24 * time.Hour),
-		LabelFontApplied: labelFontApplied,
-		CreatedAt:        time.Now(),
+	return qr.Bitmap(), url, nil
+}
+
+// qrLabel is the text printed under a code: the customization's LabelText,
+// else with IncludeLabel the placement label or "Scan Me".
+func qrLabel(request *domain.QRCodeRequest) (string, bool) {
+	if request.Customization != nil && request.Customization.LabelText != "" {
+		return request.Customization.LabelText, true
 	}
-	return resp, nil
+	if !request.IncludeLabel {
+		return "", false
+	}
+	if request.Placement.Label != "" {
+		return request.Placement.Label, true
+	}
+	return "Scan Me", true
+}
+
+// qrLogoMaxSide is the largest side a centre logo may cover on a code of the
+// given size, so that the code still scans.
+func qrLogoMaxSide(size int, percent float64, level qrcode.RecoveryLevel, modules int) int {
+	// Configurable logo size (default: 25% of QR code size)
+	logoSizePercent := 0.25
+	if percent > 0 {
+		logoSizePercent = percent
+	}
+	// Safety caps by error correction level (approx recommended)
+	// H: up to ~30% data restoration => allow <=0.30, M: <=0.20, L: <=0.15
+	maxAllowed := 0.25
+	switch level {
+	case qrcode.High:
+		maxAllowed = 0.30
+	case qrcode.Medium:
+		maxAllowed = 0.20
+	case qrcode.Low:
+		maxAllowed = 0.15
+	default:
+		maxAllowed = 0.22
+	}
+	if logoSizePercent > maxAllowed {
+		logoSizePercent = maxAllowed
+	}
+	if logoSizePercent < 0.05 {
+		logoSizePercent = 0.05
+	}
+	// Additionally limit absolute pixel size so modules around finder patterns remain
+	maxSide := int(float64(size) * logoSizePercent)
+	// Ensure at least 6 modules clearance (rough) from each edge of logo to outer code edge
+	if modules > 0 {
+		moduleSize := size / modules
+		minClearance := moduleSize * 6
+		maxLogical := size - 2*minClearance
+		if maxLogical < maxSide {
+			maxSide = maxLogical
+		}
+		if maxSide < moduleSize*8 {
+			maxSide = moduleSize * 8
+		} // keep logo legible but not tiny
+	}
+	return maxSide
 }
 
 func (qs *QRService) GetQRCodePath(filename string) string {
@@ -354,32 +488,72 @@ func parseHexColor(s string) (color.Color, error) {
 }
 
 func fetchLogoImage(path string) (image.Image, error) {
-	var rc io.ReadCloser
-	var err error
-	if strings.HasPrefix(strings.ToLower(path), "http://") || strings.HasPrefix(strings.ToLower(path), "https://") {
-		client := &http.Client{Timeout: 10 * time.Second}
-		resp, err := client.Get(path)
-		if err != nil {
-			return nil, fmt.Errorf("fetch logo from URL: %w", err)
-		}
-		if resp.StatusCode != http.StatusOK {
-			resp.Body.Close()
-			return nil, fmt.Errorf("logo fetch: %s", resp.Status)
-		}
-		rc = resp.Body
-	} else {
-		rc, err = os.Open(path)
-		if err != nil {
-			return nil, fmt.Errorf("open local logo file: %w", err)
-		}
+	buf, err := fetchLogo(path)
+	if err != nil {
+		return nil, err
 	}
-	defer rc.Close()
+	return decodeLogoImage(buf)
+}
+
+// qrMaxLogoBytes caps the size of a logo download or data URL.
+const qrMaxLogoBytes = 10 << 20
+
+// isLogoRef reports whether ref is a logo that may be fetched: an http(s) URL
+// or a data URL. Logos come from clients, so paths on this machine are not.
+func isLogoRef(ref string) bool {
+	lower := strings.ToLower(ref)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "data:")
+}
 
-	buf, err := io.ReadAll(rc)
+// fetchLogo reads a logo from an http(s) URL or a data URL; anything else is
+// ErrInvalidQRLogo.
+func fetchLogo(ref string) ([]byte, error) {
+	if !isLogoRef(ref) {
+		return nil, domain.ErrInvalidQRLogo
+	}
+	if strings.HasPrefix(strings.ToLower(ref), "data:") {
+		return decodeDataURL(ref)
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(ref)
+	if err != nil {
+		return nil, fmt.Errorf("fetch logo from URL: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("logo fetch: %s", resp.Status)
+	}
+	buf, err := io.ReadAll(io.LimitReader(resp.Body, qrMaxLogoBytes+1))
 	if err != nil {
 		return nil, fmt.Errorf("read logo image: %w", err)
 	}
+	if len(buf) > qrMaxLogoBytes {
+		return nil, fmt.Errorf("logo is larger than %d bytes", qrMaxLogoBytes)
+	}
+	return buf, nil
+}
+
+// decodeDataURL returns the bytes of a "data:[<type>][;base64],<data>" URL.
+func decodeDataURL(ref string) ([]byte, error) {
+	meta, data, ok := strings.Cut(ref[len("data:"):], ",")
+	if !ok || len(data) > qrMaxLogoBytes*4/3+4 {
+		return nil, domain.ErrInvalidQRLogo
+	}
+	if strings.HasSuffix(strings.ToLower(meta), ";base64") {
+		buf, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, domain.ErrInvalidQRLogo
+		}
+		return buf, nil
+	}
+	buf, err := url.PathUnescape(data)
+	if err != nil {
+		return nil, domain.ErrInvalidQRLogo
+	}
+	return []byte(buf), nil
+}
 
+func decodeLogoImage(buf []byte) (image.Image, error) {
 	// Try decoding as PNG
 	if img, err := png.Decode(bytes.NewReader(buf)); err == nil {
 		return img, nil
@@ -0,0 +1,366 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/disintegration/imaging"
+	"github.com/golang/freetype/truetype"
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// qrGradient colours the dark modules from one colour to the other across the
+// whole code, margin included, like the raster gradient.
+type qrGradient struct {
+	from, to color.Color
+	vertical bool
+}
+
+// qrMaxLogoPixels caps the side of a raster logo embedded in vector output.
+// Smaller logos keep their own resolution so print is as sharp as the upload.
+const qrMaxLogoPixels = 2048
+
+// qrVectorLayout is a QR code laid out in user units (SVG) or points (PDF),
+// one unit per pixel of the equivalent raster code.
+type qrVectorLayout struct {
+	width, height float64
+	// codeSide is the code with its margin, without the label below it
+	codeSide float64
+	// modules are the dark modules merged into horizontal runs
+	modules  []pdfRect
+	fg, bg   color.Color
+	gradient *qrGradient
+
+	logo     image.Image
+	logoSVG  []byte
+	logoRect pdfRect
+
+	label         string
+	labelFonts    pdfFontSet
+	labelSize     float64
+	labelColor    color.Color
+	labelX        float64
+	labelBaseline float64
+}
+
+// renderVectorQRCode writes modules as an SVG or PDF document. Labels use the
+// bundled fonts, so nothing is fetched unless the logo itself is a URL.
+func renderVectorQRCode(modules [][]bool, level qrcode.RecoveryLevel, request *domain.QRCodeRequest, fg, bg color.Color, gradient *qrGradient) ([]byte, error) {
+	size := float64(request.Size)
+	margin := 0.0
+	if c := request.Customization; c != nil && c.Margin > 0 {
+		margin = float64(c.Margin)
+	}
+	l := &qrVectorLayout{codeSide: size + 2*margin, fg: fg, bg: bg, gradient: gradient}
+	l.width, l.height = l.codeSide, l.codeSide
+
+	if n := len(modules); n > 0 {
+		cell := size / float64(n)
+		for y, row := range modules {
+			for x := 0; x < len(row); {
+				if !row[x] {
+					x++
+					continue
+				}
+				start := x
+				for x < len(row) && row[x] {
+					x++
+				}
+				l.modules = append(l.modules, pdfRect{x: margin + float64(start)*cell, y: margin + float64(y)*cell, w: float64(x-start) * cell, h: cell})
+			}
+		}
+	}
+
+	if c := request.Customization; c != nil && c.Logo != "" {
+		if err := l.placeLogo(c.Logo, request.Format, qrLogoMaxSide(request.Size, c.LogoSizePercent, level, len(modules))); err != nil {
+			return nil, err
+		}
+	}
+	if label, ok := qrLabel(request); ok {
+		if err := l.placeLabel(label, request.Customization); err != nil {
+			return nil, fmt.Errorf("qr label: %w", err)
+		}
+	}
+
+	if request.Format == "pdf" {
+		return l.pdf()
+	}
+	return l.svg()
+}
+
+// placeLogo centres the logo on the code. SVG logos stay vectors in SVG output
+// and are refused for PDF, which has no way to draw them; raster logos are
+// drawn no larger than maxSide but keep their pixels. A logo that cannot be
+// downloaded is left out.
+func (l *qrVectorLayout) placeLogo(ref, format string, maxSide int) error {
+	data, err := fetchLogo(ref)
+	if errors.Is(err, domain.ErrInvalidQRLogo) {
+		return err
+	}
+	if err != nil {
+		log.Printf("Failed to fetch logo: %v", err)
+		return nil
+	}
+	side := float64(maxSide)
+	if isSVGLogo(data) {
+		if format == "pdf" {
+			return domain.ErrUnsupportedQRLogo
+		}
+		l.logoSVG = data
+		l.logoRect = pdfRect{x: (l.codeSide - side) / 2, y: (l.codeSide - side) / 2, w: side, h: side}
+		return nil
+	}
+	img, err := decodeLogoImage(data)
+	if err != nil {
+		log.Printf("Failed to decode logo: %v", err)
+		return nil
+	}
+	b := img.Bounds()
+	if b.Dx() > qrMaxLogoPixels || b.Dy() > qrMaxLogoPixels {
+		img = imaging.Fit(img, qrMaxLogoPixels, qrMaxLogoPixels, imaging.Lanczos)
+		b = img.Bounds()
+	}
+	w, h := float64(b.Dx()), float64(b.Dy())
+	if scale := side / math.Max(w, h); scale < 1 {
+		w, h = w*scale, h*scale
+	}
+	l.logo = img
+	l.logoRect = pdfRect{x: (l.codeSide - w) / 2, y: (l.codeSide - h) / 2, w: w, h: h}
+	return nil
+}
+
+// placeLabel sets label under the code, shrinking it to fit the code's width.
+func (l *qrVectorLayout) placeLabel(label string, c *domain.QRCodeCustomization) error {
+	l.labelColor = color.Black
+	size := 16
+	if c != nil {
+		if c.LabelColor != "" {
+			if col, err := parseHexColor(c.LabelColor); err == nil {
+				l.labelColor = col
+			}
+		}
+		if c.LabelFontSize > 0 {
+			size = min(c.LabelFontSize, 300)
+		}
+		if c.LabelFontURL != "" {
+			log.Printf("[qr-label] vector output uses the bundled fonts; ignoring label font %s", c.LabelFontURL)
+		}
+	}
+	regular, _ := pdfFontFiles()
+	for i, data := range regular {
+		f, err := parsePDFFont(fmt.Sprintf("F%d", i+1), data)
+		if err != nil {
+			return err
+		}
+		l.labelFonts = append(l.labelFonts, f)
+	}
+	if !l.labelFonts.covers(label) && strings.ContainsFunc(label, func(r rune) bool { return unicode.Is(unicode.Ethiopic, r) }) {
+		if err := CheckPDFFonts(); err != nil {
+			return err
+		}
+	}
+
+	fontSize := float64(size)
+	for fontSize > 6 && l.labelFonts.width(label, fontSize) > l.width-8 {
+		fontSize--
+	}
+	metrics := truetype.NewFace(l.labelFonts[0].ttf, &truetype.Options{Size: fontSize, DPI: 72}).Metrics()
+	ascent := float64(metrics.Ascent) / 64
+	descent := float64(metrics.Descent) / 64
+	padding := 8.0 // vertical padding total, as in the raster label
+
+	l.label = label
+	l.labelSize = fontSize
+	l.labelX = (l.width - l.labelFonts.width(label, fontSize)) / 2
+	l.labelBaseline = l.codeSide + padding/2 + ascent
+	l.height = l.codeSide + ascent + descent + padding
+	return nil
+}
+
+func (l *qrVectorLayout) svg() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" version="1.1" width="%s" height="%s" viewBox="0 0 %s %s">`+"\n",
+		pdfNum(l.width), pdfNum(l.height), pdfNum(l.width), pdfNum(l.height))
+	if _, _, _, a := l.bg.RGBA(); a > 0 {
+		fmt.Fprintf(&b, `<rect width="%s" height="%s" %s/>`+"\n", pdfNum(l.width), pdfNum(l.height), svgPaint("fill", l.bg))
+	}
+
+	fill := svgPaint("fill", l.fg)
+	if g := l.gradient; g != nil {
+		x2, y2 := l.codeSide, 0.0
+		if g.vertical {
+			x2, y2 = 0, l.codeSide
+		}
+		fmt.Fprintf(&b, `<defs><linearGradient id="qr-gradient" gradientUnits="userSpaceOnUse" x1="0" y1="0" x2="%s" y2="%s"><stop offset="0" %s/><stop offset="1" %s/></linearGradient></defs>`+"\n",
+			pdfNum(x2), pdfNum(y2), svgPaint("stop-color", g.from), svgPaint("stop-color", g.to))
+		fill = `fill="url(#qr-gradient)"`
+	}
+	if len(l.modules) > 0 {
+		fmt.Fprintf(&b, `<path %s shape-rendering="crispEdges" d="`, fill)
+		for _, r := range l.modules {
+			fmt.Fprintf(&b, "M%s %sh%sv%sh-%sz", pdfNum(r.x), pdfNum(r.y), pdfNum(r.w), pdfNum(r.h), pdfNum(r.w))
+		}
+		b.WriteString(`"/>` + "\n")
+	}
+
+	var logoURI string
+	switch {
+	case l.logoSVG != nil:
+		logoURI = "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(l.logoSVG)
+	case l.logo != nil:
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, l.logo); err != nil {
+			return nil, fmt.Errorf("png encode logo: %w", err)
+		}
+		logoURI = "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+	}
+	if logoURI != "" {
+		r := l.logoRect
+		fmt.Fprintf(&b, `<image x="%s" y="%s" width="%s" height="%s" preserveAspectRatio="xMidYMid meet" xlink:href="%s"/>`+"\n",
+			pdfNum(r.x), pdfNum(r.y), pdfNum(r.w), pdfNum(r.h), logoURI)
+	}
+
+	if l.label != "" {
+		// glyphs are written as outlines so the file needs no fonts to print
+		d, err := l.labelOutlines()
+		if err != nil {
+			return nil, fmt.Errorf("qr label: %w", err)
+		}
+		fmt.Fprintf(&b, `<path %s d="%s"/>`+"\n", svgPaint("fill", l.labelColor), d)
+	}
+	b.WriteString("</svg>\n")
+	return b.Bytes(), nil
+}
+
+func (l *qrVectorLayout) pdf() ([]byte, error) {
+	doc := newPDFDocument(l.width, l.height)
+	doc.addPage()
+	if _, _, _, a := l.bg.RGBA(); a > 0 {
+		doc.fillRect(0, 0, l.width, l.height, l.bg)
+	}
+	if g := l.gradient; g != nil {
+		x1, y1 := l.codeSide, 0.0
+		if g.vertical {
+			x1, y1 = 0, l.codeSide
+		}
+		doc.shadeRects(l.modules, doc.addShading(0, 0, x1, y1, g.from, g.to))
+	} else {
+		doc.fillRects(l.modules, l.fg)
+	}
+
+	if l.logo != nil {
+		r := l.logoRect
+		doc.drawImage(doc.addLosslessImage(l.logo), r.x, r.y, r.w, r.h)
+	}
+
+	if l.label != "" {
+		var fs pdfFontSet
+		for _, f := range l.labelFonts {
+			pf, err := doc.addFont(f.data)
+			if err != nil {
+				return nil, fmt.Errorf("qr label: %w", err)
+			}
+			fs = append(fs, pf)
+		}
+		doc.text(fs, l.labelSize, l.labelX, l.labelBaseline, l.labelColor, l.label)
+	}
+	return doc.bytes()
+}
+
+// labelOutlines returns the label's glyph outlines as SVG path data.
+func (l *qrVectorLayout) labelOutlines() (string, error) {
+	var b strings.Builder
+	var glyph truetype.GlyphBuf
+	scale := fixed.Int26_6(l.labelSize * 64)
+	x := l.labelX
+	for _, r := range l.label {
+		f := l.labelFonts.fontFor(r)
+		if err := glyph.Load(f.ttf, scale, f.ttf.Index(r), font.HintingNone); err != nil {
+			return "", err
+		}
+		start := 0
+		for _, end := range glyph.Ends {
+			writeGlyphContour(&b, glyph.Points[start:end], x, l.labelBaseline)
+			start = end
+		}
+		x += l.labelFonts.width(string(r), l.labelSize)
+	}
+	return b.String(), nil
+}
+
+// writeGlyphContour writes one closed TrueType contour, whose points are
+// quadratic on- and off-curve points with the y axis pointing up.
+func writeGlyphContour(b *strings.Builder, pts []truetype.Point, ox, oy float64) {
+	if len(pts) == 0 {
+		return
+	}
+	on := func(p truetype.Point) bool { return p.Flags&0x01 != 0 }
+	mid := func(p, q truetype.Point) truetype.Point {
+		return truetype.Point{X: (p.X + q.X) / 2, Y: (p.Y + q.Y) / 2, Flags: 0x01}
+	}
+	xy := func(p truetype.Point) string {
+		return pdfNum(ox+float64(p.X)/64) + " " + pdfNum(oy-float64(p.Y)/64)
+	}
+
+	// start on an on-curve point; between two off-curve points one is implied
+	start, rest := pts[0], pts[1:]
+	switch last := pts[len(pts)-1]; {
+	case on(start):
+	case on(last):
+		start, rest = last, pts[:len(pts)-1]
+	default:
+		start, rest = mid(last, start), pts
+	}
+	b.WriteString("M" + xy(start))
+	var ctrl truetype.Point
+	haveCtrl := false
+	for _, p := range rest {
+		if on(p) {
+			if haveCtrl {
+				b.WriteString("Q" + xy(ctrl) + " " + xy(p))
+				haveCtrl = false
+			} else {
+				b.WriteString("L" + xy(p))
+			}
+			continue
+		}
+		if haveCtrl {
+			b.WriteString("Q" + xy(ctrl) + " " + xy(mid(ctrl, p)))
+		}
+		ctrl, haveCtrl = p, true
+	}
+	if haveCtrl {
+		b.WriteString("Q" + xy(ctrl) + " " + xy(start))
+	}
+	b.WriteString("Z")
+}
+
+// svgPaint returns a colour attribute, adding the matching opacity attribute
+// for translucent colours.
+func svgPaint(attr string, c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	s := fmt.Sprintf(`%s="#%02x%02x%02x"`, attr, n.R, n.G, n.B)
+	if n.A < 0xff {
+		opacity := strings.TrimSuffix(attr, "-color") + "-opacity"
+		s += fmt.Sprintf(` %s="%s"`, opacity, pdfNum(float64(n.A)/0xff))
+	}
+	return s
+}
+
+func isSVGLogo(data []byte) bool {
+	head := data[:min(len(data), 1024)]
+	return bytes.Contains(bytes.ToLower(head), []byte("<svg"))
+}
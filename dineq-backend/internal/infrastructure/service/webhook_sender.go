@@ -0,0 +1,179 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+// Headers sent with every webhook request.
+const (
+	WebhookEventHeader     = "X-DineQ-Event"
+	WebhookDeliveryHeader  = "X-DineQ-Delivery"
+	WebhookTimestampHeader = "X-DineQ-Timestamp"
+	// WebhookSignatureHeader is "sha256=" and the hex HMAC-SHA256 of
+	// "<timestamp>.<body>" keyed with the subscription secret
+	WebhookSignatureHeader = "X-DineQ-Signature"
+)
+
+// WebhookRequest is one signed POST to a subscriber.
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID string
+	Body       []byte
+}
+
+// IWebhookSender posts webhook payloads. A response outside 2xx is an error;
+// the status code is returned whenever a response came back. CheckURL tells
+// whether a URL may be registered as a receiver at all.
+type IWebhookSender interface {
+	Send(ctx context.Context, req WebhookRequest) (int, error)
+	CheckURL(ctx context.Context, rawURL string) error
+}
+
+// WebhookTargets loosens which receivers webhooks may go to. The zero value
+// allows only https URLs on public addresses.
+type WebhookTargets struct {
+	// AllowHTTP accepts plain http receivers (development)
+	AllowHTTP bool
+	// AllowPrivate accepts loopback, link-local and private addresses
+	AllowPrivate bool
+}
+
+type HTTPWebhookSender struct {
+	client   *http.Client
+	targets  WebhookTargets
+	resolver *net.Resolver
+	now      func() time.Time
+}
+
+func NewWebhookSender(targets WebhookTargets) *HTTPWebhookSender {
+	s := &HTTPWebhookSender{targets: targets, resolver: net.DefaultResolver, now: time.Now}
+	// the address is checked again when connecting, after DNS answered, so
+	// a name that resolved to a public address when it was registered cannot
+	// be pointed at an internal one later
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: s.checkDial}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	s.client = &http.Client{
+		Transport: transport,
+		// receivers are not followed to other hosts; the signature is for
+		// the URL that was registered
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	return s
+}
+
+// CheckURL returns ErrInvalidWebhookURL unless rawURL is absolute https (or
+// http when allowed) and its host resolves only to addresses that may be
+// reached.
+func (s *HTTPWebhookSender) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" || (u.Scheme != "https" && (u.Scheme != "http" || !s.targets.AllowHTTP)) {
+		return domain.ErrInvalidWebhookURL
+	}
+	if s.targets.AllowPrivate {
+		return nil
+	}
+	addrs, err := s.resolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return domain.ErrInvalidWebhookURL
+	}
+	for _, addr := range addrs {
+		if !PublicAddr(addr) {
+			return domain.ErrInvalidWebhookURL
+		}
+	}
+	return nil
+}
+
+func (s *HTTPWebhookSender) checkDial(_, address string, _ syscall.RawConn) error {
+	if s.targets.AllowPrivate {
+		return nil
+	}
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !PublicAddr(ap.Addr()) {
+		return fmt.Errorf("webhook receiver address %s is not public", ap.Addr())
+	}
+	return nil
+}
+
+// nonPublicPrefixes are ranges outside the net/netip predicates that do not
+// reach the internet: "this network", carrier-grade NAT and benchmarking.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// PublicAddr reports whether addr is a unicast internet address, not
+// loopback, link-local (cloud metadata lives there), private or otherwise
+// reserved.
+func PublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *HTTPWebhookSender) Send(ctx context.Context, req WebhookRequest) (int, error) {
+	timestamp := s.now().Unix()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "DineQ-Webhooks/1.0")
+	httpReq.Header.Set(WebhookEventHeader, req.Event)
+	httpReq.Header.Set(WebhookDeliveryHeader, req.DeliveryID)
+	httpReq.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(req.Secret, timestamp, req.Body))
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// the body is not used, but reading a little of it lets the connection
+	// be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook is the hex HMAC-SHA256 of "<timestamp>.<body>". Receivers
+// compute the same from the timestamp header and the raw body, and should
+// reject old timestamps to stop replays.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
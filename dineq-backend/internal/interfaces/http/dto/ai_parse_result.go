@@ -1,39 +1,102 @@
 package dto
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
 )
 
-type ItemDataDTO struct {
-	Name  string  `json:"name"`
-	Price float64 `json:"price"`
+// ParsedItemResponse is one AI-read item as shown to the reviewing manager.
+type ParsedItemResponse struct {
+	ID         string        `json:"id"`
+	Tab        string        `json:"tab"`
+	Category   string        `json:"category"`
+	Item       *ItemResponse `json:"item"`
+	Original   *ItemResponse `json:"original,omitempty"`
+	Confidence float64       `json:"confidence"`
+	Flags      []string      `json:"flags,omitempty"`
+	Status     string        `json:"status"`
+	ReviewedBy string        `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time    `json:"reviewed_at,omitempty"`
 }
 
-type CategoryDataDTO struct {
-	Name  string        `json:"name"`
-	Items []ItemDataDTO `json:"items"`
+type AIParseResultResponse struct {
+	ID              string               `json:"id"`
+	OCRJobID        string               `json:"ocr_job_id"`
+	RestaurantID    string               `json:"restaurant_id,omitempty"`
+	TargetMenuID    string               `json:"target_menu_id,omitempty"`
+	Status          string               `json:"status"`
+	ConfidenceScore float64              `json:"confidence_score"`
+	PendingItems    int                  `json:"pending_items"`
+	Items           []ParsedItemResponse `json:"items"`
+	RawText         string               `json:"raw_text,omitempty"`
+	MenuID          string               `json:"menu_id,omitempty"`
+	ApprovedBy      string               `json:"approved_by,omitempty"`
+	ApprovedAt      *time.Time           `json:"approved_at,omitempty"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
 }
 
-type TabDataDTO struct {
-	Name       string            `json:"name"`
-	Categories []CategoryDataDTO `json:"categories"`
+// ParsedItemReviewRequest accepts or rejects one parsed item. Sending item
+// with an accept replaces the parsed item with the manager's version.
+type ParsedItemReviewRequest struct {
+	Status   string       `json:"status" validate:"required,oneof=accepted rejected"`
+	Item     *ItemRequest `json:"item,omitempty"`
+	Tab      string       `json:"tab,omitempty"`
+	Category string       `json:"category,omitempty"`
 }
 
-type StructuredDataDTO struct {
-	Tabs []TabDataDTO `json:"tabs"`
+// ParseResultApproveRequest names the restaurant, and optionally the menu,
+// the reviewed items are approved into. KeepRemoved applies to results
+// uploaded in merge mode: existing items missing from the photo stay on the
+// menu instead of being removed.
+type ParseResultApproveRequest struct {
+	RestaurantSlug string `json:"restaurant_slug" validate:"required"`
+	Name           string `json:"name,omitempty"`
+	KeepRemoved    bool   `json:"keep_removed,omitempty"`
 }
 
-type AIParseResultDTO struct {
-	ID              string            `json:"id"`
-	OCRJobID        string            `json:"ocr_job_id"`
-	RawText         string            `json:"raw_text"`
-	StructuredData  StructuredDataDTO `json:"structured_data"`
-	ConfidenceScore float64           `json:"confidence_score"`
-	CreatedAt       time.Time         `json:"created_at"`
-	UpdatedAt       time.Time         `json:"updated_at"`
+func (r *ParsedItemReviewRequest) ToDomain() domain.ParsedItemReview {
+	review := domain.ParsedItemReview{Status: domain.ParsedItemStatus(r.Status), Tab: r.Tab, Category: r.Category}
+	if r.Item != nil {
+		review.Edit = RequestToItem(r.Item)
+	}
+	return review
+}
+
+func AIParseResultToResponse(r *domain.AIParseResult) *AIParseResultResponse {
+	items := make([]ParsedItemResponse, len(r.Items))
+	for i := range r.Items {
+		p := &r.Items[i]
+		items[i] = ParsedItemResponse{
+			ID:         p.ID,
+			Tab:        p.Tab,
+			Category:   p.Category,
+			Item:       ItemToResponse(&p.Item),
+			Original:   ItemToResponse(p.Original),
+			Confidence: p.Confidence,
+			Flags:      p.Flags,
+			Status:     string(p.Status),
+			ReviewedBy: p.ReviewedBy,
+			ReviewedAt: p.ReviewedAt,
+		}
+	}
+	return &AIParseResultResponse{
+		ID:              r.ID,
+		OCRJobID:        r.OCRJobID,
+		RestaurantID:    r.RestaurantID,
+		TargetMenuID:    r.TargetMenuID,
+		Status:          string(r.Status),
+		ConfidenceScore: r.ConfidenceScore,
+		PendingItems:    r.PendingItems(),
+		Items:           items,
+		RawText:         r.RawText,
+		MenuID:          r.MenuID,
+		ApprovedBy:      r.ApprovedBy,
+		ApprovedAt:      r.ApprovedAt,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
 }
 
 type MenuItemDTO struct {
@@ -45,90 +108,3 @@ type MenuItemDTO struct {
 	Price                  map[string]string `json:"price"`
 	ImageURL               string            `json:"image_url"`
 }
-
-func (apr *AIParseResultDTO) Validate() error {
-	if apr.OCRJobID == "" || apr.RawText == "" {
-		return fmt.Errorf("aiParseResult ID, ocrJobID, and rawText are required")
-	}
-	// Optional: Validate structured data if present
-	if len(apr.StructuredData.Tabs) > 0 {
-		for _, tab := range apr.StructuredData.Tabs {
-			if tab.Name == "" {
-				return fmt.Errorf("tab name is required if structured data is provided")
-			}
-		}
-	}
-	return nil
-}
-
-// ToDomain converts the AIParseResultDTO to a domain.AIParseResult entity
-func (apr *AIParseResultDTO) ToDomain() *domain.AIParseResult {
-	tabs := make([]domain.TabData, len(apr.StructuredData.Tabs))
-	for i, tabDTO := range apr.StructuredData.Tabs {
-		categories := make([]domain.CategoryData, len(tabDTO.Categories))
-		for j, catDTO := range tabDTO.Categories {
-			items := make([]domain.ItemData, len(catDTO.Items))
-			for k, itemDTO := range catDTO.Items {
-				items[k] = domain.ItemData{
-					Name:  itemDTO.Name,
-					Price: itemDTO.Price,
-				}
-			}
-			categories[j] = domain.CategoryData{
-				Name:  catDTO.Name,
-				Items: items,
-			}
-		}
-		tabs[i] = domain.TabData{
-			Name:       tabDTO.Name,
-			Categories: categories,
-		}
-	}
-	return &domain.AIParseResult{
-		ID:       apr.ID,
-		OCRJobID: apr.OCRJobID,
-		RawText:  apr.RawText,
-		StructuredData: domain.StructuredData{
-			Tabs: tabs,
-		},
-		ConfidenceScore: apr.ConfidenceScore,
-		CreatedAt:       apr.CreatedAt,
-		UpdatedAt:       apr.UpdatedAt,
-	}
-}
-
-// FromDomain converts a domain.AIParseResult entity to an AIParseResultDTO
-func (apr *AIParseResultDTO) FromDomain(result *domain.AIParseResult) *AIParseResultDTO {
-	tabs := make([]TabDataDTO, len(result.StructuredData.Tabs))
-	for i, tab := range result.StructuredData.Tabs {
-		categories := make([]CategoryDataDTO, len(tab.Categories))
-		for j, cat := range tab.Categories {
-			items := make([]ItemDataDTO, len(cat.Items))
-			for k, item := range cat.Items {
-				items[k] = ItemDataDTO{
-					Name:  item.Name,
-					Price: item.Price,
-				}
-			}
-			categories[j] = CategoryDataDTO{
-				Name:  cat.Name,
-				Items: items,
-			}
-		}
-		tabs[i] = TabDataDTO{
-			Name:       tab.Name,
-			Categories: categories,
-		}
-	}
-	return &AIParseResultDTO{
-		ID:       result.ID,
-		OCRJobID: result.OCRJobID,
-		RawText:  result.RawText,
-		StructuredData: StructuredDataDTO{
-			Tabs: tabs,
-		},
-		ConfidenceScore: result.ConfidenceScore,
-		CreatedAt:       result.CreatedAt,
-		UpdatedAt:       result.UpdatedAt,
-	}
-}
@@ -33,6 +33,28 @@ var domainErrorCode = map[error]string{
 	domain.ErrNotFound:                       "not_found",
 	domain.ErrUserNotFound:                   "user_not_found",
 	domain.ErrRestaurantNotFound:             "restaurant_not_found",
+	domain.ErrMenuVersionNotFound:            "menu_version_not_found",
+	domain.ErrMenuDraftChanged:               "menu_draft_changed",
+	domain.ErrMenuItemNotFound:               "menu_item_not_found",
+	domain.ErrMenuNotPublished:               "menu_not_published",
+	domain.ErrInvalidModifierGroups:          "invalid_modifier_groups",
+	domain.ErrUnsupportedImportFormat:        "unsupported_import_format",
+	domain.ErrInvalidMenuImport:              "invalid_menu_import",
+	domain.ErrTranslationUnavailable:         "translation_unavailable",
+	domain.ErrParseResultNotFound:            "parse_result_not_found",
+	domain.ErrParseResultApproved:            "parse_result_approved",
+	domain.ErrParseResultNotReviewed:         "parse_result_not_reviewed",
+	domain.ErrOCRJobNotCancellable:           "ocr_job_not_cancellable",
+	domain.ErrUsageQuotaExceeded:             "usage_quota_exceeded",
+	domain.ErrWebhookNotFound:                "webhook_not_found",
+	domain.ErrWebhookDeliveryNotFound:        "webhook_delivery_not_found",
+	domain.ErrInvalidWebhookURL:              "invalid_webhook_url",
+	domain.ErrInvalidQRLogo:                  "invalid_qr_logo",
+	domain.ErrUnsupportedQRLogo:              "unsupported_qr_logo",
+	domain.ErrNoManagedRestaurant:            "no_managed_restaurant",
+	domain.ErrQRCodeNotFound:                 "qr_code_not_found",
+	domain.ErrQRCodeInactive:                 "qr_code_inactive",
+	domain.ErrQRCodeExpired:                  "qr_code_expired",
 	domain.ErrRestaurantDeleted:              "restaurant_deleted",
 	domain.ErrFailedToRegisterUser:           "failed_to_register_user",
 	domain.ErrTokenGenerationIssue:           "token_generation_failed",
@@ -94,18 +116,26 @@ func NormalizeError(err error) (status int, resp ErrorResponse) {
 
 func statusFromDomainError(err error) int {
 	switch err {
-	case domain.ErrNotFound, domain.ErrUserNotFound, domain.ErrRestaurantNotFound:
+	case domain.ErrNotFound, domain.ErrUserNotFound, domain.ErrRestaurantNotFound, domain.ErrMenuVersionNotFound, domain.ErrMenuItemNotFound, domain.ErrParseResultNotFound,
+		domain.ErrWebhookNotFound, domain.ErrWebhookDeliveryNotFound, domain.ErrQRCodeNotFound:
 		return http.StatusNotFound
-	case domain.ErrRestaurantDeleted:
+	case domain.ErrRestaurantDeleted, domain.ErrQRCodeInactive, domain.ErrQRCodeExpired:
 		return http.StatusGone
 	case domain.ErrUnauthorized:
 		return http.StatusUnauthorized
-	case domain.ErrForbidden:
+	case domain.ErrForbidden, domain.ErrNoManagedRestaurant:
 		return http.StatusForbidden
-	case domain.ErrInvalidCredentials, domain.ErrInvalidInput:
+	case domain.ErrInvalidCredentials, domain.ErrInvalidInput, domain.ErrInvalidModifierGroups, domain.ErrUnsupportedImportFormat, domain.ErrMenuNotPublished, domain.ErrInvalidWebhookURL,
+		domain.ErrInvalidQRLogo, domain.ErrUnsupportedQRLogo:
 		return http.StatusBadRequest
-	case domain.ErrEmailAlreadyInUse, domain.ErrUsernameAlreadyInUse, domain.ErrPhoneAlreadyInUse:
+	case domain.ErrInvalidMenuImport, domain.ErrParseResultNotReviewed:
+		return http.StatusUnprocessableEntity
+	case domain.ErrEmailAlreadyInUse, domain.ErrUsernameAlreadyInUse, domain.ErrPhoneAlreadyInUse, domain.ErrMenuDraftChanged, domain.ErrParseResultApproved, domain.ErrOCRJobNotCancellable:
 		return http.StatusConflict
+	case domain.ErrUsageQuotaExceeded:
+		return http.StatusTooManyRequests
+	case domain.ErrTranslationUnavailable:
+		return http.StatusServiceUnavailable
 	default:
 		return http.StatusInternalServerError
 	}
@@ -174,6 +204,17 @@ func WriteError(c *gin.Context, err error) {
 	c.JSON(status, e)
 }
 
+// WriteErrorWithDetails writes err like WriteError and attaches details the
+// client needs to fix the request.
+func WriteErrorWithDetails(c *gin.Context, err error, details any) {
+	status, e := NormalizeError(err)
+	if isProduction() {
+		e.Error = ""
+	}
+	e.Details = details
+	c.JSON(status, e)
+}
+
 // WriteValidationError writes a structured validation error for a specific field.
 func WriteValidationError(c *gin.Context, field, message, code string, internal error) {
 	if code == "" {
@@ -3,6 +3,7 @@ package dto
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
@@ -16,6 +17,41 @@ type NutritionalInfoDTO struct {
 	Fat      int `json:"fat"`
 }
 
+// ModifierGroupDTO mirrors domain.ModifierGroup for transport
+type ModifierGroupDTO struct {
+	ID           string              `json:"id,omitempty"`
+	Name         string              `json:"name"`
+	NameAm       string              `json:"name_am"`
+	MinSelect    int                 `json:"min_select" validate:"gte=0"`
+	MaxSelect    int                 `json:"max_select" validate:"gte=0"`
+	Options      []ModifierOptionDTO `json:"options" validate:"required,min=1,dive"`
+	Translations map[string]string   `json:"translations,omitempty" validate:"omitempty,dive,keys,oneof=en am om ti,endkeys"`
+}
+
+// ModifierOptionDTO mirrors domain.ModifierOption for transport
+type ModifierOptionDTO struct {
+	ID           string            `json:"id,omitempty"`
+	Name         string            `json:"name"`
+	NameAm       string            `json:"name_am"`
+	PriceDelta   float64           `json:"price_delta"`
+	IsDefault    bool              `json:"is_default,omitempty"`
+	Translations map[string]string `json:"translations,omitempty" validate:"omitempty,dive,keys,oneof=en am om ti,endkeys"`
+}
+
+// ItemTranslationDTO mirrors domain.ItemTranslation for transport
+type ItemTranslationDTO struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Allergies   string   `json:"allergies,omitempty"`
+	TabTags     []string `json:"tab_tags,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Ingredients []string `json:"ingredients,omitempty"`
+	HowToEat    string   `json:"how_to_eat,omitempty"`
+	// MachineTranslated lists unreviewed machine-translated fields; leave a
+	// field out when saving to mark it reviewed
+	MachineTranslated []string `json:"machine_translated,omitempty"`
+}
+
 // ItemRequest represents data needed to create/update an item
 type ItemRequest struct {
 	ID              string              `json:"id,omitempty"`
@@ -33,6 +69,7 @@ type ItemRequest struct {
 	UserImages      []string            `json:"user_images,omitempty"`
 	TabTags         []string            `json:"tab_tags,omitempty"`
 	TabTagsAm       []string            `json:"tab_tags_am,omitempty"`
+	Category        string              `json:"category,omitempty"`
 	Calories        int                 `json:"calories,omitempty" validate:"gte=0"` // backward compatibility (flattened)
 	Protein         int                 `json:"protein,omitempty" validate:"gte=0"`
 	Carbs           int                 `json:"carbs,omitempty" validate:"gte=0"`
@@ -43,6 +80,13 @@ type ItemRequest struct {
 	PreparationTime int                 `json:"preparation_time,omitempty" validate:"gte=0"`
 	HowToEat        string              `json:"how_to_eat,omitempty"`
 	HowToEatAm      string              `json:"how_to_eat_am,omitempty"`
+	// IsAvailable lets a new item start out sold out; omitted means available
+	IsAvailable *bool `json:"is_available,omitempty"`
+	// ModifierGroups replaces the item's groups when present; [] removes them
+	ModifierGroups []ModifierGroupDTO `json:"modifier_groups,omitempty" validate:"omitempty,dive"`
+	// Translations holds other languages by code; the *_am fields above are
+	// shorthand for Translations["am"] and win over it
+	Translations map[string]ItemTranslationDTO `json:"translations,omitempty" validate:"omitempty,dive,keys,oneof=en am om ti,endkeys"`
 }
 
 // ItemResponse represents the outward facing item payload
@@ -62,6 +106,7 @@ type ItemResponse struct {
 	UserImages      []string            `json:"user_images,omitempty"`
 	TabTags         []string            `json:"tab_tags,omitempty"`
 	TabTagsAm       []string            `json:"tab_tags_am,omitempty"`
+	Category        string              `json:"category,omitempty"`
 	Calories        int                 `json:"calories,omitempty"`
 	Protein         int                 `json:"protein,omitempty"`
 	Carbs           int                 `json:"carbs,omitempty"`
@@ -78,41 +123,63 @@ type ItemResponse struct {
 	ViewCount       int                 `json:"view_count"`
 	AverageRating   float64             `json:"average_rating"`
 	ReviewIDs       []string            `json:"review_ids"`
+	// IsAvailable is false while the item is sold out; UnavailableUntil is when it comes back
+	IsAvailable      bool       `json:"is_available"`
+	UnavailableUntil *time.Time `json:"unavailable_until,omitempty"`
+	// MinPrice and MaxPrice span every valid modifier selection; only set with modifiers
+	ModifierGroups []ModifierGroupDTO            `json:"modifier_groups,omitempty"`
+	MinPrice       float64                       `json:"min_price,omitempty"`
+	MaxPrice       float64                       `json:"max_price,omitempty"`
+	Translations   map[string]ItemTranslationDTO `json:"translations,omitempty"`
+	// Language is set on public responses, whose text fields are in that language
+	Language string `json:"language,omitempty"`
+}
+
+// ItemAvailabilityRequest marks an item sold out or available again. A sold out
+// item stays out until Until, until the end of the restaurant's day with
+// RestOfDay, or until it is marked available when neither is given.
+type ItemAvailabilityRequest struct {
+	Available *bool      `json:"available" validate:"required"`
+	Until     *time.Time `json:"until,omitempty"`
+	RestOfDay bool       `json:"rest_of_day,omitempty"`
 }
 
 // ItemDTO consolidated struct (camelCase variant if needed by other layers)
 type ItemDTO struct {
-	ID              string              `json:"id"`
-	Name            string              `json:"name"`
-	NameAm          string              `json:"name_am,omitempty"`
-	Slug            string              `json:"slug"`
-	MenuSlug        string              `json:"menu_slug"`
-	Description     string              `json:"description,omitempty"`
-	DescriptionAm   string              `json:"description_am,omitempty"`
-	Image           []string            `json:"image,omitempty"`
-	Price           float64             `json:"price"`
-	Currency        string              `json:"currency"`
-	Allergies       []string            `json:"allergies,omitempty"`
-	AllergiesAm     string              `json:"allergies_am,omitempty"`
-	TabTags         []string            `json:"tab_tags,omitempty"`
-	TabTagsAm       []string            `json:"tab_tags_am,omitempty"`
-	UserImages      []string            `json:"user_images,omitempty"`
-	Calories        int                 `json:"calories,omitempty"`
-	Protein         int                 `json:"protein,omitempty"`
-	Carbs           int                 `json:"carbs,omitempty"`
-	Fat             int                 `json:"fat,omitempty"`
-	NutritionalInfo *NutritionalInfoDTO `json:"nutritional_info,omitempty"`
-	Ingredients     []string            `json:"ingredients,omitempty"`
-	IngredientsAm   []string            `json:"ingredients_am,omitempty"`
-	PreparationTime int                 `json:"preparation_time,omitempty"`
-	HowToEat        string              `json:"how_to_eat,omitempty"`
-	HowToEatAm      string              `json:"how_to_eat_am,omitempty"`
-	CreatedAt       time.Time           `json:"created_at"`
-	UpdatedAt       time.Time           `json:"updated_at"`
-	IsDeleted       bool                `json:"is_deleted"`
-	ViewCount       int                 `json:"view_count"`
-	AverageRating   float64             `json:"average_rating"`
-	ReviewIDs       []string            `json:"review_ids"`
+	ID              string                        `json:"id"`
+	Name            string                        `json:"name"`
+	NameAm          string                        `json:"name_am,omitempty"`
+	Slug            string                        `json:"slug"`
+	MenuSlug        string                        `json:"menu_slug"`
+	Description     string                        `json:"description,omitempty"`
+	DescriptionAm   string                        `json:"description_am,omitempty"`
+	Image           []string                      `json:"image,omitempty"`
+	Price           float64                       `json:"price"`
+	Currency        string                        `json:"currency"`
+	Allergies       []string                      `json:"allergies,omitempty"`
+	AllergiesAm     string                        `json:"allergies_am,omitempty"`
+	TabTags         []string                      `json:"tab_tags,omitempty"`
+	TabTagsAm       []string                      `json:"tab_tags_am,omitempty"`
+	Category        string                        `json:"category,omitempty"`
+	UserImages      []string                      `json:"user_images,omitempty"`
+	Calories        int                           `json:"calories,omitempty"`
+	Protein         int                           `json:"protein,omitempty"`
+	Carbs           int                           `json:"carbs,omitempty"`
+	Fat             int                           `json:"fat,omitempty"`
+	NutritionalInfo *NutritionalInfoDTO           `json:"nutritional_info,omitempty"`
+	Ingredients     []string                      `json:"ingredients,omitempty"`
+	IngredientsAm   []string                      `json:"ingredients_am,omitempty"`
+	PreparationTime int                           `json:"preparation_time,omitempty"`
+	HowToEat        string                        `json:"how_to_eat,omitempty"`
+	HowToEatAm      string                        `json:"how_to_eat_am,omitempty"`
+	CreatedAt       time.Time                     `json:"created_at"`
+	UpdatedAt       time.Time                     `json:"updated_at"`
+	IsDeleted       bool                          `json:"is_deleted"`
+	ViewCount       int                           `json:"view_count"`
+	AverageRating   float64                       `json:"average_rating"`
+	ReviewIDs       []string                      `json:"review_ids"`
+	ModifierGroups  []ModifierGroupDTO            `json:"modifier_groups,omitempty"`
+	Translations    map[string]ItemTranslationDTO `json:"translations,omitempty"`
 }
 
 // Validate basic required fields for ItemDTO
@@ -132,18 +199,15 @@ func (i *ItemDTO) ToDomain() *domain.Item {
 	return &domain.Item{
 		ID:              i.ID,
 		Name:            i.Name,
-		NameAm:          i.NameAm,
 		Slug:            i.Slug,
 		MenuSlug:        i.MenuSlug,
 		Description:     i.Description,
-		DescriptionAm:   i.DescriptionAm,
 		Image:           i.Image,
 		Price:           i.Price,
 		Currency:        i.Currency,
 		Allergies:       i.Allergies,
-		AllergiesAm:     i.AllergiesAm,
 		TabTags:         i.TabTags,
-		TabTagsAm:       i.TabTagsAm,
+		Category:        i.Category,
 		UserImages:      i.UserImages,
 		Calories:        i.Calories,
 		Protein:         i.Protein,
@@ -151,16 +215,19 @@ func (i *ItemDTO) ToDomain() *domain.Item {
 		Fat:             i.Fat,
 		NutritionalInfo: nutri,
 		Ingredients:     i.Ingredients,
-		IngredientsAm:   i.IngredientsAm,
 		PreparationTime: i.PreparationTime,
 		HowToEat:        i.HowToEat,
-		HowToEatAm:      i.HowToEatAm,
 		CreatedAt:       i.CreatedAt,
 		UpdatedAt:       i.UpdatedAt,
 		IsDeleted:       i.IsDeleted,
 		ViewCount:       i.ViewCount,
 		AverageRating:   i.AverageRating,
 		ReviewIds:       i.ReviewIDs,
+		ModifierGroups:  DTOToModifierGroups(i.ModifierGroups),
+		Translations: ItemTranslationsFromDTO(i.Translations, ItemTranslationDTO{
+			Name: i.NameAm, Description: i.DescriptionAm, Allergies: i.AllergiesAm,
+			TabTags: i.TabTagsAm, Ingredients: i.IngredientsAm, HowToEat: i.HowToEatAm,
+		}),
 	}
 }
 
@@ -173,21 +240,23 @@ func (i *ItemDTO) FromDomain(item *domain.Item) *ItemDTO {
 	if item.NutritionalInfo != nil {
 		nutri = &NutritionalInfoDTO{Calories: item.NutritionalInfo.Calories, Protein: item.NutritionalInfo.Protein, Carbs: item.NutritionalInfo.Carbs, Fat: item.NutritionalInfo.Fat}
 	}
+	am := item.Translation(domain.LangAmharic)
 	return &ItemDTO{
 		ID:              item.ID,
 		Name:            item.Name,
-		NameAm:          item.NameAm,
+		NameAm:          am.Name,
 		Slug:            item.Slug,
 		MenuSlug:        item.MenuSlug,
 		Description:     item.Description,
-		DescriptionAm:   item.DescriptionAm,
+		DescriptionAm:   am.Description,
 		Image:           item.Image,
 		Price:           item.Price,
 		Currency:        item.Currency,
 		Allergies:       item.Allergies,
-		AllergiesAm:     item.AllergiesAm,
+		AllergiesAm:     am.Allergies,
 		TabTags:         item.TabTags,
-		TabTagsAm:       item.TabTagsAm,
+		TabTagsAm:       am.TabTags,
+		Category:        item.Category,
 		UserImages:      item.UserImages,
 		Calories:        item.Calories,
 		Protein:         item.Protein,
@@ -195,16 +264,18 @@ func (i *ItemDTO) FromDomain(item *domain.Item) *ItemDTO {
 		Fat:             item.Fat,
 		NutritionalInfo: nutri,
 		Ingredients:     item.Ingredients,
-		IngredientsAm:   item.IngredientsAm,
+		IngredientsAm:   am.Ingredients,
 		PreparationTime: item.PreparationTime,
 		HowToEat:        item.HowToEat,
-		HowToEatAm:      item.HowToEatAm,
+		HowToEatAm:      am.HowToEat,
 		CreatedAt:       item.CreatedAt,
 		UpdatedAt:       item.UpdatedAt,
 		IsDeleted:       item.IsDeleted,
 		ViewCount:       item.ViewCount,
 		AverageRating:   item.AverageRating,
 		ReviewIDs:       item.ReviewIds,
+		ModifierGroups:  ModifierGroupsToDTO(item.ModifierGroups),
+		Translations:    ItemTranslationsToDTO(item.Translations),
 	}
 }
 
@@ -223,29 +294,30 @@ func RequestToItem(r *ItemRequest) *domain.Item {
 	return &domain.Item{
 		ID:              r.ID,
 		Name:            r.Name,
-		NameAm:          r.NameAm,
 		Slug:            r.Slug,
 		MenuSlug:        r.MenuSlug,
 		Description:     r.Description,
-		DescriptionAm:   r.DescriptionAm,
 		Image:           r.Image,
 		Price:           r.Price,
 		Currency:        r.Currency,
 		Allergies:       r.Allergies.ToSlice(),
-		AllergiesAm:     r.AllergiesAm,
 		UserImages:      r.UserImages,
 		TabTags:         r.TabTags,
-		TabTagsAm:       r.TabTagsAm,
+		Category:        r.Category,
 		Calories:        r.Calories,
 		Protein:         r.Protein,
 		Carbs:           r.Carbs,
 		Fat:             r.Fat,
 		NutritionalInfo: nutri,
 		Ingredients:     r.Ingredients,
-		IngredientsAm:   r.IngredientsAm,
 		PreparationTime: r.PreparationTime,
 		HowToEat:        r.HowToEat,
-		HowToEatAm:      r.HowToEatAm,
+		Unavailable:     r.IsAvailable != nil && !*r.IsAvailable,
+		ModifierGroups:  DTOToModifierGroups(r.ModifierGroups),
+		Translations: ItemTranslationsFromDTO(r.Translations, ItemTranslationDTO{
+			Name: r.NameAm, Description: r.DescriptionAm, Allergies: r.AllergiesAm,
+			TabTags: r.TabTagsAm, Ingredients: r.IngredientsAm, HowToEat: r.HowToEatAm,
+		}),
 	}
 }
 
@@ -285,39 +357,180 @@ func ItemToResponse(item *domain.Item) *ItemResponse {
 	if item.NutritionalInfo != nil {
 		nutri = &NutritionalInfoDTO{Calories: item.NutritionalInfo.Calories, Protein: item.NutritionalInfo.Protein, Carbs: item.NutritionalInfo.Carbs, Fat: item.NutritionalInfo.Fat}
 	}
-	return &ItemResponse{
-		ID:              item.ID,
-		Name:            item.Name,
-		NameAm:          item.NameAm,
-		Slug:            item.Slug,
-		MenuSlug:        item.MenuSlug,
-		Description:     item.Description,
-		DescriptionAm:   item.DescriptionAm,
-		Image:           item.Image,
-		Price:           item.Price,
-		Currency:        item.Currency,
-		Allergies:       item.Allergies,
-		AllergiesAm:     item.AllergiesAm,
-		UserImages:      item.UserImages,
-		TabTags:         item.TabTags,
-		TabTagsAm:       item.TabTagsAm,
-		Calories:        item.Calories,
-		Protein:         item.Protein,
-		Carbs:           item.Carbs,
-		Fat:             item.Fat,
-		NutritionalInfo: nutri,
-		Ingredients:     item.Ingredients,
-		IngredientsAm:   item.IngredientsAm,
-		PreparationTime: item.PreparationTime,
-		HowToEat:        item.HowToEat,
-		HowToEatAm:      item.HowToEatAm,
-		CreatedAt:       item.CreatedAt,
-		UpdatedAt:       item.UpdatedAt,
-		IsDeleted:       item.IsDeleted,
-		ViewCount:       item.ViewCount,
-		AverageRating:   item.AverageRating,
-		ReviewIDs:       item.ReviewIds,
+	available := item.IsAvailableAt(time.Now())
+	var until *time.Time
+	if !available {
+		until = item.UnavailableUntil
+	}
+	am := item.Translation(domain.LangAmharic)
+	res := &ItemResponse{
+		ID:               item.ID,
+		Name:             item.Name,
+		NameAm:           am.Name,
+		Slug:             item.Slug,
+		MenuSlug:         item.MenuSlug,
+		Description:      item.Description,
+		DescriptionAm:    am.Description,
+		Image:            item.Image,
+		Price:            item.Price,
+		Currency:         item.Currency,
+		Allergies:        item.Allergies,
+		AllergiesAm:      am.Allergies,
+		UserImages:       item.UserImages,
+		TabTags:          item.TabTags,
+		TabTagsAm:        am.TabTags,
+		Category:         item.Category,
+		Calories:         item.Calories,
+		Protein:          item.Protein,
+		Carbs:            item.Carbs,
+		Fat:              item.Fat,
+		NutritionalInfo:  nutri,
+		Ingredients:      item.Ingredients,
+		IngredientsAm:    am.Ingredients,
+		PreparationTime:  item.PreparationTime,
+		HowToEat:         item.HowToEat,
+		HowToEatAm:       am.HowToEat,
+		CreatedAt:        item.CreatedAt,
+		UpdatedAt:        item.UpdatedAt,
+		IsDeleted:        item.IsDeleted,
+		ViewCount:        item.ViewCount,
+		AverageRating:    item.AverageRating,
+		ReviewIDs:        item.ReviewIds,
+		IsAvailable:      available,
+		UnavailableUntil: until,
+		Translations:     ItemTranslationsToDTO(item.Translations),
+	}
+	if len(item.ModifierGroups) > 0 {
+		res.ModifierGroups = ModifierGroupsToDTO(item.ModifierGroups)
+		res.MinPrice, res.MaxPrice = item.PriceRange()
+	}
+	return res
+}
+
+// LocalizedItemResponse is ItemResponse with the text fields in lang, falling
+// back to the base text where the item has not been translated. The *_am
+// fields and the translations map are left as stored.
+func LocalizedItemResponse(item *domain.Item, lang string) *ItemResponse {
+	res := ItemToResponse(item)
+	if res == nil {
+		return nil
+	}
+	t := item.Localized(lang)
+	res.Name, res.Description, res.HowToEat = t.Name, t.Description, t.HowToEat
+	res.TabTags, res.Category, res.Ingredients = t.TabTags, t.Category, t.Ingredients
+	if tr := item.Translation(lang); tr.Allergies != "" {
+		res.Allergies = []string{tr.Allergies}
+	}
+	for i := range res.ModifierGroups {
+		g := &res.ModifierGroups[i]
+		g.Name = domain.Translations(g.Translations).In(lang, g.Name)
+		for j := range g.Options {
+			o := &g.Options[j]
+			o.Name = domain.Translations(o.Translations).In(lang, o.Name)
+		}
+	}
+	res.Language = lang
+	return res
+}
+
+// ItemTranslationsFromDTO converts request translations to the domain model.
+// Non-empty fields of am (the legacy *_am fields) override Translations["am"].
+// Keys are normalized and unsupported languages dropped.
+func ItemTranslationsFromDTO(in map[string]ItemTranslationDTO, am ItemTranslationDTO) map[string]domain.ItemTranslation {
+	item := &domain.Item{}
+	for key, t := range in {
+		if lang := domain.NormalizeLanguage(key); lang != "" {
+			item.SetTranslation(lang, domain.ItemTranslation(t))
+		}
+	}
+	// a shorthand value that differs from the stored text is a manager's edit
+	t := item.Translation(domain.LangAmharic)
+	if am.Name != "" && am.Name != t.Name {
+		t.Name = am.Name
+		t.MarkReviewed(domain.FieldName)
+	}
+	if am.Description != "" && am.Description != t.Description {
+		t.Description = am.Description
+		t.MarkReviewed(domain.FieldDescription)
+	}
+	if am.Allergies != "" && am.Allergies != t.Allergies {
+		t.Allergies = am.Allergies
+		t.MarkReviewed(domain.FieldAllergies)
+	}
+	if len(am.TabTags) > 0 && !slices.Equal(am.TabTags, t.TabTags) {
+		t.TabTags = am.TabTags
+		t.MarkReviewed(domain.FieldTabTags)
+	}
+	if len(am.Ingredients) > 0 && !slices.Equal(am.Ingredients, t.Ingredients) {
+		t.Ingredients = am.Ingredients
+		t.MarkReviewed(domain.FieldIngredients)
 	}
+	if am.HowToEat != "" && am.HowToEat != t.HowToEat {
+		t.HowToEat = am.HowToEat
+		t.MarkReviewed(domain.FieldHowToEat)
+	}
+	item.SetTranslation(domain.LangAmharic, t)
+	return item.Translations
+}
+
+// ItemTranslationsToDTO converts domain translations for responses.
+func ItemTranslationsToDTO(in map[string]domain.ItemTranslation) map[string]ItemTranslationDTO {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]ItemTranslationDTO, len(in))
+	for lang, t := range in {
+		out[lang] = ItemTranslationDTO(t)
+	}
+	return out
+}
+
+// NameTranslationsFromDTO converts a name translation map from a request,
+// applying a legacy name_am value on top.
+func NameTranslationsFromDTO(in map[string]string, nameAm string) domain.Translations {
+	var out domain.Translations
+	for key, name := range in {
+		if lang := domain.NormalizeLanguage(key); lang != "" {
+			out = out.With(lang, name)
+		}
+	}
+	if nameAm != "" {
+		out = out.With(domain.LangAmharic, nameAm)
+	}
+	return out
+}
+
+// DTOToModifierGroups converts transport modifier groups to the domain model,
+// keeping nil (not sent) distinct from empty (clear).
+func DTOToModifierGroups(groups []ModifierGroupDTO) []domain.ModifierGroup {
+	if groups == nil {
+		return nil
+	}
+	out := make([]domain.ModifierGroup, len(groups))
+	for i, g := range groups {
+		options := make([]domain.ModifierOption, len(g.Options))
+		for j, o := range g.Options {
+			options[j] = domain.ModifierOption{ID: o.ID, Name: o.Name, PriceDelta: o.PriceDelta, IsDefault: o.IsDefault, Translations: NameTranslationsFromDTO(o.Translations, o.NameAm)}
+		}
+		out[i] = domain.ModifierGroup{ID: g.ID, Name: g.Name, MinSelect: g.MinSelect, MaxSelect: g.MaxSelect, Options: options, Translations: NameTranslationsFromDTO(g.Translations, g.NameAm)}
+	}
+	return out
+}
+
+// ModifierGroupsToDTO converts domain modifier groups for responses.
+func ModifierGroupsToDTO(groups []domain.ModifierGroup) []ModifierGroupDTO {
+	if len(groups) == 0 {
+		return nil
+	}
+	out := make([]ModifierGroupDTO, len(groups))
+	for i, g := range groups {
+		options := make([]ModifierOptionDTO, len(g.Options))
+		for j, o := range g.Options {
+			options[j] = ModifierOptionDTO{ID: o.ID, Name: o.Name, NameAm: o.Translations[domain.LangAmharic], PriceDelta: o.PriceDelta, IsDefault: o.IsDefault, Translations: o.Translations}
+		}
+		out[i] = ModifierGroupDTO{ID: g.ID, Name: g.Name, NameAm: g.Translations[domain.LangAmharic], MinSelect: g.MinSelect, MaxSelect: g.MaxSelect, Options: options, Translations: g.Translations}
+	}
+	return out
 }
 
 // ItemToResponseList converts slice domain -> slice response
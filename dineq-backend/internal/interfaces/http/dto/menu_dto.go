@@ -19,22 +19,50 @@ type MenuRequest struct {
 
 // MenuResponse represents the structure for menu responses.
 type MenuResponse struct {
-	ID             string         `json:"id"`
-	Name           string         `json:"name"`
-	RestaurantID   string         `json:"restaurant_id"`
-	RestaurantSlug string         `json:"restaurant_slug"`
-	Slug           string         `json:"slug"`
-	Version        int            `json:"version"`
-	IsPublished    bool           `json:"is_published"`
-	PublishedAt    *time.Time     `json:"published_at,omitempty"`
-	Items          []ItemResponse `json:"items"`
-	CreatedAt      time.Time      `json:"created_at"`
-	UpdatedAt      time.Time      `json:"updated_at"`
-	CreatedBy      string         `json:"created_by"`
-	UpdatedBy      string         `json:"updated_by"`
-	IsDeleted      bool           `json:"is_deleted,omitempty"`
-	ViewCount      int            `json:"view_count,omitempty"`
-	DeletedAt      *time.Time     `json:"deleted_at,omitempty"`
+	ID             string           `json:"id"`
+	Name           string           `json:"name"`
+	RestaurantID   string           `json:"restaurant_id"`
+	RestaurantSlug string           `json:"restaurant_slug"`
+	Slug           string           `json:"slug"`
+	Version        int              `json:"version"`
+	IsPublished    bool             `json:"is_published"`
+	PublishedAt    *time.Time       `json:"published_at,omitempty"`
+	Items          []ItemResponse   `json:"items"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	CreatedBy      string           `json:"created_by"`
+	UpdatedBy      string           `json:"updated_by"`
+	IsDeleted      bool             `json:"is_deleted,omitempty"`
+	ViewCount      int              `json:"view_count,omitempty"`
+	DeletedAt      *time.Time       `json:"deleted_at,omitempty"`
+	Schedule       *MenuScheduleDTO `json:"schedule,omitempty"`
+	// Draft bookkeeping, only present on manager-facing responses
+	PublishedVersion      int  `json:"published_version,omitempty"`
+	HasUnpublishedChanges bool `json:"has_unpublished_changes,omitempty"`
+	// Language is set on public responses, whose item text is in that language
+	Language string `json:"language,omitempty"`
+}
+
+// MenuTranslateRequest asks for machine translation of a menu's missing
+// translations. Languages defaults to every supported language.
+type MenuTranslateRequest struct {
+	Languages []string `json:"languages,omitempty"`
+}
+
+// MenuScheduleDTO is used both to set and to return a menu schedule.
+type MenuScheduleDTO struct {
+	Windows     []MenuActivationWindowDTO `json:"windows"`
+	PublishAt   *time.Time                `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time                `json:"unpublish_at,omitempty"`
+}
+
+// MenuActivationWindowDTO mirrors domain.MenuActivationWindow for transport.
+type MenuActivationWindowDTO struct {
+	Days      []string `json:"days,omitempty"`
+	StartTime string   `json:"start_time,omitempty"`
+	EndTime   string   `json:"end_time,omitempty"`
+	StartDate string   `json:"start_date,omitempty"`
+	EndDate   string   `json:"end_date,omitempty"`
 }
 
 // RequestToMenu converts a MenuRequest to a domain Menu.
@@ -62,19 +90,33 @@ func RequestToMenu(dto *MenuRequest) *domain.Menu {
 
 // MenuToResponse converts a domain Menu to a MenuResponse.
 func MenuToResponse(menu *domain.Menu) *MenuResponse {
+	return menuToResponse(menu, "")
+}
+
+// LocalizedMenuResponse converts a menu for public responses, with item text in
+// lang (see LocalizedItemResponse).
+func LocalizedMenuResponse(menu *domain.Menu, lang string) *MenuResponse {
+	return menuToResponse(menu, lang)
+}
+
+func menuToResponse(menu *domain.Menu, lang string) *MenuResponse {
 	if menu == nil {
 		return nil
 	}
 	items := make([]ItemResponse, len(menu.Items))
-	for i, item := range menu.Items {
-		items[i] = *ItemToResponse(&item)
+	for i := range menu.Items {
+		if lang == "" {
+			items[i] = *ItemToResponse(&menu.Items[i])
+		} else {
+			items[i] = *LocalizedItemResponse(&menu.Items[i], lang)
+		}
 	}
 	var publishedAtPtr *time.Time
 	if menu.IsPublished && !menu.PublishedAt.IsZero() {
 		pa := menu.PublishedAt
 		publishedAtPtr = &pa
 	}
-	return &MenuResponse{
+	res := &MenuResponse{
 		ID:             menu.ID,
 		Name:           menu.Name,
 		RestaurantID:   menu.RestaurantID,
@@ -92,15 +134,64 @@ func MenuToResponse(menu *domain.Menu) *MenuResponse {
 		ViewCount:      menu.ViewCount,
 		DeletedAt:      menu.DeletedAt,
 	}
+	if menu.Published != nil {
+		res.PublishedVersion = menu.Published.Version
+		res.HasUnpublishedChanges = menu.HasUnpublishedChanges()
+	}
+	res.Schedule = MenuScheduleToDTO(menu.Schedule)
+	res.Language = lang
+	return res
+}
+
+// DTOToMenuSchedule converts a MenuScheduleDTO to a domain MenuSchedule.
+func DTOToMenuSchedule(d *MenuScheduleDTO) *domain.MenuSchedule {
+	if d == nil {
+		return nil
+	}
+	windows := make([]domain.MenuActivationWindow, len(d.Windows))
+	for i, w := range d.Windows {
+		windows[i] = domain.MenuActivationWindow{
+			Days:      w.Days,
+			StartTime: w.StartTime,
+			EndTime:   w.EndTime,
+			StartDate: w.StartDate,
+			EndDate:   w.EndDate,
+		}
+	}
+	return &domain.MenuSchedule{Windows: windows, PublishAt: d.PublishAt, UnpublishAt: d.UnpublishAt}
+}
+
+// MenuScheduleToDTO converts a domain MenuSchedule to a MenuScheduleDTO.
+func MenuScheduleToDTO(s *domain.MenuSchedule) *MenuScheduleDTO {
+	if s == nil {
+		return nil
+	}
+	windows := make([]MenuActivationWindowDTO, len(s.Windows))
+	for i, w := range s.Windows {
+		windows[i] = MenuActivationWindowDTO{
+			Days:      w.Days,
+			StartTime: w.StartTime,
+			EndTime:   w.EndTime,
+			StartDate: w.StartDate,
+			EndDate:   w.EndDate,
+		}
+	}
+	return &MenuScheduleDTO{Windows: windows, PublishAt: s.PublishAt, UnpublishAt: s.UnpublishAt}
 }
 
 func MenuResponseList(menus []*domain.Menu) []*MenuResponse {
+	return LocalizedMenuResponseList(menus, "")
+}
+
+// LocalizedMenuResponseList converts menus for public responses in lang; ""
+// leaves the text as stored.
+func LocalizedMenuResponseList(menus []*domain.Menu, lang string) []*MenuResponse {
 	if menus == nil {
 		return nil
 	}
 	res := make([]*MenuResponse, len(menus))
 	for i, menu := range menus {
-		res[i] = MenuToResponse(menu)
+		res[i] = menuToResponse(menu, lang)
 	}
 	return res
 }
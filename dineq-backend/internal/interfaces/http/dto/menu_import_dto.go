@@ -0,0 +1,37 @@
+package dto
+
+import "github.com/RealEskalate/G6-MenuMate/internal/domain"
+
+// MenuImportIssueResponse is a single validation problem in an import file.
+type MenuImportIssueResponse struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+// MenuImportReportResponse is the per-row validation report of an import.
+type MenuImportReportResponse struct {
+	DryRun    bool                      `json:"dry_run"`
+	TotalRows int                       `json:"total_rows"`
+	ValidRows int                       `json:"valid_rows"`
+	Issues    []MenuImportIssueResponse `json:"issues"`
+	MenuID    string                    `json:"menu_id,omitempty"`
+}
+
+func MenuImportReportToResponse(r *domain.MenuImportReport) *MenuImportReportResponse {
+	if r == nil {
+		return nil
+	}
+	res := &MenuImportReportResponse{
+		DryRun:    r.DryRun,
+		TotalRows: r.TotalRows,
+		ValidRows: r.ValidRows,
+		Issues:    make([]MenuImportIssueResponse, 0, len(r.Issues)),
+		MenuID:    r.MenuID,
+	}
+	for _, is := range r.Issues {
+		res.Issues = append(res.Issues, MenuImportIssueResponse{Row: is.Row, Field: is.Field, Value: is.Value, Message: is.Message})
+	}
+	return res
+}
@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+// MenuRollbackRequest selects the version to restore.
+type MenuRollbackRequest struct {
+	Version int `json:"version" validate:"required,gt=0"`
+}
+
+// MenuVersionResponse represents a stored menu snapshot. Snapshot is only
+// populated when a single version is requested.
+type MenuVersionResponse struct {
+	ID        string        `json:"id"`
+	MenuID    string        `json:"menu_id"`
+	Version   int           `json:"version"`
+	Action    string        `json:"action"`
+	ItemCount int           `json:"item_count"`
+	CreatedBy string        `json:"created_by"`
+	CreatedAt time.Time     `json:"created_at"`
+	Snapshot  *MenuResponse `json:"snapshot,omitempty"`
+}
+
+// ItemPriceChangeResponse is a repriced item inside a menu diff.
+type ItemPriceChangeResponse struct {
+	ItemID   string  `json:"item_id"`
+	Slug     string  `json:"slug"`
+	Name     string  `json:"name"`
+	OldPrice float64 `json:"old_price"`
+	NewPrice float64 `json:"new_price"`
+	Currency string  `json:"currency"`
+}
+
+// MenuDiffResponse represents the structured difference between two versions.
+type MenuDiffResponse struct {
+	MenuID        string                    `json:"menu_id"`
+	FromVersion   int                       `json:"from_version"`
+	ToVersion     int                       `json:"to_version"`
+	NameChanged   bool                      `json:"name_changed"`
+	ItemsAdded    []ItemResponse            `json:"items_added"`
+	ItemsRemoved  []ItemResponse            `json:"items_removed"`
+	ItemsRepriced []ItemPriceChangeResponse `json:"items_repriced"`
+	TabsAdded     []string                  `json:"tabs_added"`
+	TabsRemoved   []string                  `json:"tabs_removed"`
+}
+
+// MenuVersionToResponse converts a domain MenuVersion to its response; withSnapshot
+// controls whether the full menu body is included.
+func MenuVersionToResponse(v *domain.MenuVersion, withSnapshot bool) *MenuVersionResponse {
+	if v == nil {
+		return nil
+	}
+	res := &MenuVersionResponse{
+		ID:        v.ID,
+		MenuID:    v.MenuID,
+		Version:   v.Version,
+		Action:    string(v.Action),
+		ItemCount: len(v.Snapshot.Items),
+		CreatedBy: v.CreatedBy,
+		CreatedAt: v.CreatedAt,
+	}
+	if withSnapshot {
+		res.Snapshot = MenuToResponse(&v.Snapshot)
+	}
+	return res
+}
+
+func MenuVersionResponseList(versions []*domain.MenuVersion) []*MenuVersionResponse {
+	res := make([]*MenuVersionResponse, len(versions))
+	for i, v := range versions {
+		res[i] = MenuVersionToResponse(v, false)
+	}
+	return res
+}
+
+// MenuDiffToResponse converts a domain MenuDiff to a MenuDiffResponse.
+func MenuDiffToResponse(d *domain.MenuDiff) *MenuDiffResponse {
+	if d == nil {
+		return nil
+	}
+	repriced := make([]ItemPriceChangeResponse, len(d.ItemsRepriced))
+	for i, c := range d.ItemsRepriced {
+		repriced[i] = ItemPriceChangeResponse{
+			ItemID:   c.ItemID,
+			Slug:     c.Slug,
+			Name:     c.Name,
+			OldPrice: c.OldPrice,
+			NewPrice: c.NewPrice,
+			Currency: c.Currency,
+		}
+	}
+	return &MenuDiffResponse{
+		MenuID:        d.MenuID,
+		FromVersion:   d.FromVersion,
+		ToVersion:     d.ToVersion,
+		NameChanged:   d.NameChanged,
+		ItemsAdded:    ItemToResponseList(d.ItemsAdded),
+		ItemsRemoved:  ItemToResponseList(d.ItemsRemoved),
+		ItemsRepriced: repriced,
+		TabsAdded:     d.TabsAdded,
+		TabsRemoved:   d.TabsRemoved,
+	}
+}
@@ -9,15 +9,17 @@ import (
 
 // OCRJobDTO represents the data transfer object for an OCRJob
 type OCRJobDTO struct {
-	ID               string    `json:"id"`
-	RestaurantID     string    `json:"restaurant_id"`
-	ImageURL         string    `json:"image_url"`
-	Status           string    `json:"status"`
-	ResultText       string    `json:"result_text,omitempty"`
-	StructuredMenuID string    `json:"structured_menu_id,omitempty"`
-	Error            string    `json:"error,omitempty"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID           string `json:"id"`
+	RestaurantID string `json:"restaurant_id"`
+	ImageURL     string `json:"image_url"`
+	// ProcessedImageURL is the preprocessed image OCR read, when there is one
+	ProcessedImageURL string    `json:"processed_image_url,omitempty"`
+	Status            string    `json:"status"`
+	ResultText        string    `json:"result_text,omitempty"`
+	StructuredMenuID  string    `json:"structured_menu_id,omitempty"`
+	Error             string    `json:"error,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 // Validate checks the OCRJobDTO for required fields
@@ -46,14 +48,59 @@ func (oj *OCRJobDTO) ToDomain() *domain.OCRJob {
 // FromDomain converts a domain.OCRJob entity to an OCRJobDTO
 func (oj *OCRJobDTO) FromDomain(job *domain.OCRJob) *OCRJobDTO {
 	return &OCRJobDTO{
-		ID:               job.ID,
-		RestaurantID:     job.RestaurantID,
-		ImageURL:         job.ImageURL,
-		Status:           string(job.Status),
-		ResultText:       job.ResultText,
-		StructuredMenuID: job.StructuredMenuID,
-		Error:            job.Error,
-		CreatedAt:        job.CreatedAt,
-		UpdatedAt:        job.UpdatedAt,
+		ID:                job.ID,
+		RestaurantID:      job.RestaurantID,
+		ImageURL:          job.ImageURL,
+		ProcessedImageURL: job.ProcessedImageURL,
+		Status:            string(job.Status),
+		ResultText:        job.ResultText,
+		StructuredMenuID:  job.StructuredMenuID,
+		Error:             job.Error,
+		CreatedAt:         job.CreatedAt,
+		UpdatedAt:         job.UpdatedAt,
 	}
 }
+
+// OCRJobSummary is a job in a history listing, without its results.
+type OCRJobSummary struct {
+	ID             string              `json:"job_id"`
+	RestaurantID   string              `json:"restaurant_id,omitempty"`
+	UserID         string              `json:"user_id"`
+	Status         domain.OCRJobStatus `json:"status"`
+	Phase          string              `json:"phase,omitempty"`
+	Progress       int                 `json:"progress"`
+	ImageURL       string              `json:"image_url,omitempty"`
+	PageCount      int                 `json:"page_count"`
+	Error          string              `json:"error,omitempty"`
+	ParseResultID  string              `json:"parse_result_id,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	CompletedAt    *time.Time          `json:"completed_at,omitempty"`
+	ImagesPurgedAt *time.Time          `json:"images_purged_at,omitempty"`
+}
+
+func OCRJobSummaries(jobs []*domain.OCRJob) []OCRJobSummary {
+	out := make([]OCRJobSummary, 0, len(jobs))
+	for _, job := range jobs {
+		s := OCRJobSummary{
+			ID:             job.ID,
+			RestaurantID:   job.RestaurantID,
+			UserID:         job.UserID,
+			Status:         job.Status,
+			Phase:          job.Phase,
+			Progress:       job.Progress,
+			PageCount:      max(len(job.Pages), 1),
+			Error:          job.Error,
+			CreatedAt:      job.CreatedAt,
+			CompletedAt:    job.CompletedAt,
+			ImagesPurgedAt: job.ImagesPurgedAt,
+		}
+		if job.ImagesPurgedAt == nil {
+			s.ImageURL = job.ImageURL
+		}
+		if job.Results != nil {
+			s.ParseResultID = job.Results.ParseResultID
+		}
+		out = append(out, s)
+	}
+	return out
+}
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"strings"
 	"time"
 
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
@@ -8,11 +9,22 @@ import (
 
 // QRCodeRequest represents a QR code generation request
 type QRCodeRequest struct {
-	Format        string               `json:"format"` // png, jpg, svg
+	Format        string               `json:"format"` // png, jpg, gif, svg, pdf
 	Size          int                  `json:"size"`   // size in pixels
 	IncludeLabel  bool                 `json:"include_label"`
 	Quality       int                  `json:"quality,omitempty"`
 	Customization *QRCodeCustomization `json:"customization,omitempty"`
+	// Label, Table and Location tag the code with where it is displayed,
+	// e.g. "Table 12", "12", "patio"
+	Label    string `json:"label,omitempty"`
+	Table    string `json:"table,omitempty"`
+	Location string `json:"location,omitempty"`
+}
+
+// QRCodeUpdateRequest renames or (de)activates a single QR code.
+type QRCodeUpdateRequest struct {
+	Label    *string `json:"label,omitempty" validate:"omitempty,max=100"`
+	IsActive *bool   `json:"is_active,omitempty"`
 }
 
 // QRCodeCustomization represents QR code customization options
@@ -33,15 +45,22 @@ type QRCodeCustomization struct {
 
 // QRCodeResponse represents a QR code generation response
 type QRCodeResponse struct {
-	QRCodeID         string    `json:"qr_code_id"`
-	ImageURL         string    `json:"image_url"`
-	CloudImageURL    string    `json:"cloud_image_url,omitempty"`
-	PublicMenuURL    string    `json:"public_menu_url"`
-	DownloadURL      string    `json:"download_url"`
-	IsActive         bool      `json:"is_active"`
-	ExpiresAt        time.Time `json:"expires_at"`
-	CreatedAt        time.Time `json:"created_at"`
-	LabelFontApplied bool      `json:"label_font_applied,omitempty"`
+	QRCodeID         string     `json:"qr_code_id"`
+	ImageURL         string     `json:"image_url"`
+	CloudImageURL    string     `json:"cloud_image_url,omitempty"`
+	PublicMenuURL    string     `json:"public_menu_url"`
+	ScanURL          string     `json:"scan_url,omitempty"`
+	DownloadURL      string     `json:"download_url"`
+	MenuID           string     `json:"menu_id,omitempty"`
+	Label            string     `json:"label,omitempty"`
+	Table            string     `json:"table,omitempty"`
+	Location         string     `json:"location,omitempty"`
+	IsActive         bool       `json:"is_active"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	LabelFontApplied bool       `json:"label_font_applied,omitempty"`
+	ScanCount        int64      `json:"scan_count"`
+	LastScannedAt    *time.Time `json:"last_scanned_at,omitempty"`
 }
 
 func DTOToQRCodeRequest(req *QRCodeRequest) *domain.QRCodeRequest {
@@ -54,6 +73,11 @@ func DTOToQRCodeRequest(req *QRCodeRequest) *domain.QRCodeRequest {
 		IncludeLabel:  req.IncludeLabel,
 		Quality:       req.Quality,
 		Customization: DTOToQRCodeCustomization(req.Customization),
+		Placement: domain.QRPlacement{
+			Label:    strings.TrimSpace(req.Label),
+			Table:    strings.TrimSpace(req.Table),
+			Location: strings.TrimSpace(req.Location),
+		},
 	}
 }
 
@@ -86,9 +110,40 @@ func DomainToQRCodeResponse(qr *domain.QRCode) *QRCodeResponse {
 		QRCodeID:      qr.ID,
 		ImageURL:      qr.ImageURL,
 		PublicMenuURL: qr.PublicMenuURL,
+		ScanURL:       qr.ScanURL,
 		DownloadURL:   qr.DownloadURL,
+		MenuID:        qr.MenuID,
+		Label:         qr.Label,
+		Table:         qr.Table,
+		Location:      qr.Location,
 		IsActive:      qr.IsActive,
 		ExpiresAt:     qr.ExpiresAt,
 		CreatedAt:     qr.CreatedAt,
+		ScanCount:     qr.ScanCount,
+		LastScannedAt: qr.LastScannedAt,
+	}
+}
+
+func DomainToQRCodeResponseList(qrs []*domain.QRCode) []*QRCodeResponse {
+	out := make([]*QRCodeResponse, len(qrs))
+	for i, qr := range qrs {
+		out[i] = DomainToQRCodeResponse(qr)
+	}
+	return out
+}
+
+// QRPlacementResponse tells the menu page which table or location it was
+// opened from, so orders can be sent there.
+type QRPlacementResponse struct {
+	QRCodeID string `json:"qr_code_id"`
+	Label    string `json:"label,omitempty"`
+	Table    string `json:"table,omitempty"`
+	Location string `json:"location,omitempty"`
+}
+
+func DomainToQRPlacementResponse(qr *domain.QRCode) *QRPlacementResponse {
+	if qr == nil {
+		return nil
 	}
+	return &QRPlacementResponse{QRCodeID: qr.ID, Label: qr.Label, Table: qr.Table, Location: qr.Location}
 }
@@ -7,11 +7,13 @@ package dto
 //	code: machine-readable snake_case token (ALWAYS present)
 //	field: (optional) field name related to the error (e.g., "email")
 //	error: (optional) internal/debug detail (only in non-production or when safe)
+//	details: (optional) structured context the client can act on (e.g., an import report)
 type ErrorResponse struct {
 	Message string `json:"message"`
 	Code    string `json:"code"`
 	Field   string `json:"field,omitempty"`
 	Error   string `json:"error,omitempty"`
+	Details any    `json:"details,omitempty"`
 }
 
 type SuccessResponse struct {
@@ -7,31 +7,42 @@ import (
 )
 
 type RestaurantResponse struct {
-	ID                 string          `json:"id"`
-	Slug               string          `json:"slug"`
-	Name               string          `json:"name"`
-	ManagerID          string          `json:"manager_id"`
-	Phone              string          `json:"phone"`
-	PreviousSlugs      []string        `json:"previous_slugs,omitempty"`
-	Tags               []string        `json:"tags,omitempty"`
-	About              *string         `json:"about,omitempty"`
-	LogoImage          *string         `json:"logo_image,omitempty"`
-	VerificationStatus string          `json:"verification_status"`
-	VerificationDocs   *string         `json:"verification_docs,omitempty"`
-	DefaultCurrency    string          `json:"default_currency,omitempty"`
-	DefaultLanguage    string          `json:"default_language,omitempty"`
-	DefaultVat         float64         `json:"default_vat,omitempty"`
-	TaxId              string          `json:"tax_id,omitempty"`
-	PrimaryColor       string          `json:"primary_color,omitempty"`
-	AccentColor        string          `json:"accent_color,omitempty"`
-	Schedule           []ScheduleDTO   `json:"schedule,omitempty"`
-	SpecialDays        []SpecialDayDTO `json:"special_days,omitempty"`
-	Location           *LocationDTO    `json:"location,omitempty"`
-	CoverImage         *string         `json:"cover_image,omitempty"`
-	AverageRating      float64         `json:"average_rating"`
-	ViewCount          int64           `json:"view_count"`
-	CreatedAt          time.Time       `json:"created_at"`
-	UpdatedAt          time.Time       `json:"updated_at"`
+	ID                 string                              `json:"id"`
+	Slug               string                              `json:"slug"`
+	Name               string                              `json:"name"`
+	ManagerID          string                              `json:"manager_id"`
+	Phone              string                              `json:"phone"`
+	PreviousSlugs      []string                            `json:"previous_slugs,omitempty"`
+	Tags               []string                            `json:"tags,omitempty"`
+	About              *string                             `json:"about,omitempty"`
+	LogoImage          *string                             `json:"logo_image,omitempty"`
+	VerificationStatus string                              `json:"verification_status"`
+	VerificationDocs   *string                             `json:"verification_docs,omitempty"`
+	DefaultCurrency    string                              `json:"default_currency,omitempty"`
+	DefaultLanguage    string                              `json:"default_language,omitempty"`
+	Translations       map[string]RestaurantTranslationDTO `json:"translations,omitempty"`
+	Glossary           []string                            `json:"glossary,omitempty"`
+	// Language is set on public responses, whose name and about are in that language
+	Language      string          `json:"language,omitempty"`
+	Timezone      string          `json:"timezone,omitempty"`
+	DefaultVat    float64         `json:"default_vat,omitempty"`
+	TaxId         string          `json:"tax_id,omitempty"`
+	PrimaryColor  string          `json:"primary_color,omitempty"`
+	AccentColor   string          `json:"accent_color,omitempty"`
+	Schedule      []ScheduleDTO   `json:"schedule,omitempty"`
+	SpecialDays   []SpecialDayDTO `json:"special_days,omitempty"`
+	Location      *LocationDTO    `json:"location,omitempty"`
+	CoverImage    *string         `json:"cover_image,omitempty"`
+	AverageRating float64         `json:"average_rating"`
+	ViewCount     int64           `json:"view_count"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// RestaurantTranslationDTO mirrors domain.RestaurantTranslation for transport
+type RestaurantTranslationDTO struct {
+	Name  string `json:"name,omitempty"`
+	About string `json:"about,omitempty"`
 }
 
 type ScheduleDTO struct {
@@ -96,6 +107,9 @@ func ToRestaurantResponse(r *domain.Restaurant) *RestaurantResponse {
 		SpecialDays:        specialDTO,
 		DefaultCurrency:    r.DefaultCurrency,
 		DefaultLanguage:    r.DefaultLanguage,
+		Translations:       RestaurantTranslationsToDTO(r.Translations),
+		Glossary:           r.Glossary,
+		Timezone:           r.Timezone,
 		DefaultVat:         r.DefaultVat,
 		TaxId:              r.TaxId,
 		PrimaryColor:       r.PrimaryColor,
@@ -109,6 +123,51 @@ func ToRestaurantResponse(r *domain.Restaurant) *RestaurantResponse {
 	}
 }
 
+// LocalizedRestaurantResponse is ToRestaurantResponse with the name and about
+// text in lang, falling back to the base text.
+func LocalizedRestaurantResponse(r *domain.Restaurant, lang string) *RestaurantResponse {
+	res := ToRestaurantResponse(r)
+	if res == nil {
+		return nil
+	}
+	t := r.Localized(lang)
+	res.Name = t.Name
+	if t.About != "" {
+		res.About = &t.About
+	}
+	res.Language = lang
+	return res
+}
+
+// RestaurantTranslationsFromDTO converts request translations, normalizing the
+// language keys and dropping unsupported or empty entries.
+func RestaurantTranslationsFromDTO(in map[string]RestaurantTranslationDTO) map[string]domain.RestaurantTranslation {
+	var out map[string]domain.RestaurantTranslation
+	for key, t := range in {
+		lang := domain.NormalizeLanguage(key)
+		if lang == "" || (t.Name == "" && t.About == "") {
+			continue
+		}
+		if out == nil {
+			out = map[string]domain.RestaurantTranslation{}
+		}
+		out[lang] = domain.RestaurantTranslation(t)
+	}
+	return out
+}
+
+// RestaurantTranslationsToDTO converts domain translations for responses.
+func RestaurantTranslationsToDTO(in map[string]domain.RestaurantTranslation) map[string]RestaurantTranslationDTO {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]RestaurantTranslationDTO, len(in))
+	for lang, t := range in {
+		out[lang] = RestaurantTranslationDTO(t)
+	}
+	return out
+}
+
 func ToDomainSchedule(s []ScheduleDTO) []domain.Schedule {
 	var schedule []domain.Schedule
 	for _, sd := range s {
@@ -175,6 +234,9 @@ func ToDomainRestaurant(r *RestaurantResponse) *domain.Restaurant {
 		SpecialDays:        specialDay,
 		DefaultCurrency:    r.DefaultCurrency,
 		DefaultLanguage:    r.DefaultLanguage,
+		Translations:       RestaurantTranslationsFromDTO(r.Translations),
+		Glossary:           r.Glossary,
+		Timezone:           r.Timezone,
 		DefaultVat:         r.DefaultVat,
 		TaxId:              r.TaxId,
 		PrimaryColor:       r.PrimaryColor,
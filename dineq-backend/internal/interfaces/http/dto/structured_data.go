@@ -0,0 +1,334 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+const schemaOrg = "https://schema.org"
+
+// JSONLDRestaurant is a schema.org Restaurant. HasMenu is either the URL of
+// the restaurant's menus or an embedded JSONLDMenu.
+type JSONLDRestaurant struct {
+	Context            string                 `json:"@context"`
+	Type               string                 `json:"@type"`
+	ID                 string                 `json:"@id,omitempty"`
+	Name               string                 `json:"name"`
+	URL                string                 `json:"url,omitempty"`
+	Description        string                 `json:"description,omitempty"`
+	Logo               string                 `json:"logo,omitempty"`
+	Image              string                 `json:"image,omitempty"`
+	Telephone          string                 `json:"telephone,omitempty"`
+	ServesCuisine      []string               `json:"servesCuisine,omitempty"`
+	CurrenciesAccepted string                 `json:"currenciesAccepted,omitempty"`
+	Geo                *JSONLDGeo             `json:"geo,omitempty"`
+	OpeningHours       []JSONLDOpeningHours   `json:"openingHoursSpecification,omitempty"`
+	AggregateRating    *JSONLDAggregateRating `json:"aggregateRating,omitempty"`
+	HasMenu            any                    `json:"hasMenu,omitempty"`
+}
+
+type JSONLDGeo struct {
+	Type      string  `json:"@type"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// JSONLDOpeningHours is an OpeningHoursSpecification. Special days carry
+// ValidFrom/ValidThrough; a closed day opens and closes at 00:00.
+type JSONLDOpeningHours struct {
+	Type         string `json:"@type"`
+	DayOfWeek    string `json:"dayOfWeek,omitempty"`
+	Opens        string `json:"opens"`
+	Closes       string `json:"closes"`
+	ValidFrom    string `json:"validFrom,omitempty"`
+	ValidThrough string `json:"validThrough,omitempty"`
+}
+
+type JSONLDAggregateRating struct {
+	Type        string  `json:"@type"`
+	RatingValue float64 `json:"ratingValue"`
+	BestRating  int     `json:"bestRating"`
+	WorstRating int     `json:"worstRating"`
+}
+
+type JSONLDMenu struct {
+	Type       string              `json:"@type"`
+	Name       string              `json:"name"`
+	URL        string              `json:"url,omitempty"`
+	InLanguage []string            `json:"inLanguage,omitempty"`
+	Sections   []JSONLDMenuSection `json:"hasMenuSection,omitempty"`
+}
+
+type JSONLDMenuSection struct {
+	Type          string              `json:"@type"`
+	Name          string              `json:"name"`
+	AlternateName string              `json:"alternateName,omitempty"`
+	Sections      []JSONLDMenuSection `json:"hasMenuSection,omitempty"`
+	Items         []JSONLDMenuItem    `json:"hasMenuItem,omitempty"`
+}
+
+type JSONLDMenuItem struct {
+	Type          string           `json:"@type"`
+	Name          string           `json:"name"`
+	AlternateName string           `json:"alternateName,omitempty"`
+	Description   string           `json:"description,omitempty"`
+	Image         string           `json:"image,omitempty"`
+	Nutrition     *JSONLDNutrition `json:"nutrition,omitempty"`
+	Offers        JSONLDOffer      `json:"offers"`
+}
+
+type JSONLDNutrition struct {
+	Type     string `json:"@type"`
+	Calories string `json:"calories"`
+}
+
+// JSONLDOffer is an Offer, or an AggregateOffer when modifiers make the price
+// a range.
+type JSONLDOffer struct {
+	Type          string   `json:"@type"`
+	Price         *float64 `json:"price,omitempty"`
+	LowPrice      *float64 `json:"lowPrice,omitempty"`
+	HighPrice     *float64 `json:"highPrice,omitempty"`
+	PriceCurrency string   `json:"priceCurrency"`
+	Availability  string   `json:"availability"`
+}
+
+// OpenGraph holds the og: tags for link previews.
+type OpenGraph struct {
+	Type        string
+	Title       string
+	Description string
+	URL         string
+	Image       string
+	SiteName    string
+	Locale      string
+}
+
+// RestaurantOpenGraph fills the link preview of a restaurant page or of one of
+// its menus, using the cover image (or logo) and the about text in lang.
+func RestaurantOpenGraph(r *domain.Restaurant, ogType, title, pageURL, lang string) OpenGraph {
+	og := OpenGraph{Type: ogType, Title: title, URL: pageURL, SiteName: "DineQ", Locale: lang}
+	og.Description = r.Localized(lang).About
+	if r.CoverImage != nil && *r.CoverImage != "" {
+		og.Image = *r.CoverImage
+	} else if r.LogoImage != nil {
+		og.Image = *r.LogoImage
+	}
+	return og
+}
+
+var schemaDays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// RestaurantToJSONLD builds the schema.org description of a restaurant in
+// lang. pageURL is its public page and menusURL where its menus are listed.
+func RestaurantToJSONLD(r *domain.Restaurant, pageURL, menusURL, lang string) *JSONLDRestaurant {
+	text := r.Localized(lang)
+	ld := &JSONLDRestaurant{
+		Context:            schemaOrg,
+		Type:               "Restaurant",
+		ID:                 pageURL,
+		Name:               text.Name,
+		Description:        text.About,
+		URL:                pageURL,
+		Telephone:          r.RestaurantPhone,
+		ServesCuisine:      r.Tags,
+		CurrenciesAccepted: r.DefaultCurrency,
+	}
+	if menusURL != "" {
+		ld.HasMenu = menusURL
+	}
+	if r.LogoImage != nil {
+		ld.Logo = *r.LogoImage
+	}
+	if r.CoverImage != nil && *r.CoverImage != "" {
+		ld.Image = *r.CoverImage
+	} else {
+		ld.Image = ld.Logo
+	}
+	if r.Location != nil && (r.Location.Coordinates[0] != 0 || r.Location.Coordinates[1] != 0) {
+		ld.Geo = &JSONLDGeo{Type: "GeoCoordinates", Longitude: r.Location.Coordinates[0], Latitude: r.Location.Coordinates[1]}
+	}
+	ld.OpeningHours = openingHoursToJSONLD(r.Schedule, r.SpecialDays)
+	if r.AverageRating > 0 {
+		ld.AggregateRating = &JSONLDAggregateRating{Type: "AggregateRating", RatingValue: r.AverageRating, BestRating: 5, WorstRating: 1}
+	}
+	return ld
+}
+
+// MenuToJSONLD builds a schema.org Menu from a customer-facing menu in lang.
+// Tabs become sections and named categories become nested sections.
+func MenuToJSONLD(m *domain.Menu, r *domain.Restaurant, menuURL, lang string, now time.Time) *JSONLDMenu {
+	currency := r.DefaultCurrency
+	if currency == "" {
+		currency = "ETB"
+	}
+	ld := &JSONLDMenu{Type: "Menu", Name: m.Name, URL: menuURL}
+	languages := map[string]bool{domain.LangEnglish: true}
+	for _, tab := range m.Sections() {
+		name, alt := localizedNames(tab.Name, tab.Translations, lang)
+		section := JSONLDMenuSection{Type: "MenuSection", Name: name, AlternateName: alt}
+		for _, cat := range tab.Categories {
+			items := make([]JSONLDMenuItem, 0, len(cat.Items))
+			for i := range cat.Items {
+				it := &cat.Items[i]
+				for l, t := range it.Translations {
+					languages[l] = languages[l] || t.Name != ""
+				}
+				items = append(items, menuItemToJSONLD(it, currency, lang, now))
+			}
+			if cat.Name == "" {
+				section.Items = append(section.Items, items...)
+				continue
+			}
+			name, alt := localizedNames(cat.Name, cat.Translations, lang)
+			section.Sections = append(section.Sections, JSONLDMenuSection{Type: "MenuSection", Name: name, AlternateName: alt, Items: items})
+		}
+		ld.Sections = append(ld.Sections, section)
+	}
+	for _, l := range domain.SupportedLanguages {
+		if languages[l] {
+			ld.InLanguage = append(ld.InLanguage, l)
+		}
+	}
+	return ld
+}
+
+// localizedNames returns the name in lang and, as alternate name, the base
+// name when they differ or else the Amharic one.
+func localizedNames(base string, t domain.Translations, lang string) (name, alternate string) {
+	name = t.In(lang, base)
+	if name != base {
+		return name, base
+	}
+	if am := t[domain.LangAmharic]; am != name {
+		return name, am
+	}
+	return name, ""
+}
+
+func menuItemToJSONLD(it *domain.Item, currency, lang string, now time.Time) JSONLDMenuItem {
+	if it.Currency != "" {
+		currency = it.Currency
+	}
+	availability := schemaOrg + "/InStock"
+	if !it.IsAvailableAt(now) {
+		availability = schemaOrg + "/OutOfStock"
+	}
+	offer := JSONLDOffer{Type: "Offer", PriceCurrency: currency, Availability: availability}
+	low, high := it.PriceRange()
+	if high > low {
+		offer.Type = "AggregateOffer"
+		offer.LowPrice, offer.HighPrice = &low, &high
+	} else {
+		offer.Price = &low
+	}
+	text := it.Localized(lang)
+	nameTranslations := domain.Translations{}
+	for l, t := range it.Translations {
+		nameTranslations[l] = t.Name
+	}
+	name, alt := localizedNames(it.Name, nameTranslations, lang)
+	out := JSONLDMenuItem{
+		Type:          "MenuItem",
+		Name:          name,
+		AlternateName: alt,
+		Description:   text.Description,
+		Offers:        offer,
+	}
+	if out.Name == "" {
+		out.Name, out.AlternateName = it.DisplayName(), ""
+	}
+	if len(it.Image) > 0 {
+		out.Image = it.Image[0]
+	}
+	calories := it.Calories
+	if calories == 0 && it.NutritionalInfo != nil {
+		calories = it.NutritionalInfo.Calories
+	}
+	if calories > 0 {
+		out.Nutrition = &JSONLDNutrition{Type: "NutritionInformation", Calories: fmt.Sprintf("%d calories", calories)}
+	}
+	return out
+}
+
+func openingHoursToJSONLD(schedule []domain.Schedule, special []domain.SpecialDay) []JSONLDOpeningHours {
+	var out []JSONLDOpeningHours
+	for _, s := range schedule {
+		day := schemaDay(s.Day)
+		if day == "" || !s.IsOpen || s.StartTime == "" || s.EndTime == "" {
+			continue
+		}
+		out = append(out, JSONLDOpeningHours{Type: "OpeningHoursSpecification", DayOfWeek: schemaOrg + "/" + day, Opens: s.StartTime, Closes: s.EndTime})
+	}
+	for _, sd := range special {
+		if sd.Date == "" {
+			continue
+		}
+		spec := JSONLDOpeningHours{Type: "OpeningHoursSpecification", Opens: "00:00", Closes: "00:00", ValidFrom: sd.Date, ValidThrough: sd.Date}
+		if sd.IsOpen {
+			if sd.StartTime == "" || sd.EndTime == "" {
+				continue
+			}
+			spec.Opens, spec.Closes = sd.StartTime, sd.EndTime
+		}
+		out = append(out, spec)
+	}
+	return out
+}
+
+// schemaDay maps stored day names ("monday", "Mon") to schema.org DayOfWeek.
+func schemaDay(day string) string {
+	day = strings.ToLower(strings.TrimSpace(day))
+	if len(day) < 3 {
+		return ""
+	}
+	for _, d := range schemaDays {
+		if strings.HasPrefix(strings.ToLower(d), day[:3]) {
+			return d
+		}
+	}
+	return ""
+}
+
+var openGraphPage = template.Must(template.New("og").Parse(`<!DOCTYPE html>
+<html lang="{{or .OG.Locale "en"}}">
+<head>
+<meta charset="utf-8">
+<title>{{.OG.Title}}</title>
+<meta name="description" content="{{.OG.Description}}">
+<link rel="canonical" href="{{.OG.URL}}">
+<meta property="og:type" content="{{.OG.Type}}">
+<meta property="og:title" content="{{.OG.Title}}">
+<meta property="og:description" content="{{.OG.Description}}">
+<meta property="og:url" content="{{.OG.URL}}">
+{{if .OG.Image}}<meta property="og:image" content="{{.OG.Image}}">
+{{end}}<meta property="og:site_name" content="{{.OG.SiteName}}">
+{{if .OG.Locale}}<meta property="og:locale" content="{{.OG.Locale}}">
+{{end}}<meta name="twitter:card" content="summary_large_image">
+<script type="application/ld+json">{{.JSONLD}}</script>
+</head>
+<body><a href="{{.OG.URL}}">{{.OG.Title}}</a></body>
+</html>
+`))
+
+// RenderOpenGraphHTML renders a minimal HTML head with Open Graph tags and the
+// JSON-LD document, for crawlers that do not run the frontend.
+func RenderOpenGraphHTML(og OpenGraph, jsonLD any) ([]byte, error) {
+	raw, err := json.Marshal(jsonLD)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	// json.Marshal escapes <, > and &, so menu text cannot close the script block
+	err = openGraphPage.Execute(&buf, struct {
+		OG     OpenGraph
+		JSONLD template.JS
+	}{og, template.JS(raw)})
+	return buf.Bytes(), err
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+// WebhookRequest creates a webhook. Active defaults to true.
+type WebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1"`
+	Active *bool    `json:"active,omitempty"`
+}
+
+// WebhookUpdateRequest changes the fields it sets and keeps the others.
+type WebhookUpdateRequest struct {
+	URL    *string  `json:"url,omitempty" validate:"omitempty,url"`
+	Events []string `json:"events,omitempty" validate:"omitempty,min=1"`
+	Active *bool    `json:"active,omitempty"`
+}
+
+// WebhookEvents converts requested event names; unknown names are left for
+// the use case to reject.
+func WebhookEvents(names []string) []domain.WebhookEvent {
+	events := make([]domain.WebhookEvent, len(names))
+	for i, n := range names {
+		events[i] = domain.WebhookEvent(n)
+	}
+	return events
+}
+
+// WebhookDeliverySummary is a delivery in the log without its payload.
+type WebhookDeliverySummary struct {
+	ID            string                       `json:"id"`
+	Event         domain.WebhookEvent          `json:"event"`
+	EventID       string                       `json:"event_id"`
+	Status        domain.WebhookDeliveryStatus `json:"status"`
+	Attempts      int                          `json:"attempts"`
+	LastAttempt   *domain.WebhookAttempt       `json:"last_attempt,omitempty"`
+	NextAttemptAt *time.Time                   `json:"next_attempt_at,omitempty"`
+	DeliveredAt   *time.Time                   `json:"delivered_at,omitempty"`
+	RedeliveryOf  string                       `json:"redelivery_of,omitempty"`
+	CreatedAt     time.Time                    `json:"created_at"`
+}
+
+func WebhookDeliverySummaries(deliveries []*domain.WebhookDelivery) []WebhookDeliverySummary {
+	out := make([]WebhookDeliverySummary, len(deliveries))
+	for i, d := range deliveries {
+		s := WebhookDeliverySummary{
+			ID: d.ID, Event: d.Event, EventID: d.EventID, Status: d.Status, Attempts: len(d.Attempts),
+			DeliveredAt: d.DeliveredAt, RedeliveryOf: d.RedeliveryOf, CreatedAt: d.CreatedAt,
+		}
+		if n := len(d.Attempts); n > 0 {
+			s.LastAttempt = &d.Attempts[n-1]
+		}
+		if d.Status == domain.WebhookPending {
+			s.NextAttemptAt = d.NextAttemptAt
+		}
+		out[i] = s
+	}
+	return out
+}
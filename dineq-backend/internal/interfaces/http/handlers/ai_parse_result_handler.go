@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// AIParseResultHandler serves the review of OCR parse results: managers go
+// through the items the model read, then approve them into a draft menu.
+type AIParseResultHandler struct {
+	UseCase           domain.IAIParseResultUseCase
+	RestaurantUseCase domain.IRestaurantUsecase
+}
+
+func NewAIParseResultHandler(uc domain.IAIParseResultUseCase, rc domain.IRestaurantUsecase) *AIParseResultHandler {
+	return &AIParseResultHandler{UseCase: uc, RestaurantUseCase: rc}
+}
+
+// loadForReview fetches the parse result when the caller uploaded the menu or
+// is an owner or admin.
+func (h *AIParseResultHandler) loadForReview(c *gin.Context) (*domain.AIParseResult, bool) {
+	result, err := h.UseCase.GetAIParseResultByID(c.Param("id"))
+	if err != nil {
+		dto.WriteError(c, err)
+		return nil, false
+	}
+	role := c.GetString("role")
+	if result.UserID != c.GetString("user_id") && role != string(domain.RoleOwner) && role != string(domain.RoleAdmin) {
+		dto.WriteError(c, domain.ErrForbidden)
+		return nil, false
+	}
+	return result, true
+}
+
+// GetParseResult returns the items awaiting review with their confidence.
+func (h *AIParseResultHandler) GetParseResult(c *gin.Context) {
+	result, ok := h.loadForReview(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgRetrieved, Data: dto.AIParseResultToResponse(result)})
+}
+
+// ReviewItem accepts, edits or rejects one parsed item.
+func (h *AIParseResultHandler) ReviewItem(c *gin.Context) {
+	if _, ok := h.loadForReview(c); !ok {
+		return
+	}
+	var req dto.ParsedItemReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	if req.Item != nil && req.Status == string(domain.ParsedItemAccepted) {
+		if err := validate.Struct(req.Item); err != nil {
+			dto.WriteValidationError(c, "item", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+			return
+		}
+	}
+	result, err := h.UseCase.ReviewItem(c.Param("id"), c.Param("item_id"), c.GetString("user_id"), req.ToDomain())
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgUpdated, Data: dto.AIParseResultToResponse(result)})
+}
+
+// PreviewMerge lists what approving a result uploaded in merge mode would add,
+// remove and reprice on its target menu. The caller must still manage the
+// restaurant of that menu.
+func (h *AIParseResultHandler) PreviewMerge(c *gin.Context) {
+	result, ok := h.loadForReview(c)
+	if !ok {
+		return
+	}
+	if !managesRestaurant(c, h.RestaurantUseCase, result.RestaurantID) {
+		dto.WriteError(c, domain.ErrForbidden)
+		return
+	}
+	changes, err := h.UseCase.PreviewMerge(c.Param("id"))
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgRetrieved, Data: changes})
+}
+
+// ApproveParseResult creates a draft menu for the given restaurant from the
+// accepted and edited items, or merges them into the target menu of a merge
+// mode upload. Every item must have been reviewed.
+func (h *AIParseResultHandler) ApproveParseResult(c *gin.Context) {
+	if _, ok := h.loadForReview(c); !ok {
+		return
+	}
+	var req dto.ParseResultApproveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		dto.WriteValidationError(c, "restaurant_slug", "restaurant_slug is required", "invalid_request", err)
+		return
+	}
+	userID := c.GetString("user_id")
+	rest, err := h.RestaurantUseCase.GetRestaurantBySlug(c.Request.Context(), req.RestaurantSlug)
+	if err != nil || rest == nil {
+		dto.WriteError(c, domain.ErrRestaurantNotFound)
+		return
+	}
+	if rest.ManagerID != userID && c.GetString("role") != string(domain.RoleOwner) {
+		dto.WriteError(c, domain.ErrForbidden)
+		return
+	}
+
+	menu := &domain.Menu{
+		Name:           req.Name,
+		RestaurantID:   rest.ID,
+		RestaurantSlug: rest.Slug,
+		CreatedBy:      userID,
+		UpdatedBy:      userID,
+	}
+	changes, err := h.UseCase.ApproveParseResult(c.Param("id"), menu, req.KeepRemoved)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	if changes != nil {
+		c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgUpdated, Data: gin.H{"menu": dto.MenuToResponse(menu), "changes": changes}})
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse{Message: domain.MsgCreated, Data: gin.H{"menu": dto.MenuToResponse(menu)}})
+}
+
+// DeleteParseResult discards a parse result; an approved menu is kept.
+func (h *AIParseResultHandler) DeleteParseResult(c *gin.Context) {
+	if _, ok := h.loadForReview(c); !ok {
+		return
+	}
+	if err := h.UseCase.DeleteAIParseResult(c.Param("id")); err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgDeleted})
+}
@@ -2,11 +2,13 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
 	"time"
 
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
 	applog "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
 	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
 	"github.com/gin-gonic/gin"
@@ -37,10 +39,42 @@ type ImageSearchHandler struct {
 	pexels   *services.PexelsSearchService
 	clf      services.EthiopianFoodClassifier
 	ai       services.IAIService
+	// usage counts searches against monthly quotas; nil disables them
+	usage domain.IUsageUseCase
+	// restaurants finds the restaurant a search is charged to
+	restaurants domain.IRestaurantUsecase
 }
 
-func NewImageSearchHandler(google services.IGoogleCustomSearchService, unsplash *services.UnsplashSearchService, pexels *services.PexelsSearchService, clf services.EthiopianFoodClassifier, ai services.IAIService) *ImageSearchHandler {
-	return &ImageSearchHandler{google: google, unsplash: unsplash, pexels: pexels, clf: clf, ai: ai}
+func NewImageSearchHandler(google services.IGoogleCustomSearchService, unsplash *services.UnsplashSearchService, pexels *services.PexelsSearchService, clf services.EthiopianFoodClassifier, ai services.IAIService, usage domain.IUsageUseCase, restaurants domain.IRestaurantUsecase) *ImageSearchHandler {
+	return &ImageSearchHandler{google: google, unsplash: unsplash, pexels: pexels, clf: clf, ai: ai, usage: usage, restaurants: restaurants}
+}
+
+// allowSearch checks the image search quotas of the caller and of the
+// restaurant they manage, answering 429 when one is used up. Callers without a
+// restaurant are refused. It returns the restaurant the search is charged to.
+func (h *ImageSearchHandler) allowSearch(c *gin.Context) (string, bool) {
+	rest, ok := managedRestaurant(c, h.restaurants)
+	if !ok {
+		return "", false
+	}
+	if h.usage == nil {
+		return rest.ID, true
+	}
+	err := h.usage.CheckQuota(rest.ID, c.GetString("user_id"), domain.UsageImageSearches, 1)
+	if errors.Is(err, domain.ErrUsageQuotaExceeded) {
+		writeQuotaExceeded(c, err)
+		return "", false
+	}
+	if err != nil {
+		applog.Log.Warn().Err(err).Msg("image search quota check failed")
+	}
+	return rest.ID, true
+}
+
+func (h *ImageSearchHandler) recordSearch(c *gin.Context, restaurantID string) {
+	if h.usage != nil {
+		h.usage.Record(domain.UsageEntry{RestaurantID: restaurantID, UserID: c.GetString("user_id"), Kind: domain.UsageImageSearches, Quantity: 1, Provider: "google"})
+	}
 }
 
 // performSearch encapsulates the core image search aggregation logic.
@@ -213,8 +247,13 @@ func (h *ImageSearchHandler) Search(c *gin.Context) {
 	restaurant := c.Query("restaurant")
 	// optional limit param is ignored in favor of fixed per-source count (backwards compatibility parsing retained)
 	_ = c.Query("limit")
+	restaurantID, ok := h.allowSearch(c)
+	if !ok {
+		return
+	}
 
 	combined, diag := h.performSearchWithDiagnostics(c.Request.Context(), item, restaurant)
+	h.recordSearch(c, restaurantID)
 	resp := gin.H{"success": true, "data": gin.H{"item": item, "restaurant": restaurant, "count": len(combined), "results": combined}}
 	if len(combined) == 0 {
 		resp["diagnostics"] = diag
@@ -238,7 +277,12 @@ func (h *ImageSearchHandler) SearchPost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "item field required"})
 		return
 	}
+	restaurantID, ok := h.allowSearch(c)
+	if !ok {
+		return
+	}
 	combined, diag := h.performSearchWithDiagnostics(c.Request.Context(), body.Item, body.Restaurant)
+	h.recordSearch(c, restaurantID)
 	resp := gin.H{"success": true, "data": gin.H{"item": body.Item, "restaurant": body.Restaurant, "count": len(combined), "results": combined}}
 	if len(combined) == 0 {
 		resp["diagnostics"] = diag
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -36,17 +37,17 @@ func (h *ItemHandler) CreateItem(c *gin.Context) {
 	item := dto.RequestToItem(&itemDto)
 	item.MenuSlug = c.Param("menu_slug")
 	if err := h.UseCase.CreateItem(item); err != nil {
-		dto.WriteError(c, err)
+		writeItemError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, dto.SuccessResponse{Message: domain.MsgCreated, Data: gin.H{"item": item}})
 }
 
-// GetItemByID retrieves an item by ID
+// GetItemByID retrieves an item of a published menu by ID
 func (h *ItemHandler) GetItemByID(c *gin.Context) {
 	id := c.Param("id")
-	item, err := h.UseCase.GetItemByID(id)
+	item, err := h.UseCase.GetPublishedItem(c.Param("menu_slug"), id)
 	if err != nil {
 		dto.WriteError(c, domain.ErrNotFound)
 		return
@@ -63,7 +64,7 @@ func (h *ItemHandler) GetItemByID(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgRetrieved, Data: gin.H{"item": item}})
 }
 
-// GetItems retrieves all items
+// GetItems retrieves all items of a published menu
 func (h *ItemHandler) GetItems(c *gin.Context) {
 	menuSlug := c.Param("menu_slug")
 
@@ -141,6 +142,16 @@ func (h *ItemHandler) SearchItems(c *gin.Context) {
 	})
 }
 
+// writeItemError writes err, keeping the detail of a modifier group
+// validation failure so clients can point at the offending group
+func writeItemError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrInvalidModifierGroups) {
+		dto.WriteValidationError(c, "modifier_groups", err.Error(), "invalid_modifier_groups", nil)
+		return
+	}
+	dto.WriteError(c, err)
+}
+
 // Helper to extract user ID from context (if available)
 func getUserID(c *gin.Context) string {
 	if uid, ok := c.Get("user_id"); ok {
@@ -166,7 +177,7 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 	// }
 	item := dto.RequestToItem(&itemDto)
 	if err := h.UseCase.UpdateItem(id, item); err != nil {
-		dto.WriteError(c, err)
+		writeItemError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgUpdated, Data: gin.H{"item": item}})
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// responseLanguage negotiates the language of a public response from ?lang=
+// and Accept-Language, falling back to the restaurant's default language, and
+// announces it in Content-Language.
+func responseLanguage(c *gin.Context, rest *domain.Restaurant) string {
+	fallback := ""
+	if rest != nil {
+		fallback = rest.DefaultLanguage
+	}
+	lang := domain.NegotiateLanguage(c.Query("lang"), c.GetHeader("Accept-Language"), fallback)
+	c.Header("Content-Language", lang)
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	return lang
+}
+
+// parseRestaurantTranslations reads the translations form field, a JSON object
+// such as {"am": {"name": "...", "about": "..."}} keyed by language code.
+func parseRestaurantTranslations(raw string) (map[string]domain.RestaurantTranslation, error) {
+	var in map[string]dto.RestaurantTranslationDTO
+	if err := json.Unmarshal([]byte(raw), &in); err != nil {
+		return nil, err
+	}
+	for key := range in {
+		if domain.NormalizeLanguage(key) == "" {
+			return nil, fmt.Errorf("unsupported language %q", key)
+		}
+	}
+	return dto.RestaurantTranslationsFromDTO(in), nil
+}
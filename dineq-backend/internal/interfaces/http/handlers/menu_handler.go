@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
 	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
 	"github.com/gin-gonic/gin"
 )
@@ -25,17 +26,24 @@ func NewMenuHandler(uc domain.IMenuUseCase, qc domain.IQRCodeUseCase, rc domain.
 }
 
 func (h *MenuHandler) ensureOwnership(c *gin.Context, slug string, userID string) bool {
+	_, ok := h.ownedRestaurant(c, slug, userID)
+	return ok
+}
+
+// ownedRestaurant loads the restaurant of the route and checks that the caller
+// manages or owns it. On failure the error response is already written.
+func (h *MenuHandler) ownedRestaurant(c *gin.Context, slug string, userID string) (*domain.Restaurant, bool) {
 	rest, err := h.RestaurantUseCase.GetRestaurantBySlug(c.Request.Context(), slug)
 	if err != nil || rest == nil {
 		dto.WriteError(c, domain.ErrRestaurantNotFound)
-		return false
+		return nil, false
 	}
 	role := c.GetString("role")
 	if rest.ManagerID != userID && role != string(domain.RoleOwner) {
 		dto.WriteError(c, domain.ErrForbidden)
-		return false
+		return nil, false
 	}
-	return true
+	return rest, true
 }
 
 // CreateMenu handles the creation of a new menu
@@ -109,7 +117,7 @@ func (h *MenuHandler) CreateMenu(c *gin.Context) {
 	menu.CreatedBy = userId
 	menu.UpdatedBy = userId // attribute creator
 	if err := h.UseCase.CreateMenu(menu); err != nil {
-		dto.WriteError(c, err)
+		writeItemError(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, dto.SuccessResponse{Message: domain.MsgCreated, Data: gin.H{"menu": dto.MenuToResponse(menu)}})
@@ -188,7 +196,7 @@ func (h *MenuHandler) UpdateMenu(c *gin.Context) {
 	}
 	menu := dto.RequestToMenu(&menuDto)
 	if err := h.UseCase.UpdateMenu(menuID, userId, menu); err != nil {
-		dto.WriteError(c, err)
+		writeItemError(c, err)
 		return
 	}
 	// Reload full menu to include DB-populated fields (id, slug, version, etc.)
@@ -223,6 +231,25 @@ func (h *MenuHandler) PublishMenu(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"menu": dto.MenuToResponse(updated)}})
 }
 
+// PreviewMenu renders the current draft through the public menu response so
+// managers can check it before publishing
+func (h *MenuHandler) PreviewMenu(c *gin.Context) {
+	slug := c.Param("restaurant_slug")
+	userID := c.GetString("user_id")
+	menuID := c.Param("id")
+	rest, ok := h.ownedRestaurant(c, slug, userID)
+	if !ok {
+		return
+	}
+
+	preview, err := h.UseCase.PreviewDraft(menuID, rest)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"menu": dto.MenuToResponse(preview)}})
+}
+
 // GenerateQRCode generates a QR code for a menu
 func (h *MenuHandler) GenerateQRCode(c *gin.Context) {
 	restaurantID := c.Param("restaurant_slug")
@@ -341,7 +368,8 @@ func (h *MenuHandler) GetMenuItemBySlug(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"item": dto.ItemToResponse(item)}})
 }
 
-// PublicGetPublishedMenus lists published menus for a restaurant (by slug) without auth.
+// PublicGetPublishedMenus lists the published menus of a restaurant (by slug) that are
+// active right now, without auth. ?at= evaluates schedules at another time for previews.
 func (h *MenuHandler) PublicGetPublishedMenus(c *gin.Context) {
 	restSlug := c.Param("restaurant_slug")
 	rest, err := h.RestaurantUseCase.GetRestaurantBySlug(c.Request.Context(), restSlug)
@@ -349,31 +377,43 @@ func (h *MenuHandler) PublicGetPublishedMenus(c *gin.Context) {
 		dto.WriteError(c, domain.ErrRestaurantNotFound)
 		return
 	}
+	loc := rest.TimeLocation()
+	at, err := parseScheduleTime(c.Query("at"), loc)
+	if err != nil {
+		dto.WriteValidationError(c, "at", "at must be RFC3339 or YYYY-MM-DDTHH:MM in the restaurant timezone", "invalid_time", err)
+		return
+	}
 	menus, err := h.UseCase.GetByRestaurantID(restSlug)
 	if err != nil || len(menus) == 0 {
 		dto.WriteError(c, domain.ErrNotFound)
 		return
 	}
-	// filter only published
+	// filter only published and currently active, serving the published copy rather than the draft
 	var published []*domain.Menu
 	for _, m := range menus {
-		if m.IsPublished && !m.IsDeleted {
-			published = append(published, m)
+		if view := m.PublicView(); view != nil && view.IsActiveAt(at, loc) {
+			published = append(published, view)
 		}
 	}
 	if len(published) == 0 {
 		dto.WriteError(c, domain.ErrNotFound)
 		return
 	}
-	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"menus": dto.MenuResponseList(published)}})
+	lang := responseLanguage(c, rest)
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"menus": dto.LocalizedMenuResponseList(published, lang)}})
 }
 
 // PublicGetPublishedMenuByID returns a single published menu & increments view count.
 func (h *MenuHandler) PublicGetPublishedMenuByID(c *gin.Context) {
 	restSlug := c.Param("restaurant_slug")
 	menuID := c.Param("id")
-	menu, err := h.UseCase.GetByID(menuID)
-	if err != nil || menu == nil || menu.IsDeleted || !menu.IsPublished {
+	draft, err := h.UseCase.GetByID(menuID)
+	if err != nil || draft == nil {
+		dto.WriteError(c, domain.ErrNotFound)
+		return
+	}
+	menu := draft.PublicView()
+	if menu == nil || (menu.Schedule != nil && menu.Schedule.UnpublishAt != nil && !time.Now().Before(*menu.Schedule.UnpublishAt)) {
 		dto.WriteError(c, domain.ErrNotFound)
 		return
 	}
@@ -384,5 +424,332 @@ func (h *MenuHandler) PublicGetPublishedMenuByID(c *gin.Context) {
 		return
 	}
 	_ = h.UseCase.IncrementMenuViewCount(menuID) // best-effort
-	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"menu": dto.MenuToResponse(menu)}})
+	rest, err := h.RestaurantUseCase.GetRestaurantBySlug(c.Request.Context(), restSlug)
+	if err != nil {
+		rest = nil // the menu is still served, in the negotiated language without a restaurant default
+	}
+	lang := responseLanguage(c, rest)
+	if format := structuredFormat(c); format != "" {
+		h.writeMenuStructuredData(c, format, rest, menu, lang)
+		return
+	}
+	data := gin.H{"menu": dto.LocalizedMenuResponse(menu, lang)}
+	if qr := h.scannedQRCode(c, draft); qr != nil {
+		h.ViewEventRepo.LogView(&domain.ViewEvent{
+			EntityType: "menu",
+			EntityID:   draft.ID,
+			UserID:     getUserID(c),
+			Timestamp:  time.Now(),
+			IP:         c.ClientIP(),
+			UserAgent:  c.Request.UserAgent(),
+			QRCodeID:   qr.ID,
+			Table:      qr.Table,
+			Location:   qr.Location,
+		})
+		data["qr_code"] = dto.DomainToQRPlacementResponse(qr)
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: data})
+}
+
+// scannedQRCode resolves the ?qr= code a public menu was opened from. The
+// stored table and location are used rather than the ones in the URL, and
+// codes that are inactive, expired or for another menu are ignored.
+func (h *MenuHandler) scannedQRCode(c *gin.Context, menu *domain.Menu) *domain.QRCode {
+	id := strings.TrimSpace(c.Query("qr"))
+	if id == "" || h.QrUseCase == nil {
+		return nil
+	}
+	qr, err := h.QrUseCase.GetQRCode(id)
+	if err != nil || !qr.IsActive || qr.MenuID != menu.ID {
+		return nil
+	}
+	if !qr.ExpiresAt.IsZero() && time.Now().After(qr.ExpiresAt) {
+		return nil
+	}
+	return qr
+}
+
+// writeMenuStructuredData describes a public menu as a schema.org Restaurant
+// with the menu embedded, either as JSON-LD or as an Open Graph page.
+func (h *MenuHandler) writeMenuStructuredData(c *gin.Context, format string, rest *domain.Restaurant, menu *domain.Menu, lang string) {
+	if rest == nil {
+		dto.WriteError(c, domain.ErrRestaurantNotFound)
+		return
+	}
+	menuURL := services.PublicMenuURL(rest.Slug, menu.Slug)
+	ld := dto.RestaurantToJSONLD(rest, services.PublicRestaurantURL(rest.Slug), "", lang)
+	ld.HasMenu = dto.MenuToJSONLD(menu, rest, menuURL, lang, time.Now())
+	og := dto.RestaurantOpenGraph(rest, "website", menu.Name+" | "+rest.Localized(lang).Name, menuURL, lang)
+	writeStructuredData(c, format, og, ld)
+}
+
+// ListMenuVersions lists the stored snapshots of a menu, newest first
+func (h *MenuHandler) ListMenuVersions(c *gin.Context) {
+	slug := c.Param("restaurant_slug")
+	userID := c.GetString("user_id")
+	menuID := c.Param("id")
+	rest, ok := h.ownedRestaurant(c, slug, userID)
+	if !ok {
+		return
+	}
+
+	versions, err := h.UseCase.ListVersions(menuID, rest)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"versions": dto.MenuVersionResponseList(versions)}})
+}
+
+// GetMenuVersion returns a single menu snapshot including its full body
+func (h *MenuHandler) GetMenuVersion(c *gin.Context) {
+	slug := c.Param("restaurant_slug")
+	userID := c.GetString("user_id")
+	menuID := c.Param("id")
+	rest, ok := h.ownedRestaurant(c, slug, userID)
+	if !ok {
+		return
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version <= 0 {
+		dto.WriteValidationError(c, "version", "version must be a positive integer", "invalid_version", err)
+		return
+	}
+	v, err := h.UseCase.GetVersion(menuID, rest, version)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"version": dto.MenuVersionToResponse(v, true)}})
+}
+
+// DiffMenuVersions compares two menu versions given as ?from=&to=
+func (h *MenuHandler) DiffMenuVersions(c *gin.Context) {
+	slug := c.Param("restaurant_slug")
+	userID := c.GetString("user_id")
+	menuID := c.Param("id")
+	rest, ok := h.ownedRestaurant(c, slug, userID)
+	if !ok {
+		return
+	}
+
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil || from <= 0 {
+		dto.WriteValidationError(c, "from", "from must be a positive integer", "invalid_version", err)
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil || to <= 0 {
+		dto.WriteValidationError(c, "to", "to must be a positive integer", "invalid_version", err)
+		return
+	}
+	diff, err := h.UseCase.DiffVersions(menuID, rest, from, to)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"diff": dto.MenuDiffToResponse(diff)}})
+}
+
+// RollbackMenu restores the live menu to an earlier version
+func (h *MenuHandler) RollbackMenu(c *gin.Context) {
+	slug := c.Param("restaurant_slug")
+	userID := c.GetString("user_id")
+	menuID := c.Param("id")
+	rest, ok := h.ownedRestaurant(c, slug, userID)
+	if !ok {
+		return
+	}
+
+	var req dto.MenuRollbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		dto.WriteValidationError(c, "version", "version must be a positive integer", "invalid_version", err)
+		return
+	}
+	if err := h.UseCase.RollbackMenu(menuID, req.Version, userID, rest); err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	updated, err := h.UseCase.GetByID(menuID)
+	if err != nil {
+		c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgUpdated})
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgUpdated, Data: gin.H{"menu": dto.MenuToResponse(updated)}})
+}
+
+// UnpublishMenu takes a menu off the public endpoints
+func (h *MenuHandler) UnpublishMenu(c *gin.Context) {
+	slug := c.Param("restaurant_slug")
+	userID := c.GetString("user_id")
+	menuID := c.Param("id")
+	rest, ok := h.ownedRestaurant(c, slug, userID)
+	if !ok {
+		return
+	}
+
+	if err := h.UseCase.UnpublishMenu(menuID, userID, rest); err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	updated, err := h.UseCase.GetByID(menuID)
+	if err != nil {
+		c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess})
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"menu": dto.MenuToResponse(updated)}})
+}
+
+// SetMenuSchedule replaces the activation windows and scheduled publish/unpublish times of a menu
+func (h *MenuHandler) SetMenuSchedule(c *gin.Context) {
+	slug := c.Param("restaurant_slug")
+	userID := c.GetString("user_id")
+	menuID := c.Param("id")
+	rest, ok := h.ownedRestaurant(c, slug, userID)
+	if !ok {
+		return
+	}
+
+	var req dto.MenuScheduleDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	schedule := dto.DTOToMenuSchedule(&req)
+	if err := schedule.Validate(); err != nil {
+		dto.WriteValidationError(c, "schedule", err.Error(), "invalid_schedule", nil)
+		return
+	}
+	if err := h.UseCase.SetSchedule(menuID, rest, schedule); err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	updated, err := h.UseCase.GetByID(menuID)
+	if err != nil {
+		c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgUpdated})
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgUpdated, Data: gin.H{"menu": dto.MenuToResponse(updated)}})
+}
+
+// TranslateMenu fills the draft's missing translations by machine translation.
+// Filled fields are flagged machine_translated until a manager saves them.
+func (h *MenuHandler) TranslateMenu(c *gin.Context) {
+	slug := c.Param("restaurant_slug")
+	userID := c.GetString("user_id")
+	menuID := c.Param("id")
+	if !h.ensureOwnership(c, slug, userID) {
+		return
+	}
+	rest, err := h.RestaurantUseCase.GetRestaurantBySlug(c.Request.Context(), slug)
+	if err != nil || rest == nil {
+		dto.WriteError(c, domain.ErrRestaurantNotFound)
+		return
+	}
+
+	var req dto.MenuTranslateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+			return
+		}
+	}
+	langs := req.Languages
+	if len(langs) == 0 {
+		langs = domain.SupportedLanguages
+	}
+	report, err := h.UseCase.TranslateMenu(menuID, userID, rest, langs)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgUpdated, Data: report})
+}
+
+// SetItemAvailability 86es a menu item or brings it back. The change applies to
+// the live menu immediately, without a publish.
+func (h *MenuHandler) SetItemAvailability(c *gin.Context) {
+	slug := c.Param("restaurant_slug")
+	userID := c.GetString("user_id")
+	menuID := c.Param("id")
+	itemID := c.Param("item_id")
+	rest, ok := h.ownedRestaurant(c, slug, userID)
+	if !ok {
+		return
+	}
+
+	var req dto.ItemAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		dto.WriteValidationError(c, "available", "available is required", "invalid_input", err)
+		return
+	}
+
+	until := req.Until
+	if !*req.Available && req.RestOfDay {
+		endOfDay := endOfDayIn(time.Now(), rest.TimeLocation())
+		until = &endOfDay
+	}
+	if !*req.Available && until != nil && !until.After(time.Now()) {
+		dto.WriteValidationError(c, "until", "until must be in the future", "invalid_time", nil)
+		return
+	}
+
+	item, err := h.UseCase.SetItemAvailability(menuID, itemID, rest, *req.Available, until)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgUpdated, Data: gin.H{"item": dto.ItemToResponse(item)}})
+}
+
+// endOfDayIn returns the next local midnight after t.
+func endOfDayIn(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+}
+
+// parseScheduleTime reads the ?at= override; an empty value means now. Values
+// without an offset are read in the restaurant timezone.
+func parseScheduleTime(raw string, loc *time.Location) (time.Time, error) {
+	if raw == "" {
+		return time.Now(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02T15:04", raw, loc)
+}
+
+// ExportMenuPDF downloads the published menu as a print-ready PDF. Pass
+// ?amharic=true to add the Amharic column.
+func (h *MenuHandler) ExportMenuPDF(c *gin.Context) {
+	slug := c.Param("restaurant_slug")
+	userID := c.GetString("user_id")
+	menuID := c.Param("id")
+	if !h.ensureOwnership(c, slug, userID) {
+		return
+	}
+	rest, err := h.RestaurantUseCase.GetRestaurantBySlug(c.Request.Context(), slug)
+	if err != nil || rest == nil {
+		dto.WriteError(c, domain.ErrRestaurantNotFound)
+		return
+	}
+
+	opts := domain.MenuPDFOptions{IncludeAmharic: c.Query("amharic") == "true"}
+	pdf, err := h.UseCase.ExportMenuPDF(menuID, rest, opts)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="`+slug+`-menu.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", pdf)
 }
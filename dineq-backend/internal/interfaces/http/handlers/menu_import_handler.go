@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+const maxMenuImportSize = 10 << 20
+
+// ImportMenu creates a menu from a CSV, XLSX or JSON file. The file is sent as
+// the multipart field "file", or a JSON document is sent as the request body.
+// With ?dry_run=true only the validation report is returned.
+func (h *MenuHandler) ImportMenu(c *gin.Context) {
+	slug := c.Param("restaurant_slug")
+	userID := c.GetString("user_id")
+	if !h.ensureOwnership(c, slug, userID) {
+		return
+	}
+	rest, err := h.RestaurantUseCase.GetRestaurantBySlug(c.Request.Context(), slug)
+	if err != nil || rest == nil {
+		dto.WriteError(c, domain.ErrRestaurantNotFound)
+		return
+	}
+
+	var (
+		data     []byte
+		filename string
+		name     = c.Query("name")
+		format   = c.Query("format")
+	)
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		fh, err := c.FormFile("file")
+		if err != nil {
+			dto.WriteValidationError(c, "file", "file is required", "file_required", err)
+			return
+		}
+		if fh.Size > maxMenuImportSize {
+			dto.WriteValidationError(c, "file", "file exceeds 10MB", "file_too_large", nil)
+			return
+		}
+		f, err := fh.Open()
+		if err != nil {
+			dto.WriteValidationError(c, "file", "failed to open file", "file_open_failed", err)
+			return
+		}
+		data, err = io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			dto.WriteValidationError(c, "file", "failed to read file", "file_read_failed", err)
+			return
+		}
+		filename = fh.Filename
+		if v := c.PostForm("name"); v != "" {
+			name = v
+		}
+		if v := c.PostForm("format"); v != "" {
+			format = v
+		}
+	} else {
+		data, err = io.ReadAll(io.LimitReader(c.Request.Body, maxMenuImportSize+1))
+		if err != nil {
+			dto.WriteValidationError(c, "payload", "failed to read body", "body_read_failed", err)
+			return
+		}
+		if len(data) > maxMenuImportSize {
+			dto.WriteValidationError(c, "payload", "body exceeds 10MB", "file_too_large", nil)
+			return
+		}
+	}
+
+	kind, err := services.DetectMenuImportFormat(format, filename, c.ContentType())
+	if err != nil {
+		dto.WriteValidationError(c, "format", "format must be csv, xlsx or json", "unsupported_import_format", err)
+		return
+	}
+	imp, err := services.ParseMenuImport(kind, data)
+	if err != nil {
+		dto.WriteValidationError(c, "file", "could not read "+string(kind)+" file: "+err.Error(), "invalid_import_file", err)
+		return
+	}
+
+	menu := &domain.Menu{
+		Name:           name,
+		RestaurantID:   rest.ID,
+		RestaurantSlug: slug,
+		CreatedBy:      userID,
+		UpdatedBy:      userID,
+	}
+	dryRun := c.Query("dry_run") == "true"
+	report, err := h.UseCase.ImportMenu(menu, imp, rest.DefaultCurrency, dryRun)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidMenuImport) {
+			dto.WriteErrorWithDetails(c, err, gin.H{"report": dto.MenuImportReportToResponse(report)})
+			return
+		}
+		dto.WriteError(c, err)
+		return
+	}
+	if dryRun {
+		c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"report": dto.MenuImportReportToResponse(report)}})
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse{Message: domain.MsgCreated, Data: gin.H{"report": dto.MenuImportReportToResponse(report), "menu": dto.MenuToResponse(menu)}})
+}
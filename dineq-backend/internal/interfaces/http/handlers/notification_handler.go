@@ -3,6 +3,7 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
 	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
@@ -88,7 +89,7 @@ func (h *NotificationHandler) WSHandler(c *gin.Context) {
 	}
 	defer conn.Close()
 
-	h.NotifySvc.RegisterClient(userID, conn)
+	h.NotifySvc.RegisterClient(userID, conn, subscribedRestaurants(c)...)
 	fmt.Println("Client registered:", userID) // Debug log
 
 	go h.NotifySvc.StartPing(conn, userID) // Start ping loop
@@ -103,3 +104,17 @@ func (h *NotificationHandler) WSHandler(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "WebSocket connection established"})
 }
+
+// subscribedRestaurants reads the restaurants whose live events a WebSocket
+// client wants, given as ?restaurant=a&restaurant=b or ?restaurant=a,b.
+func subscribedRestaurants(c *gin.Context) []string {
+	var slugs []string
+	for _, value := range c.QueryArray("restaurant") {
+		for _, slug := range strings.Split(value, ",") {
+			if slug = strings.TrimSpace(slug); slug != "" {
+				slugs = append(slugs, slug)
+			}
+		}
+	}
+	return slugs
+}
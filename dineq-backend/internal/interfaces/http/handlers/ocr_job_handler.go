@@ -1,10 +1,12 @@
 package handler
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,8 +21,21 @@ import (
 type OCRJobHandler struct {
 	UseCase             domain.IOCRJobUseCase
 	MenuUseCase         domain.IMenuUseCase
+	RestaurantUseCase   domain.IRestaurantUsecase
 	StorageService      services.StorageService
 	NotificationUseCase domain.INotificationUseCase
+	// PDFRenderer splits PDF uploads into page images; nil rejects PDFs
+	PDFRenderer services.IPDFPageRenderer
+	// MaxPages bounds the pages of one upload, images and PDF pages together
+	MaxPages int
+	// StreamPollInterval is how often a progress stream re-reads its job, for
+	// jobs processed by another instance
+	StreamPollInterval time.Duration
+	// DuplicateWindow is how far back uploads are matched against completed
+	// jobs of the restaurant; 0 turns duplicate detection off
+	DuplicateWindow time.Duration
+	// Usage enforces the monthly OCR page and token quotas; nil disables them
+	Usage domain.IUsageUseCase
 
 	// Worker         *services.Worker
 }
@@ -53,6 +68,8 @@ type menuItemOut struct {
 	PreparationTime int                 `json:"preparation_time,omitempty"`
 	HowToEat        string              `json:"how_to_eat,omitempty"`
 	HowToEatAm      string              `json:"how_to_eat_am,omitempty"`
+	// MachineTranslatedAm lists the Amharic fields filled in by machine translation
+	MachineTranslatedAm []string `json:"machine_translated_am,omitempty"`
 }
 
 const (
@@ -90,15 +107,6 @@ func anyToString(v any) string {
 	}
 }
 
-// fallbackTranslate currently returns the source string if translation missing.
-// TODO: integrate real Amharic translation (batch AI call) so blanks are fully localized.
-func fallbackTranslate(src string, existing string) string {
-	if existing != "" {
-		return existing
-	}
-	return src // placeholder until real translation integrated
-}
-
 // enforceAmharicScript replaces common English category/tab words with Amharic script if model missed translation
 func enforceAmharicScript(words []string) []string {
 	mapper := map[string]string{
@@ -125,8 +133,8 @@ func enforceAmharicScript(words []string) []string {
 	return out
 }
 
-func NewOCRJobHandler(uc domain.IOCRJobUseCase, mc domain.IMenuUseCase, stg services.StorageService, nc domain.INotificationUseCase) *OCRJobHandler {
-	return &OCRJobHandler{UseCase: uc, MenuUseCase: mc, StorageService: stg, NotificationUseCase: nc}
+func NewOCRJobHandler(uc domain.IOCRJobUseCase, mc domain.IMenuUseCase, rc domain.IRestaurantUsecase, stg services.StorageService, nc domain.INotificationUseCase, pdf services.IPDFPageRenderer, maxPages int) *OCRJobHandler {
+	return &OCRJobHandler{UseCase: uc, MenuUseCase: mc, RestaurantUseCase: rc, StorageService: stg, NotificationUseCase: nc, PDFRenderer: pdf, MaxPages: maxPages, StreamPollInterval: 5 * time.Second}
 }
 
 // CreateOCRJob handles the creation of a new OCR job
@@ -169,10 +177,22 @@ func (h *OCRJobHandler) GetOCRJobByID(c *gin.Context) {
 		"progress":                  job.Progress,
 		"phases":                    job.PhaseHistory,
 	}
+	if job.ProcessedImageURL != "" {
+		response["processed_image_url"] = job.ProcessedImageURL
+	}
+	if len(job.Pages) > 1 || (len(job.Pages) == 1 && job.Pages[0].Preprocess != nil) {
+		response["pages"] = job.Pages
+	}
+	if len(job.AICalls) > 0 {
+		response["ai_calls"] = job.AICalls
+	}
 	if job.CompletedAt != nil {
 		response["completed_at"] = job.CompletedAt
 	}
-	if job.Status == domain.OCRFailed && job.Error != "" {
+	if job.ImagesPurgedAt != nil {
+		response["images_purged_at"] = job.ImagesPurgedAt
+	}
+	if (job.Status == domain.OCRFailed || job.Status == domain.OCRCancelled) && job.Error != "" {
 		response["error"] = job.Error
 	}
 	if job.Status == domain.OCRCompleted && job.Results != nil {
@@ -202,27 +222,29 @@ func (h *OCRJobHandler) GetOCRJobByID(c *gin.Context) {
 							}
 						}
 
+						am := it.Translation(domain.LangAmharic)
 						mi := menuItemOut{
-							Name:            it.Name,
-							NameAm:          fallbackTranslate(it.Name, it.NameAm),
-							Description:     it.Description,
-							DescriptionAm:   fallbackTranslate(it.Description, it.DescriptionAm),
-							TabTags:         []string{tab.Name},
-							Price:           it.Price,
-							Currency:        it.Currency,
-							Allergies:       allergiesArr,
-							AllergiesAm:     it.AllergiesAm,
-							Ingredients:     it.Ingredients,
-							IngredientsAm:   it.IngredientsAm,
-							PreparationTime: it.PreparationTime,
-							HowToEat:        anyToString(it.HowToEat),
-							HowToEatAm:      fallbackTranslate(anyToString(it.HowToEat), anyToString(it.HowToEatAm)),
+							Name:                it.Name,
+							NameAm:              am.Name,
+							Description:         it.Description,
+							DescriptionAm:       am.Description,
+							TabTags:             []string{tab.Name},
+							Price:               it.Price,
+							Currency:            it.Currency,
+							Allergies:           allergiesArr,
+							AllergiesAm:         am.Allergies,
+							Ingredients:         it.Ingredients,
+							IngredientsAm:       am.Ingredients,
+							PreparationTime:     it.PreparationTime,
+							HowToEat:            anyToString(it.HowToEat),
+							HowToEatAm:          am.HowToEat,
+							MachineTranslatedAm: am.MachineTranslated,
 						}
 						if len(mi.Allergies) == 0 && mi.AllergiesAm != "" {
 							mi.Allergies = []string{"Contains none commonly recognized. Please inform staff of any allergies."}
 						}
-						if tab.NameAm != "" {
-							mi.TabTagsAm = []string{tab.NameAm}
+						if tabAm := tab.Translations[domain.LangAmharic]; tabAm != "" {
+							mi.TabTagsAm = []string{tabAm}
 						} else {
 							mi.TabTagsAm = enforceAmharicScript([]string{tab.Name})
 						}
@@ -239,6 +261,9 @@ func (h *OCRJobHandler) GetOCRJobByID(c *gin.Context) {
 			if res.StructuredMenuID != "" {
 				sanitized["structured_menu_id"] = res.StructuredMenuID
 			}
+			if res.ParseResultID != "" {
+				sanitized["parse_result_id"] = res.ParseResultID
+			}
 			response["results"] = sanitized
 		} else {
 			response["results"] = res
@@ -260,6 +285,18 @@ func (h *OCRJobHandler) DeleteOCRJob(c *gin.Context) {
 // RetryOCRJob retries a failed OCR job without re-uploading image
 func (h *OCRJobHandler) RetryOCRJob(c *gin.Context) {
 	id := c.Param("id")
+	if job, err := h.UseCase.GetOCRJobByID(id); err == nil {
+		pending := 0
+		for _, page := range job.Pages {
+			if page.Status != domain.OCRPageDone {
+				pending++
+			}
+		}
+		if err := h.checkOCRQuota(job.RestaurantID, job.UserID, max(pending, 1)); err != nil {
+			writeQuotaExceeded(c, err)
+			return
+		}
+	}
 	job, err := h.UseCase.RetryJob(id)
 	if err != nil {
 		dto.WriteError(c, err)
@@ -268,6 +305,81 @@ func (h *OCRJobHandler) RetryOCRJob(c *gin.Context) {
 	c.JSON(http.StatusAccepted, gin.H{"success": true, "data": gin.H{"job_id": job.ID, "status": job.Status, "estimated_completion_time": job.EstimatedCompletion}})
 }
 
+// StreamOCRJob sends the progress of a job as server-sent events: the current
+// state first, then a "progress" event per update and a final "completed",
+// "failed" or "cancelled" event, after which the stream ends.
+func (h *OCRJobHandler) StreamOCRJob(c *gin.Context) {
+	id := c.Param("id")
+	// subscribe before reading the job so no update falls in between
+	events, cancel := h.UseCase.SubscribeJob(id)
+	defer cancel()
+	job, err := h.UseCase.GetOCRJobByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "ocr job not found"})
+		return
+	}
+	role := c.GetString("role")
+	if job.UserID != c.GetString("user_id") && role != string(domain.RoleOwner) && role != string(domain.RoleAdmin) {
+		dto.WriteError(c, domain.ErrForbidden)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	last := domain.NewOCRJobEvent(job)
+	writeOCRJobEvent(c, last)
+	if last.Final {
+		return
+	}
+
+	poll := h.StreamPollInterval
+	if poll <= 0 {
+		poll = 5 * time.Second
+	}
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case ev := <-events:
+			writeOCRJobEvent(c, ev)
+			last = ev
+		case <-ticker.C:
+			job, err := h.UseCase.GetOCRJobByID(id)
+			if err != nil {
+				c.SSEvent("failed", gin.H{"job_id": id, "error": "ocr job not found"})
+				c.Writer.Flush()
+				return
+			}
+			ev := domain.NewOCRJobEvent(job)
+			if ev.Status == last.Status && ev.Phase == last.Phase && ev.Progress == last.Progress {
+				// comment line: keeps proxies from closing an idle stream
+				fmt.Fprint(c.Writer, ": ping\n\n")
+				c.Writer.Flush()
+				continue
+			}
+			ev.PhaseChanged = ev.Phase != last.Phase
+			writeOCRJobEvent(c, ev)
+			last = ev
+		}
+		if last.Final {
+			return
+		}
+	}
+}
+
+func writeOCRJobEvent(c *gin.Context, ev domain.OCRJobEvent) {
+	name := "progress"
+	if ev.Final {
+		name = string(ev.Status)
+	}
+	c.SSEvent(name, ev)
+	c.Writer.Flush()
+}
+
 // UploadMenu handles OCR job creation from an uploaded menu image
 func (h *OCRJobHandler) UploadMenu(c *gin.Context) {
 	userId := c.GetString("user_id")
@@ -275,63 +387,146 @@ func (h *OCRJobHandler) UploadMenu(c *gin.Context) {
 		userId = c.GetString("userId")
 	}
 
-	// Attempt to derive restaurant ID from context or query; fallback to userId (TODO: fetch from user profile/role association)
-	restaurantID := c.GetString("restaurant_id")
-	if restaurantID == "" {
-		restaurantID = c.Query("restaurant_id")
-	}
-	if restaurantID == "" {
-		restaurantID = userId
+	// the menu is read for the restaurant the caller manages, or in merge mode
+	// for the restaurant of the target menu; never for one the client names
+	var restaurantID string
+	targetMenuID := strings.TrimSpace(c.PostForm("menu_id"))
+	if targetMenuID != "" {
+		target, err := h.MenuUseCase.GetByID(targetMenuID)
+		if err != nil {
+			dto.WriteError(c, err)
+			return
+		}
+		if target.RestaurantID == "" || !managesRestaurant(c, h.RestaurantUseCase, target.RestaurantID) {
+			dto.WriteError(c, domain.ErrForbidden)
+			return
+		}
+		restaurantID = target.RestaurantID
+	} else {
+		rest, ok := managedRestaurant(c, h.RestaurantUseCase)
+		if !ok {
+			return
+		}
+		restaurantID = rest.ID
 	}
 
-	file, err := c.FormFile("menuImage")
-	if err != nil {
+	// one or more images, or PDFs split into page images, read as one menu
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["menuImage"]) == 0 {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: "menuImage file required"})
 		return
 	}
-
-	// Basic size validation (max 6MB to accommodate high-res, adjustable)
-	if file.Size > MaxUploadSizeBytes {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: "file too large (max 6MB)"})
-		return
+	maxPages := h.MaxPages
+	if maxPages <= 0 {
+		maxPages = 1
 	}
-	f, err := file.Open()
-	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: err.Error()})
-		return
+	var pages [][]byte
+	var pageNames []string
+	for _, file := range form.File["menuImage"] {
+		if file.Size > MaxUploadSizeBytes {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: fmt.Sprintf("%s too large (max %dMB)", file.Filename, MaxUploadSizeBytes>>20)})
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: err.Error()})
+			return
+		}
+		data, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: err.Error()})
+			return
+		}
+
+		// MIME sniffing
+		if len(data) < 10 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: "empty file"})
+			return
+		}
+		if len(pages) >= maxPages {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: fmt.Sprintf("too many pages (max %d)", maxPages)})
+			return
+		}
+		contentType := http.DetectContentType(data[:min(512, len(data))])
+		allowed := map[string]bool{"image/jpeg": true, "image/png": true, "image/webp": true}
+		switch {
+		case allowed[contentType]:
+			pages = append(pages, data)
+			pageNames = append(pageNames, file.Filename)
+		case contentType == "application/pdf":
+			if h.PDFRenderer == nil {
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: "PDF uploads are not enabled on this server"})
+				return
+			}
+			rendered, err := h.PDFRenderer.RenderPages(c.Request.Context(), data, maxPages-len(pages))
+			if err != nil {
+				msg := "could not read PDF"
+				if errors.Is(err, services.ErrPDFTooManyPages) {
+					msg = fmt.Sprintf("too many pages (max %d)", maxPages)
+				}
+				logger.Log.Warn().Str("file", file.Filename).Err(err).Msg("PDF page rendering failed")
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: msg})
+				return
+			}
+			base := strings.TrimSuffix(file.Filename, path.Ext(file.Filename))
+			for i, img := range rendered {
+				pages = append(pages, img)
+				pageNames = append(pageNames, fmt.Sprintf("%s-page-%d.png", base, i+1))
+			}
+		default:
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: "unsupported file type"})
+			return
+		}
 	}
-	defer f.Close()
 
-	data, err := io.ReadAll(f)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: err.Error()})
-		return
+	jobPages := make([]domain.OCRPage, len(pages))
+	for i := range pages {
+		jobPages[i] = domain.OCRPage{Number: i + 1, Status: domain.OCRPagePending}
+		sha, phash, err := services.ImageHashes(pages[i])
+		if err != nil {
+			logger.Log.Warn().Str("file", pageNames[i]).Err(err).Msg("Could not fingerprint menu page")
+			continue
+		}
+		jobPages[i].SHA256, jobPages[i].PerceptualHash = sha, phash
 	}
 
-	// MIME sniffing
-	if len(data) < 10 {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: "empty file"})
-		return
+	// the same menu read recently: offer its result instead of paying for OCR again
+	force, _ := strconv.ParseBool(c.PostForm("force"))
+	if !force && h.DuplicateWindow > 0 {
+		draft := &domain.OCRJob{RestaurantID: restaurantID, UserID: userId, TargetMenuID: targetMenuID, Pages: jobPages}
+		dup, err := h.UseCase.FindDuplicate(draft, time.Now().Add(-h.DuplicateWindow))
+		if err != nil {
+			logger.Log.Warn().Str("restaurant_id", restaurantID).Err(err).Msg("Duplicate upload check failed")
+		} else if dup != nil {
+			logger.Log.Info().Str("job_id", dup.Job.ID).Str("user_id", userId).Bool("exact", dup.Exact).Msg("Duplicate OCR upload")
+			c.JSON(http.StatusOK, gin.H{"success": true, "data": duplicateUploadResponse(dup)})
+			return
+		}
 	}
-	contentType := http.DetectContentType(data[:min(512, len(data))])
-	allowed := map[string]bool{"image/jpeg": true, "image/png": true, "image/webp": true}
-	if !allowed[contentType] {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: domain.ErrInvalidFile.Error(), Error: "unsupported image type"})
+
+	if err := h.checkOCRQuota(restaurantID, userId, len(pages)); err != nil {
+		writeQuotaExceeded(c, err)
 		return
 	}
 
-	url, _, err := h.StorageService.UploadFile(c.Request.Context(), file.Filename, data, "menus")
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Message: domain.ErrFileToUpload.Error(), Error: err.Error()})
-		return
+	for i := range pages {
+		url, publicID, err := h.StorageService.UploadFile(c.Request.Context(), pageNames[i], pages[i], "menus")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Message: domain.ErrFileToUpload.Error(), Error: err.Error()})
+			return
+		}
+		jobPages[i].ImageURL, jobPages[i].ImagePublicID = url, publicID
 	}
 
 	job := &domain.OCRJob{
 		ID:           bson.NewObjectID().Hex(),
 		RestaurantID: restaurantID,
 		UserID:       userId,
-		ImageURL:     url,
-		Status:       domain.OCRProcessing,
+		TargetMenuID: targetMenuID,
+		ImageURL:     jobPages[0].ImageURL,
+		Pages:        jobPages,
+		Status:       domain.OCRPending,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -343,28 +538,193 @@ func (h *OCRJobHandler) UploadMenu(c *gin.Context) {
 
 	// Notifications disabled per request (TODO: integrate notification system behind feature flag)
 
-	// Launch async processing with cancellation context (TODO: configurable timeout via env)
-	jobCtx, cancel := context.WithCancel(context.Background())
-	go func() { defer cancel(); h.processJobAsync(jobCtx, job.ID, userId) }()
-
+	// the worker pool picks the job up from the queue
 	c.JSON(http.StatusAccepted, gin.H{
 		"success": true,
 		"data": gin.H{
 			"job_id":                    job.ID,
 			"status":                    job.Status,
+			"pages":                     len(job.Pages),
 			"estimated_completion_time": job.EstimatedCompletion,
 		},
 	})
 }
-func (h *OCRJobHandler) processJobAsync(ctx context.Context, jobID, userId string) {
-	select {
-	case <-ctx.Done():
-		logger.Log.Warn().Str("job_id", jobID).Msg("job context canceled before processing started")
+
+// managedRestaurant loads the restaurant the caller manages. Without one the
+// error response is already written.
+func managedRestaurant(c *gin.Context, rests domain.IRestaurantUsecase) (*domain.Restaurant, bool) {
+	rest, err := rests.GetRestaurantByManagerId(c.Request.Context(), c.GetString("user_id"))
+	if err != nil || rest == nil {
+		dto.WriteError(c, domain.ErrNoManagedRestaurant)
+		return nil, false
+	}
+	return rest, true
+}
+
+// managesRestaurant reports whether the caller manages the restaurant, or is
+// an owner or admin.
+func managesRestaurant(c *gin.Context, rests domain.IRestaurantUsecase, restaurantID string) bool {
+	role := c.GetString("role")
+	if role == string(domain.RoleOwner) || role == string(domain.RoleAdmin) {
+		return true
+	}
+	if restaurantID == "" {
+		return false
+	}
+	rest, err := rests.GetRestaurantByManagerId(c.Request.Context(), c.GetString("user_id"))
+	return err == nil && rest != nil && rest.ID == restaurantID
+}
+
+// checkOCRQuota returns the quota error when reading that many more pages, or
+// structuring them, would go over a monthly quota. Other failures are
+// logged and let the job through.
+func (h *OCRJobHandler) checkOCRQuota(restaurantID, userID string, pages int) error {
+	if h.Usage == nil {
+		return nil
+	}
+	err := h.Usage.CheckQuota(restaurantID, userID, domain.UsageOCRPages, int64(pages))
+	if err == nil {
+		err = h.Usage.CheckQuota(restaurantID, userID, domain.UsageTokens, 0)
+	}
+	if err != nil && !errors.Is(err, domain.ErrUsageQuotaExceeded) {
+		logger.Log.Warn().Str("restaurant_id", restaurantID).Err(err).Msg("Usage quota check failed")
+		return nil
+	}
+	return err
+}
+
+// duplicateUploadResponse points the client at the earlier job instead of a
+// new one.
+func duplicateUploadResponse(dup *domain.OCRDuplicate) gin.H {
+	match := "similar"
+	if dup.Exact {
+		match = "exact"
+	}
+	data := gin.H{
+		"duplicate":           true,
+		"match":               match,
+		"job_id":              dup.Job.ID,
+		"status":              dup.Job.Status,
+		"created_at":          dup.Job.CreatedAt,
+		"parse_result_id":     dup.ParseResult.ID,
+		"parse_result_status": dup.ParseResult.Status,
+		"message":             "this menu was already read; upload again with force=true to read it anyway",
+	}
+	if dup.Job.CompletedAt != nil {
+		data["completed_at"] = dup.Job.CompletedAt
+	}
+	if dup.ParseResult.MenuID != "" {
+		data["menu_id"] = dup.ParseResult.MenuID
+	}
+	return data
+}
+
+// QueueStats reports OCR queue depth and worker activity for operators.
+func (h *OCRJobHandler) QueueStats(c *gin.Context) {
+	stats, err := h.UseCase.QueueStats()
+	if err != nil {
+		dto.WriteError(c, err)
 		return
-	default:
 	}
-	h.UseCase.ProcessJob(jobID)
-	// Notifications disabled per request
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": stats})
+}
+
+// CancelOCRJob stops a pending or running OCR job. Jobs that already finished
+// answer 409.
+func (h *OCRJobHandler) CancelOCRJob(c *gin.Context) {
+	id := c.Param("id")
+	job, err := h.UseCase.GetOCRJobByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "ocr job not found"})
+		return
+	}
+	role := c.GetString("role")
+	if job.UserID != c.GetString("user_id") && role != string(domain.RoleOwner) && role != string(domain.RoleAdmin) {
+		dto.WriteError(c, domain.ErrForbidden)
+		return
+	}
+	job, err = h.UseCase.CancelJob(id)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{"job_id": job.ID, "status": job.Status, "error": job.Error}})
+}
+
+// ListOCRJobs lists the caller's OCR jobs, newest first. Admins may list
+// another user's jobs with user_id, or a restaurant's with restaurant_id.
+func (h *OCRJobHandler) ListOCRJobs(c *gin.Context) {
+	filter, ok := ocrJobFilterFromQuery(c)
+	if !ok {
+		return
+	}
+	filter.UserID = c.GetString("user_id")
+	if c.GetString("role") == string(domain.RoleAdmin) {
+		filter.UserID = c.Query("user_id")
+		filter.RestaurantID = c.Query("restaurant_id")
+		if filter.UserID == "" && filter.RestaurantID == "" {
+			filter.UserID = c.GetString("user_id")
+		}
+	}
+	h.writeOCRJobList(c, filter)
+}
+
+// ListRestaurantOCRJobs is the OCR history of a restaurant, for its manager.
+func (h *OCRJobHandler) ListRestaurantOCRJobs(c *gin.Context) {
+	filter, ok := ocrJobFilterFromQuery(c)
+	if !ok {
+		return
+	}
+	rest, err := h.RestaurantUseCase.GetRestaurantBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil || rest == nil {
+		dto.WriteError(c, domain.ErrRestaurantNotFound)
+		return
+	}
+	role := c.GetString("role")
+	if rest.ManagerID != c.GetString("user_id") && role != string(domain.RoleOwner) && role != string(domain.RoleAdmin) {
+		dto.WriteError(c, domain.ErrForbidden)
+		return
+	}
+	filter.RestaurantID = rest.ID
+	h.writeOCRJobList(c, filter)
+}
+
+func (h *OCRJobHandler) writeOCRJobList(c *gin.Context, filter domain.OCRJobFilter) {
+	filter = filter.Normalize()
+	jobs, total, err := h.UseCase.ListJobs(filter)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	totalPages := (total + int64(filter.PageSize) - 1) / int64(filter.PageSize)
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{
+		"page":       filter.Page,
+		"pageSize":   filter.PageSize,
+		"total":      total,
+		"totalPages": totalPages,
+		"jobs":       dto.OCRJobSummaries(jobs),
+	}})
+}
+
+// ocrJobFilterFromQuery reads status (comma separated), page and pageSize,
+// answering 400 for an unknown status.
+func ocrJobFilterFromQuery(c *gin.Context) (domain.OCRJobFilter, bool) {
+	var filter domain.OCRJobFilter
+	for _, st := range strings.Split(c.Query("status"), ",") {
+		st = strings.TrimSpace(strings.ToLower(st))
+		switch domain.OCRJobStatus(st) {
+		case "":
+			continue
+		case domain.OCRPending, domain.OCRProcessing, domain.OCRCompleted, domain.OCRFailed, domain.OCRCancelled:
+			filter.Statuses = append(filter.Statuses, domain.OCRJobStatus(st))
+		default:
+			dto.WriteValidationError(c, "status", "unknown status "+st, "invalid_status", nil)
+			return filter, false
+		}
+	}
+	filter.Page, _ = strconv.Atoi(c.Query("page"))
+	filter.PageSize, _ = strconv.Atoi(c.Query("pageSize"))
+	return filter, true
 }
 
 // helper: min int
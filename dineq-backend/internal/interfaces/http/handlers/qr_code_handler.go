@@ -3,35 +3,37 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
 	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
 	"github.com/gin-gonic/gin"
 )
 
 type QRCodeHandler struct {
-	qrUsecase domain.IQRCodeUseCase
-	notifUc   domain.INotificationUseCase
+	qrUsecase         domain.IQRCodeUseCase
+	notifUc           domain.INotificationUseCase
+	restaurantUsecase domain.IRestaurantUsecase
 }
 
-func NewQRCodeHandler(qrUsecase domain.IQRCodeUseCase, notifUc domain.INotificationUseCase) *QRCodeHandler {
+func NewQRCodeHandler(qrUsecase domain.IQRCodeUseCase, notifUc domain.INotificationUseCase, restaurantUsecase domain.IRestaurantUsecase) *QRCodeHandler {
 	return &QRCodeHandler{
-		qrUsecase: qrUsecase,
-		notifUc:   notifUc,
+		qrUsecase:         qrUsecase,
+		notifUc:           notifUc,
+		restaurantUsecase: restaurantUsecase,
 	}
 }
 
-// change status
+// UpdateQRCodeStatus activates or deactivates every QR code of a restaurant,
+// including codes made under its earlier slugs.
 func (h *QRCodeHandler) UpdateQRCodeStatus(c *gin.Context) {
-	restaurantId := c.Param("restaurant_slug")
-	statusStr := c.Param("status")
-
-	if restaurantId == "" {
-		dto.WriteValidationError(c, "restaurant_slug", domain.ErrInvalidRequest.Error(), "invalid_request", errors.New("restaurant slug is required"))
+	rest, ok := h.restaurant(c)
+	if !ok {
 		return
 	}
-
+	statusStr := c.Param("status")
 	if statusStr == "" {
 		dto.WriteValidationError(c, "status", domain.ErrInvalidRequest.Error(), "invalid_request", errors.New("status is required"))
 		return
@@ -42,23 +44,31 @@ func (h *QRCodeHandler) UpdateQRCodeStatus(c *gin.Context) {
 		return
 	}
 
-	if err := h.qrUsecase.ChangeQRCodeStatus(restaurantId, status); err != nil {
-		dto.WriteError(c, err)
-		return
+	for _, slug := range rest.Slugs() {
+		if err := h.qrUsecase.ChangeQRCodeStatus(slug, status); err != nil {
+			dto.WriteError(c, err)
+			return
+		}
 	}
 
 	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgUpdated})
 }
 
-// Get qr code
+// GetQRCode returns a QR code of the restaurant, looking under its earlier
+// slugs when none was made under the current one.
 func (h *QRCodeHandler) GetQRCode(c *gin.Context) {
-	restaurantId := c.Param("restaurant_slug")
-	if restaurantId == "" {
-		dto.WriteValidationError(c, "restaurant_slug", domain.ErrInvalidRequest.Error(), "invalid_request", errors.New("restaurant id is required"))
+	rest, ok := h.restaurant(c)
+	if !ok {
 		return
 	}
 
-	qrCode, err := h.qrUsecase.GetQRCodeByRestaurantId(restaurantId)
+	var qrCode *domain.QRCode
+	err := domain.ErrQRCodeNotFound
+	for _, slug := range rest.Slugs() {
+		if qrCode, err = h.qrUsecase.GetQRCodeByRestaurantId(slug); err == nil {
+			break
+		}
+	}
 	if err != nil {
 		if errors.Is(err, domain.ErrQRCodeNotFound) {
 			dto.WriteError(c, domain.ErrQRCodeNotFound)
@@ -71,22 +81,134 @@ func (h *QRCodeHandler) GetQRCode(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"qr_code": qrCode}})
 }
 
-// Delete qr code
+// DeleteQRCode deletes every QR code of a restaurant, including codes made
+// under its earlier slugs.
 func (h *QRCodeHandler) DeleteQRCode(c *gin.Context) {
-	restaurantId := c.Param("restaurant_slug")
-	if restaurantId == "" {
-		dto.WriteValidationError(c, "restaurant_slug", domain.ErrInvalidRequest.Error(), "invalid_request", errors.New("restaurant id is required"))
+	rest, ok := h.restaurant(c)
+	if !ok {
 		return
 	}
 
-	if err := h.qrUsecase.DeleteQRCode(restaurantId); err != nil {
-		if errors.Is(err, domain.ErrQRCodeNotFound) {
-			dto.WriteError(c, domain.ErrQRCodeNotFound)
-		} else {
-			dto.WriteError(c, err)
+	for _, slug := range rest.Slugs() {
+		if err := h.qrUsecase.DeleteQRCode(slug); err != nil {
+			if errors.Is(err, domain.ErrQRCodeNotFound) {
+				dto.WriteError(c, domain.ErrQRCodeNotFound)
+			} else {
+				dto.WriteError(c, err)
+			}
+			return
 		}
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgDeleted})
+}
+
+// restaurant loads the restaurant of the route if the caller manages it, or
+// is an owner or admin.
+func (h *QRCodeHandler) restaurant(c *gin.Context) (*domain.Restaurant, bool) {
+	rest, err := h.restaurantUsecase.GetRestaurantBySlug(c.Request.Context(), c.Param("restaurant_slug"))
+	if err != nil || rest == nil {
+		dto.WriteError(c, domain.ErrRestaurantNotFound)
+		return nil, false
+	}
+	role := c.GetString("role")
+	if rest.ManagerID != c.GetString("user_id") && role != string(domain.RoleOwner) && role != string(domain.RoleAdmin) {
+		dto.WriteError(c, domain.ErrForbidden)
+		return nil, false
+	}
+	return rest, true
+}
+
+// qrCode loads the code of the route, which may have been made under an
+// earlier slug; codes of other restaurants are not found.
+func (h *QRCodeHandler) qrCode(c *gin.Context) (*domain.QRCode, bool) {
+	rest, ok := h.restaurant(c)
+	if !ok {
+		return nil, false
+	}
+	qr, err := h.qrUsecase.GetQRCode(c.Param("id"))
+	if err == nil && !slices.Contains(rest.Slugs(), qr.RestaurantID) {
+		err = domain.ErrQRCodeNotFound
+	}
+	if err != nil {
+		dto.WriteError(c, err)
+		return nil, false
+	}
+	return qr, true
+}
+
+// ListQRCodes lists every QR code of a restaurant, or of one menu with ?menu_id=.
+func (h *QRCodeHandler) ListQRCodes(c *gin.Context) {
+	rest, ok := h.restaurant(c)
+	if !ok {
 		return
 	}
+	qrCodes, err := h.qrUsecase.ListQRCodes(rest.Slugs(), c.Query("menu_id"))
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"qr_codes": dto.DomainToQRCodeResponseList(qrCodes)}})
+}
+
+func (h *QRCodeHandler) GetQRCodeByID(c *gin.Context) {
+	qrCode, ok := h.qrCode(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgSuccess, Data: gin.H{"qr_code": dto.DomainToQRCodeResponse(qrCode)}})
+}
 
+// UpdateQRCode renames or (de)activates a single QR code. Its table and
+// location are printed in the code and need a new code to change.
+func (h *QRCodeHandler) UpdateQRCode(c *gin.Context) {
+	qrCode, ok := h.qrCode(c)
+	if !ok {
+		return
+	}
+	var req dto.QRCodeUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	if req.Label != nil {
+		qrCode.Label = *req.Label
+	}
+	if req.IsActive != nil {
+		qrCode.IsActive = *req.IsActive
+	}
+	if err := h.qrUsecase.UpdateQRCode(qrCode); err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgUpdated, Data: gin.H{"qr_code": dto.DomainToQRCodeResponse(qrCode)}})
+}
+
+func (h *QRCodeHandler) DeleteQRCodeByID(c *gin.Context) {
+	qrCode, ok := h.qrCode(c)
+	if !ok {
+		return
+	}
+	if err := h.qrUsecase.DeleteQRCodeByID(qrCode.ID); err != nil {
+		dto.WriteError(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgDeleted})
 }
+
+// ScanQRCode logs a scan of a QR code's short link and redirects to the menu
+// that is active now. The redirect is temporary and not cached, so the code
+// follows slug and menu changes.
+func (h *QRCodeHandler) ScanQRCode(c *gin.Context) {
+	target, err := h.qrUsecase.ResolveScan(c.Param("id"), c.Request.UserAgent())
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, services.QRScanRedirectURL(target))
+}
@@ -13,6 +13,7 @@ import (
 
 	utils "github.com/RealEskalate/G6-MenuMate/Utils"
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
 	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
@@ -88,6 +89,23 @@ func (h *RestaurantHandler) CreateRestaurant(c *gin.Context) {
 		r.About = &about
 	}
 	r.DefaultLanguage = c.DefaultPostForm("default_language", "English")
+	if domain.NormalizeLanguage(r.DefaultLanguage) == "" {
+		dto.WriteValidationError(c, "default_language", "unsupported default_language", "unsupported_language", nil)
+		return
+	}
+	if raw := c.PostForm("translations"); raw != "" {
+		translations, err := parseRestaurantTranslations(raw)
+		if err != nil {
+			dto.WriteValidationError(c, "translations", "invalid translations", "invalid_translations", err)
+			return
+		}
+		r.Translations = translations
+	}
+	r.Timezone = c.DefaultPostForm("timezone", domain.DefaultRestaurantTimezone)
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid timezone"})
+		return
+	}
 	r.VerificationStatus = domain.VerificationStatus(c.DefaultPostForm("verification_status", string(domain.VerificationPending)))
 	r.DefaultCurrency = c.DefaultPostForm("default_currency", "ETB")
 	r.PrimaryColor = c.DefaultPostForm("primary_color", "#89643E")
@@ -117,6 +135,7 @@ func (h *RestaurantHandler) CreateRestaurant(c *gin.Context) {
 	if tags := collectTags(c); len(tags) > 0 {
 		r.Tags = tags
 	}
+	r.Glossary = collectFormList(c, "glossary")
 
 	// Read optional files
 	files := make(map[string][]byte)
@@ -185,7 +204,14 @@ func (h *RestaurantHandler) GetRestaurant(c *gin.Context) {
 		IP:         c.ClientIP(),
 		UserAgent:  c.Request.UserAgent(),
 	})
-	c.JSON(http.StatusOK, dto.ToRestaurantResponse(r))
+	lang := responseLanguage(c, r)
+	if format := structuredFormat(c); format != "" {
+		pageURL := services.PublicRestaurantURL(r.Slug)
+		og := dto.RestaurantOpenGraph(r, "restaurant", r.Localized(lang).Name, pageURL, lang)
+		writeStructuredData(c, format, og, dto.RestaurantToJSONLD(r, pageURL, pageURL, lang))
+		return
+	}
+	c.JSON(http.StatusOK, dto.LocalizedRestaurantResponse(r, lang))
 }
 
 // GetRestaurant retrieves a restaurant by its slug.
@@ -318,8 +344,27 @@ func (h *RestaurantHandler) UpdateRestaurant(c *gin.Context) {
 		existing.VerificationStatus = domain.VerificationStatus(status)
 	}
 	if lang := c.PostForm("default_language"); lang != "" {
+		if domain.NormalizeLanguage(lang) == "" {
+			dto.WriteValidationError(c, "default_language", "unsupported default_language", "unsupported_language", nil)
+			return
+		}
 		existing.DefaultLanguage = lang
 	}
+	if raw := c.PostForm("translations"); raw != "" {
+		translations, err := parseRestaurantTranslations(raw)
+		if err != nil {
+			dto.WriteValidationError(c, "translations", "invalid translations", "invalid_translations", err)
+			return
+		}
+		existing.Translations = translations
+	}
+	if tz := c.PostForm("timezone"); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			dto.WriteValidationError(c, "timezone", "invalid timezone", "invalid_timezone", err)
+			return
+		}
+		existing.Timezone = tz
+	}
 	if currency := c.PostForm("default_currency"); currency != "" {
 		existing.DefaultCurrency = currency
 	}
@@ -343,6 +388,10 @@ func (h *RestaurantHandler) UpdateRestaurant(c *gin.Context) {
 	if tags := collectTags(c); len(tags) > 0 {
 		existing.Tags = tags
 	}
+	// glossary terms are kept as written by machine translation; a blank value clears them
+	if _, ok := c.GetPostFormArray("glossary"); ok {
+		existing.Glossary = collectFormList(c, "glossary")
+	}
 
 	// Update coordinates if provided
 	latStr := c.PostForm("lat")
@@ -442,9 +491,15 @@ func updateSlug(r *domain.Restaurant, newName string) {
 
 // collectTags gathers tags from form fields: tags, tags[], or a single comma-separated value.
 func collectTags(c *gin.Context) []string {
-	raw := c.PostFormArray("tags")
+	return collectFormList(c, "tags")
+}
+
+// collectFormList gathers a list form field sent as name, name[], or a single
+// comma-separated value.
+func collectFormList(c *gin.Context, name string) []string {
+	raw := c.PostFormArray(name)
 	if len(raw) == 0 {
-		raw = c.PostFormArray("tags[]")
+		raw = c.PostFormArray(name + "[]")
 	}
 	if len(raw) == 1 && strings.Contains(raw[0], ",") {
 		parts := strings.Split(raw[0], ",")
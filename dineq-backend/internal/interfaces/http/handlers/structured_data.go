@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	structuredJSONLD = "jsonld"
+	structuredHTML   = "html"
+)
+
+// structuredFormat picks the search-engine representation a public endpoint
+// was asked for: ?format=jsonld or an Accept of application/ld+json gives
+// JSON-LD, ?format=html gives an Open Graph page. "" means the regular JSON.
+func structuredFormat(c *gin.Context) string {
+	switch strings.ToLower(c.Query("format")) {
+	case structuredJSONLD, "ld+json":
+		return structuredJSONLD
+	case structuredHTML:
+		return structuredHTML
+	}
+	if strings.Contains(c.GetHeader("Accept"), "application/ld+json") {
+		return structuredJSONLD
+	}
+	return ""
+}
+
+// writeStructuredData answers with the JSON-LD document or the Open Graph page.
+func writeStructuredData(c *gin.Context, format string, og dto.OpenGraph, jsonLD any) {
+	c.Writer.Header().Add("Vary", "Accept")
+	if format == structuredJSONLD {
+		c.Header("Content-Type", "application/ld+json; charset=utf-8")
+		c.JSON(http.StatusOK, jsonLD)
+		return
+	}
+	page, err := dto.RenderOpenGraphHTML(og, jsonLD)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+type UsageHandler struct {
+	UseCase domain.IUsageUseCase
+}
+
+func NewUsageHandler(uc domain.IUsageUseCase) *UsageHandler {
+	return &UsageHandler{UseCase: uc}
+}
+
+// Report sums the usage ledger per restaurant or user over from..to, the
+// current month by default.
+func (h *UsageHandler) Report(c *gin.Context) {
+	filter := domain.UsageReportFilter{
+		GroupBy:      domain.UsageGroup(c.Query("group_by")),
+		RestaurantID: c.Query("restaurant_id"),
+		UserID:       c.Query("user_id"),
+	}
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		if v := c.Query(p.name); v != "" {
+			t, err := parseReportTime(v)
+			if err != nil {
+				dto.WriteValidationError(c, p.name, "expected a date (2006-01-02) or RFC 3339 time", "invalid_time", err)
+				return
+			}
+			*p.dst = t
+		}
+	}
+	report, err := h.UseCase.Report(filter)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": report})
+}
+
+func parseReportTime(v string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+// writeQuotaExceeded answers 429 with the quota that was hit and when it
+// resets.
+func writeQuotaExceeded(c *gin.Context, err error) {
+	status, resp := dto.NormalizeError(err)
+	var qe *domain.QuotaExceededError
+	if errors.As(err, &qe) {
+		c.Header("Retry-After", strconv.Itoa(int(time.Until(qe.ResetsAt).Seconds())+1))
+		resp.Message = qe.Error()
+		resp.Details = qe
+	}
+	c.JSON(status, resp)
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler lets the manager of a restaurant, owners and admins manage
+// its webhooks and follow their deliveries.
+type WebhookHandler struct {
+	UseCase           domain.IWebhookUseCase
+	RestaurantUseCase domain.IRestaurantUsecase
+}
+
+func NewWebhookHandler(uc domain.IWebhookUseCase, rc domain.IRestaurantUsecase) *WebhookHandler {
+	return &WebhookHandler{UseCase: uc, RestaurantUseCase: rc}
+}
+
+// ListEvents names the events webhooks can subscribe to.
+func (h *WebhookHandler) ListEvents(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": domain.WebhookEvents})
+}
+
+// restaurant loads the restaurant of the route when the caller manages it or
+// is an owner or admin.
+func (h *WebhookHandler) restaurant(c *gin.Context) (*domain.Restaurant, bool) {
+	rest, err := h.RestaurantUseCase.GetRestaurantBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil || rest == nil {
+		dto.WriteError(c, domain.ErrRestaurantNotFound)
+		return nil, false
+	}
+	role := c.GetString("role")
+	if rest.ManagerID != c.GetString("user_id") && role != string(domain.RoleOwner) && role != string(domain.RoleAdmin) {
+		dto.WriteError(c, domain.ErrForbidden)
+		return nil, false
+	}
+	return rest, true
+}
+
+// subscription loads the webhook of the route; webhooks of other restaurants
+// are not found.
+func (h *WebhookHandler) subscription(c *gin.Context) (*domain.WebhookSubscription, bool) {
+	rest, ok := h.restaurant(c)
+	if !ok {
+		return nil, false
+	}
+	sub, err := h.UseCase.GetSubscription(c.Param("id"))
+	if err == nil && sub.RestaurantID != rest.ID {
+		err = domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		dto.WriteError(c, err)
+		return nil, false
+	}
+	return sub, true
+}
+
+// delivery loads the delivery of the route, which must belong to the webhook
+// of the route.
+func (h *WebhookHandler) delivery(c *gin.Context) (*domain.WebhookDelivery, bool) {
+	sub, ok := h.subscription(c)
+	if !ok {
+		return nil, false
+	}
+	d, err := h.UseCase.GetDelivery(c.Param("delivery_id"))
+	if err == nil && d.SubscriptionID != sub.ID {
+		err = domain.ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		dto.WriteError(c, err)
+		return nil, false
+	}
+	return d, true
+}
+
+// CreateWebhook registers a URL for some events of the restaurant. The
+// signing secret is only returned here.
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	rest, ok := h.restaurant(c)
+	if !ok {
+		return
+	}
+	var req dto.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	sub := &domain.WebhookSubscription{
+		RestaurantID: rest.ID,
+		URL:          req.URL,
+		Events:       dto.WebhookEvents(req.Events),
+		Active:       req.Active == nil || *req.Active,
+		CreatedBy:    c.GetString("user_id"),
+	}
+	if err := h.UseCase.CreateSubscription(sub); err != nil {
+		h.writeWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": gin.H{"webhook": sub, "secret": sub.Secret}})
+}
+
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	rest, ok := h.restaurant(c)
+	if !ok {
+		return
+	}
+	subs, err := h.UseCase.ListSubscriptions(rest.ID)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	if subs == nil {
+		subs = []*domain.WebhookSubscription{}
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": subs})
+}
+
+// UpdateWebhook changes the URL or events of a webhook, or pauses it.
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	sub, ok := h.subscription(c)
+	if !ok {
+		return
+	}
+	var req dto.WebhookUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		dto.WriteValidationError(c, "payload", domain.ErrInvalidRequest.Error(), "invalid_request", err)
+		return
+	}
+	if req.URL != nil {
+		sub.URL = *req.URL
+	}
+	if req.Events != nil {
+		sub.Events = dto.WebhookEvents(req.Events)
+	}
+	if req.Active != nil {
+		sub.Active = *req.Active
+	}
+	if err := h.UseCase.UpdateSubscription(sub); err != nil {
+		h.writeWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": sub})
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	sub, ok := h.subscription(c)
+	if !ok {
+		return
+	}
+	if err := h.UseCase.DeleteSubscription(sub.ID); err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{Message: domain.MsgDeleted})
+}
+
+// ListDeliveries pages through the delivery log of a webhook, newest first.
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	sub, ok := h.subscription(c)
+	if !ok {
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	deliveries, total, err := h.UseCase.ListDeliveries(sub.ID, page, pageSize)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{
+		"page":       page,
+		"pageSize":   pageSize,
+		"total":      total,
+		"totalPages": totalPages,
+		"deliveries": dto.WebhookDeliverySummaries(deliveries),
+	}})
+}
+
+// GetDelivery returns a delivery with its payload and every attempt.
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	d, ok := h.delivery(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": d})
+}
+
+// Redeliver sends a delivery again, as a new delivery with the same event ID
+// and body.
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	d, ok := h.delivery(c)
+	if !ok {
+		return
+	}
+	redelivery, err := h.UseCase.Redeliver(d.ID)
+	if err != nil {
+		dto.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"success": true, "data": redelivery})
+}
+
+// writeWebhookError names the field a rejected webhook got wrong.
+func (h *WebhookHandler) writeWebhookError(c *gin.Context, err error) {
+	switch err {
+	case domain.ErrInvalidWebhookURL:
+		dto.WriteValidationError(c, "url", "expected an absolute http or https URL", "invalid_webhook_url", err)
+	case domain.ErrInvalidInput:
+		dto.WriteValidationError(c, "events", "expected one or more of ocr.completed, ocr.failed, menu.published, menu.updated", "invalid_webhook_events", err)
+	default:
+		dto.WriteError(c, err)
+	}
+}
@@ -2,15 +2,24 @@ package routers
 
 import (
 	"context"
+	"time"
 
 	"github.com/RealEskalate/G6-MenuMate/internal/bootstrap"
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	mongo "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/repositories"
 	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
 	handler "github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/handlers"
 	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/middleware"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
 	"github.com/gin-gonic/gin"
 )
 
-func NewImageSearchRoutes(env *bootstrap.Env, group *gin.RouterGroup) {
+func NewImageSearchRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Database, usageUc domain.IUsageUseCase) {
+	ctxTimeout := time.Duration(env.CtxTSeconds) * time.Second
+	restaurantRepo := repositories.NewRestaurantRepo(db, env.RestaurantCollection)
+	storage := services.NewCloudinaryStorage(env.CloudinaryName, env.CloudinaryAPIKey, env.CloudinarySecret)
+	restaurantUsecase := usecase.NewRestaurantUsecase(restaurantRepo, ctxTimeout, storage)
 	googleSvc := services.NewGoogleCustomSearchService(env.SearchAPIKey, env.SearchEngineID)
 	unsplashSvc := services.NewUnsplashSearchService(env.UnsplashAPIKey)
 	pexelsSvc := services.NewPexelsSearchService(env.PexelsAPIKey)
@@ -23,7 +32,7 @@ func NewImageSearchRoutes(env *bootstrap.Env, group *gin.RouterGroup) {
 		// also create the optional classifier wrapper (returns nil if key empty)
 		clfSvc = services.NewGeminiFoodClassifier(env.GeminiAPIKey, env.GeminiModelName)
 	}
-	h := handler.NewImageSearchHandler(googleSvc, unsplashSvc, pexelsSvc, clfSvc, aiSvc)
+	h := handler.NewImageSearchHandler(googleSvc, unsplashSvc, pexelsSvc, clfSvc, aiSvc, usageUc, restaurantUsecase)
 	g := group.Group("/images")
 	g.Use(middleware.AuthMiddleware(*env))
 	{
@@ -1,11 +1,13 @@
 package routers
 
 import (
+	"context"
 	"time"
 
 	"github.com/RealEskalate/G6-MenuMate/internal/bootstrap"
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
 	mongo "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
 	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/repositories"
 	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
 	handler "github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/handlers"
@@ -14,15 +16,12 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func NewMenuRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Database, notifUc domain.INotificationUseCase) {
+func NewMenuRoutes(ctx context.Context, env *bootstrap.Env, group *gin.RouterGroup, db mongo.Database, notifUc domain.INotificationUseCase, webhookUc domain.IWebhookUseCase) {
 	// context time out
 	ctxTimeout := time.Duration(env.CtxTSeconds) * time.Second
 
 	qrService := services.NewQRService()
 
-	qrRepo := repositories.NewQRCodeRepository(db, env.QRCodeCollection)
-	qrUsecase := usecase.NewQRCodeUseCase(qrRepo, ctxTimeout)
-
 	// storage services
 	cloudinaryStorage := services.NewCloudinaryStorage(
 		env.CloudinaryName,
@@ -31,16 +30,37 @@ func NewMenuRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Database
 	)
 
 	menuRepo := repositories.NewMenuRepository(db, env.MenuCollection)
-	menuUsecase := usecase.NewMenuUseCase(menuRepo, *qrService, ctxTimeout)
+	menuVersionRepo := repositories.NewMenuVersionRepository(db, env.MenuVersionCollection)
+	itemRepo := repositories.NewItemRepository(db, env.ItemCollection)
+	// machine translation of missing menu translations; disabled without a Gemini key
+	var translator domain.ITranslator
+	if env.GeminiAPIKey != "" {
+		aiCtx, cancelAI := context.WithTimeout(context.Background(), ctxTimeout)
+		aiService, err := services.NewAIService(aiCtx, env.GeminiAPIKey, env.GeminiModelName, nil)
+		cancelAI()
+		if err != nil {
+			logger.Log.Error().Err(err).Msg("failed to initialize AI service; menu translation disabled")
+		} else {
+			translator = aiService
+		}
+	}
+	menuUsecase := usecase.NewMenuUseCase(menuRepo, menuVersionRepo, itemRepo, notifUc, *qrService, services.NewMenuPDFService(qrService), translator, webhookUc, ctxTimeout)
 
 	restaurantRepo := repositories.NewRestaurantRepo(db, env.RestaurantCollection)
 
+	qrRepo := repositories.NewQRCodeRepository(db, env.QRCodeCollection)
+	qrScanRepo := repositories.NewQRScanRepository(db, env.QRScanCollection)
+	qrUsecase := usecase.NewQRCodeUseCase(qrRepo, qrScanRepo, menuRepo, restaurantRepo, ctxTimeout)
+
 	cloudinaryStorage = services.NewCloudinaryStorage(env.CloudinaryName, env.CloudinaryAPIKey, env.CloudinarySecret)
 	restaurantUsecase := usecase.NewRestaurantUsecase(restaurantRepo, ctxTimeout, cloudinaryStorage)
 
 	// View events repository for logging views
 	viewEventRepo := repositories.NewViewEventRepository(db, env.ViewEventCollection)
 
+	// apply scheduled publish/unpublish transitions in the background
+	usecase.StartMenuScheduler(ctx, menuUsecase, time.Duration(env.MenuSchedulerIntervalSeconds)*time.Second)
+
 	menuHandler := handler.NewMenuHandler(menuUsecase, qrUsecase, restaurantUsecase, notifUc, viewEventRepo)
 
 	// Public (unauthenticated) menu routes - only expose published menus
@@ -60,10 +80,21 @@ func NewMenuRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Database
 	{
 		// Authenticated endpoints for managing menus
 		protected.POST("/:restaurant_slug", menuHandler.CreateMenu)
+		protected.POST("/:restaurant_slug/import", menuHandler.ImportMenu)
 		protected.PATCH("/:restaurant_slug/:id", menuHandler.UpdateMenu)
 		protected.DELETE("/:restaurant_slug/:id", menuHandler.DeleteMenu)
 		protected.POST("/:restaurant_slug/qrcode/:id", menuHandler.GenerateQRCode)
 		protected.POST("/:restaurant_slug/publish/:id", menuHandler.PublishMenu)
+		protected.GET("/:restaurant_slug/preview/:id", menuHandler.PreviewMenu)
+		protected.GET("/:restaurant_slug/export/:id", menuHandler.ExportMenuPDF)
+		protected.POST("/:restaurant_slug/translate/:id", menuHandler.TranslateMenu)
+		protected.POST("/:restaurant_slug/unpublish/:id", menuHandler.UnpublishMenu)
+		protected.PUT("/:restaurant_slug/schedule/:id", menuHandler.SetMenuSchedule)
+		protected.PATCH("/:restaurant_slug/availability/:id/:item_id", menuHandler.SetItemAvailability)
+		protected.GET("/:restaurant_slug/versions/:id", menuHandler.ListMenuVersions)
+		protected.GET("/:restaurant_slug/versions/:id/:version", menuHandler.GetMenuVersion)
+		protected.GET("/:restaurant_slug/diff/:id", menuHandler.DiffMenuVersions)
+		protected.POST("/:restaurant_slug/rollback/:id", menuHandler.RollbackMenu)
 		protected.PATCH("/item/:menu_slug", menuHandler.MenuItemUpdate)
 		protected.GET("/item/:menu_slug/:item_slug", menuHandler.GetMenuItemBySlug)
 
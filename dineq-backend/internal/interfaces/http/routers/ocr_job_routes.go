@@ -17,7 +17,7 @@ import (
 	"github.com/veryfi/veryfi-go/veryfi"
 )
 
-func NewOCRJobRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Database, notifUc domain.INotificationUseCase) {
+func NewOCRJobRoutes(appCtx context.Context, env *bootstrap.Env, group *gin.RouterGroup, db mongo.Database, notifUc domain.INotificationUseCase, usageUc domain.IUsageUseCase, webhookUc domain.IWebhookUseCase) {
 	// base context & timeout for service initialization (long-running OCR/AI may exceed; see TODO below)
 	ctxTimeout := time.Duration(env.CtxTSeconds) * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
@@ -25,7 +25,8 @@ func NewOCRJobRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Databa
 	// TODO: Consider a separate, larger timeout specifically for long OCR+AI pipeline stages if env.CtxTSeconds is small.
 
 	// Basic credential presence checks (log warnings, continue in degraded mode)
-	if env.VeryfiClientID == "" || env.VeryfiClientSecret == "" || env.VeryfiAPIKey == "" || env.VeryfiUsername == "" {
+	veryfiSelected := env.OCRProvider == "" || env.OCRProvider == services.OCRProviderVeryfi
+	if veryfiSelected && (env.VeryfiClientID == "" || env.VeryfiClientSecret == "" || env.VeryfiAPIKey == "" || env.VeryfiUsername == "") {
 		logger.Log.Warn().Msg("veryfi credentials incomplete; OCR extraction may fail")
 	}
 	if env.CloudinaryName == "" || env.CloudinaryAPIKey == "" || env.CloudinarySecret == "" {
@@ -38,13 +39,21 @@ func NewOCRJobRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Databa
 		logger.Log.Warn().Msg("image search credentials missing; photo enrichment disabled")
 	}
 
-	ocrOption := veryfi.Options{
-		ClientID:     env.VeryfiClientID,
-		ClientSecret: env.VeryfiClientSecret,
-		APIKey:       env.VeryfiAPIKey,
-		Username:     env.VeryfiUsername,
+	ocrService, err := services.NewOCRProvider(services.OCRProviderConfig{
+		Provider: env.OCRProvider,
+		Veryfi: veryfi.Options{
+			ClientID:     env.VeryfiClientID,
+			ClientSecret: env.VeryfiClientSecret,
+			APIKey:       env.VeryfiAPIKey,
+			Username:     env.VeryfiUsername,
+		},
+		TesseractPath:      env.OCRTesseractPath,
+		TesseractLanguages: env.OCRTesseractLanguages,
+		FixtureDir:         env.OCRFixtureDir,
+	})
+	if err != nil {
+		logger.Log.Fatal().Err(err).Str("provider", env.OCRProvider).Msg("failed to initialize OCR provider")
 	}
-	ocrService := services.NewOCRService(&ocrOption)
 
 	// storage services
 	cloudinaryStorage := services.NewCloudinaryStorage(
@@ -66,32 +75,95 @@ func NewOCRJobRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Databa
 		logger.Log.Error().Err(err).Msg("failed to initialize AI service; OCR results will lack AI enhancements")
 		aiService = nil // graceful degrade
 	}
+	model := env.AIModel
+	if model == "" && (env.AIProvider == "" || env.AIProvider == services.AIProviderGemini) {
+		model = env.GeminiModelName
+	}
+	structurer, err := services.NewMenuStructurer(ctx, services.AIProviderConfig{
+		Provider:     env.AIProvider,
+		Model:        model,
+		GeminiAPIKey: env.GeminiAPIKey,
+		BaseURL:      env.AIBaseURL,
+		APIKey:       env.AIAPIKey,
+	})
+	if err != nil {
+		logger.Log.Error().Err(err).Str("provider", env.AIProvider).Msg("failed to initialize menu structuring; OCR jobs will fail at the AI stage")
+	}
+	// the structuring model also fills in missing Amharic when it can translate
+	var translator domain.ITranslator
+	if t, ok := structurer.(domain.ITranslator); ok {
+		translator = t
+	}
+
+	// PDF uploads are split into page images with pdftoppm when it is installed
+	var pdfRenderer services.IPDFPageRenderer
+	if r, err := services.NewPDFPageRenderer(env.OCRPDFRendererPath); err != nil {
+		logger.Log.Warn().Err(err).Msg("PDF menu uploads disabled")
+	} else {
+		pdfRenderer = r
+	}
+
+	// page images are cleaned up in pure Go before OCR; the processed copy is
+	// stored next to the original
+	var preprocessor services.IImagePreprocessor
+	if env.OCRPreprocess {
+		preprocessor = services.NewImagePreprocessor(cloudinaryStorage, services.PreprocessOptions{Crop: env.OCRPreprocessCrop})
+	}
 
 	// qr services
 	qrServices := services.NewQRService()
 
 	// repositories
 	menuRepo := repositories.NewMenuRepository(db, env.MenuCollection)
+	menuVersionRepo := repositories.NewMenuVersionRepository(db, env.MenuVersionCollection)
+	itemRepo := repositories.NewItemRepository(db, env.ItemCollection)
 	ocrJobRepo := repositories.NewOCRJobRepository(db, env.OCRJobCollection)
+	parseResultRepo := repositories.NewAIParseResultRepository(db, env.AIParseResultCollection)
+	restaurantRepo := repositories.NewRestaurantRepo(db, env.RestaurantCollection)
 
 	// use cases
-	menuUsecase := usecase.NewMenuUseCase(menuRepo, *qrServices, ctxTimeout)
-	ocrJobUsecase := usecase.NewOCRJobUseCase(ocrJobRepo, menuRepo, ocrService, aiService, ctxTimeout)
+	menuUsecase := usecase.NewMenuUseCase(menuRepo, menuVersionRepo, itemRepo, notifUc, *qrServices, services.NewMenuPDFService(qrServices), aiService, webhookUc, ctxTimeout)
+	restaurantUsecase := usecase.NewRestaurantUsecase(restaurantRepo, ctxTimeout, cloudinaryStorage)
+	parseResultUsecase := usecase.NewAIParseResultUseCase(parseResultRepo, menuUsecase, ctxTimeout)
+	ocrJobUsecase := usecase.NewOCRJobUseCase(ocrJobRepo, parseResultRepo, ocrService, preprocessor, structurer, translator, notifUc, cloudinaryStorage, usageUc, webhookUc, ctxTimeout)
 
-	// Worker (disabled)
-	// TODO: Re-enable background worker for OCR job polling & retries.
+	// queued jobs are run by the worker pool; jobs of a crashed process are recovered here
+	ocrJobUsecase.StartWorkers(appCtx, domain.OCRWorkerConfig{
+		Workers:      env.OCRWorkers,
+		Lease:        time.Duration(env.OCRLeaseSeconds) * time.Second,
+		PollInterval: time.Duration(env.OCRPollIntervalSeconds) * time.Second,
+		MaxAttempts:  env.OCRMaxAttempts,
+		// page images of finished jobs are deleted from storage after these
+		Retention:       time.Duration(env.OCRRetentionDays) * 24 * time.Hour,
+		FailedRetention: time.Duration(env.OCRFailedRetentionDays) * 24 * time.Hour,
+	})
 	// TODO: Add notification dispatch integration guarded by feature flag.
 
 	// OCR Handler
-	ocrJobHandler := handler.NewOCRJobHandler(ocrJobUsecase, menuUsecase, cloudinaryStorage, notifUc)
+	ocrJobHandler := handler.NewOCRJobHandler(ocrJobUsecase, menuUsecase, restaurantUsecase, cloudinaryStorage, notifUc, pdfRenderer, env.OCRMaxPages)
+	ocrJobHandler.DuplicateWindow = time.Duration(env.OCRDuplicateWindowDays) * 24 * time.Hour
+	ocrJobHandler.Usage = usageUc
+	parseResultHandler := handler.NewAIParseResultHandler(parseResultUsecase, restaurantUsecase)
 
 	// Single canonical OCR route group (legacy /ocr-jobs removed)
 	protected := group.Group("/ocr")
 	protected.Use(middleware.AuthMiddleware(*env))
 	{
 		protected.POST("/upload", ocrJobHandler.UploadMenu)
+		protected.GET("/queue", middleware.AdminOnly(), ocrJobHandler.QueueStats)
+		protected.GET("/jobs", ocrJobHandler.ListOCRJobs)
+		protected.GET("/restaurants/:slug/jobs", ocrJobHandler.ListRestaurantOCRJobs)
 		protected.GET("/:id", ocrJobHandler.GetOCRJobByID) // endpoint returns JSON for job
+		protected.GET("/:id/events", ocrJobHandler.StreamOCRJob)
 		protected.DELETE("/:id", ocrJobHandler.DeleteOCRJob)
 		protected.POST("/:id/retry", ocrJobHandler.RetryOCRJob)
+		protected.POST("/:id/cancel", ocrJobHandler.CancelOCRJob)
+
+		// review of the menu read by a job before it becomes a draft
+		protected.GET("/parse-results/:id", parseResultHandler.GetParseResult)
+		protected.PATCH("/parse-results/:id/items/:item_id", parseResultHandler.ReviewItem)
+		protected.GET("/parse-results/:id/changes", parseResultHandler.PreviewMerge)
+		protected.POST("/parse-results/:id/approve", parseResultHandler.ApproveParseResult)
+		protected.DELETE("/parse-results/:id", parseResultHandler.DeleteParseResult)
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
 	mongo "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database"
 	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/repositories"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
 	handler "github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/handlers"
 	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/middleware"
 	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
@@ -17,10 +18,10 @@ func NewQRCodeRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Databa
 	// context time out
 	ctxTimeout := time.Duration(env.CtxTSeconds) * time.Second
 
-	qrRepo := repositories.NewQRCodeRepository(db, env.QRCodeCollection)
-	qrUsecase := usecase.NewQRCodeUseCase(qrRepo, ctxTimeout)
-
-	qrHandler := handler.NewQRCodeHandler(qrUsecase, notifUc)
+	restaurantRepo := repositories.NewRestaurantRepo(db, env.RestaurantCollection)
+	storage := services.NewCloudinaryStorage(env.CloudinaryName, env.CloudinaryAPIKey, env.CloudinarySecret)
+	restaurantUsecase := usecase.NewRestaurantUsecase(restaurantRepo, ctxTimeout, storage)
+	qrHandler := handler.NewQRCodeHandler(newQRCodeUseCase(env, db, ctxTimeout), notifUc, restaurantUsecase)
 
 	protected := group.Group("/qr-code")
 	protected.Use(middleware.AuthMiddleware(*env))
@@ -29,5 +30,26 @@ func NewQRCodeRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Databa
 		protected.GET("/:restaurant_slug", qrHandler.GetQRCode)
 		protected.PATCH("/:restaurant_slug/:status", qrHandler.UpdateQRCodeStatus)
 		protected.DELETE("/:restaurant_slug", qrHandler.DeleteQRCode)
+		// a restaurant has a code per table and location
+		protected.GET("/:restaurant_slug/codes", qrHandler.ListQRCodes)
+		protected.GET("/:restaurant_slug/codes/:id", qrHandler.GetQRCodeByID)
+		protected.PATCH("/:restaurant_slug/codes/:id", qrHandler.UpdateQRCode)
+		protected.DELETE("/:restaurant_slug/codes/:id", qrHandler.DeleteQRCodeByID)
 	}
 }
+
+// NewQRScanRoutes serves the short links encoded in QR codes, /q/:id, which
+// redirect to the menu that is active when scanned.
+func NewQRScanRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Database) {
+	ctxTimeout := time.Duration(env.CtxTSeconds) * time.Second
+	qrHandler := handler.NewQRCodeHandler(newQRCodeUseCase(env, db, ctxTimeout), nil, nil)
+	group.GET("/q/:id", qrHandler.ScanQRCode)
+}
+
+func newQRCodeUseCase(env *bootstrap.Env, db mongo.Database, ctxTimeout time.Duration) domain.IQRCodeUseCase {
+	qrRepo := repositories.NewQRCodeRepository(db, env.QRCodeCollection)
+	qrScanRepo := repositories.NewQRScanRepository(db, env.QRScanCollection)
+	menuRepo := repositories.NewMenuRepository(db, env.MenuCollection)
+	restaurantRepo := repositories.NewRestaurantRepo(db, env.RestaurantCollection)
+	return usecase.NewQRCodeUseCase(qrRepo, qrScanRepo, menuRepo, restaurantRepo, ctxTimeout)
+}
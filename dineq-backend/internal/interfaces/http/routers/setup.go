@@ -1,10 +1,12 @@
 package routers
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/RealEskalate/G6-MenuMate/internal/bootstrap"
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
 	mongo "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database"
 	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/repositories"
 	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
@@ -14,7 +16,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func Setup(env *bootstrap.Env, timeout time.Duration, db mongo.Database, router *gin.Engine) {
+// Setup registers all routes. Background loops (menu scheduler, OCR workers,
+// webhook dispatcher) run until ctx is cancelled.
+func Setup(ctx context.Context, env *bootstrap.Env, timeout time.Duration, db mongo.Database, router *gin.Engine) {
 	// Configure CORS to allow all origins (with credentials). For stricter control, scope this down.
 	cfg := cors.Config{
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
@@ -34,22 +38,44 @@ func Setup(env *bootstrap.Env, timeout time.Duration, db mongo.Database, router
 	notifyRepo := repositories.NewNotificationRepository(db, env.NotificationCollection)
 	notificationUseCase := usecase.NewNotificationUseCase(notifyRepo, notifySvc)
 
+	// usage ledger shared by the OCR pipeline and image search
+	usageRepo := repositories.NewUsageRepository(db, env.UsageCollection)
+	usageUseCase := usecase.NewUsageUseCase(usageRepo, repositories.NewOCRJobRepository(db, env.OCRJobCollection), domain.UsageQuotas{
+		Restaurant: domain.UsageQuota{OCRPages: env.UsageQuotaRestaurantOCRPages, Tokens: env.UsageQuotaRestaurantTokens, ImageSearches: env.UsageQuotaRestaurantImageSearches},
+		User:       domain.UsageQuota{OCRPages: env.UsageQuotaUserOCRPages, Tokens: env.UsageQuotaUserTokens, ImageSearches: env.UsageQuotaUserImageSearches},
+	}, timeout)
+
+	// webhooks of restaurants, raised by the OCR pipeline and menu changes
+	webhookRepo := repositories.NewWebhookRepository(db, env.WebhookCollection, env.WebhookDeliveryCollection)
+	webhookUseCase := usecase.NewWebhookUseCase(webhookRepo, services.NewWebhookSender(services.WebhookTargets{AllowHTTP: env.AppEnv == "development"}), timeout)
+	webhookUseCase.StartDispatcher(ctx, domain.WebhookConfig{
+		MaxAttempts: env.WebhookMaxAttempts,
+		Backoff:     time.Duration(env.WebhookBackoffSeconds) * time.Second,
+		MaxBackoff:  time.Duration(env.WebhookMaxBackoffSeconds) * time.Second,
+		Timeout:     time.Duration(env.WebhookTimeoutSeconds) * time.Second,
+	})
+
 	router.GET("/", func(ctx *gin.Context) { ctx.Redirect(http.StatusPermanentRedirect, "/api") })
 
 	// Fallback routes for Google OAuth if redirect URI is configured without /api/v1 prefix
 	router.GET("/auth/google/login", func(c *gin.Context) { c.Redirect(http.StatusTemporaryRedirect, "/api/v1/auth/google/login") })
 	router.GET("/auth/google/callback", func(c *gin.Context) { c.Redirect(http.StatusTemporaryRedirect, "/api/v1/auth/google/callback") })
 
+	// short links encoded in QR codes
+	NewQRScanRoutes(env, &router.RouterGroup, db)
+
 	api := router.Group("/api/v1")
 	{
 		NewAuthRoutes(env, api, db)
 		NewUserRoutes(env, api, db)
-		NewOCRJobRoutes(env, api, db, notificationUseCase)
+		NewOCRJobRoutes(ctx, env, api, db, notificationUseCase, usageUseCase, webhookUseCase)
 		NewNotificationRoutes(env, api, db, notifySvc, notificationUseCase)
 		NewRestaurantRoutes(env, api, db)
-		NewImageSearchRoutes(env, api)
+		NewImageSearchRoutes(env, api, db, usageUseCase)
+		NewUsageRoutes(env, api, usageUseCase)
+		NewWebhookRoutes(env, api, db, webhookUseCase)
 		NewReactionRoutes(env, api, db)
-		NewMenuRoutes(env, api, db, notificationUseCase)
+		NewMenuRoutes(ctx, env, api, db, notificationUseCase, webhookUseCase)
 		NewQRCodeRoutes(env, api, db, notificationUseCase)
 		NewUploadRoutes(env, api)
 		NewItemRoutes(env, api, db, notifySvc)
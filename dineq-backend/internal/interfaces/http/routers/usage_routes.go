@@ -0,0 +1,18 @@
+package routers
+
+import (
+	"github.com/RealEskalate/G6-MenuMate/internal/bootstrap"
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	handler "github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/handlers"
+	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewUsageRoutes(env *bootstrap.Env, group *gin.RouterGroup, usageUc domain.IUsageUseCase) {
+	h := handler.NewUsageHandler(usageUc)
+	g := group.Group("/usage")
+	g.Use(middleware.AuthMiddleware(*env), middleware.AdminOnly())
+	{
+		g.GET("", h.Report)
+	}
+}
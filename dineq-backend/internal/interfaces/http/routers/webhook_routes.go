@@ -0,0 +1,36 @@
+package routers
+
+import (
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/bootstrap"
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	mongo "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/repositories"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	handler "github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/handlers"
+	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/middleware"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+func NewWebhookRoutes(env *bootstrap.Env, group *gin.RouterGroup, db mongo.Database, webhookUc domain.IWebhookUseCase) {
+	ctxTimeout := time.Duration(env.CtxTSeconds) * time.Second
+	restaurantRepo := repositories.NewRestaurantRepo(db, env.RestaurantCollection)
+	storage := services.NewCloudinaryStorage(env.CloudinaryName, env.CloudinaryAPIKey, env.CloudinarySecret)
+	restaurantUsecase := usecase.NewRestaurantUsecase(restaurantRepo, ctxTimeout, storage)
+	h := handler.NewWebhookHandler(webhookUc, restaurantUsecase)
+
+	g := group.Group("/webhooks")
+	g.Use(middleware.AuthMiddleware(*env))
+	{
+		g.GET("/events", h.ListEvents)
+		g.POST("/restaurants/:slug", h.CreateWebhook)
+		g.GET("/restaurants/:slug", h.ListWebhooks)
+		g.PATCH("/restaurants/:slug/:id", h.UpdateWebhook)
+		g.DELETE("/restaurants/:slug/:id", h.DeleteWebhook)
+		g.GET("/restaurants/:slug/:id/deliveries", h.ListDeliveries)
+		g.GET("/restaurants/:slug/:id/deliveries/:delivery_id", h.GetDelivery)
+		g.POST("/restaurants/:slug/:id/deliveries/:delivery_id/redeliver", h.Redeliver)
+	}
+}
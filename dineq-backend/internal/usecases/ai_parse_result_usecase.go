@@ -1,64 +1,358 @@
 package usecase
 
-// import (
-// 	"context"
-// 	"time"
-
-// 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
-// 	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
-// 	"github.com/veryfi/veryfi-go/veryfi/scheme"
-// )
-
-// type AIParseResultUseCase struct {
-// 	repo      domain.IAIParseResultRepository
-// 	aiService services.IAIService
-// }
-
-// func NewAIParseResultUseCase(repo domain.IAIParseResultRepository, aiService services.IAIService) *AIParseResultUseCase {
-// 	return &AIParseResultUseCase{repo: repo, aiService: aiService}
-// }
-
-// func (uc *AIParseResultUseCase) CreateAIParseResult(ctx context.Context, result *domain.AIParseResult) error {
-// 	result.CreatedAt = time.Now()
-// 	result.UpdatedAt = time.Now()
-// 	return uc.repo.Create(ctx, result)
-// }
-
-// func (uc *AIParseResultUseCase) GetAIParseResultByID(ctx context.Context, id string) (*domain.AIParseResult, error) {
-// 	return uc.repo.GetByID(ctx, id)
-// }
-
-// func (uc *AIParseResultUseCase) ApproveParseResult(ctx context.Context, id string) error {
-// 	result, err := uc.repo.GetByID(ctx, id)
-// 	if err != nil {
-// 		return err
-// 	}
-// 	// Add approval logic here
-// 	result.UpdatedAt = time.Now()
-// 	return uc.repo.Create(ctx, result)
-// }
-
-// func (uc *AIParseResultUseCase) DeleteAIParseResult(ctx context.Context, id string) error {
-// 	result, err := uc.repo.GetByID(ctx, id)
-// 	if err != nil {
-// 		return err
-// 	}
-// 	result.UpdatedAt = time.Now()
-// 	return uc.repo.Create(ctx, result)
-// }
-
-// func (uc *AIParseResultUseCase) StructureMenuItems(ctx context.Context, result *domain.AIParseResult) (*domain.Menu, error) {
-// 	// Convert line items to MenuItem format using aiService
-// 	lineItems := uc.parseLineItems(result.RawText) // Placeholder parsing
-// 	menuItem, err := uc.aiService.StructureWithGemini(ctx, lineItems)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	return menuItem, nil
-// }
-
-// func (uc *AIParseResultUseCase) parseLineItems(rawText string) []scheme.LineItem {
-// 	// Placeholder: Parse rawText into line items
-// 	// This should be implemented based on Veryfi response structure
-// 	return []scheme.LineItem{} // Replace with actual parsing logic
-// }
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	utils "github.com/RealEskalate/G6-MenuMate/Utils"
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
+)
+
+type AIParseResultUseCase struct {
+	repo        domain.IAIParseResultRepository
+	menuUseCase domain.IMenuUseCase
+	ctxTimeout  time.Duration
+}
+
+func NewAIParseResultUseCase(repo domain.IAIParseResultRepository, menuUseCase domain.IMenuUseCase, ctxTimeout time.Duration) domain.IAIParseResultUseCase {
+	return &AIParseResultUseCase{repo: repo, menuUseCase: menuUseCase, ctxTimeout: ctxTimeout}
+}
+
+func (uc *AIParseResultUseCase) GetAIParseResultByID(id string) (*domain.AIParseResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	return uc.repo.GetByID(ctx, id)
+}
+
+func (uc *AIParseResultUseCase) ReviewItem(id, itemID, userID string, review domain.ParsedItemReview) (*domain.AIParseResult, error) {
+	if review.Status != domain.ParsedItemAccepted && review.Status != domain.ParsedItemRejected {
+		return nil, domain.ErrInvalidInput
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	result, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if result.Status != domain.ParseResultPendingReview {
+		return nil, domain.ErrParseResultApproved
+	}
+	item := result.FindItem(itemID)
+	if item == nil {
+		return nil, domain.ErrMenuItemNotFound
+	}
+
+	if review.Status == domain.ParsedItemAccepted && review.Edit != nil {
+		edit := *review.Edit
+		if edit.Price <= 0 {
+			return nil, domain.ErrInvalidInput
+		}
+		if err := domain.ValidateModifierGroups(edit.ModifierGroups); err != nil {
+			return nil, err
+		}
+		if item.Original == nil {
+			original := item.Item
+			item.Original = &original
+		}
+		edit.ID = item.Item.ID
+		if edit.Currency == "" {
+			edit.Currency = item.Item.Currency
+		}
+		item.Item = edit
+	}
+	if review.Tab != "" {
+		item.Tab = review.Tab
+	}
+	if review.Category != "" {
+		item.Category = review.Category
+	}
+	item.Status = review.Status
+	// an item that was ever edited no longer is what the model read
+	if review.Status == domain.ParsedItemAccepted && item.Original != nil {
+		item.Status = domain.ParsedItemEdited
+	}
+	now := time.Now().UTC()
+	item.ReviewedBy, item.ReviewedAt = userID, &now
+
+	if err := uc.repo.UpdateItem(ctx, id, *item); err != nil {
+		return nil, err
+	}
+	result.UpdatedAt = now
+	return result, nil
+}
+
+func (uc *AIParseResultUseCase) PreviewMerge(id string) (*domain.MenuChangeSet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	result, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if result.TargetMenuID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	target, err := uc.menuUseCase.GetByID(result.TargetMenuID)
+	if err != nil {
+		return nil, err
+	}
+	// merge mode results are read for the restaurant of their target menu
+	if target.RestaurantID != result.RestaurantID {
+		return nil, domain.ErrForbidden
+	}
+	return PlanMenuMerge(target, result), nil
+}
+
+func (uc *AIParseResultUseCase) ApproveParseResult(id string, menu *domain.Menu, keepRemoved bool) (*domain.MenuChangeSet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	result, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if result.Status != domain.ParseResultPendingReview {
+		return nil, domain.ErrParseResultApproved
+	}
+	if result.PendingItems() > 0 {
+		return nil, domain.ErrParseResultNotReviewed
+	}
+	tabs, items := ReviewedMenuContent(result)
+	if len(items) == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	for _, it := range items {
+		if it.Price <= 0 {
+			return nil, domain.ErrInvalidInput
+		}
+	}
+
+	// the menu was read for the restaurant verified at upload; it can only be
+	// approved into that restaurant
+	if result.RestaurantID == "" || result.RestaurantID != menu.RestaurantID {
+		return nil, domain.ErrForbidden
+	}
+	var target *domain.Menu
+	if result.TargetMenuID != "" {
+		if target, err = uc.menuUseCase.GetByID(result.TargetMenuID); err != nil {
+			return nil, err
+		}
+		if target.RestaurantID != menu.RestaurantID {
+			return nil, domain.ErrForbidden
+		}
+	}
+
+	// claim the result first so two approvals cannot create two menus
+	now := time.Now().UTC()
+	if err := uc.repo.MarkApproved(ctx, id, menu.CreatedBy, now); err != nil {
+		return nil, err
+	}
+	reopen := func() {
+		if rerr := uc.repo.Reopen(context.Background(), id); rerr != nil {
+			logger.Log.Error().Str("parse_result_id", id).Err(rerr).Msg("Failed to reopen parse result after the menu could not be saved")
+		}
+	}
+
+	var changes *domain.MenuChangeSet
+	if target == nil {
+		menu.Tabs, menu.Items = tabs, items
+		if err := uc.menuUseCase.CreateMenu(menu); err != nil {
+			reopen()
+			return nil, err
+		}
+	} else {
+		var mergeItems []domain.Item
+		var removeIDs []string
+		changes, mergeItems, removeIDs = planMenuMerge(target, result)
+		if keepRemoved {
+			changes.Removed, removeIDs = nil, nil
+		}
+		if err := uc.menuUseCase.MergeMenuItems(target.ID, menu.CreatedBy, mergeItems, removeIDs); err != nil {
+			reopen()
+			return nil, err
+		}
+		merged, err := uc.menuUseCase.GetByID(target.ID)
+		if err != nil {
+			merged = target
+		}
+		*menu = *merged
+	}
+	if err := uc.repo.SetMenuID(ctx, id, menu.ID); err != nil {
+		logger.Log.Error().Str("parse_result_id", id).Str("menu_id", menu.ID).Err(err).Msg("Failed to link parse result to its menu")
+	}
+	return changes, nil
+}
+
+func (uc *AIParseResultUseCase) DeleteAIParseResult(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	return uc.repo.Delete(ctx, id)
+}
+
+// ReviewedMenuContent lays out the accepted and edited items of a parse result
+// as the tabs and tagged flat item list stored on a menu.
+func ReviewedMenuContent(result *domain.AIParseResult) ([]domain.Tab, []domain.Item) {
+	var tabs []domain.Tab
+	var items []domain.Item
+	for i := range result.Items {
+		p := &result.Items[i]
+		if !p.Kept() {
+			continue
+		}
+		item := reviewedItem(p)
+		cat := importCategory(&tabs, item.TabTags[0], nil, item.Category, nil)
+		cat.Items = append(cat.Items, item)
+		items = append(items, item)
+	}
+	return tabs, items
+}
+
+// reviewedItem is a parsed item as stored on a menu, tagged with its tab first
+// and carrying its category.
+func reviewedItem(p *domain.ParsedItem) domain.Item {
+	item := p.Item
+	item.Category = firstNonBlank(p.Category, defaultImportSection)
+	tabName := firstNonBlank(p.Tab, defaultImportSection)
+	tags := []string{tabName}
+	for _, t := range item.TabTags {
+		if !strings.EqualFold(t, tabName) {
+			tags = append(tags, t)
+		}
+	}
+	item.TabTags = tags
+	return item
+}
+
+// BuildParseResult turns a structured menu into a parse result awaiting
+// review, scoring each item against the OCR text it was read from.
+func BuildParseResult(job *domain.OCRJob, menu *domain.Menu, source string) *domain.AIParseResult {
+	now := time.Now().UTC()
+	result := &domain.AIParseResult{
+		OCRJobID:     job.ID,
+		RestaurantID: job.RestaurantID,
+		UserID:       job.UserID,
+		TargetMenuID: job.TargetMenuID,
+		RawText:      source,
+		Status:       domain.ParseResultPendingReview,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	src := normalizeParseSource(source)
+	names := map[string]int{}
+	for _, tab := range menu.Tabs {
+		for _, cat := range tab.Categories {
+			for _, it := range cat.Items {
+				names[strings.ToLower(strings.TrimSpace(it.Name))]++
+			}
+		}
+	}
+	var total float64
+	for _, tab := range menu.Tabs {
+		for _, cat := range tab.Categories {
+			for _, it := range cat.Items {
+				confidence, flags := scoreParsedItem(&it, src, names)
+				result.Items = append(result.Items, domain.ParsedItem{
+					ID:         utils.GenerateUUID(),
+					Tab:        tab.Name,
+					Category:   cat.Name,
+					Item:       it,
+					Confidence: confidence,
+					Flags:      flags,
+					Status:     domain.ParsedItemPending,
+				})
+				total += confidence
+			}
+		}
+	}
+	if n := len(result.Items); n > 0 {
+		result.ConfidenceScore = math.Round(total/float64(n)*100) / 100
+	}
+	return result
+}
+
+// scoreParsedItem estimates how likely the model read an item correctly. The
+// model gives no confidence of its own, so the score checks what can be
+// checked: that the name and price appear in the OCR text, that the price is
+// set, and that the name is not read twice.
+func scoreParsedItem(it *domain.Item, src string, names map[string]int) (float64, []string) {
+	score := 1.0
+	var flags []string
+	if it.Price <= 0 {
+		score -= 0.4
+		flags = append(flags, domain.ParseFlagPriceMissing)
+	} else if !priceInSource(it.Price, src) {
+		score -= 0.2
+		flags = append(flags, domain.ParseFlagPriceNotInSource)
+	}
+	am := it.Translation(domain.LangAmharic).Name
+	if !nameInSource(it.Name, src) && !nameInSource(am, src) {
+		score -= 0.25
+		flags = append(flags, domain.ParseFlagNameNotInSource)
+	}
+	if names[strings.ToLower(strings.TrimSpace(it.Name))] > 1 {
+		score -= 0.15
+		flags = append(flags, domain.ParseFlagDuplicateName)
+	}
+	if strings.TrimSpace(am) == "" {
+		score -= 0.05
+		flags = append(flags, domain.ParseFlagTranslationMissing)
+	}
+	return math.Round(math.Max(score, 0)*100) / 100, flags
+}
+
+// normalizeParseSource lowercases OCR text and drops thousands separators so
+// "1,200" matches a price of 1200.
+func normalizeParseSource(s string) string {
+	var b strings.Builder
+	runes := []rune(strings.ToLower(s))
+	for i, r := range runes {
+		if r == ',' && i > 0 && i+1 < len(runes) && unicode.IsDigit(runes[i-1]) && unicode.IsDigit(runes[i+1]) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func priceInSource(price float64, src string) bool {
+	candidates := []string{strconv.FormatFloat(price, 'f', -1, 64), strconv.FormatFloat(price, 'f', 2, 64)}
+	for _, c := range candidates {
+		for i := strings.Index(src, c); i >= 0; {
+			end := i + len(c)
+			before := i == 0 || !unicode.IsDigit(rune(src[i-1]))
+			after := end == len(src) || !unicode.IsDigit(rune(src[end]))
+			if before && after {
+				return true
+			}
+			next := strings.Index(src[i+1:], c)
+			if next < 0 {
+				break
+			}
+			i += next + 1
+		}
+	}
+	return false
+}
+
+// nameInSource reports whether most words of name (of three or more letters)
+// occur in the OCR text.
+func nameInSource(name, src string) bool {
+	var words, found int
+	for _, w := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if len([]rune(w)) < 3 {
+			continue
+		}
+		words++
+		if strings.Contains(src, w) {
+			found++
+		}
+	}
+	if words == 0 {
+		name = strings.ToLower(strings.TrimSpace(name))
+		return name != "" && strings.Contains(src, name)
+	}
+	return found*2 >= words
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"strings"
+
+	utils "github.com/RealEskalate/G6-MenuMate/Utils"
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+// mergeModifierGroups applies an incoming modifier group list onto an item's
+// existing groups. The incoming list is authoritative for which groups and
+// options exist and in what order; groups and options are matched by ID and
+// then by name so they keep their IDs across edits.
+func mergeModifierGroups(existing, incoming []domain.ModifierGroup) []domain.ModifierGroup {
+	byID := make(map[string]*domain.ModifierGroup, len(existing))
+	byName := make(map[string]*domain.ModifierGroup, len(existing))
+	for i := range existing {
+		g := &existing[i]
+		if g.ID != "" {
+			byID[g.ID] = g
+		}
+		if key := modifierKey(g.Name, g.Translations); key != "" {
+			byName[key] = g
+		}
+	}
+
+	merged := make([]domain.ModifierGroup, len(incoming))
+	for i, in := range incoming {
+		match, ok := byID[in.ID]
+		if !ok {
+			match = byName[modifierKey(in.Name, in.Translations)]
+		}
+		if match != nil {
+			in.ID = match.ID
+			in.Options = mergeModifierOptions(match.Options, in.Options)
+		} else {
+			in.ID = ""
+			in.Options = mergeModifierOptions(nil, in.Options)
+		}
+		if in.ID == "" {
+			in.ID = utils.GenerateUUID()
+		}
+		merged[i] = in
+	}
+	return merged
+}
+
+func mergeModifierOptions(existing, incoming []domain.ModifierOption) []domain.ModifierOption {
+	byID := make(map[string]string, len(existing))
+	byName := make(map[string]string, len(existing))
+	for _, o := range existing {
+		if o.ID != "" {
+			byID[o.ID] = o.ID
+		}
+		if key := modifierKey(o.Name, o.Translations); key != "" {
+			byName[key] = o.ID
+		}
+	}
+	merged := make([]domain.ModifierOption, len(incoming))
+	for i, in := range incoming {
+		id, ok := byID[in.ID]
+		if !ok {
+			id = byName[modifierKey(in.Name, in.Translations)]
+		}
+		if id == "" {
+			id = utils.GenerateUUID()
+		}
+		in.ID = id
+		merged[i] = in
+	}
+	return merged
+}
+
+// assignModifierIDs gives new groups and options an ID.
+func assignModifierIDs(item *domain.Item) {
+	if len(item.ModifierGroups) > 0 {
+		item.ModifierGroups = mergeModifierGroups(nil, item.ModifierGroups)
+	}
+}
+
+func modifierKey(name string, translations domain.Translations) string {
+	if key := strings.ToLower(strings.TrimSpace(name)); key != "" {
+		return key
+	}
+	for _, lang := range domain.SupportedLanguages {
+		if key := strings.TrimSpace(translations[lang]); key != "" {
+			return key
+		}
+	}
+	return ""
+}
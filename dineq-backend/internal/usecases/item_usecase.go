@@ -24,6 +24,10 @@ func (uc *ItemUseCase) CreateItem(item *domain.Item) error {
 	item.CreatedAt = time.Now()
 	item.UpdatedAt = time.Now()
 	item.Slug = utils.GenerateSlug(item.Name)
+	if err := domain.ValidateModifierGroups(item.ModifierGroups); err != nil {
+		return err
+	}
+	assignModifierIDs(item)
 	return uc.repo.CreateItem(ctx, item)
 }
 
@@ -31,6 +35,19 @@ func (uc *ItemUseCase) UpdateItem(id string, item *domain.Item) error {
 	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
 	defer cancel()
 
+	if err := domain.ValidateModifierGroups(item.ModifierGroups); err != nil {
+		return err
+	}
+	// keep the stored groups when none are sent so the price range follows price changes
+	existing, err := uc.repo.GetItemByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if item.ModifierGroups == nil {
+		item.ModifierGroups = existing.ModifierGroups
+	} else {
+		item.ModifierGroups = mergeModifierGroups(existing.ModifierGroups, item.ModifierGroups)
+	}
 	item.UpdatedAt = time.Now()
 	return uc.repo.UpdateItem(ctx, id, item)
 }
@@ -42,6 +59,14 @@ func (uc *ItemUseCase) GetItemByID(id string) (*domain.Item, error) {
 	return uc.repo.GetItemByID(ctx, id)
 }
 
+// GetPublishedItem returns an item as customers see it on the published menu.
+func (uc *ItemUseCase) GetPublishedItem(menuSlug, id string) (*domain.Item, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	return uc.repo.GetPublishedItem(ctx, menuSlug, id)
+}
+
 func (uc *ItemUseCase) AddReview(itemID, reviewID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
 	defer cancel()
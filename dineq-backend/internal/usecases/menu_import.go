@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	utils "github.com/RealEskalate/G6-MenuMate/Utils"
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+const defaultImportSection = "General"
+
+// ImportMenu validates an import and, unless dryRun is set or a row failed,
+// creates the menu from it through the regular CreateMenu path. menu carries
+// the restaurant, author and name; its tabs and items come from the import.
+func (uc *MenuUseCase) ImportMenu(menu *domain.Menu, imp *domain.MenuImport, defaultCurrency string, dryRun bool) (*domain.MenuImportReport, error) {
+	tabs, items, report := BuildImportedMenu(imp, defaultCurrency)
+	report.DryRun = dryRun
+	if report.HasIssues() {
+		if dryRun {
+			return report, nil
+		}
+		return report, domain.ErrInvalidMenuImport
+	}
+	if dryRun {
+		return report, nil
+	}
+	if strings.TrimSpace(menu.Name) == "" {
+		menu.Name = imp.Name
+	}
+	menu.Tabs = tabs
+	menu.Items = items
+	if err := uc.CreateMenu(menu); err != nil {
+		return report, err
+	}
+	report.MenuID = menu.ID
+	return report, nil
+}
+
+// BuildImportedMenu validates every import row and maps the valid ones onto
+// tabs, categories and the flat item list stored on a menu. Items carry their
+// tab and category so the structure survives persistence.
+func BuildImportedMenu(imp *domain.MenuImport, defaultCurrency string) ([]domain.Tab, []domain.Item, *domain.MenuImportReport) {
+	report := &domain.MenuImportReport{TotalRows: len(imp.Rows), Issues: []domain.MenuImportIssue{}}
+	if len(imp.Rows) == 0 {
+		report.Issues = append(report.Issues, domain.MenuImportIssue{Field: "items", Message: "import contains no items"})
+		return nil, nil, report
+	}
+	defaultCurrency = strings.ToUpper(strings.TrimSpace(defaultCurrency))
+	if !domain.IsSupportedCurrency(defaultCurrency) {
+		defaultCurrency = "ETB"
+	}
+
+	var tabs []domain.Tab
+	var items []domain.Item
+	slugRows := map[string]int{}
+	for _, row := range imp.Rows {
+		issue := func(field, value, msg string) {
+			report.Issues = append(report.Issues, domain.MenuImportIssue{Row: row.Row, Field: field, Value: value, Message: msg})
+		}
+		before := len(report.Issues)
+
+		name := row.Name
+		for _, lang := range domain.SupportedLanguages {
+			if name == "" {
+				name = row.Translations[lang].Name
+			}
+		}
+		if name == "" {
+			issue("name", "", "name is required")
+		}
+		price, err := parseImportPrice(row.Price)
+		if err != nil {
+			issue("price", row.Price, err.Error())
+		}
+		currency := strings.ToUpper(strings.TrimSpace(row.Currency))
+		if currency == "" {
+			currency = defaultCurrency
+		} else if !domain.IsSupportedCurrency(currency) {
+			issue("currency", row.Currency, "unknown currency, expected one of "+strings.Join(domain.SupportedCurrencies, ", "))
+		}
+		// explicit slugs are kept as written; otherwise one is derived from the name
+		key := utils.SlugBase(firstNonBlank(row.Slug, name))
+		slug := key
+		if row.Slug == "" && name != "" {
+			slug = utils.GenerateSlug(name)
+		}
+		switch {
+		case row.Slug != "" && key == "":
+			issue("slug", row.Slug, "slug has no letters or digits")
+		case key != "":
+			if first, dup := slugRows[key]; dup {
+				issue("slug", key, fmt.Sprintf("duplicate slug, already used on row %d", first))
+			} else {
+				slugRows[key] = row.Row
+			}
+		}
+		if len(report.Issues) > before {
+			continue
+		}
+
+		tabName := firstNonBlank(row.Tab, defaultImportSection)
+		catName := firstNonBlank(row.Category, defaultImportSection)
+		item := domain.Item{
+			Name:        row.Name,
+			Slug:        slug,
+			Description: row.Description,
+			Price:       price,
+			Currency:    currency,
+			Ingredients: row.Ingredients,
+			Allergies:   row.Allergies,
+			TabTags:     []string{tabName},
+			Category:    catName,
+		}
+		var tabNames, catNames domain.Translations
+		for lang, t := range row.Translations {
+			it := domain.ItemTranslation{Name: t.Name, Description: t.Description, Category: t.Category}
+			if t.Tab != "" {
+				it.TabTags = []string{t.Tab}
+			}
+			item.SetTranslation(lang, it)
+			tabNames = tabNames.With(lang, t.Tab)
+			catNames = catNames.With(lang, t.Category)
+		}
+		cat := importCategory(&tabs, tabName, tabNames, catName, catNames)
+		cat.Items = append(cat.Items, item)
+		items = append(items, item)
+		report.ValidRows++
+	}
+	return tabs, items, report
+}
+
+// importCategory finds or appends the named tab and category.
+func importCategory(tabs *[]domain.Tab, tabName string, tabNames domain.Translations, catName string, catNames domain.Translations) *domain.Category {
+	var tab *domain.Tab
+	for i := range *tabs {
+		if strings.EqualFold((*tabs)[i].Name, tabName) {
+			tab = &(*tabs)[i]
+			break
+		}
+	}
+	if tab == nil {
+		*tabs = append(*tabs, domain.Tab{ID: utils.GenerateUUID(), Name: tabName, Translations: tabNames})
+		tab = &(*tabs)[len(*tabs)-1]
+	}
+	for i := range tab.Categories {
+		if strings.EqualFold(tab.Categories[i].Name, catName) {
+			return &tab.Categories[i]
+		}
+	}
+	tab.Categories = append(tab.Categories, domain.Category{ID: utils.GenerateUUID(), TabID: tab.ID, Name: catName, Translations: catNames})
+	return &tab.Categories[len(tab.Categories)-1]
+}
+
+// parseImportPrice accepts plain decimals with optional thousands separators.
+func parseImportPrice(raw string) (float64, error) {
+	s := strings.ReplaceAll(strings.TrimSpace(raw), ",", "")
+	if s == "" {
+		return 0, fmt.Errorf("price is required")
+	}
+	p, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(p) || math.IsInf(p, 0) {
+		return 0, fmt.Errorf("price is not a number")
+	}
+	if p <= 0 {
+		return 0, fmt.Errorf("price must be greater than zero")
+	}
+	return p, nil
+}
+
+func firstNonBlank(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"maps"
+	"strings"
+	"unicode"
+
+	utils "github.com/RealEskalate/G6-MenuMate/Utils"
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+// fuzzyNameThreshold is the similarity (1 - edit distance / length) above
+// which two item names are taken to be the same item read differently.
+const fuzzyNameThreshold = 0.8
+
+// PlanMenuMerge works out what merging the reviewed items of a parse result
+// into existing would change. Rejected items still match, so an item the
+// manager rejected as misread is not removed from the menu.
+func PlanMenuMerge(existing *domain.Menu, result *domain.AIParseResult) *domain.MenuChangeSet {
+	changes, _, _ := planMenuMerge(existing, result)
+	return changes
+}
+
+// planMenuMerge returns the change set with the items to hand to
+// MergeMenuItems and the IDs of the items to remove. Matched items are sent as
+// copies of the existing item carrying the new price, so the merge keeps
+// everything the photo cannot show (images, reviews, modifiers, ratings).
+func planMenuMerge(existing *domain.Menu, result *domain.AIParseResult) (*domain.MenuChangeSet, []domain.Item, []string) {
+	matches := matchParsedItems(existing.Items, result.Items)
+
+	changes := &domain.MenuChangeSet{MenuID: existing.ID}
+	var items []domain.Item
+	used := make(map[int]bool, len(matches))
+	for i := range result.Items {
+		p := &result.Items[i]
+		m, ok := matches[i]
+		if ok {
+			used[m.index] = true
+		}
+		if !p.Kept() {
+			continue
+		}
+		if !ok {
+			item := reviewedItem(p)
+			item.ID = ""
+			items = append(items, item)
+			changes.Added = append(changes.Added, domain.MenuItemChange{ParsedItemID: p.ID, Name: item.Name, NewPrice: item.Price})
+			continue
+		}
+		ex := existing.Items[m.index]
+		item := ex
+		item.ModifierGroups = nil // omitted groups are kept by the merge
+		item.Translations = maps.Clone(ex.Translations)
+		if strings.TrimSpace(item.Description) == "" {
+			item.Description = p.Item.Description
+		}
+		fillMissingTranslations(&item, &p.Item, domain.SupportedLanguages)
+		if p.Item.Price > 0 && p.Item.Price != ex.Price {
+			item.Price = p.Item.Price
+			if p.Item.Currency != "" {
+				item.Currency = p.Item.Currency
+			}
+			changes.PriceChanged = append(changes.PriceChanged, domain.MenuItemChange{
+				ParsedItemID: p.ID, ItemID: ex.ID, Name: ex.Name, OldPrice: ex.Price, NewPrice: item.Price, MatchedBy: m.by,
+			})
+		} else {
+			changes.Unchanged++
+		}
+		items = append(items, item)
+	}
+
+	var removeIDs []string
+	for i, ex := range existing.Items {
+		if used[i] {
+			continue
+		}
+		removeIDs = append(removeIDs, ex.ID)
+		changes.Removed = append(changes.Removed, domain.MenuItemChange{ItemID: ex.ID, Name: ex.Name, OldPrice: ex.Price})
+	}
+	return changes, items, removeIDs
+}
+
+type mergeMatch struct {
+	index int
+	by    string
+}
+
+// matchParsedItems pairs parsed items (by position) with existing items,
+// each existing item at most once. Exact matches on slug, Amharic name and
+// name are made for all items before any fuzzy match, so a near name never
+// takes an item another parsed item names exactly.
+func matchParsedItems(existing []domain.Item, parsed []domain.ParsedItem) map[int]mergeMatch {
+	matches := make(map[int]mergeMatch, len(parsed))
+	taken := make(map[int]bool, len(existing))
+	exact := []struct {
+		by  string
+		key func(*domain.Item) string
+	}{
+		{domain.MergeMatchSlug, itemSlugKey},
+		{domain.MergeMatchNameAm, func(it *domain.Item) string { return normalizeItemName(it.Translation(domain.LangAmharic).Name) }},
+		{domain.MergeMatchName, func(it *domain.Item) string { return normalizeItemName(it.Name) }},
+	}
+	for _, pass := range exact {
+		index := map[string]int{}
+		for i := range existing {
+			if k := pass.key(&existing[i]); k != "" && !taken[i] {
+				if _, dup := index[k]; !dup {
+					index[k] = i
+				}
+			}
+		}
+		for pi := range parsed {
+			if _, done := matches[pi]; done {
+				continue
+			}
+			k := pass.key(&parsed[pi].Item)
+			if k == "" {
+				continue
+			}
+			if ei, ok := index[k]; ok && !taken[ei] {
+				matches[pi] = mergeMatch{index: ei, by: pass.by}
+				taken[ei] = true
+			}
+		}
+	}
+
+	for pi := range parsed {
+		if _, done := matches[pi]; done {
+			continue
+		}
+		name := normalizeItemName(parsed[pi].Item.Name)
+		if name == "" {
+			continue
+		}
+		best, bestScore := -1, fuzzyNameThreshold
+		for ei := range existing {
+			if taken[ei] {
+				continue
+			}
+			if score := nameSimilarity(name, normalizeItemName(existing[ei].Name)); score >= bestScore {
+				best, bestScore = ei, score
+			}
+		}
+		if best >= 0 {
+			matches[pi] = mergeMatch{index: best, by: domain.MergeMatchFuzzy}
+			taken[best] = true
+		}
+	}
+	return matches
+}
+
+// itemSlugKey is the item's slug without the unique suffix GenerateSlug adds,
+// or the slug its name would get.
+func itemSlugKey(it *domain.Item) string {
+	slug := it.Slug
+	if slug == "" {
+		return utils.SlugBase(it.DisplayName())
+	}
+	if i := strings.LastIndexByte(slug, '-'); i > 0 && len(slug)-i-1 == 8 && isHex(slug[i+1:]) {
+		return slug[:i]
+	}
+	return slug
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if !unicode.Is(unicode.ASCII_Hex_Digit, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeItemName lowercases a name and reduces it to words of letters and
+// digits, so "Doro Wat (Special)" and "doro wat special" compare equal.
+func normalizeItemName(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// nameSimilarity is 1 minus the edit distance of a and b over the longer
+// length, in runes.
+func nameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 0
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
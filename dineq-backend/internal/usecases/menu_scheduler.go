@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
+)
+
+// scheduledBy is recorded as the actor of scheduler-driven publish/unpublish.
+const scheduledBy = "scheduler"
+
+// menuScheduler guards the single scheduler loop of the process.
+var menuScheduler struct {
+	mu      sync.Mutex
+	started bool
+}
+
+// StartMenuScheduler applies due menu publish/unpublish transitions and restores
+// items whose sold out period ended, every interval until ctx is cancelled.
+// Calling it again while the scheduler runs is a no-op.
+func StartMenuScheduler(ctx context.Context, uc domain.IMenuUseCase, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	menuScheduler.mu.Lock()
+	if menuScheduler.started {
+		menuScheduler.mu.Unlock()
+		return
+	}
+	menuScheduler.started = true
+	menuScheduler.mu.Unlock()
+
+	go func() {
+		defer func() {
+			menuScheduler.mu.Lock()
+			menuScheduler.started = false
+			menuScheduler.mu.Unlock()
+		}()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if changed, err := uc.ApplyScheduledChanges(now); err != nil {
+					logger.Log.Error().Err(err).Msg("Menu scheduler run failed")
+				} else if changed > 0 {
+					logger.Log.Info().Int("menus", changed).Msg("Menu scheduler applied scheduled transitions")
+				}
+				if restored, err := uc.RestoreExpiredAvailability(now); err != nil {
+					logger.Log.Error().Err(err).Msg("Item availability reset failed")
+				} else if restored > 0 {
+					logger.Log.Info().Int("items", restored).Msg("Menu scheduler restored sold out items")
+				}
+			}
+		}
+	}()
+}
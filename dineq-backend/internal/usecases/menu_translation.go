@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+// DefaultTranslationBatchSize caps how many strings go to the provider per call.
+const DefaultTranslationBatchSize = 40
+
+// MenuTranslator fills in missing item translations with batched machine
+// translation. Source text is deduplicated across the menu, so a tab name
+// shared by twenty items is translated once, and glossary terms are swapped
+// for placeholders before the text leaves the service.
+type MenuTranslator struct {
+	translator domain.ITranslator
+	batchSize  int
+}
+
+func NewMenuTranslator(translator domain.ITranslator, batchSize int) *MenuTranslator {
+	if batchSize <= 0 {
+		batchSize = DefaultTranslationBatchSize
+	}
+	return &MenuTranslator{translator: translator, batchSize: batchSize}
+}
+
+// pendingField is one untranslated item field; list fields have several sources.
+type pendingField struct {
+	item    int
+	field   string
+	sources []string
+}
+
+// Translate fills the untranslated fields of items into each of langs in
+// place and marks them machine translated. English is the source language and
+// is skipped. Fields that already have a translation are never overwritten.
+func (mt *MenuTranslator) Translate(ctx context.Context, items []domain.Item, langs []string, glossary []string) (*domain.MenuTranslationReport, error) {
+	if mt == nil || mt.translator == nil {
+		return nil, domain.ErrTranslationUnavailable
+	}
+	targets, err := translationTargets(langs)
+	if err != nil {
+		return nil, err
+	}
+	report := &domain.MenuTranslationReport{Languages: targets}
+	g := newGlossary(glossary)
+	for _, lang := range targets {
+		pending := untranslatedFields(items, lang)
+		var sources []string
+		seen := map[string]bool{}
+		for _, p := range pending {
+			for _, src := range p.sources {
+				if !seen[src] {
+					seen[src] = true
+					sources = append(sources, src)
+				}
+			}
+		}
+		if len(sources) == 0 {
+			continue
+		}
+		translated, verbatim, requests, err := mt.translateAll(ctx, sources, lang, g)
+		report.Requests += requests
+		if err != nil {
+			return report, err
+		}
+		for _, p := range pending {
+			out := make([]string, 0, len(p.sources))
+			machine := false
+			for _, src := range p.sources {
+				if s, ok := translated[src]; ok {
+					out = append(out, s)
+					machine = machine || !verbatim[src]
+				}
+			}
+			if len(out) != len(p.sources) {
+				report.Failed++
+				continue
+			}
+			t := items[p.item].Translation(lang)
+			switch p.field {
+			case domain.FieldName:
+				t.Name = out[0]
+			case domain.FieldDescription:
+				t.Description = out[0]
+			case domain.FieldAllergies:
+				t.Allergies = out[0]
+			case domain.FieldHowToEat:
+				t.HowToEat = out[0]
+			case domain.FieldTabTags:
+				t.TabTags = out
+			case domain.FieldIngredients:
+				t.Ingredients = out
+			}
+			if machine {
+				t.MarkMachineTranslated(p.field)
+			}
+			items[p.item].SetTranslation(lang, t)
+			report.Translated++
+		}
+	}
+	return report, nil
+}
+
+// translateAll translates unique source strings in chunks. Sources that are a
+// glossary term on their own are kept as written without a provider call
+// (reported in verbatim); sources whose placeholders do not survive the round
+// trip are left out of the result.
+func (mt *MenuTranslator) translateAll(ctx context.Context, sources []string, lang string, g *glossary) (map[string]string, map[string]bool, int, error) {
+	translated := make(map[string]string, len(sources))
+	verbatim := map[string]bool{}
+	var masked []string
+	var originals []string
+	var terms [][]string
+	for _, src := range sources {
+		if g.isTerm(src) {
+			translated[src] = src
+			verbatim[src] = true
+			continue
+		}
+		m, t := g.mask(src)
+		masked = append(masked, m)
+		originals = append(originals, src)
+		terms = append(terms, t)
+	}
+	requests := 0
+	for start := 0; start < len(masked); start += mt.batchSize {
+		end := min(start+mt.batchSize, len(masked))
+		out, err := mt.translator.TranslateBatch(ctx, masked[start:end], lang)
+		requests++
+		if err != nil {
+			return nil, nil, requests, fmt.Errorf("%w: %v", domain.ErrTranslationUnavailable, err)
+		}
+		if len(out) != end-start {
+			return nil, nil, requests, fmt.Errorf("%w: got %d translations for %d texts", domain.ErrTranslationUnavailable, len(out), end-start)
+		}
+		for i, s := range out {
+			if s, ok := unmask(s, terms[start+i]); ok && strings.TrimSpace(s) != "" {
+				translated[originals[start+i]] = strings.TrimSpace(s)
+			}
+		}
+	}
+	return translated, verbatim, requests, nil
+}
+
+// translationTargets normalizes and deduplicates the requested languages,
+// dropping English, the source language.
+func translationTargets(langs []string) ([]string, error) {
+	var out []string
+	seen := map[string]bool{}
+	for _, l := range langs {
+		lang := domain.NormalizeLanguage(l)
+		if lang == "" {
+			return nil, fmt.Errorf("%w: unsupported language %q", domain.ErrInvalidInput, l)
+		}
+		if lang == domain.LangEnglish || seen[lang] {
+			continue
+		}
+		seen[lang] = true
+		out = append(out, lang)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%w: no language to translate into", domain.ErrInvalidInput)
+	}
+	return out, nil
+}
+
+// untranslatedFields lists the item fields with base text but no translation in lang.
+func untranslatedFields(items []domain.Item, lang string) []pendingField {
+	var out []pendingField
+	for i := range items {
+		it := &items[i]
+		if it.IsDeleted {
+			continue
+		}
+		t := it.Translation(lang)
+		add := func(field string, have bool, sources ...string) {
+			var kept []string
+			for _, s := range sources {
+				if s = strings.TrimSpace(s); s != "" {
+					kept = append(kept, s)
+				}
+			}
+			if !have && len(kept) > 0 {
+				out = append(out, pendingField{item: i, field: field, sources: kept})
+			}
+		}
+		add(domain.FieldName, t.Name != "", it.Name)
+		add(domain.FieldDescription, t.Description != "", it.Description)
+		add(domain.FieldAllergies, t.Allergies != "", strings.Join(it.Allergies, ", "))
+		add(domain.FieldHowToEat, t.HowToEat != "", it.HowToEat)
+		add(domain.FieldTabTags, len(t.TabTags) > 0, it.TabTags...)
+		add(domain.FieldIngredients, len(t.Ingredients) > 0, it.Ingredients...)
+	}
+	return out
+}
+
+// glossary finds a restaurant's untranslatable dish names in menu text.
+type glossary struct {
+	terms map[string]bool
+	re    *regexp.Regexp
+}
+
+func newGlossary(terms []string) *glossary {
+	g := &glossary{terms: map[string]bool{}}
+	var quoted []string
+	for _, t := range terms {
+		t = strings.TrimSpace(t)
+		if t == "" || g.terms[strings.ToLower(t)] {
+			continue
+		}
+		g.terms[strings.ToLower(t)] = true
+		quoted = append(quoted, regexp.QuoteMeta(t))
+	}
+	if len(quoted) == 0 {
+		return g
+	}
+	// longest first, so "special kitfo" wins over "kitfo"
+	sort.SliceStable(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	g.re = regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+	return g
+}
+
+func (g *glossary) isTerm(s string) bool {
+	return g.terms[strings.ToLower(strings.TrimSpace(s))]
+}
+
+// mask replaces whole-word glossary terms in s with {{0}}, {{1}}, ... and
+// returns the replaced text in placeholder order.
+func (g *glossary) mask(s string) (string, []string) {
+	if g.re == nil {
+		return s, nil
+	}
+	var b strings.Builder
+	var terms []string
+	last := 0
+	for _, loc := range g.re.FindAllStringIndex(s, -1) {
+		if !wordBoundary(s, loc[0], loc[1]) {
+			continue
+		}
+		b.WriteString(s[last:loc[0]])
+		b.WriteString("{{" + strconv.Itoa(len(terms)) + "}}")
+		terms = append(terms, s[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(s[last:])
+	return b.String(), terms
+}
+
+// unmask puts the glossary terms back, failing if any placeholder was lost.
+func unmask(s string, terms []string) (string, bool) {
+	for i, term := range terms {
+		ph := "{{" + strconv.Itoa(i) + "}}"
+		if !strings.Contains(s, ph) {
+			return "", false
+		}
+		s = strings.ReplaceAll(s, ph, term)
+	}
+	return s, true
+}
+
+// wordBoundary reports whether s[start:end] is not part of a longer word.
+// regexp's \b only knows ASCII, which would miss terms written in Ge'ez script.
+func wordBoundary(s string, start, end int) bool {
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) }
+	if r, _ := utf8.DecodeLastRuneInString(s[:start]); start > 0 && isWord(r) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(s[end:]); end < len(s) && isWord(r) {
+		return false
+	}
+	return true
+}
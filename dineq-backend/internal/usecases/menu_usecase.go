@@ -2,22 +2,33 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"sort"
 	"strings"
 	"time"
 
 	utils "github.com/RealEskalate/G6-MenuMate/Utils"
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
 	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
 )
 
 type MenuUseCase struct {
-	menuRepo   domain.IMenuRepository
-	qrService  services.QRService
-	ctxTimeout time.Duration
+	menuRepo    domain.IMenuRepository
+	versionRepo domain.IMenuVersionRepository
+	itemRepo    domain.IItemRepository
+	notifier    domain.INotificationUseCase
+	qrService   services.QRService
+	pdfRenderer domain.IMenuPDFRenderer
+	translator  *MenuTranslator
+	webhooks    domain.IWebhookPublisher
+	ctxTimeout  time.Duration
 }
 
-func NewMenuUseCase(menuRepo domain.IMenuRepository, qrService services.QRService, ctxTimeout time.Duration) domain.IMenuUseCase {
-	return &MenuUseCase{menuRepo: menuRepo, qrService: qrService, ctxTimeout: ctxTimeout}
+// NewMenuUseCase manages menus; webhooks, when set, is told about menus
+// published and drafts changed.
+func NewMenuUseCase(menuRepo domain.IMenuRepository, versionRepo domain.IMenuVersionRepository, itemRepo domain.IItemRepository, notifier domain.INotificationUseCase, qrService services.QRService, pdfRenderer domain.IMenuPDFRenderer, translator domain.ITranslator, webhooks domain.IWebhookPublisher, ctxTimeout time.Duration) domain.IMenuUseCase {
+	return &MenuUseCase{menuRepo: menuRepo, versionRepo: versionRepo, itemRepo: itemRepo, notifier: notifier, qrService: qrService, pdfRenderer: pdfRenderer, translator: NewMenuTranslator(translator, DefaultTranslationBatchSize), webhooks: webhooks, ctxTimeout: ctxTimeout}
 }
 
 func (uc *MenuUseCase) CreateMenu(menu *domain.Menu) error {
@@ -44,16 +55,32 @@ func (uc *MenuUseCase) CreateMenu(menu *domain.Menu) error {
 
 	// Ensure each item has slug + menu slug
 	for i := range menu.Items {
-		base := strings.TrimSpace(menu.Items[i].Name)
-		if base == "" {
-			base = strings.TrimSpace(menu.Items[i].NameAm)
-		}
+		base := menu.Items[i].DisplayName()
 		if menu.Items[i].Slug == "" && base != "" {
 			menu.Items[i].Slug = utils.GenerateSlug(base)
 		}
 		menu.Items[i].MenuSlug = menu.Slug
+		if err := domain.ValidateModifierGroups(menu.Items[i].ModifierGroups); err != nil {
+			return err
+		}
+		assignModifierIDs(&menu.Items[i])
+	}
+	// A menu created as published goes live with its initial content as version 1
+	if menu.IsPublished {
+		menu.PublishedAt = time.Now()
+		menu.Published = &domain.PublishedMenu{
+			Name:        menu.Name,
+			Items:       menu.Items,
+			Version:     1,
+			PublishedAt: menu.PublishedAt,
+			PublishedBy: menu.CreatedBy,
+		}
+	}
+	if err := uc.menuRepo.Create(ctx, menu); err != nil {
+		return err
 	}
-	return uc.menuRepo.Create(ctx, menu)
+	uc.recordVersion(ctx, menu, domain.MenuVersionCreate, menu.CreatedBy)
+	return nil
 }
 
 func (uc *MenuUseCase) UpdateMenu(id string, userId string, menu *domain.Menu) error {
@@ -72,105 +99,526 @@ func (uc *MenuUseCase) UpdateMenu(id string, userId string, menu *domain.Menu) e
 	}
 
 	if len(menu.Items) > 0 {
-		// Build indices: by ID, slug, and lowercase name
-		idIndex := make(map[string]*domain.Item, len(existing.Items))
-		slugIndex := make(map[string]*domain.Item, len(existing.Items))
-		nameIndex := make(map[string]*domain.Item, len(existing.Items))
-		for i := range existing.Items {
-			it := &existing.Items[i]
-			if it.ID != "" {
-				idIndex[it.ID] = it
-			}
-			if it.Slug != "" {
-				slugIndex[it.Slug] = it
-			}
-			if it.Name != "" {
-				nameIndex[strings.ToLower(it.Name)] = it
-			}
-		}
-		for i := range menu.Items {
-			in := &menu.Items[i]
-			base := strings.TrimSpace(in.Name)
-			if base == "" {
-				base = strings.TrimSpace(in.NameAm)
-			}
-			var target *domain.Item
-			if in.ID != "" { // try ID first
-				if ex, ok := idIndex[in.ID]; ok {
-					target = ex
-				} else {
-					return domain.ErrMenuItemNotFound
-				}
+		if err := mergeMenuItems(existing, menu.Items); err != nil {
+			return err
+		}
+	}
+
+	existing.UpdatedAt = time.Now()
+	existing.UpdatedBy = userId
+	saved, err := uc.menuRepo.Update(ctx, id, existing)
+	if err != nil {
+		return err
+	}
+	uc.recordVersion(ctx, saved, domain.MenuVersionUpdate, userId)
+	return nil
+}
+
+// mergeMenuItems applies items to existing: an item matched by ID, slug or
+// name updates the existing one in place, keeping its ID, reviews, ratings and
+// view count; anything else is appended as a new item. Nothing is removed.
+func mergeMenuItems(existing *domain.Menu, items []domain.Item) error {
+	// Build indices: by ID, slug, and lowercase name
+	idIndex := make(map[string]*domain.Item, len(existing.Items))
+	slugIndex := make(map[string]*domain.Item, len(existing.Items))
+	nameIndex := make(map[string]*domain.Item, len(existing.Items))
+	for i := range existing.Items {
+		it := &existing.Items[i]
+		if it.ID != "" {
+			idIndex[it.ID] = it
+		}
+		if it.Slug != "" {
+			slugIndex[it.Slug] = it
+		}
+		if it.Name != "" {
+			nameIndex[strings.ToLower(it.Name)] = it
+		}
+	}
+	// new items are appended after the loop: growing existing.Items here would
+	// leave the index pointers on the old backing array
+	var added []domain.Item
+	for i := range items {
+		in := &items[i]
+		if err := domain.ValidateModifierGroups(in.ModifierGroups); err != nil {
+			return err
+		}
+		base := in.DisplayName()
+		var target *domain.Item
+		if in.ID != "" { // try ID first
+			if ex, ok := idIndex[in.ID]; ok {
+				target = ex
+			} else {
+				return domain.ErrMenuItemNotFound
 			}
-			if target == nil && in.Slug != "" { // fallback slug
-				if ex, ok := slugIndex[in.Slug]; ok {
-					target = ex
-				}
+		}
+		if target == nil && in.Slug != "" { // fallback slug
+			if ex, ok := slugIndex[in.Slug]; ok {
+				target = ex
 			}
-			if target == nil && base != "" { // fallback name (best-effort)
-				if ex, ok := nameIndex[strings.ToLower(base)]; ok {
-					target = ex
-				}
+		}
+		if target == nil && base != "" { // fallback name (best-effort)
+			if ex, ok := nameIndex[strings.ToLower(base)]; ok {
+				target = ex
 			}
-			if target != nil { // update existing
-				target.Name = in.Name
-				target.NameAm = in.NameAm
-				target.Description = in.Description
-				target.DescriptionAm = in.DescriptionAm
-				target.Price = in.Price
-				target.Currency = in.Currency
-				target.Allergies = in.Allergies
-				target.AllergiesAm = in.AllergiesAm
-				target.TabTags = in.TabTags
-				target.TabTagsAm = in.TabTagsAm
-				target.NutritionalInfo = in.NutritionalInfo
-				target.Calories = in.Calories
-				target.Protein = in.Protein
-				target.Carbs = in.Carbs
-				target.Fat = in.Fat
-				target.PreparationTime = in.PreparationTime
-				target.HowToEat = in.HowToEat
-				target.HowToEatAm = in.HowToEatAm
-				target.UpdatedAt = time.Now()
-				// If ID update did not provide slug but existing has none & we have base name, generate
-				if target.Slug == "" && base != "" {
-					target.Slug = utils.GenerateSlug(base)
-				}
-			} else { // create new item
-				if base != "" && in.Slug == "" {
-					in.Slug = utils.GenerateSlug(base)
-				}
-				in.MenuSlug = existing.Slug
-				in.CreatedAt = time.Now()
-				in.UpdatedAt = time.Now()
-				// ensure ID (if provided) retained; if empty DB layer will assign
-				existing.Items = append(existing.Items, *in)
+		}
+		if target != nil { // update existing
+			target.Name = in.Name
+			target.Description = in.Description
+			target.Price = in.Price
+			target.Currency = in.Currency
+			target.Allergies = in.Allergies
+			target.TabTags = in.TabTags
+			target.Category = in.Category
+			target.Translations = in.Translations
+			target.NutritionalInfo = in.NutritionalInfo
+			target.Calories = in.Calories
+			target.Protein = in.Protein
+			target.Carbs = in.Carbs
+			target.Fat = in.Fat
+			target.PreparationTime = in.PreparationTime
+			target.HowToEat = in.HowToEat
+			// omitted modifier groups are kept; an explicit empty list clears them
+			if in.ModifierGroups != nil {
+				target.ModifierGroups = mergeModifierGroups(target.ModifierGroups, in.ModifierGroups)
+			}
+			target.UpdatedAt = time.Now()
+			// If ID update did not provide slug but existing has none & we have base name, generate
+			if target.Slug == "" && base != "" {
+				target.Slug = utils.GenerateSlug(base)
+			}
+		} else { // create new item
+			if base != "" && in.Slug == "" {
+				in.Slug = utils.GenerateSlug(base)
 			}
+			in.MenuSlug = existing.Slug
+			assignModifierIDs(in)
+			in.CreatedAt = time.Now()
+			in.UpdatedAt = time.Now()
+			// ensure ID (if provided) retained; if empty DB layer will assign
+			added = append(added, *in)
+		}
+	}
+	existing.Items = append(existing.Items, added...)
+	for i := range existing.Items {
+		if existing.Items[i].MenuSlug == "" {
+			existing.Items[i].MenuSlug = existing.Slug
+		}
+	}
+	return nil
+}
+
+// MergeMenuItems is UpdateMenu's item merge plus removal, used to apply an OCR
+// change set to an existing menu.
+func (uc *MenuUseCase) MergeMenuItems(id string, userID string, items []domain.Item, removeIDs []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	existing, err := uc.menuRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(removeIDs) > 0 {
+		remove := make(map[string]bool, len(removeIDs))
+		for _, rid := range removeIDs {
+			remove[rid] = true
 		}
-		for i := range existing.Items {
-			if existing.Items[i].MenuSlug == "" {
-				existing.Items[i].MenuSlug = existing.Slug
+		kept := existing.Items[:0]
+		for _, it := range existing.Items {
+			if !remove[it.ID] {
+				kept = append(kept, it)
 			}
 		}
+		existing.Items = kept
+	}
+	if err := mergeMenuItems(existing, items); err != nil {
+		return err
+	}
+	if len(existing.Items) == 0 {
+		return domain.ErrInvalidInput
 	}
 
 	existing.UpdatedAt = time.Now()
-	existing.UpdatedBy = userId
-	return uc.menuRepo.Update(ctx, id, existing)
+	existing.UpdatedBy = userID
+	saved, err := uc.menuRepo.Update(ctx, id, existing)
+	if err != nil {
+		return err
+	}
+	uc.recordVersion(ctx, saved, domain.MenuVersionUpdate, userID)
+	return nil
 }
 
 func (uc *MenuUseCase) PublishMenu(id string, userID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
 	defer cancel()
 
-	menu, err := uc.menuRepo.GetByID(ctx, id)
+	return uc.publish(ctx, id, userID)
+}
+
+// publish promotes the current draft to the published copy. Customers keep
+// seeing the previous published copy until this succeeds.
+func (uc *MenuUseCase) publish(ctx context.Context, id string, userID string) error {
+	draft, err := uc.menuRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	menu.IsPublished = true
-	menu.PublishedAt = time.Now()
-	menu.UpdatedBy = userID
-	return uc.menuRepo.Update(ctx, id, menu)
+	items := make([]domain.Item, len(draft.Items))
+	for i, it := range draft.Items {
+		if it.MenuSlug == "" {
+			it.MenuSlug = draft.Slug
+		}
+		items[i] = it
+	}
+	published := &domain.PublishedMenu{
+		Name:        draft.Name,
+		Items:       items,
+		Version:     draft.Version + 1, // the publish write itself bumps the version
+		PublishedAt: time.Now(),
+		PublishedBy: userID,
+	}
+	saved, err := uc.menuRepo.Publish(ctx, id, draft.Version, published)
+	if err != nil {
+		return err
+	}
+	uc.recordVersion(ctx, saved, domain.MenuVersionPublish, userID)
+	return nil
+}
+
+// UnpublishMenu takes a menu off the public endpoints without touching its draft.
+func (uc *MenuUseCase) UnpublishMenu(id string, userID string, restaurant *domain.Restaurant) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	if _, err := uc.restaurantMenu(ctx, id, restaurant); err != nil {
+		return err
+	}
+	return uc.menuRepo.Unpublish(ctx, id, userID)
+}
+
+// SetSchedule validates and stores the activation windows and scheduled
+// transitions of a menu. An empty schedule clears it.
+func (uc *MenuUseCase) SetSchedule(id string, restaurant *domain.Restaurant, schedule *domain.MenuSchedule) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	if _, err := uc.restaurantMenu(ctx, id, restaurant); err != nil {
+		return err
+	}
+	if schedule != nil {
+		if err := schedule.Validate(); err != nil {
+			return err
+		}
+		if len(schedule.Windows) == 0 && schedule.PublishAt == nil && schedule.UnpublishAt == nil {
+			schedule = nil
+		}
+	}
+	return uc.menuRepo.SetSchedule(ctx, id, schedule)
+}
+
+// ApplyScheduledChanges publishes and unpublishes menus whose scheduled time has
+// passed. When both are due they run in chronological order so the later one
+// wins. Only the times that were carried out are cleared, and only if nobody
+// changed them meanwhile; a transition that fails keeps its schedule entry and
+// is retried on the next run.
+func (uc *MenuUseCase) ApplyScheduledChanges(now time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	menus, err := uc.menuRepo.FindScheduleDue(ctx, now)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, m := range menus {
+		if m.Schedule == nil {
+			continue
+		}
+		schedule := *m.Schedule
+		publishDue := schedule.PublishAt != nil && !schedule.PublishAt.After(now)
+		unpublishDue := schedule.UnpublishAt != nil && !schedule.UnpublishAt.After(now)
+
+		var published, unpublished *time.Time
+		publish := func(ctx context.Context) error {
+			if err := uc.publish(ctx, m.ID, scheduledBy); err != nil {
+				return err
+			}
+			published = schedule.PublishAt
+			return nil
+		}
+		unpublish := func(ctx context.Context) error {
+			if err := uc.menuRepo.Unpublish(ctx, m.ID, scheduledBy); err != nil {
+				return err
+			}
+			unpublished = schedule.UnpublishAt
+			return nil
+		}
+		var steps []func(context.Context) error
+		switch {
+		case publishDue && unpublishDue && schedule.UnpublishAt.Before(*schedule.PublishAt):
+			steps = append(steps, unpublish, publish)
+		default:
+			if publishDue {
+				steps = append(steps, publish)
+			}
+			if unpublishDue {
+				steps = append(steps, unpublish)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+		var stepErr error
+		for _, step := range steps {
+			if stepErr = step(ctx); stepErr != nil {
+				break
+			}
+		}
+		if published != nil || unpublished != nil {
+			if err := uc.menuRepo.ClearScheduledTransitions(ctx, m.ID, published, unpublished); err != nil && stepErr == nil {
+				stepErr = err
+			}
+		}
+		cancel()
+		if stepErr != nil {
+			logger.Log.Warn().Str("menu_id", m.ID).Err(stepErr).Msg("Scheduled menu transition failed; will retry")
+			continue
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// SetItemAvailability 86es an item or brings it back. Menu items are updated in
+// place on both the draft and the published copy; items kept in the standalone
+// item collection are matched by menu slug. Connected clients are notified.
+func (uc *MenuUseCase) SetItemAvailability(menuID, itemID string, restaurant *domain.Restaurant, available bool, until *time.Time) (*domain.Item, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	menu, err := uc.restaurantMenu(ctx, menuID, restaurant)
+	if err != nil {
+		return nil, err
+	}
+	return uc.setItemAvailability(ctx, menu, itemID, available, until)
+}
+
+func (uc *MenuUseCase) setItemAvailability(ctx context.Context, menu *domain.Menu, itemID string, available bool, until *time.Time) (*domain.Item, error) {
+	if available {
+		until = nil
+	}
+	menuID := menu.ID
+	var item *domain.Item
+	err := uc.menuRepo.SetItemAvailability(ctx, menuID, itemID, available, until)
+	switch {
+	case err == nil:
+		if updated, err := uc.menuRepo.GetByID(ctx, menuID); err == nil {
+			menu = updated
+		}
+		item = findMenuItem(menu, itemID)
+	case errors.Is(err, domain.ErrMenuItemNotFound) && uc.itemRepo != nil:
+		standalone, getErr := uc.itemRepo.GetItemByID(ctx, itemID)
+		if getErr != nil || standalone.MenuSlug != menu.Slug {
+			return nil, domain.ErrMenuItemNotFound
+		}
+		if err := uc.itemRepo.SetAvailability(ctx, itemID, available, until); err != nil {
+			return nil, err
+		}
+		item, err = uc.itemRepo.GetItemByID(ctx, itemID)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+	if item == nil {
+		return nil, domain.ErrMenuItemNotFound
+	}
+
+	uc.broadcastAvailability(ctx, menu, item)
+	return item, nil
+}
+
+// RestoreExpiredAvailability brings back menu items whose sold out period has
+// ended. Responses already treat them as available; this clears the flag and
+// tells connected clients.
+func (uc *MenuUseCase) RestoreExpiredAvailability(now time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	menus, err := uc.menuRepo.FindExpiredUnavailable(ctx, now)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	restored := 0
+	for _, m := range menus {
+		expired := map[string]bool{}
+		collect := func(items []domain.Item) {
+			for _, it := range items {
+				if it.Unavailable && it.UnavailableUntil != nil && !it.UnavailableUntil.After(now) {
+					expired[it.ID] = true
+				}
+			}
+		}
+		collect(m.Items)
+		if m.Published != nil {
+			collect(m.Published.Items)
+		}
+		for itemID := range expired {
+			ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+			_, err := uc.setItemAvailability(ctx, m, itemID, true, nil)
+			cancel()
+			if err != nil {
+				logger.Log.Warn().Str("menu_id", m.ID).Str("item_id", itemID).Err(err).Msg("Failed to restore item availability; will retry")
+				continue
+			}
+			restored++
+		}
+	}
+	return restored, nil
+}
+
+func (uc *MenuUseCase) broadcastAvailability(ctx context.Context, menu *domain.Menu, item *domain.Item) {
+	if uc.notifier == nil {
+		return
+	}
+	available := item.IsAvailableAt(time.Now())
+	message := item.Name + " is sold out"
+	if available {
+		message = item.Name + " is available again"
+	}
+	data := map[string]any{
+		"menu_id":         menu.ID,
+		"menu_slug":       menu.Slug,
+		"restaurant_slug": menu.RestaurantSlug,
+		"item_id":         item.ID,
+		"item_slug":       item.Slug,
+		"available":       available,
+	}
+	if !available && item.UnavailableUntil != nil {
+		data["unavailable_until"] = item.UnavailableUntil
+	}
+	notification := &domain.Notification{Message: message, Type: domain.ItemAvailability, Data: data}
+	if err := uc.notifier.Broadcast(ctx, menu.RestaurantSlug, notification); err != nil {
+		logger.Log.Warn().Str("menu_id", menu.ID).Str("item_id", item.ID).Err(err).Msg("Failed to broadcast item availability")
+	}
+}
+
+// findMenuItem looks an item up in the published copy first, since that is what
+// customers see, and then in the draft.
+func findMenuItem(menu *domain.Menu, itemID string) *domain.Item {
+	if menu.Published != nil {
+		for i := range menu.Published.Items {
+			if menu.Published.Items[i].ID == itemID {
+				return &menu.Published.Items[i]
+			}
+		}
+	}
+	for i := range menu.Items {
+		if menu.Items[i].ID == itemID {
+			return &menu.Items[i]
+		}
+	}
+	return nil
+}
+
+// PreviewDraft returns the draft shaped like a published menu.
+func (uc *MenuUseCase) PreviewDraft(id string, restaurant *domain.Restaurant) (*domain.Menu, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	menu, err := uc.restaurantMenu(ctx, id, restaurant)
+	if err != nil {
+		return nil, err
+	}
+	return menu.DraftView(), nil
+}
+
+// ExportMenuPDF renders the published copy of a menu, the one customers see,
+// as a printable PDF.
+func (uc *MenuUseCase) ExportMenuPDF(id string, restaurant *domain.Restaurant, opts domain.MenuPDFOptions) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	menu, err := uc.restaurantMenu(ctx, id, restaurant)
+	if err != nil {
+		return nil, err
+	}
+	view := menu.PublicView()
+	if view == nil {
+		return nil, domain.ErrMenuNotPublished
+	}
+	return uc.pdfRenderer.RenderMenuPDF(ctx, view, restaurant, opts)
+}
+
+// TranslateMenu machine-translates the draft's missing translations. The
+// provider can take a while, so the draft is read again before saving and only
+// fields that are still untranslated are filled in; edits made meanwhile win.
+func (uc *MenuUseCase) TranslateMenu(id string, userID string, restaurant *domain.Restaurant, langs []string) (*domain.MenuTranslationReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	menu, err := uc.restaurantMenu(ctx, id, restaurant)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	aiCtx, cancelAI := context.WithTimeout(context.Background(), uc.ctxTimeout*4)
+	report, err := uc.translator.Translate(aiCtx, menu.Items, langs, restaurant.Glossary)
+	cancelAI()
+	if err != nil {
+		return nil, err
+	}
+	report.MenuID = id
+	if report.Translated == 0 {
+		return report, nil
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	fresh, err := uc.menuRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	translated := make(map[string]*domain.Item, len(menu.Items))
+	for i := range menu.Items {
+		if menu.Items[i].ID != "" {
+			translated[menu.Items[i].ID] = &menu.Items[i]
+		}
+	}
+	for i := range fresh.Items {
+		if src, ok := translated[fresh.Items[i].ID]; ok {
+			fillMissingTranslations(&fresh.Items[i], src, report.Languages)
+		}
+	}
+	fresh.UpdatedAt = time.Now()
+	fresh.UpdatedBy = userID
+	saved, err := uc.menuRepo.Update(ctx, id, fresh)
+	if err != nil {
+		return nil, err
+	}
+	uc.recordVersion(ctx, saved, domain.MenuVersionUpdate, userID)
+	return report, nil
+}
+
+// fillMissingTranslations copies src's translations in langs into the fields
+// of dst that are still empty, along with their machine translation marks.
+func fillMissingTranslations(dst *domain.Item, src *domain.Item, langs []string) {
+	for _, lang := range langs {
+		d, s := dst.Translation(lang), src.Translation(lang)
+		fill := func(field string, empty bool, set func()) {
+			if !empty {
+				return
+			}
+			set()
+			if s.IsMachineTranslated(field) {
+				d.MarkMachineTranslated(field)
+			}
+		}
+		fill(domain.FieldName, d.Name == "", func() { d.Name = s.Name })
+		fill(domain.FieldDescription, d.Description == "", func() { d.Description = s.Description })
+		fill(domain.FieldAllergies, d.Allergies == "", func() { d.Allergies = s.Allergies })
+		fill(domain.FieldHowToEat, d.HowToEat == "", func() { d.HowToEat = s.HowToEat })
+		fill(domain.FieldTabTags, len(d.TabTags) == 0, func() { d.TabTags = s.TabTags })
+		fill(domain.FieldIngredients, len(d.Ingredients) == 0, func() { d.Ingredients = s.Ingredients })
+		dst.SetTranslation(lang, d)
+	}
 }
 
 func (uc *MenuUseCase) GetByRestaurantID(id string) ([]*domain.Menu, error) {
@@ -206,9 +654,13 @@ func (uc *MenuUseCase) GenerateQRCode(restaurantId string, menuId string, req *d
 		ID:            res.QRCodeID,
 		ImageURL:      res.ImageURL,
 		PublicMenuURL: res.PublicMenuURL,
+		ScanURL:       res.ScanURL,
 		DownloadURL:   res.DownloadURL,
 		MenuID:        menu.ID,
 		RestaurantID:  restaurantId,
+		Label:         req.Placement.Label,
+		Table:         req.Placement.Table,
+		Location:      req.Placement.Location,
 		IsActive:      true,
 		CreatedAt:     res.CreatedAt,
 		ExpiresAt:     res.ExpiresAt,
@@ -250,3 +702,274 @@ func (uc *MenuUseCase) GetMenuItemBySlug(menuSlug string, itemSlug string) (*dom
 
 	return uc.menuRepo.GetMenuItemBySlug(ctx, menuSlug, itemSlug)
 }
+
+// recordVersion stores an immutable snapshot of current, the menu exactly as
+// one write left it. The repository bumps the version on every write and
+// returns the written document, so concurrent edits each record their own
+// state under their own version rather than whatever a later read would see.
+// Snapshot failures are logged rather than returned: the write itself already succeeded.
+// The restaurant's webhooks are told about the write from the same snapshot.
+func (uc *MenuUseCase) recordVersion(ctx context.Context, current *domain.Menu, action domain.MenuVersionAction, userID string) {
+	if uc.versionRepo == nil && uc.webhooks == nil {
+		return
+	}
+	menuID := current.ID
+	uc.publishWebhook(current, action, userID)
+	if uc.versionRepo == nil {
+		return
+	}
+	version := &domain.MenuVersion{
+		MenuID:    menuID,
+		Version:   current.Version,
+		Action:    action,
+		Snapshot:  *current,
+		CreatedBy: userID,
+		CreatedAt: time.Now(),
+	}
+	if err := uc.versionRepo.Create(ctx, version); err != nil {
+		logger.Log.Warn().Str("menu_id", menuID).Int("version", current.Version).Err(err).Msg("Failed to store menu version snapshot")
+	}
+}
+
+// menuWebhookData is the data of the menu.published and menu.updated events.
+type menuWebhookData struct {
+	MenuID    string `json:"menu_id"`
+	Slug      string `json:"slug"`
+	Name      string `json:"name"`
+	Version   int    `json:"version"`
+	Action    string `json:"action"`
+	Published bool   `json:"published"`
+	// PublishedVersion is the version customers see, 0 before the first publish
+	PublishedVersion int       `json:"published_version,omitempty"`
+	ChangedBy        string    `json:"changed_by,omitempty"`
+	ChangedAt        time.Time `json:"changed_at"`
+}
+
+// publishWebhook raises menu.published when a menu goes live, including one
+// created published, and menu.updated when its draft changes.
+func (uc *MenuUseCase) publishWebhook(menu *domain.Menu, action domain.MenuVersionAction, userID string) {
+	if uc.webhooks == nil {
+		return
+	}
+	event := domain.WebhookMenuUpdated
+	switch action {
+	case domain.MenuVersionPublish:
+		event = domain.WebhookMenuPublished
+	case domain.MenuVersionCreate:
+		if menu.Published == nil {
+			return
+		}
+		event = domain.WebhookMenuPublished
+	}
+	data := menuWebhookData{
+		MenuID: menu.ID, Slug: menu.Slug, Name: menu.Name, Version: menu.Version, Action: string(action),
+		Published: menu.IsPublished, ChangedBy: userID, ChangedAt: menu.UpdatedAt,
+	}
+	if menu.Published != nil {
+		data.PublishedVersion = menu.Published.Version
+	}
+	uc.webhooks.Publish(menu.RestaurantID, event, data)
+}
+
+// restaurantMenu loads a menu for a request made on behalf of restaurant. A
+// menu of another restaurant is reported as not found.
+func (uc *MenuUseCase) restaurantMenu(ctx context.Context, id string, restaurant *domain.Restaurant) (*domain.Menu, error) {
+	menu, err := uc.menuRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// OCR-created menus may not be linked to a restaurant yet
+	if menu.RestaurantID != "" && menu.RestaurantID != restaurant.ID {
+		return nil, domain.ErrNotFound
+	}
+	return menu, nil
+}
+
+func (uc *MenuUseCase) ListVersions(menuID string, restaurant *domain.Restaurant) ([]*domain.MenuVersion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	if _, err := uc.restaurantMenu(ctx, menuID, restaurant); err != nil {
+		return nil, err
+	}
+	return uc.versionRepo.ListByMenuID(ctx, menuID)
+}
+
+func (uc *MenuUseCase) GetVersion(menuID string, restaurant *domain.Restaurant, version int) (*domain.MenuVersion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	if _, err := uc.restaurantMenu(ctx, menuID, restaurant); err != nil {
+		return nil, err
+	}
+	return uc.versionRepo.GetByMenuIDAndVersion(ctx, menuID, version)
+}
+
+func (uc *MenuUseCase) DiffVersions(menuID string, restaurant *domain.Restaurant, from, to int) (*domain.MenuDiff, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	if _, err := uc.restaurantMenu(ctx, menuID, restaurant); err != nil {
+		return nil, err
+	}
+	fromVersion, err := uc.versionRepo.GetByMenuIDAndVersion(ctx, menuID, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := uc.versionRepo.GetByMenuIDAndVersion(ctx, menuID, to)
+	if err != nil {
+		return nil, err
+	}
+	diff := DiffMenus(&fromVersion.Snapshot, &toVersion.Snapshot)
+	diff.MenuID = menuID
+	diff.FromVersion = from
+	diff.ToVersion = to
+	return diff, nil
+}
+
+// RollbackMenu restores the name and items of an earlier version onto the menu draft
+// and, if the menu is live, republishes it so customers see the restored menu too.
+// The rollback itself is a new version, so history is never rewritten. State that
+// belongs to the live item (views, ratings, reviews, availability) is carried over
+// for items that still exist so rolling back does not orphan reviews or un-86 items.
+func (uc *MenuUseCase) RollbackMenu(menuID string, version int, userID string, restaurant *domain.Restaurant) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	existing, err := uc.restaurantMenu(ctx, menuID, restaurant)
+	if err != nil {
+		return err
+	}
+	target, err := uc.versionRepo.GetByMenuIDAndVersion(ctx, menuID, version)
+	if err != nil {
+		return err
+	}
+
+	live := make(map[string]*domain.Item, len(existing.Items))
+	for i := range existing.Items {
+		if existing.Items[i].ID != "" {
+			live[existing.Items[i].ID] = &existing.Items[i]
+		}
+	}
+	items := make([]domain.Item, len(target.Snapshot.Items))
+	for i, it := range target.Snapshot.Items {
+		if cur, ok := live[it.ID]; ok {
+			it.ViewCount = cur.ViewCount
+			it.AverageRating = cur.AverageRating
+			it.ReviewIds = cur.ReviewIds
+			it.Unavailable = cur.Unavailable
+			it.UnavailableUntil = cur.UnavailableUntil
+		}
+		if it.MenuSlug == "" {
+			it.MenuSlug = existing.Slug
+		}
+		it.UpdatedAt = time.Now()
+		items[i] = it
+	}
+
+	existing.Name = target.Snapshot.Name
+	existing.Items = items
+	existing.UpdatedAt = time.Now()
+	existing.UpdatedBy = userID
+	saved, err := uc.menuRepo.Update(ctx, menuID, existing)
+	if err != nil {
+		return err
+	}
+	uc.recordVersion(ctx, saved, domain.MenuVersionRollback, userID)
+	if existing.IsPublished {
+		return uc.publish(ctx, menuID, userID)
+	}
+	return nil
+}
+
+// DiffMenus compares two menu snapshots. Items are matched by ID and then by slug;
+// soft-deleted items count as absent. Tabs are compared by name, including the
+// tab tags carried on items since tabs themselves are not always persisted.
+func DiffMenus(from, to *domain.Menu) *domain.MenuDiff {
+	diff := &domain.MenuDiff{
+		NameChanged:   from.Name != to.Name,
+		ItemsAdded:    []domain.Item{},
+		ItemsRemoved:  []domain.Item{},
+		ItemsRepriced: []domain.ItemPriceChange{},
+		TabsAdded:     []string{},
+		TabsRemoved:   []string{},
+	}
+
+	key := func(it *domain.Item) string {
+		if it.ID != "" {
+			return "id:" + it.ID
+		}
+		return "slug:" + it.Slug
+	}
+	fromItems := make(map[string]*domain.Item, len(from.Items))
+	for i := range from.Items {
+		if !from.Items[i].IsDeleted {
+			fromItems[key(&from.Items[i])] = &from.Items[i]
+		}
+	}
+	seen := make(map[string]bool, len(to.Items))
+	for i := range to.Items {
+		it := &to.Items[i]
+		if it.IsDeleted {
+			continue
+		}
+		k := key(it)
+		seen[k] = true
+		old, ok := fromItems[k]
+		if !ok {
+			diff.ItemsAdded = append(diff.ItemsAdded, *it)
+			continue
+		}
+		if old.Price != it.Price {
+			diff.ItemsRepriced = append(diff.ItemsRepriced, domain.ItemPriceChange{
+				ItemID:   it.ID,
+				Slug:     it.Slug,
+				Name:     it.Name,
+				OldPrice: old.Price,
+				NewPrice: it.Price,
+				Currency: it.Currency,
+			})
+		}
+	}
+	for i := range from.Items {
+		it := &from.Items[i]
+		if !it.IsDeleted && !seen[key(it)] {
+			diff.ItemsRemoved = append(diff.ItemsRemoved, *it)
+		}
+	}
+
+	fromTabs, toTabs := menuTabNames(from), menuTabNames(to)
+	for name := range toTabs {
+		if !fromTabs[name] {
+			diff.TabsAdded = append(diff.TabsAdded, name)
+		}
+	}
+	for name := range fromTabs {
+		if !toTabs[name] {
+			diff.TabsRemoved = append(diff.TabsRemoved, name)
+		}
+	}
+	sort.Strings(diff.TabsAdded)
+	sort.Strings(diff.TabsRemoved)
+	return diff
+}
+
+func menuTabNames(menu *domain.Menu) map[string]bool {
+	names := make(map[string]bool)
+	for _, tab := range menu.Tabs {
+		if !tab.IsDeleted && strings.TrimSpace(tab.Name) != "" {
+			names[strings.TrimSpace(tab.Name)] = true
+		}
+	}
+	for _, it := range menu.Items {
+		if it.IsDeleted {
+			continue
+		}
+		for _, tag := range it.TabTags {
+			if strings.TrimSpace(tag) != "" {
+				names[strings.TrimSpace(tag)] = true
+			}
+		}
+	}
+	return names
+}
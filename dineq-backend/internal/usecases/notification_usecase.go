@@ -40,6 +40,19 @@ func (uc *NotificationUseCase) SendNotificationFromRoute(ctx context.Context, us
 	return uc.CreateNotification(ctx, &notification)
 }
 
+func (uc *NotificationUseCase) Broadcast(ctx context.Context, restaurantSlug string, notification *domain.Notification) error {
+	notification.CreatedAt = time.Now()
+	notification.UpdatedAt = notification.CreatedAt
+	return uc.notifySvc.Broadcast(ctx, restaurantSlug, notification)
+}
+
+func (uc *NotificationUseCase) Push(ctx context.Context, userID string, notification *domain.Notification) error {
+	notification.UserID = userID
+	notification.CreatedAt = time.Now()
+	notification.UpdatedAt = notification.CreatedAt
+	return uc.notifySvc.Push(ctx, userID, notification)
+}
+
 func (uc *NotificationUseCase) GetNotificationsByUserID(ctx context.Context, userID string) ([]domain.Notification, error) {
 	return uc.repo.GetByUserID(ctx, userID)
 }
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+)
+
+func (uc *OCRJobUseCase) FindDuplicate(job *domain.OCRJob, since time.Time) (*domain.OCRDuplicate, error) {
+	// an empty filter field matches every job: never look outside the
+	// restaurant, or without one the uploader
+	filter := domain.OCRJobFilter{
+		Statuses:     []domain.OCRJobStatus{domain.OCRCompleted},
+		CreatedAfter: since,
+		PageSize:     100,
+	}
+	switch {
+	case job.RestaurantID != "":
+		filter.RestaurantID = job.RestaurantID
+	case job.UserID != "":
+		filter.UserID = job.UserID
+	default:
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	candidates, _, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var matches []domain.OCRDuplicate
+	for _, cand := range candidates {
+		if cand.TargetMenuID != job.TargetMenuID || cand.Results == nil || cand.Results.ParseResultID == "" {
+			continue
+		}
+		if m, ok := matchPages(job.Pages, cand.Pages); ok {
+			m.Job = cand
+			matches = append(matches, m)
+		}
+	}
+	// exact copies first, then the closest; newest first among equals
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Exact != matches[j].Exact {
+			return matches[i].Exact
+		}
+		return matches[i].Distance < matches[j].Distance
+	})
+	for _, m := range matches {
+		result, err := uc.parseRepo.GetByID(ctx, m.Job.Results.ParseResultID)
+		if err != nil {
+			continue // discarded: the menu has to be read again
+		}
+		m.ParseResult = result
+		return &m, nil
+	}
+	return nil, nil
+}
+
+// matchPages compares two uploads page by page, in order.
+func matchPages(pages, other []domain.OCRPage) (domain.OCRDuplicate, bool) {
+	if len(pages) == 0 || len(pages) != len(other) {
+		return domain.OCRDuplicate{}, false
+	}
+	m := domain.OCRDuplicate{Exact: true}
+	for i := range pages {
+		if pages[i].SHA256 != "" && pages[i].SHA256 == other[i].SHA256 {
+			continue
+		}
+		m.Exact = false
+		d := services.PerceptualHashDistance(pages[i].PerceptualHash, other[i].PerceptualHash)
+		if d < 0 || d > services.SimilarImageBits {
+			return domain.OCRDuplicate{}, false
+		}
+		m.Distance = max(m.Distance, d)
+	}
+	return m, true
+}
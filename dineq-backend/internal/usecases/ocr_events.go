@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
+)
+
+// ocrEventBuffer is how many events a slow subscriber may fall behind before
+// older progress events are dropped for it.
+const ocrEventBuffer = 16
+
+// ocrJobEvents fans the events of each job out to the subscribers of this
+// process. Subscribers of a job processed by another instance see nothing
+// here and fall back to reading the job.
+type ocrJobEvents struct {
+	mu   sync.Mutex
+	subs map[string]map[chan domain.OCRJobEvent]struct{}
+}
+
+func newOCRJobEvents() *ocrJobEvents {
+	return &ocrJobEvents{subs: make(map[string]map[chan domain.OCRJobEvent]struct{})}
+}
+
+func (e *ocrJobEvents) subscribe(jobID string) (<-chan domain.OCRJobEvent, func()) {
+	ch := make(chan domain.OCRJobEvent, ocrEventBuffer)
+	e.mu.Lock()
+	if e.subs[jobID] == nil {
+		e.subs[jobID] = make(map[chan domain.OCRJobEvent]struct{})
+	}
+	e.subs[jobID][ch] = struct{}{}
+	e.mu.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			e.mu.Lock()
+			delete(e.subs[jobID], ch)
+			if len(e.subs[jobID]) == 0 {
+				delete(e.subs, jobID)
+			}
+			e.mu.Unlock()
+		})
+	}
+}
+
+// publish never blocks the pipeline: a subscriber whose buffer is full loses
+// its oldest event, so the final one always gets through.
+func (e *ocrJobEvents) publish(ev domain.OCRJobEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for ch := range e.subs[ev.JobID] {
+		for {
+			select {
+			case ch <- ev:
+			default:
+				select {
+				case <-ch:
+				default:
+				}
+				continue
+			}
+			break
+		}
+	}
+}
+
+func (uc *OCRJobUseCase) SubscribeJob(id string) (<-chan domain.OCRJobEvent, func()) {
+	return uc.events.subscribe(id)
+}
+
+// publishProgress tells subscribers and the uploader's notification socket
+// where the job stands, and the restaurant's webhooks how it ended.
+func (uc *OCRJobUseCase) publishProgress(job *domain.OCRJob) {
+	ev := domain.NewOCRJobEvent(job)
+	ev.PhaseChanged = uc.swapPhase(job.ID, ev.Phase, ev.Final) != ev.Phase
+	uc.events.publish(ev)
+	if ev.Final {
+		uc.publishWebhook(job)
+	}
+	if uc.notifier == nil || ev.UserID == "" {
+		return
+	}
+	message := "OCR job " + string(ev.Status)
+	if ev.Phase != "" && !ev.Final {
+		message += ": " + ev.Phase
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	notification := &domain.Notification{Message: message, Type: domain.OCRProgress, Data: map[string]any{"job": ev}}
+	if err := uc.notifier.Push(ctx, ev.UserID, notification); err != nil {
+		logger.Log.Debug().Str("job_id", job.ID).Err(err).Msg("Failed to push OCR progress")
+	}
+}
+
+// ocrWebhookData is the data of the ocr.completed and ocr.failed events.
+type ocrWebhookData struct {
+	JobID         string              `json:"job_id"`
+	Status        domain.OCRJobStatus `json:"status"`
+	TargetMenuID  string              `json:"target_menu_id,omitempty"`
+	Pages         int                 `json:"pages"`
+	ParseResultID string              `json:"parse_result_id,omitempty"`
+	Error         string              `json:"error,omitempty"`
+	FinishedAt    time.Time           `json:"finished_at"`
+}
+
+// publishWebhook raises ocr.completed or ocr.failed; cancelled jobs were
+// stopped by the restaurant itself and raise nothing.
+func (uc *OCRJobUseCase) publishWebhook(job *domain.OCRJob) {
+	if uc.webhooks == nil {
+		return
+	}
+	data := ocrWebhookData{JobID: job.ID, Status: job.Status, TargetMenuID: job.TargetMenuID, Pages: len(job.Pages), FinishedAt: job.UpdatedAt}
+	switch job.Status {
+	case domain.OCRCompleted:
+		if job.Results != nil {
+			data.ParseResultID = job.Results.ParseResultID
+		}
+		uc.webhooks.Publish(job.RestaurantID, domain.WebhookOCRCompleted, data)
+	case domain.OCRFailed:
+		data.Error = job.Error
+		uc.webhooks.Publish(job.RestaurantID, domain.WebhookOCRFailed, data)
+	}
+}
+
+// swapPhase records the phase last published for a job and returns the one
+// before it. Jobs are forgotten once they end.
+func (uc *OCRJobUseCase) swapPhase(jobID, phase string, final bool) string {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	prev := uc.phases[jobID]
+	if final {
+		delete(uc.phases, jobID)
+	} else {
+		uc.phases[jobID] = phase
+	}
+	return prev
+}
+
+// estimateCompletion projects the time left from the progress made since the
+// job was picked up. Finished jobs keep their estimate.
+func estimateCompletion(job *domain.OCRJob, now time.Time) {
+	if job.Status != domain.OCRProcessing || job.Progress <= 5 || job.Progress >= 100 {
+		return
+	}
+	var started *time.Time
+	for _, ph := range job.PhaseHistory {
+		if ph.Name == domain.PhaseReceived {
+			started = ph.StartedAt
+			break
+		}
+	}
+	if started == nil {
+		return
+	}
+	// progress starts at 5 when the job is received
+	elapsed := now.Sub(*started)
+	done := float64(job.Progress - 5)
+	remaining := time.Duration(float64(elapsed) * float64(100-job.Progress) / done)
+	job.EstimatedCompletion = now.Add(remaining)
+}
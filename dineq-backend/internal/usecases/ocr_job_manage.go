@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
+)
+
+// OCRCancelReason is the error recorded on jobs cancelled by a user.
+const OCRCancelReason = "cancelled by user"
+
+// errOCRJobCancelled is the cause of a run stopped by CancelJob.
+var errOCRJobCancelled = errors.New(OCRCancelReason)
+
+// ocrPurgeBatch bounds the jobs handled by one purge run.
+const ocrPurgeBatch = 100
+
+// startRun registers a run of jobID that CancelJob (or a lost lease) can
+// stop. done must be called when the run returns.
+func (uc *OCRJobUseCase) startRun(jobID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	uc.mu.Lock()
+	uc.running[jobID] = cancel
+	uc.mu.Unlock()
+	return ctx, func() {
+		uc.mu.Lock()
+		delete(uc.running, jobID)
+		uc.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// stopRun cancels the run of jobID in this process, if there is one.
+func (uc *OCRJobUseCase) stopRun(jobID string, cause error) bool {
+	uc.mu.Lock()
+	cancel, ok := uc.running[jobID]
+	uc.mu.Unlock()
+	if ok {
+		cancel(cause)
+	}
+	return ok
+}
+
+// stopped reports whether the run was stopped. A job cancelled by a user is
+// recorded as cancelled; a job whose lease was lost belongs to someone else
+// now and is left alone, apart from telling subscribers if it was cancelled
+// from another instance.
+func (uc *OCRJobUseCase) stopped(ctx context.Context, job *domain.OCRJob) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+	if errors.Is(context.Cause(ctx), errOCRJobCancelled) {
+		logger.Log.Info().Str("job_id", job.ID).Str("phase", job.Phase).Msg("OCR job cancelled")
+		now := time.Now()
+		job.Status = domain.OCRCancelled
+		job.Error = OCRCancelReason
+		job.CompletedAt = &now
+		for _, ph := range job.PhaseHistory {
+			if ph.Status == "running" {
+				appendPhase(job, ph.Name, "cancelled")
+			}
+		}
+		job.UpdatedAt = now
+		persistWithFallback(uc, job, "cancelled")
+		return true
+	}
+	logger.Log.Warn().Str("job_id", job.ID).Err(context.Cause(ctx)).Msg("OCR job run stopped")
+	fetchCtx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	if current, err := uc.repo.GetByID(fetchCtx, job.ID); err == nil && current.Status == domain.OCRCancelled {
+		uc.publishProgress(current)
+	}
+	return true
+}
+
+// CancelJob cancels a pending job, or stops a running one. A job running on
+// this instance stops at once; one running elsewhere stops when its worker
+// next renews its lease.
+func (uc *OCRJobUseCase) CancelJob(id string) (*domain.OCRJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	job, err := uc.repo.Cancel(ctx, id, OCRCancelReason, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	// a local run records the cancellation and publishes it itself
+	if !uc.stopRun(id, errOCRJobCancelled) {
+		uc.publishProgress(job)
+	}
+	return job, nil
+}
+
+func (uc *OCRJobUseCase) ListJobs(filter domain.OCRJobFilter) ([]*domain.OCRJob, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	return uc.repo.List(ctx, filter.Normalize())
+}
+
+// PurgeExpiredImages deletes the stored images of jobs past their retention
+// and records the purge on the job; the job itself is kept as history. A job
+// whose images could not all be deleted is tried again on the next run.
+func (uc *OCRJobUseCase) PurgeExpiredImages(completedBefore, failedBefore time.Time) (int, error) {
+	if uc.storage == nil {
+		return 0, errors.New("image storage is not configured")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout*4)
+	defer cancel()
+	jobs, err := uc.repo.ListImagesToPurge(ctx, completedBefore, failedBefore, ocrPurgeBatch)
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, job := range jobs {
+		var failed bool
+		for _, page := range job.Pages {
+			for _, publicID := range []string{page.ImagePublicID, page.ProcessedPublicID} {
+				if publicID == "" {
+					continue
+				}
+				if err := uc.storage.DeleteFile(ctx, publicID); err != nil {
+					logger.Log.Warn().Str("job_id", job.ID).Str("public_id", publicID).Err(err).Msg("Failed to delete expired OCR image")
+					failed = true
+				}
+			}
+		}
+		if failed {
+			continue
+		}
+		if err := uc.repo.MarkImagesPurged(ctx, job.ID, time.Now()); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
@@ -3,7 +3,10 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
@@ -12,15 +15,52 @@ import (
 )
 
 type OCRJobUseCase struct {
-	repo       domain.IOCRJobRepository
-	menuRepo   domain.IMenuRepository
-	ocrService services.IOCRService
-	aiService  services.IAIService
-	ctxTimeout time.Duration
+	repo         domain.IOCRJobRepository
+	parseRepo    domain.IAIParseResultRepository
+	ocrService   services.IOCRService
+	preprocessor services.IImagePreprocessor
+	structurer   services.IMenuStructurer
+	translator   *MenuTranslator
+	notifier     domain.INotificationUseCase
+	storage      services.StorageService
+	usage        domain.IUsageUseCase
+	webhooks     domain.IWebhookPublisher
+	ctxTimeout   time.Duration
+
+	wakeCh chan struct{}
+	mu     sync.Mutex
+	pool   *ocrWorkerPool
+	events *ocrJobEvents
+	// phases is the last phase published per running job
+	phases map[string]string
+	// running cancels the jobs this instance is processing
+	running map[string]context.CancelCauseFunc
 }
 
-func NewOCRJobUseCase(repo domain.IOCRJobRepository, menuRepo domain.IMenuRepository, ocrService services.IOCRService, aiService services.IAIService, ctxTimeout time.Duration) domain.IOCRJobUseCase {
-	return &OCRJobUseCase{repo: repo, menuRepo: menuRepo, ocrService: ocrService, aiService: aiService, ctxTimeout: ctxTimeout}
+// NewOCRJobUseCase wires the OCR pipeline. preprocessor cleans up page images
+// before OCR, translator fills in the Amharic text the structurer left out
+// and notifier pushes job progress to the uploader's socket; any of them may
+// be nil. storage holds the page images, deleted once they expire. usage,
+// when set, records the pages read and model tokens spent on each job, and
+// webhooks, when set, tells the restaurant's webhooks how each job ended.
+func NewOCRJobUseCase(repo domain.IOCRJobRepository, parseRepo domain.IAIParseResultRepository, ocrService services.IOCRService, preprocessor services.IImagePreprocessor, structurer services.IMenuStructurer, translator domain.ITranslator, notifier domain.INotificationUseCase, storage services.StorageService, usage domain.IUsageUseCase, webhooks domain.IWebhookPublisher, ctxTimeout time.Duration) domain.IOCRJobUseCase {
+	return &OCRJobUseCase{
+		repo:         repo,
+		parseRepo:    parseRepo,
+		ocrService:   ocrService,
+		preprocessor: preprocessor,
+		structurer:   structurer,
+		translator:   NewMenuTranslator(translator, DefaultTranslationBatchSize),
+		notifier:     notifier,
+		storage:      storage,
+		usage:        usage,
+		webhooks:     webhooks,
+		ctxTimeout:   ctxTimeout,
+		wakeCh:       make(chan struct{}, 1),
+		events:       newOCRJobEvents(),
+		phases:       make(map[string]string),
+		running:      make(map[string]context.CancelCauseFunc),
+	}
 }
 
 func (uc *OCRJobUseCase) CreateOCRJob(job *domain.OCRJob) error {
@@ -30,15 +70,20 @@ func (uc *OCRJobUseCase) CreateOCRJob(job *domain.OCRJob) error {
 	job.UpdatedAt = time.Now()
 	// naive estimate: 2 minutes from now (could refine using historical averages)
 	job.EstimatedCompletion = job.CreatedAt.Add(2 * time.Minute)
-	if job.Status == "" {
-		job.Status = domain.OCRProcessing
+	job.Status = domain.OCRPending
+	if err := uc.repo.Create(ctx, job); err != nil {
+		return err
 	}
-	return uc.repo.Create(ctx, job)
+	uc.wake()
+	return nil
 }
 
 // ProcessJob performs the heavy OCR + AI work and updates the job record.
+// CancelJob stops it between and during stages.
 func (uc *OCRJobUseCase) ProcessJob(jobID string) {
 	logger.Log.Info().Str("job_id", jobID).Msg("Starting OCR job processing")
+	ctx, done := uc.startRun(jobID)
+	defer done()
 
 	// Fetch job with a short context
 	fetchCtx, cancelFetch := context.WithTimeout(context.Background(), uc.ctxTimeout)
@@ -48,8 +93,8 @@ func (uc *OCRJobUseCase) ProcessJob(jobID string) {
 		logger.Log.Error().Str("job_id", jobID).Err(err).Msg("Failed to fetch job for processing")
 		return
 	}
-	if job.Status == domain.OCRCompleted {
-		logger.Log.Warn().Str("job_id", jobID).Msg("Job already completed; skipping")
+	if job.Status == domain.OCRCompleted || job.Status == domain.OCRCancelled {
+		logger.Log.Warn().Str("job_id", jobID).Str("status", string(job.Status)).Msg("Job already finished; skipping")
 		return
 	}
 
@@ -60,59 +105,100 @@ func (uc *OCRJobUseCase) ProcessJob(jobID string) {
 	job.UpdatedAt = time.Now()
 	persistWithFallback(uc, job, "set processing")
 
-	// OCR Stage
-	ocrCtx, cancelOCR := context.WithTimeout(context.Background(), uc.ctxTimeout*4)
-	text, err := uc.ocrService.ProcessDocumentURL(ocrCtx, job.ImageURL)
-	cancelOCR()
-	if err != nil {
-		logger.Log.Error().Str("job_id", jobID).Err(err).Msg("OCR extraction failed")
+	job.JobPages()
+	uc.preprocessPages(ctx, job)
+	if uc.stopped(ctx, job) {
+		return
+	}
+
+	// OCR stage: pages are read one at a time, and pages read by an earlier
+	// attempt are not read again
+	job.Phase = domain.PhaseOCRExtraction
+	appendPhase(job, domain.PhaseOCRExtraction, "running")
+	persistWithFallback(uc, job, "phase ocr start")
+	var failedPages []string
+	for i := range job.Pages {
+		page := &job.Pages[i]
+		phase := domain.OCRPagePhase(page.Number)
+		if page.Status == domain.OCRPageDone {
+			appendPhase(job, phase, "done")
+			continue
+		}
+		if uc.stopped(ctx, job) {
+			return
+		}
+		appendPhase(job, phase, "running")
+		ocrCtx, cancelOCR := context.WithTimeout(ctx, uc.ctxTimeout*4)
+		imageURL := page.ImageURL
+		if page.ProcessedImageURL != "" {
+			imageURL = page.ProcessedImageURL
+		}
+		doc, err := uc.ocrService.ExtractText(ocrCtx, imageURL)
+		cancelOCR()
+		page.Attempts++
+		if err != nil && uc.stopped(ctx, job) {
+			return
+		}
+		if err != nil {
+			logger.Log.Error().Str("job_id", jobID).Int("page", page.Number).Err(err).Msg("OCR extraction failed")
+			page.Status, page.Error = domain.OCRPageFailed, err.Error()
+			appendPhase(job, phase, "failed")
+			if len(job.Pages) == 1 {
+				failedPages = append(failedPages, err.Error())
+			} else {
+				failedPages = append(failedPages, fmt.Sprintf("page %d: %v", page.Number, err))
+			}
+		} else {
+			logger.Log.Info().Str("job_id", jobID).Int("page", page.Number).Str("provider", doc.Provider).Int("text_length", len(doc.Text)).Msg("OCR extraction succeeded")
+			page.Status, page.Text, page.Provider, page.Error = domain.OCRPageDone, doc.Text, doc.Provider, ""
+			uc.recordUsage(job, domain.UsageEntry{Kind: domain.UsageOCRPages, Quantity: 1, Provider: doc.Provider})
+			appendPhase(job, phase, "done")
+		}
+		job.Progress = 5 + 35*(i+1)/len(job.Pages)
+		job.UpdatedAt = time.Now()
+		persistWithFallback(uc, job, "page ocr")
+	}
+	if len(failedPages) > 0 {
 		job.Status = domain.OCRFailed
-		job.Error = err.Error()
-		job.Phase = domain.PhaseOCRExtraction
+		job.Error = strings.Join(failedPages, "; ")
 		appendPhase(job, domain.PhaseOCRExtraction, "failed")
 		job.UpdatedAt = time.Now()
 		persistWithFallback(uc, job, "failed status (OCR stage)")
 		return
 	}
-	logger.Log.Info().Str("job_id", jobID).Int("text_length", len(text.OCRText)).Msg("OCR extraction succeeded")
-	job.Phase = domain.PhaseOCRExtraction
 	appendPhase(job, domain.PhaseOCRExtraction, "done")
 	job.Progress = 40
 	persistWithFallback(uc, job, "phase ocr done")
+	text := combinePageText(job.Pages)
 
-	// AI Stage with small retry on timeout
-	var menu *domain.Menu
-	var aiErr error
+	// AI stage: each page is structured on its own and the pages combined
 	job.Phase = domain.PhaseAIStructuring
 	appendPhase(job, domain.PhaseAIStructuring, "running")
 	job.Progress = 50
 	persistWithFallback(uc, job, "phase ai start")
-	trimmed := slimOCRText(text.OCRText, 8000)
-	for attempt := 1; attempt <= 2; attempt++ {
-		base := 180 * time.Second
-		if len(trimmed) > 6000 {
-			base = 240 * time.Second
-		}
-		if len(trimmed) > 12000 {
-			base = 300 * time.Second
-		}
-		if len(trimmed) > 18000 {
-			base = 360 * time.Second
+	var pageMenus []*domain.Menu
+	var rawJSON []string
+	var totalTokens int
+	var aiErr error
+	for _, page := range job.Pages {
+		number := page.Number
+		if len(job.Pages) == 1 {
+			number = 0
 		}
-		if len(trimmed) > 24000 {
-			base = 390 * time.Second
+		var structured *domain.StructuredMenu
+		structured, aiErr = uc.structurePage(ctx, job, page.Text, number)
+		if aiErr != nil && uc.stopped(ctx, job) {
+			return
 		}
-		aiCtx, cancelAI := context.WithTimeout(context.Background(), base)
-		menu, aiErr = uc.aiService.StructureWithGemini(aiCtx, trimmed)
-		cancelAI()
-		if aiErr == nil {
+		if aiErr != nil {
+			if number > 0 {
+				aiErr = fmt.Errorf("page %d: %w", number, aiErr)
+			}
 			break
 		}
-		if errors.Is(aiErr, context.DeadlineExceeded) || strings.Contains(aiErr.Error(), "context deadline exceeded") {
-			logger.Log.Warn().Str("job_id", jobID).Int("attempt", attempt).Err(aiErr).Msg("AI structuring timeout; retrying")
-			continue
-		}
-		break
+		pageMenus = append(pageMenus, structured.Menu)
+		rawJSON = append(rawJSON, structured.RawJSON)
+		totalTokens += structured.Usage.TotalTokens
 	}
 	if aiErr != nil {
 		logger.Log.Error().Str("job_id", jobID).Err(aiErr).Msg("AI structuring failed")
@@ -123,52 +209,273 @@ func (uc *OCRJobUseCase) ProcessJob(jobID string) {
 		persistWithFallback(uc, job, "failed status (AI stage)")
 		return
 	}
-	logger.Log.Info().Str("job_id", jobID).Str("menu_id", menu.ID).Msg("AI structuring produced menu")
+	structured := &domain.StructuredMenu{Menu: CombinePageMenus(pageMenus), RawJSON: rawJSON[0]}
+	if len(rawJSON) > 1 {
+		structured.RawJSON = "[" + strings.Join(rawJSON, ",") + "]"
+	}
+	menu := structured.Menu
+	logger.Log.Info().Str("job_id", jobID).Int("pages", len(job.Pages)).Int("total_tokens", totalTokens).Msg("AI structuring produced menu")
+	uc.translateStructuredMenu(ctx, jobID, menu)
+	if uc.stopped(ctx, job) {
+		return
+	}
 	appendPhase(job, domain.PhaseAIStructuring, "done")
 	job.Progress = 75
 	persistWithFallback(uc, job, "phase ai done")
 
-	// Persist menu
-	menuCtx, cancelMenu := context.WithTimeout(context.Background(), uc.ctxTimeout*2)
-	if err := uc.menuRepo.Create(menuCtx, menu); err != nil {
-		cancelMenu()
-		logger.Log.Error().Str("job_id", jobID).Err(err).Msg("Failed to persist structured menu")
+	// Hold the menu for review; it becomes a draft once a manager approves it
+	parsed := BuildParseResult(job, menu, text)
+	parseCtx, cancelParse := context.WithTimeout(ctx, uc.ctxTimeout*2)
+	err = uc.parseRepo.Create(parseCtx, parsed)
+	cancelParse()
+	if err != nil {
+		logger.Log.Error().Str("job_id", jobID).Err(err).Msg("Failed to persist parse result")
 		job.Status = domain.OCRFailed
 		job.Error = err.Error()
 		job.Phase = domain.PhaseMenuPersist
 		appendPhase(job, domain.PhaseMenuPersist, "failed")
 		job.UpdatedAt = time.Now()
-		persistWithFallback(uc, job, "failed status (menu persist stage)")
+		persistWithFallback(uc, job, "failed status (parse result stage)")
 		return
 	}
-	cancelMenu()
 	job.Phase = domain.PhaseMenuPersist
 	appendPhase(job, domain.PhaseMenuPersist, "done")
 	job.Progress = 90
-	persistWithFallback(uc, job, "phase menu persisted")
+	persistWithFallback(uc, job, "phase parse result persisted")
 
-	job.StructuredMenuID = menu.ID
-	// capture raw AI JSON if available
-	if gs, ok := uc.aiService.(*services.GeminiService); ok {
-		job.RawAIJSON = gs.RawLastAIJSON()
-	}
+	job.RawAIJSON = structured.RawJSON
 	job.Status = domain.OCRCompleted
 	job.Phase = domain.PhaseCompleted
 	appendPhase(job, domain.PhaseCompleted, "done")
 	completed := time.Now()
 	job.CompletedAt = &completed
-	job.Results = &domain.OCRJobResult{ExtractedText: text.OCRText, StructuredMenuID: menu.ID, Menu: menu, RawAIJSON: job.RawAIJSON}
+	job.Results = &domain.OCRJobResult{ExtractedText: text, OCRProvider: job.Pages[0].Provider, ParseResultID: parsed.ID, ConfidenceScore: parsed.ConfidenceScore, Menu: menu, RawAIJSON: job.RawAIJSON}
 	job.UpdatedAt = time.Now()
 	job.Progress = 100
 	persistWithFallback(uc, job, "completed status")
-	logger.Log.Info().Str("job_id", jobID).Str("menu_id", menu.ID).Msg("OCR job completed successfully")
+	logger.Log.Info().Str("job_id", jobID).Str("parse_result_id", parsed.ID).Msg("OCR job completed; menu awaiting review")
+}
+
+// preprocessPages straightens and cleans up each page image before OCR and
+// records the processed copy on the page. It is best-effort: a page that
+// cannot be processed is read from the original image.
+func (uc *OCRJobUseCase) preprocessPages(ctx context.Context, job *domain.OCRJob) {
+	if uc.preprocessor == nil {
+		return
+	}
+	job.Phase = domain.PhasePreprocessing
+	appendPhase(job, domain.PhasePreprocessing, "running")
+	persistWithFallback(uc, job, "phase preprocessing start")
+	for i := range job.Pages {
+		page := &job.Pages[i]
+		if page.ProcessedImageURL != "" || page.Status == domain.OCRPageDone {
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		pageCtx, cancel := context.WithTimeout(ctx, uc.ctxTimeout*2)
+		url, publicID, report, err := uc.preprocessor.Preprocess(pageCtx, page.ImageURL)
+		cancel()
+		if err != nil {
+			logger.Log.Warn().Str("job_id", job.ID).Int("page", page.Number).Err(err).Msg("Image preprocessing failed; using the original image")
+			continue
+		}
+		page.ProcessedImageURL, page.ProcessedPublicID, page.Preprocess = url, publicID, report
+	}
+	job.ProcessedImageURL = job.Pages[0].ProcessedImageURL
+	appendPhase(job, domain.PhasePreprocessing, "done")
+	job.UpdatedAt = time.Now()
+	persistWithFallback(uc, job, "phase preprocessing done")
+}
+
+// structurePage runs the structuring model on the text of one page, retrying
+// once on timeout. page is 0 for single-page jobs.
+func (uc *OCRJobUseCase) structurePage(ctx context.Context, job *domain.OCRJob, text string, page int) (*domain.StructuredMenu, error) {
+	if uc.structurer == nil {
+		return nil, errors.New("AI structuring is not configured")
+	}
+	trimmed := slimOCRText(text, 8000)
+	var structured *domain.StructuredMenu
+	var aiErr error
+	for attempt := 1; attempt <= 2; attempt++ {
+		base := 180 * time.Second
+		if len(trimmed) > 6000 {
+			base = 240 * time.Second
+		}
+		if len(trimmed) > 12000 {
+			base = 300 * time.Second
+		}
+		if len(trimmed) > 18000 {
+			base = 360 * time.Second
+		}
+		if len(trimmed) > 24000 {
+			base = 390 * time.Second
+		}
+		aiCtx, cancelAI := context.WithTimeout(ctx, base)
+		started := time.Now()
+		structured, aiErr = uc.structurer.StructureMenu(aiCtx, trimmed)
+		cancelAI()
+		call := aiCall(uc.structurer, domain.PhaseAIStructuring, started, structured, aiErr)
+		call.Page = page
+		job.AICalls = append(job.AICalls, call)
+		tokensIn, tokensOut := call.PromptTokens, call.CompletionTokens
+		if tokensIn+tokensOut == 0 {
+			tokensIn = call.TotalTokens // providers that only report a total
+		}
+		uc.recordUsage(job,
+			domain.UsageEntry{Kind: domain.UsageTokensIn, Quantity: int64(tokensIn), Provider: call.Provider, Model: call.Model},
+			domain.UsageEntry{Kind: domain.UsageTokensOut, Quantity: int64(tokensOut), Provider: call.Provider, Model: call.Model},
+		)
+		if aiErr == nil {
+			return structured, nil
+		}
+		if ctx.Err() == nil && (errors.Is(aiErr, context.DeadlineExceeded) || strings.Contains(aiErr.Error(), "context deadline exceeded")) {
+			logger.Log.Warn().Str("job_id", job.ID).Int("page", page).Int("attempt", attempt).Err(aiErr).Msg("AI structuring timeout; retrying")
+			continue
+		}
+		break
+	}
+	return nil, aiErr
+}
+
+// combinePageText joins the text of every page, marking where each page
+// starts when there is more than one.
+func combinePageText(pages []domain.OCRPage) string {
+	if len(pages) == 1 {
+		return pages[0].Text
+	}
+	parts := make([]string, len(pages))
+	for i, p := range pages {
+		parts[i] = fmt.Sprintf("--- Page %d ---\n%s", p.Number, p.Text)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// CombinePageMenus merges the menus structured from each page into one. Tabs
+// with the same name on different pages become one tab. A page whose first
+// items have no section heading (the model's default tab) continues the last
+// tab of the previous page, since menus often run a section over a page break.
+func CombinePageMenus(pages []*domain.Menu) *domain.Menu {
+	if len(pages) == 1 {
+		return pages[0]
+	}
+	combined := *pages[0]
+	combined.Tabs = nil
+	lastTab := ""
+	for pi, page := range pages {
+		for ti, tab := range page.Tabs {
+			name := tab.Name
+			// structurer output without a heading lands in the default tab
+			if pi > 0 && ti == 0 && lastTab != "" && (strings.TrimSpace(name) == "" || strings.EqualFold(name, defaultImportSection)) {
+				name = lastTab
+			}
+			cats := tab.Categories
+			var dst *domain.Tab
+			for i := range combined.Tabs {
+				if strings.EqualFold(combined.Tabs[i].Name, name) {
+					dst = &combined.Tabs[i]
+					break
+				}
+			}
+			if dst == nil {
+				tab.Name = name
+				tab.Categories = nil
+				combined.Tabs = append(combined.Tabs, tab)
+				dst = &combined.Tabs[len(combined.Tabs)-1]
+			}
+			for _, cat := range cats {
+				merged := false
+				for ci := range dst.Categories {
+					if strings.EqualFold(dst.Categories[ci].Name, cat.Name) {
+						dst.Categories[ci].Items = append(dst.Categories[ci].Items, cat.Items...)
+						merged = true
+						break
+					}
+				}
+				if !merged {
+					cat.Items = slices.Clone(cat.Items)
+					dst.Categories = append(dst.Categories, cat)
+				}
+			}
+			lastTab = name
+		}
+	}
+	return &combined
+}
+
+// aiCall records one structuring call; a failed call keeps the provider name
+// and latency even though no usage came back.
+func aiCall(structurer services.IMenuStructurer, stage string, started time.Time, res *domain.StructuredMenu, err error) domain.AICall {
+	call := domain.AICall{Stage: stage, StartedAt: started, LatencyMS: time.Since(started).Milliseconds()}
+	if res != nil {
+		call.AIUsage = res.Usage
+	}
+	if call.Provider == "" {
+		call.Provider = structurer.Name()
+	}
+	if err != nil {
+		call.Error = err.Error()
+	}
+	return call
+}
+
+// recordUsage adds the work done on job to the usage ledger of its
+// restaurant and uploader.
+func (uc *OCRJobUseCase) recordUsage(job *domain.OCRJob, entries ...domain.UsageEntry) {
+	if uc.usage == nil {
+		return
+	}
+	for i := range entries {
+		entries[i].RestaurantID, entries[i].UserID, entries[i].OCRJobID = job.RestaurantID, job.UserID, job.ID
+	}
+	uc.usage.Record(entries...)
+}
+
+// translateStructuredMenu fills in the Amharic text the model left out of a
+// structured menu. It is best-effort: on failure the menu keeps its gaps.
+func (uc *OCRJobUseCase) translateStructuredMenu(ctx context.Context, jobID string, menu *domain.Menu) {
+	var items []domain.Item
+	for _, tab := range menu.Tabs {
+		for _, cat := range tab.Categories {
+			items = append(items, cat.Items...)
+		}
+	}
+	if len(items) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.ctxTimeout*4)
+	defer cancel()
+	report, err := uc.translator.Translate(ctx, items, []string{domain.LangAmharic}, nil)
+	if err != nil {
+		logger.Log.Warn().Str("job_id", jobID).Err(err).Msg("Amharic translation of structured menu failed")
+		return
+	}
+	n := 0
+	for ti := range menu.Tabs {
+		for ci := range menu.Tabs[ti].Categories {
+			cat := &menu.Tabs[ti].Categories[ci]
+			copy(cat.Items, items[n:n+len(cat.Items)])
+			n += len(cat.Items)
+		}
+	}
+	logger.Log.Info().Str("job_id", jobID).Int("fields", report.Translated).Msg("Translated missing Amharic menu text")
 }
 
 // persistWithFallback tries to persist with a short context; on failure due to context or timeout it retries with a fresh background context.
+// Every update refreshes the ETA and is published as a progress event. A run
+// whose lease was lost is stopped instead: the job belongs to another worker.
 func persistWithFallback(uc *OCRJobUseCase, job *domain.OCRJob, stage string) {
+	estimateCompletion(job, time.Now())
 	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
 	err := uc.repo.Update(ctx, job.ID, job)
 	cancel()
+	if errors.Is(err, domain.ErrOCRJobLeaseLost) {
+		logger.Log.Warn().Str("job_id", job.ID).Str("stage", stage).Msg("OCR job lease lost; dropping update and stopping")
+		uc.stopRun(job.ID, domain.ErrOCRJobLeaseLost)
+		return
+	}
+	defer uc.publishProgress(job)
 	if err == nil {
 		return
 	}
@@ -191,7 +498,7 @@ func appendPhase(job *domain.OCRJob, phaseName, status string) {
 			if job.PhaseHistory[i].StartedAt == nil {
 				job.PhaseHistory[i].StartedAt = &now
 			}
-			if status == "done" || status == "failed" {
+			if status == "done" || status == "failed" || status == "cancelled" {
 				job.PhaseHistory[i].EndedAt = &now
 			}
 			job.PhaseHistory[i].Status = status
@@ -199,7 +506,7 @@ func appendPhase(job *domain.OCRJob, phaseName, status string) {
 		}
 	}
 	ph := domain.OCRPhase{Name: phaseName, Status: status, StartedAt: &now}
-	if status == "done" || status == "failed" {
+	if status == "done" || status == "failed" || status == "cancelled" {
 		ph.EndedAt = &now
 	}
 	job.PhaseHistory = append(job.PhaseHistory, ph)
@@ -229,7 +536,7 @@ func (uc *OCRJobUseCase) DeleteOCRJob(id string) error {
 	return uc.repo.Delete(ctx, id)
 }
 
-// RetryJob resets a failed OCR job to processing and launches processing again.
+// RetryJob resets a failed OCR job and puts it back in the queue.
 func (uc *OCRJobUseCase) RetryJob(id string) (*domain.OCRJob, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
 	defer cancel()
@@ -237,11 +544,14 @@ func (uc *OCRJobUseCase) RetryJob(id string) (*domain.OCRJob, error) {
 	if err != nil {
 		return nil, err
 	}
-	if job.Status != domain.OCRFailed {
-		return nil, errors.New("only failed jobs can be retried")
+	if job.Status != domain.OCRFailed && job.Status != domain.OCRCancelled {
+		return nil, errors.New("only failed or cancelled jobs can be retried")
+	}
+	if job.ImagesPurgedAt != nil {
+		return nil, errors.New("the images of this job have been purged")
 	}
 	// reset relevant fields but keep original image URL and user context
-	job.Status = domain.OCRProcessing
+	job.Status = domain.OCRPending
 	job.Error = ""
 	job.Phase = domain.PhaseReceived
 	job.Progress = 5
@@ -249,13 +559,19 @@ func (uc *OCRJobUseCase) RetryJob(id string) (*domain.OCRJob, error) {
 	job.CompletedAt = nil
 	job.StructuredMenuID = ""
 	job.Results = nil
+	// pages already read keep their text; only failed pages are read again
+	for i := range job.Pages {
+		if job.Pages[i].Status != domain.OCRPageDone {
+			job.Pages[i].Status, job.Pages[i].Error = domain.OCRPagePending, ""
+		}
+	}
 	job.UpdatedAt = time.Now()
 	job.EstimatedCompletion = time.Now().Add(2 * time.Minute)
 	if err := uc.repo.Update(ctx, job.ID, job); err != nil {
 		return nil, err
 	}
-	// async reprocess
-	go uc.ProcessJob(job.ID)
+	uc.publishProgress(job)
+	uc.wake()
 	return job, nil
 }
 
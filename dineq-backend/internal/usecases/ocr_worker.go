@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
+)
+
+// Defaults for OCRWorkerConfig fields left zero.
+const (
+	defaultOCRWorkers      = 2
+	defaultOCRLease        = 2 * time.Minute
+	defaultOCRPollInterval = 15 * time.Second
+	defaultOCRMaxAttempts  = 3
+	defaultOCRPurgeEvery   = time.Hour
+)
+
+func withOCRWorkerDefaults(cfg domain.OCRWorkerConfig) domain.OCRWorkerConfig {
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultOCRWorkers
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaultOCRLease
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultOCRPollInterval
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultOCRMaxAttempts
+	}
+	if cfg.PurgeInterval <= 0 {
+		cfg.PurgeInterval = defaultOCRPurgeEvery
+	}
+	return cfg
+}
+
+// ocrWorkerPool runs queued OCR jobs. Jobs live in the OCR job collection;
+// a worker leases one, renews the lease while processing, and a job whose
+// worker died is put back in the queue once its lease runs out.
+type ocrWorkerPool struct {
+	uc  *OCRJobUseCase
+	cfg domain.OCRWorkerConfig
+
+	mu     sync.Mutex
+	status []domain.OCRWorkerStatus
+}
+
+// StartWorkers requeues jobs orphaned by a previous process and starts the
+// worker pool. Calling it again while the pool runs is a no-op.
+func (uc *OCRJobUseCase) StartWorkers(ctx context.Context, cfg domain.OCRWorkerConfig) {
+	cfg = withOCRWorkerDefaults(cfg)
+	pool := &ocrWorkerPool{uc: uc, cfg: cfg, status: make([]domain.OCRWorkerStatus, cfg.Workers)}
+	uc.mu.Lock()
+	if uc.pool != nil {
+		uc.mu.Unlock()
+		return
+	}
+	uc.pool = pool
+	uc.mu.Unlock()
+
+	host, _ := os.Hostname()
+	prefix := fmt.Sprintf("%s-%d", host, os.Getpid())
+	for i := range pool.status {
+		pool.status[i].ID = fmt.Sprintf("%s-%d", prefix, i)
+	}
+
+	pool.requeueExpired(time.Now())
+	for i := range pool.status {
+		go pool.work(ctx, i)
+	}
+	go pool.reap(ctx)
+	if cfg.Retention > 0 || cfg.FailedRetention > 0 {
+		go pool.purge(ctx)
+	}
+	logger.Log.Info().Int("workers", cfg.Workers).Dur("lease", cfg.Lease).Msg("OCR worker pool started")
+}
+
+// wake nudges an idle worker after a job was enqueued.
+func (uc *OCRJobUseCase) wake() {
+	select {
+	case uc.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// QueueStats reports queue depth per status and what each local worker is doing.
+func (uc *OCRJobUseCase) QueueStats() (*domain.OCRQueueStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	counts, err := uc.repo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats := &domain.OCRQueueStats{
+		Pending:    counts[domain.OCRPending],
+		Processing: counts[domain.OCRProcessing],
+		Completed:  counts[domain.OCRCompleted],
+		Failed:     counts[domain.OCRFailed],
+		Cancelled:  counts[domain.OCRCancelled],
+		Workers:    []domain.OCRWorkerStatus{},
+	}
+	uc.mu.Lock()
+	pool := uc.pool
+	uc.mu.Unlock()
+	if pool != nil {
+		pool.mu.Lock()
+		stats.Workers = append(stats.Workers, pool.status...)
+		pool.mu.Unlock()
+	}
+	return stats, nil
+}
+
+func (p *ocrWorkerPool) work(ctx context.Context, i int) {
+	workerID := p.status[i].ID
+	ticker := time.NewTicker(p.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		claimCtx, cancel := context.WithTimeout(ctx, p.uc.ctxTimeout)
+		job, err := p.uc.repo.Claim(claimCtx, workerID, time.Now().Add(p.cfg.Lease))
+		cancel()
+		if err == nil {
+			p.run(ctx, i, job)
+			continue // the queue may hold more work
+		}
+		if !errors.Is(err, domain.ErrNotFound) && ctx.Err() == nil {
+			logger.Log.Error().Str("worker", workerID).Err(err).Msg("Failed to claim OCR job")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.uc.wakeCh:
+		case <-ticker.C:
+		}
+	}
+}
+
+// run processes a claimed job, renewing its lease until processing returns.
+func (p *ocrWorkerPool) run(ctx context.Context, i int, job *domain.OCRJob) {
+	workerID := p.status[i].ID
+	started := time.Now()
+	p.setStatus(i, func(s *domain.OCRWorkerStatus) {
+		s.Busy, s.JobID, s.Since = true, job.ID, &started
+	})
+	defer p.setStatus(i, func(s *domain.OCRWorkerStatus) {
+		s.Busy, s.JobID, s.Since = false, "", nil
+		s.Processed++
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(p.cfg.Lease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				hbCtx, cancel := context.WithTimeout(context.Background(), p.uc.ctxTimeout)
+				err := p.uc.repo.ExtendLease(hbCtx, job.ID, workerID, time.Now().Add(p.cfg.Lease))
+				cancel()
+				if errors.Is(err, domain.ErrOCRJobLeaseLost) {
+					// cancelled, or requeued for another worker: stop working on it
+					logger.Log.Warn().Str("worker", workerID).Str("job_id", job.ID).Msg("OCR job lease lost; stopping")
+					p.uc.stopRun(job.ID, domain.ErrOCRJobLeaseLost)
+					return
+				}
+				if err != nil {
+					logger.Log.Warn().Str("worker", workerID).Str("job_id", job.ID).Err(err).Msg("OCR job heartbeat failed")
+				}
+			}
+		}
+	}()
+	logger.Log.Info().Str("worker", workerID).Str("job_id", job.ID).Int("attempt", job.Attempts).Msg("OCR job claimed")
+	p.uc.ProcessJob(job.ID)
+	close(done)
+}
+
+// reap requeues jobs of workers that stopped renewing their lease, e.g. in a
+// crashed instance, every lease period.
+func (p *ocrWorkerPool) reap(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Lease)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if p.requeueExpired(now) > 0 {
+				p.uc.wake()
+			}
+		}
+	}
+}
+
+// purge deletes expired job images every PurgeInterval.
+func (p *ocrWorkerPool) purge(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.PurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			var completedBefore, failedBefore time.Time
+			if p.cfg.Retention > 0 {
+				completedBefore = now.Add(-p.cfg.Retention)
+			}
+			if p.cfg.FailedRetention > 0 {
+				failedBefore = now.Add(-p.cfg.FailedRetention)
+			}
+			n, err := p.uc.PurgeExpiredImages(completedBefore, failedBefore)
+			if err != nil {
+				logger.Log.Error().Err(err).Msg("Failed to purge expired OCR images")
+			} else if n > 0 {
+				logger.Log.Info().Int("jobs", n).Msg("Purged expired OCR images")
+			}
+		}
+	}
+}
+
+func (p *ocrWorkerPool) requeueExpired(now time.Time) int64 {
+	ctx, cancel := context.WithTimeout(context.Background(), p.uc.ctxTimeout)
+	defer cancel()
+	requeued, failed, err := p.uc.repo.RequeueExpired(ctx, now, p.cfg.MaxAttempts)
+	if err != nil {
+		logger.Log.Error().Err(err).Msg("Failed to recover stuck OCR jobs")
+		return 0
+	}
+	if requeued > 0 || failed > 0 {
+		logger.Log.Warn().Int64("requeued", requeued).Int64("failed", failed).Msg("Recovered OCR jobs with expired leases")
+	}
+	return requeued
+}
+
+func (p *ocrWorkerPool) setStatus(i int, fn func(*domain.OCRWorkerStatus)) {
+	p.mu.Lock()
+	fn(&p.status[i])
+	p.mu.Unlock()
+}
@@ -2,20 +2,31 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
 )
 
 type qrCodeUseCase struct {
-	repo       domain.IQRCodeRepository
-	ctxTimeout time.Duration
+	repo           domain.IQRCodeRepository
+	scanRepo       domain.IQRScanRepository
+	menuRepo       domain.IMenuRepository
+	restaurantRepo domain.IRestaurantRepo
+	ctxTimeout     time.Duration
 }
 
-func NewQRCodeUseCase(repo domain.IQRCodeRepository, ctxTimeout time.Duration) domain.IQRCodeUseCase {
+// NewQRCodeUseCase manages QR codes; scanRepo, menuRepo and restaurantRepo
+// are only needed to resolve scans.
+func NewQRCodeUseCase(repo domain.IQRCodeRepository, scanRepo domain.IQRScanRepository, menuRepo domain.IMenuRepository, restaurantRepo domain.IRestaurantRepo, ctxTimeout time.Duration) domain.IQRCodeUseCase {
 	return &qrCodeUseCase{
-		repo:       repo,
-		ctxTimeout: ctxTimeout,
+		repo:           repo,
+		scanRepo:       scanRepo,
+		menuRepo:       menuRepo,
+		restaurantRepo: restaurantRepo,
+		ctxTimeout:     ctxTimeout,
 	}
 }
 
@@ -32,6 +43,7 @@ func (uc *qrCodeUseCase) CreateQRCode(qrCode *domain.QRCode) error {
 	}
 	// default to active on creation
 	qrCode.IsActive = true
+	qrCode.Label = strings.TrimSpace(qrCode.Label)
 	return uc.repo.Create(ctx, qrCode)
 }
 
@@ -58,3 +70,103 @@ func (uc *qrCodeUseCase) DeleteQRCode(id string) error {
 
 	return uc.repo.Delete(ctx, id)
 }
+
+func (uc *qrCodeUseCase) GetQRCode(id string) (*domain.QRCode, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	return uc.repo.GetByID(ctx, id)
+}
+
+func (uc *qrCodeUseCase) ListQRCodes(restaurantSlugs []string, menuID string) ([]*domain.QRCode, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	return uc.repo.List(ctx, restaurantSlugs, menuID)
+}
+
+func (uc *qrCodeUseCase) UpdateQRCode(qr *domain.QRCode) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	qr.Label = strings.TrimSpace(qr.Label)
+	return uc.repo.Update(ctx, qr)
+}
+
+func (uc *qrCodeUseCase) DeleteQRCodeByID(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	return uc.repo.DeleteByID(ctx, id)
+}
+
+func (uc *qrCodeUseCase) ResolveScan(id string, userAgent string) (*domain.QRScanTarget, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+
+	qr, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !qr.IsActive {
+		return nil, domain.ErrQRCodeInactive
+	}
+	if !qr.ExpiresAt.IsZero() && now.After(qr.ExpiresAt) {
+		return nil, domain.ErrQRCodeExpired
+	}
+	// codes keep the slug the restaurant had when they were printed
+	rest, err := uc.restaurantRepo.GetBySlug(ctx, qr.RestaurantID)
+	if errors.Is(err, domain.ErrRestaurantNotFound) {
+		rest, err = uc.restaurantRepo.GetByOldSlug(ctx, qr.RestaurantID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	target := &domain.QRScanTarget{QRCode: qr, RestaurantSlug: rest.Slug}
+	menuID := ""
+	if menu := uc.activeMenu(ctx, rest, qr.MenuID, now); menu != nil {
+		target.MenuSlug = menu.Slug
+		menuID = menu.ID
+	}
+
+	// a scan that cannot be logged still reaches the menu
+	scan := &domain.QRScan{
+		QRCodeID:     qr.ID,
+		RestaurantID: rest.ID,
+		MenuID:       menuID,
+		Table:        qr.Table,
+		Location:     qr.Location,
+		Device:       domain.CoarseUserAgent(userAgent),
+		ScannedAt:    now,
+	}
+	if err := uc.scanRepo.Log(ctx, scan); err != nil {
+		logger.Log.Error().Str("qr_code_id", qr.ID).Err(err).Msg("Failed to log QR scan")
+	}
+	if err := uc.repo.RecordScan(ctx, qr.ID, now); err != nil {
+		logger.Log.Error().Str("qr_code_id", qr.ID).Err(err).Msg("Failed to count QR scan")
+	}
+	return target, nil
+}
+
+// activeMenu is the code's menu when it is published and active now, else
+// another menu of the restaurant that is, so a code outlives its menu.
+func (uc *qrCodeUseCase) activeMenu(ctx context.Context, rest *domain.Restaurant, menuID string, now time.Time) *domain.Menu {
+	loc := rest.TimeLocation()
+	if menu, err := uc.menuRepo.GetByID(ctx, menuID); err == nil && (menu.RestaurantID == "" || menu.RestaurantID == rest.ID) {
+		if view := menu.PublicView(); view != nil && view.IsActiveAt(now, loc) {
+			return view
+		}
+	}
+	menus, err := uc.menuRepo.GetByRestaurantID(ctx, rest.ID)
+	if err != nil {
+		return nil
+	}
+	for _, m := range menus {
+		if view := m.PublicView(); view != nil && view.IsActiveAt(now, loc) {
+			return view
+		}
+	}
+	return nil
+}
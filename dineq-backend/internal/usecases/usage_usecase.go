@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
+)
+
+type UsageUseCase struct {
+	repo       domain.IUsageRepository
+	jobs       domain.IOCRJobRepository
+	quotas     domain.UsageQuotas
+	ctxTimeout time.Duration
+	now        func() time.Time
+}
+
+// NewUsageUseCase keeps the usage ledger and enforces quotas, which apply
+// per calendar month in UTC. Pages are only recorded once read, so the OCR
+// page quota also counts the unread pages of queued and running jobs; jobs
+// may be nil when there are none to count.
+func NewUsageUseCase(repo domain.IUsageRepository, jobs domain.IOCRJobRepository, quotas domain.UsageQuotas, ctxTimeout time.Duration) domain.IUsageUseCase {
+	return &UsageUseCase{repo: repo, jobs: jobs, quotas: quotas, ctxTimeout: ctxTimeout, now: time.Now}
+}
+
+func (uc *UsageUseCase) Record(entries ...domain.UsageEntry) {
+	var keep []domain.UsageEntry
+	for _, e := range entries {
+		if e.Quantity <= 0 {
+			continue
+		}
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = uc.now()
+		}
+		keep = append(keep, e)
+	}
+	if len(keep) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	if err := uc.repo.Insert(ctx, keep); err != nil {
+		logger.Log.Error().Err(err).Str("restaurant_id", keep[0].RestaurantID).Str("kind", string(keep[0].Kind)).Msg("Failed to record usage")
+	}
+}
+
+func (uc *UsageUseCase) CheckQuota(restaurantID, userID string, kind domain.UsageKind, requested int64) error {
+	start := monthStart(uc.now())
+	checks := []struct {
+		scope                string
+		restaurantID, userID string
+		limit                int64
+	}{
+		{"restaurant", restaurantID, "", uc.quotas.Restaurant.Limit(kind)},
+		{"user", "", userID, uc.quotas.User.Limit(kind)},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	for _, check := range checks {
+		if check.limit <= 0 || check.restaurantID+check.userID == "" {
+			continue
+		}
+		totals, err := uc.repo.Totals(ctx, check.restaurantID, check.userID, start)
+		if err != nil {
+			return err
+		}
+		used := totals.Get(kind)
+		if kind == domain.UsageOCRPages && uc.jobs != nil {
+			unread, err := uc.jobs.UnreadPages(ctx, check.restaurantID, check.userID)
+			if err != nil {
+				return err
+			}
+			used += unread
+		}
+		if used >= check.limit || used+requested > check.limit {
+			return &domain.QuotaExceededError{
+				Scope:     check.scope,
+				Kind:      kind,
+				Used:      used,
+				Requested: requested,
+				Limit:     check.limit,
+				ResetsAt:  start.AddDate(0, 1, 0),
+			}
+		}
+	}
+	return nil
+}
+
+// Report defaults to the current month so far, per restaurant.
+func (uc *UsageUseCase) Report(filter domain.UsageReportFilter) (*domain.UsageReport, error) {
+	if filter.From.IsZero() {
+		filter.From = monthStart(uc.now())
+	}
+	if filter.To.IsZero() {
+		filter.To = uc.now()
+	}
+	if filter.GroupBy == "" {
+		filter.GroupBy = domain.UsageByRestaurant
+	}
+	if !filter.To.After(filter.From) || (filter.GroupBy != domain.UsageByRestaurant && filter.GroupBy != domain.UsageByUser) {
+		return nil, domain.ErrInvalidInput
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	rows, err := uc.repo.Report(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i].UsageTotals, rows[j].UsageTotals
+		if a.OCRPages != b.OCRPages {
+			return a.OCRPages > b.OCRPages
+		}
+		return a.Get(domain.UsageTokens) > b.Get(domain.UsageTokens)
+	})
+	report := &domain.UsageReport{From: filter.From, To: filter.To, GroupBy: filter.GroupBy, Rows: rows, Quotas: uc.quotas}
+	if report.Rows == nil {
+		report.Rows = []domain.UsageReportRow{}
+	}
+	for _, row := range rows {
+		report.Total.OCRPages += row.OCRPages
+		report.Total.TokensIn += row.TokensIn
+		report.Total.TokensOut += row.TokensOut
+		report.Total.ImageSearches += row.ImageSearches
+	}
+	return report, nil
+}
+
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	utils "github.com/RealEskalate/G6-MenuMate/Utils"
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/logger"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+)
+
+// Defaults for WebhookConfig fields left zero.
+const (
+	defaultWebhookMaxAttempts  = 8
+	defaultWebhookBackoff      = 30 * time.Second
+	defaultWebhookMaxBackoff   = 6 * time.Hour
+	defaultWebhookTimeout      = 10 * time.Second
+	defaultWebhookPollInterval = 5 * time.Second
+)
+
+func withWebhookDefaults(cfg domain.WebhookConfig) domain.WebhookConfig {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultWebhookBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultWebhookMaxBackoff
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultWebhookTimeout
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultWebhookPollInterval
+	}
+	return cfg
+}
+
+type WebhookUseCase struct {
+	repo       domain.IWebhookRepository
+	sender     services.IWebhookSender
+	ctxTimeout time.Duration
+	now        func() time.Time
+
+	wakeCh  chan struct{}
+	mu      sync.Mutex
+	cfg     domain.WebhookConfig
+	started bool
+}
+
+// NewWebhookUseCase keeps the webhook subscriptions of restaurants and queues
+// a delivery per subscription for every event published. Deliveries are sent
+// by StartDispatcher.
+func NewWebhookUseCase(repo domain.IWebhookRepository, sender services.IWebhookSender, ctxTimeout time.Duration) domain.IWebhookUseCase {
+	return &WebhookUseCase{
+		repo:       repo,
+		sender:     sender,
+		ctxTimeout: ctxTimeout,
+		now:        time.Now,
+		wakeCh:     make(chan struct{}, 1),
+		cfg:        withWebhookDefaults(domain.WebhookConfig{}),
+	}
+}
+
+func (uc *WebhookUseCase) CreateSubscription(sub *domain.WebhookSubscription) error {
+	if err := uc.validateWebhook(sub); err != nil {
+		return err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	sub.Secret = "whsec_" + hex.EncodeToString(secret)
+	sub.CreatedAt = uc.now()
+	sub.UpdatedAt = sub.CreatedAt
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	return uc.repo.CreateSubscription(ctx, sub)
+}
+
+func (uc *WebhookUseCase) GetSubscription(id string) (*domain.WebhookSubscription, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	return uc.repo.GetSubscription(ctx, id)
+}
+
+func (uc *WebhookUseCase) ListSubscriptions(restaurantID string) ([]*domain.WebhookSubscription, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	return uc.repo.ListSubscriptions(ctx, restaurantID)
+}
+
+func (uc *WebhookUseCase) UpdateSubscription(sub *domain.WebhookSubscription) error {
+	if err := uc.validateWebhook(sub); err != nil {
+		return err
+	}
+	sub.UpdatedAt = uc.now()
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	return uc.repo.UpdateSubscription(ctx, sub)
+}
+
+func (uc *WebhookUseCase) DeleteSubscription(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	return uc.repo.DeleteSubscription(ctx, id)
+}
+
+// validateWebhook checks the sender may post to the URL and the events are
+// known, dropping repeated ones.
+func (uc *WebhookUseCase) validateWebhook(sub *domain.WebhookSubscription) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	if err := uc.sender.CheckURL(ctx, sub.URL); err != nil {
+		return err
+	}
+	if len(sub.Events) == 0 {
+		return domain.ErrInvalidInput
+	}
+	var events []domain.WebhookEvent
+	for _, e := range sub.Events {
+		if !e.Valid() {
+			return domain.ErrInvalidInput
+		}
+		if !slices.Contains(events, e) {
+			events = append(events, e)
+		}
+	}
+	sub.Events = events
+	return nil
+}
+
+// Publish queues event for every active subscription of the restaurant that
+// asked for it. All of them carry the same event ID and body.
+func (uc *WebhookUseCase) Publish(restaurantID string, event domain.WebhookEvent, data any) {
+	if restaurantID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	subs, err := uc.repo.ListSubscriptions(ctx, restaurantID)
+	if err != nil {
+		logger.Log.Error().Err(err).Str("restaurant_id", restaurantID).Str("event", string(event)).Msg("Failed to load webhooks")
+		return
+	}
+	now := uc.now()
+	payload := domain.WebhookPayload{ID: utils.GenerateUUID(), Event: event, RestaurantID: restaurantID, CreatedAt: now, Data: data}
+	var body []byte
+	queued := 0
+	for _, sub := range subs {
+		if !sub.Wants(event) {
+			continue
+		}
+		if body == nil {
+			if body, err = json.Marshal(payload); err != nil {
+				logger.Log.Error().Err(err).Str("event", string(event)).Msg("Failed to encode webhook payload")
+				return
+			}
+		}
+		d := &domain.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			RestaurantID:   restaurantID,
+			Event:          event,
+			EventID:        payload.ID,
+			Payload:        string(body),
+			Status:         domain.WebhookPending,
+			Attempts:       []domain.WebhookAttempt{},
+			NextAttemptAt:  &now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := uc.repo.CreateDelivery(ctx, d); err != nil {
+			logger.Log.Error().Err(err).Str("webhook_id", sub.ID).Str("event", string(event)).Msg("Failed to queue webhook delivery")
+			continue
+		}
+		queued++
+	}
+	if queued > 0 {
+		uc.wake()
+	}
+}
+
+func (uc *WebhookUseCase) ListDeliveries(subscriptionID string, page, pageSize int) ([]*domain.WebhookDelivery, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	return uc.repo.ListDeliveries(ctx, subscriptionID, page, pageSize)
+}
+
+func (uc *WebhookUseCase) GetDelivery(id string) (*domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	return uc.repo.GetDelivery(ctx, id)
+}
+
+// Redeliver copies a delivery, whatever became of it, into a new one due
+// now. The original keeps its log.
+func (uc *WebhookUseCase) Redeliver(id string) (*domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancel()
+	orig, err := uc.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.repo.GetSubscription(ctx, orig.SubscriptionID); err != nil {
+		return nil, err
+	}
+	now := uc.now()
+	d := &domain.WebhookDelivery{
+		SubscriptionID: orig.SubscriptionID,
+		RestaurantID:   orig.RestaurantID,
+		Event:          orig.Event,
+		EventID:        orig.EventID,
+		Payload:        orig.Payload,
+		Status:         domain.WebhookPending,
+		Attempts:       []domain.WebhookAttempt{},
+		NextAttemptAt:  &now,
+		RedeliveryOf:   orig.ID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := uc.repo.CreateDelivery(ctx, d); err != nil {
+		return nil, err
+	}
+	uc.wake()
+	return d, nil
+}
+
+// StartDispatcher sends due deliveries until ctx ends. Calling it again while
+// it runs is a no-op.
+func (uc *WebhookUseCase) StartDispatcher(ctx context.Context, cfg domain.WebhookConfig) {
+	uc.mu.Lock()
+	if uc.started {
+		uc.mu.Unlock()
+		return
+	}
+	uc.started = true
+	uc.cfg = withWebhookDefaults(cfg)
+	uc.mu.Unlock()
+	go uc.dispatch(ctx)
+	logger.Log.Info().Int("max_attempts", uc.cfg.MaxAttempts).Dur("backoff", uc.cfg.Backoff).Msg("Webhook dispatcher started")
+}
+
+func (uc *WebhookUseCase) wake() {
+	select {
+	case uc.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (uc *WebhookUseCase) dispatch(ctx context.Context) {
+	ticker := time.NewTicker(uc.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		for ctx.Err() == nil && uc.deliverNext(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-uc.wakeCh:
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverNext claims and sends one due delivery and reports whether there
+// was one. A claimed delivery is not due again until its request timed out,
+// so a dispatcher that dies mid-request leaves it to be retried.
+func (uc *WebhookUseCase) deliverNext(ctx context.Context) bool {
+	now := uc.now()
+	claimCtx, cancel := context.WithTimeout(ctx, uc.ctxTimeout)
+	d, err := uc.repo.ClaimDelivery(claimCtx, now, now.Add(2*uc.cfg.Timeout))
+	cancel()
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) && ctx.Err() == nil {
+			logger.Log.Error().Err(err).Msg("Failed to claim webhook delivery")
+		}
+		return false
+	}
+	uc.attempt(ctx, d)
+	return true
+}
+
+func (uc *WebhookUseCase) attempt(ctx context.Context, d *domain.WebhookDelivery) {
+	subCtx, cancel := context.WithTimeout(ctx, uc.ctxTimeout)
+	sub, err := uc.repo.GetSubscription(subCtx, d.SubscriptionID)
+	cancel()
+	started := uc.now()
+	try := domain.WebhookAttempt{At: started}
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		try.Error = "webhook was deleted"
+	case err != nil:
+		// not the receiver's fault: leave it for the next claim
+		logger.Log.Error().Err(err).Str("delivery_id", d.ID).Msg("Failed to load webhook for delivery")
+		return
+	case !sub.Active:
+		try.Error = "webhook is disabled"
+	default:
+		sendCtx, cancelSend := context.WithTimeout(ctx, uc.cfg.Timeout)
+		try.StatusCode, err = uc.sender.Send(sendCtx, services.WebhookRequest{
+			URL: sub.URL, Secret: sub.Secret, Event: string(d.Event), DeliveryID: d.ID, Body: []byte(d.Payload),
+		})
+		cancelSend()
+		if err != nil {
+			try.Error = err.Error()
+		}
+	}
+	now := uc.now()
+	try.DurationMS = now.Sub(started).Milliseconds()
+	d.Attempts = append(d.Attempts, try)
+	d.UpdatedAt = now
+	switch {
+	case try.Error == "":
+		d.Status, d.DeliveredAt, d.NextAttemptAt = domain.WebhookDelivered, &now, nil
+	case sub == nil || !sub.Active || len(d.Attempts) >= uc.cfg.MaxAttempts:
+		d.Status, d.NextAttemptAt = domain.WebhookFailed, nil
+	default:
+		next := now.Add(webhookBackoff(uc.cfg, len(d.Attempts)))
+		d.NextAttemptAt = &next
+	}
+	saveCtx, cancelSave := context.WithTimeout(context.Background(), uc.ctxTimeout)
+	defer cancelSave()
+	if err := uc.repo.UpdateDelivery(saveCtx, d); err != nil {
+		logger.Log.Error().Err(err).Str("delivery_id", d.ID).Msg("Failed to record webhook attempt")
+	}
+	if d.Status == domain.WebhookFailed {
+		logger.Log.Warn().Str("delivery_id", d.ID).Str("webhook_id", d.SubscriptionID).Str("event", string(d.Event)).Str("error", try.Error).Msg("Webhook delivery failed")
+	}
+}
+
+// webhookBackoff is the wait after the given number of failed attempts:
+// Backoff, doubled for every attempt after the first, at most MaxBackoff.
+func webhookBackoff(cfg domain.WebhookConfig, attempts int) time.Duration {
+	wait := cfg.Backoff
+	for i := 1; i < attempts && wait < cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, cfg.MaxBackoff)
+}
@@ -72,7 +72,9 @@ func TestRestaurantLifecycle_RedirectAndDeletion(t *testing.T) {
 	// Build router
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	routers.Setup(env, time.Duration(env.CtxTSeconds)*time.Second, app.Mongo.Database(env.DB_Name), router)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	routers.Setup(ctx, env, time.Duration(env.CtxTSeconds)*time.Second, app.Mongo.Database(env.DB_Name), router)
 
 	// ---- 1. Create Restaurant ----
 	createPayload := RestaurantCreatePayload{Name: "Original Integration Resto", Phone: "+10000000000"}
@@ -0,0 +1,271 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+// memParseResultRepo keeps parse results in memory with the conditional
+// updates of the Mongo repository.
+type memParseResultRepo struct {
+	mu      sync.Mutex
+	results map[string]*domain.AIParseResult
+	nextID  int
+}
+
+func newMemParseResultRepo() *memParseResultRepo {
+	return &memParseResultRepo{results: map[string]*domain.AIParseResult{}}
+}
+
+func copyParseResult(r *domain.AIParseResult) *domain.AIParseResult {
+	cp := *r
+	cp.Items = slices.Clone(r.Items)
+	return &cp
+}
+
+func (r *memParseResultRepo) Create(_ context.Context, result *domain.AIParseResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	result.ID = strconv.Itoa(r.nextID)
+	r.results[result.ID] = copyParseResult(result)
+	return nil
+}
+
+func (r *memParseResultRepo) GetByID(_ context.Context, id string) (*domain.AIParseResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res, ok := r.results[id]
+	if !ok {
+		return nil, domain.ErrParseResultNotFound
+	}
+	return copyParseResult(res), nil
+}
+
+func (r *memParseResultRepo) UpdateItem(_ context.Context, id string, item domain.ParsedItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res, ok := r.results[id]
+	if !ok {
+		return domain.ErrParseResultNotFound
+	}
+	if res.Status != domain.ParseResultPendingReview {
+		return domain.ErrParseResultApproved
+	}
+	p := res.FindItem(item.ID)
+	if p == nil {
+		return domain.ErrNotFound
+	}
+	*p = item
+	return nil
+}
+
+func (r *memParseResultRepo) MarkApproved(_ context.Context, id, approvedBy string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res, ok := r.results[id]
+	if !ok {
+		return domain.ErrParseResultNotFound
+	}
+	if res.Status != domain.ParseResultPendingReview {
+		return domain.ErrParseResultApproved
+	}
+	res.Status, res.ApprovedBy, res.ApprovedAt = domain.ParseResultApproved, approvedBy, &at
+	return nil
+}
+
+func (r *memParseResultRepo) SetMenuID(_ context.Context, id, menuID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results[id].MenuID = menuID
+	return nil
+}
+
+func (r *memParseResultRepo) Reopen(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := r.results[id]
+	res.Status, res.ApprovedBy, res.ApprovedAt, res.MenuID = domain.ParseResultPendingReview, "", nil, ""
+	return nil
+}
+
+func (r *memParseResultRepo) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.results[id]; !ok {
+		return domain.ErrParseResultNotFound
+	}
+	delete(r.results, id)
+	return nil
+}
+
+// creatingMenuUseCase records the menus approval creates.
+type creatingMenuUseCase struct {
+	domain.IMenuUseCase
+	created []*domain.Menu
+	fail    error
+}
+
+func (m *creatingMenuUseCase) CreateMenu(menu *domain.Menu) error {
+	if m.fail != nil {
+		return m.fail
+	}
+	menu.ID = "menu-" + strconv.Itoa(len(m.created)+1)
+	m.created = append(m.created, menu)
+	return nil
+}
+
+func parsedFixture(t *testing.T, repo *memParseResultRepo) *domain.AIParseResult {
+	t.Helper()
+	menu := &domain.Menu{Tabs: []domain.Tab{{
+		Name: "Food",
+		Categories: []domain.Category{{
+			Name: "Mains",
+			Items: []domain.Item{
+				{Name: "Doro Wat", Price: 450, Translations: map[string]domain.ItemTranslation{domain.LangAmharic: {Name: "ዶሮ ወጥ"}}},
+				{Name: "Tibs", Price: 380},
+				{Name: "Kitfo"},
+				{Name: "Tibs", Price: 400},
+			},
+		}},
+	}}}
+	source := "FOOD\nDoro Wat ዶሮ ወጥ 450\nTibs 380\nKitfo\n"
+	result := usecase.BuildParseResult(&domain.OCRJob{ID: "job-1", RestaurantID: "r1", UserID: "u1"}, menu, source)
+	if err := repo.Create(context.Background(), result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestBuildParseResultScoresItemsAgainstSource(t *testing.T) {
+	result := parsedFixture(t, newMemParseResultRepo())
+	if len(result.Items) != 4 || result.Status != domain.ParseResultPendingReview {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if got := result.Items[0]; got.Confidence != 1 || len(got.Flags) != 0 || got.Tab != "Food" || got.Category != "Mains" {
+		t.Fatalf("clean item scored %+v", got)
+	}
+	wantFlags := [][]string{
+		nil,
+		{domain.ParseFlagDuplicateName, domain.ParseFlagTranslationMissing},
+		{domain.ParseFlagPriceMissing, domain.ParseFlagTranslationMissing},
+		{domain.ParseFlagPriceNotInSource, domain.ParseFlagDuplicateName, domain.ParseFlagTranslationMissing},
+	}
+	for i, want := range wantFlags {
+		if !slices.Equal(result.Items[i].Flags, want) {
+			t.Errorf("item %d flags %v, want %v", i, result.Items[i].Flags, want)
+		}
+		if result.Items[i].Status != domain.ParsedItemPending || result.Items[i].ID == "" {
+			t.Errorf("item %d not pending with an id: %+v", i, result.Items[i])
+		}
+	}
+	if result.Items[2].Confidence >= result.Items[1].Confidence {
+		t.Errorf("missing price should score lower than a duplicate: %v vs %v", result.Items[2].Confidence, result.Items[1].Confidence)
+	}
+	if result.ConfidenceScore <= 0 || result.ConfidenceScore >= 1 {
+		t.Errorf("overall confidence %v", result.ConfidenceScore)
+	}
+}
+
+func TestReviewAndApproveParseResult(t *testing.T) {
+	repo := newMemParseResultRepo()
+	menus := &creatingMenuUseCase{}
+	uc := usecase.NewAIParseResultUseCase(repo, menus, time.Second)
+	result := parsedFixture(t, repo)
+	ids := []string{result.Items[0].ID, result.Items[1].ID, result.Items[2].ID, result.Items[3].ID}
+
+	accept := domain.ParsedItemReview{Status: domain.ParsedItemAccepted}
+	if _, err := uc.ReviewItem(result.ID, ids[0], "u1", accept); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if _, err := uc.ApproveParseResult(result.ID, &domain.Menu{CreatedBy: "u1"}, false); !errors.Is(err, domain.ErrParseResultNotReviewed) {
+		t.Fatalf("approve with pending items: %v", err)
+	}
+
+	// an edit needs a price
+	if _, err := uc.ReviewItem(result.ID, ids[2], "u1", domain.ParsedItemReview{Status: domain.ParsedItemAccepted, Edit: &domain.Item{Name: "Kitfo"}}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("edit without price: %v", err)
+	}
+	edited, err := uc.ReviewItem(result.ID, ids[2], "u1", domain.ParsedItemReview{
+		Status:   domain.ParsedItemAccepted,
+		Edit:     &domain.Item{Name: "Kitfo", Price: 520},
+		Tab:      "Specials",
+		Category: "Raw",
+	})
+	if err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	item := edited.FindItem(ids[2])
+	if item.Status != domain.ParsedItemEdited || item.Item.Price != 520 || item.Original == nil || item.Original.Price != 0 || item.Tab != "Specials" || item.ReviewedBy != "u1" {
+		t.Fatalf("edited item %+v", item)
+	}
+	if _, err := uc.ReviewItem(result.ID, ids[1], "u1", accept); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.ReviewItem(result.ID, ids[3], "u1", domain.ParsedItemReview{Status: domain.ParsedItemRejected}); err != nil {
+		t.Fatal(err)
+	}
+
+	// read for r1: it cannot be approved into another restaurant
+	if _, err := uc.ApproveParseResult(result.ID, &domain.Menu{Name: "Lunch", RestaurantID: "r2", CreatedBy: "u1"}, false); err != domain.ErrForbidden {
+		t.Fatalf("approve into another restaurant: %v", err)
+	}
+	if len(menus.created) != 0 {
+		t.Fatalf("a menu was created for another restaurant: %+v", menus.created)
+	}
+	menu := &domain.Menu{Name: "Lunch", RestaurantID: "r1", CreatedBy: "u1"}
+	if _, err := uc.ApproveParseResult(result.ID, menu, false); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if len(menus.created) != 1 || len(menu.Items) != 3 {
+		t.Fatalf("expected one menu with the three kept items, got %+v", menus.created)
+	}
+	if menu.Items[2].Name != "Kitfo" || menu.Items[2].TabTags[0] != "Specials" || menu.Items[0].TabTags[0] != "Food" {
+		t.Fatalf("items not tagged with their tab: %+v", menu.Items)
+	}
+	if len(menu.Tabs) != 2 || menu.Tabs[1].Categories[0].Name != "Raw" {
+		t.Fatalf("unexpected tabs %+v", menu.Tabs)
+	}
+	stored, _ := repo.GetByID(context.Background(), result.ID)
+	if stored.Status != domain.ParseResultApproved || stored.MenuID != menu.ID || stored.ApprovedBy != "u1" {
+		t.Fatalf("stored result %+v", stored)
+	}
+
+	if _, err := uc.ApproveParseResult(result.ID, &domain.Menu{CreatedBy: "u1"}, false); !errors.Is(err, domain.ErrParseResultApproved) {
+		t.Fatalf("second approval: %v", err)
+	}
+	if _, err := uc.ReviewItem(result.ID, ids[0], "u1", accept); !errors.Is(err, domain.ErrParseResultApproved) {
+		t.Fatalf("review after approval: %v", err)
+	}
+}
+
+func TestApproveParseResultReopensWhenMenuCreationFails(t *testing.T) {
+	repo := newMemParseResultRepo()
+	menus := &creatingMenuUseCase{fail: errors.New("db down")}
+	uc := usecase.NewAIParseResultUseCase(repo, menus, time.Second)
+	result := parsedFixture(t, repo)
+	for _, it := range result.Items {
+		status := domain.ParsedItemAccepted
+		if it.Item.Price <= 0 {
+			status = domain.ParsedItemRejected
+		}
+		if _, err := uc.ReviewItem(result.ID, it.ID, "u1", domain.ParsedItemReview{Status: status}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := uc.ApproveParseResult(result.ID, &domain.Menu{RestaurantID: "r1", CreatedBy: "u1"}, false); err == nil {
+		t.Fatal("expected menu creation error")
+	}
+	stored, _ := repo.GetByID(context.Background(), result.ID)
+	if stored.Status != domain.ParseResultPendingReview {
+		t.Fatalf("result should be open for another approval, got %q", stored.Status)
+	}
+}
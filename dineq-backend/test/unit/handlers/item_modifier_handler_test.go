@@ -0,0 +1,57 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	handler "github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/handlers"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// unusedItemRepo fails the test if an item reaches storage
+type unusedItemRepo struct {
+	domain.IItemRepository
+	t *testing.T
+}
+
+func (r unusedItemRepo) CreateItem(ctx context.Context, item *domain.Item) error {
+	r.t.Fatal("invalid item was stored")
+	return nil
+}
+
+func TestCreateItemReportsModifierGroupErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handler.NewItemHandler(usecase.NewItemUseCase(unusedItemRepo{t: t}, time.Second), nil)
+	r := gin.New()
+	r.POST("/menus/:menu_slug/items", h.CreateItem)
+
+	// max_select below min_select cannot be satisfied
+	body := `{"name":"Burger","name_am":"በርገር","price":300,"currency":"ETB","modifier_groups":[{"name":"Extras","min_select":2,"max_select":1,"options":[{"name":"Cheese"},{"name":"Bacon"}]}]}`
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/menus/lunch/items", bytes.NewBufferString(body)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Message string `json:"message"`
+		Code    string `json:"code"`
+		Field   string `json:"field"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Code != "invalid_modifier_groups" || resp.Field != "modifier_groups" {
+		t.Fatalf("unexpected error response: %+v", resp)
+	}
+	if !bytes.Contains([]byte(resp.Message), []byte("modifier_groups[0]")) {
+		t.Fatalf("error should name the offending group: %q", resp.Message)
+	}
+}
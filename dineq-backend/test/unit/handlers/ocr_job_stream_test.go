@@ -0,0 +1,93 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	handler "github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+// streamingOCRUsecase serves one job and lets the test publish its events.
+type streamingOCRUsecase struct {
+	domain.IOCRJobUseCase
+	mu     sync.Mutex
+	job    domain.OCRJob
+	events chan domain.OCRJobEvent
+}
+
+func (u *streamingOCRUsecase) GetOCRJobByID(id string) (*domain.OCRJob, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if id != u.job.ID {
+		return nil, domain.ErrNotFound
+	}
+	job := u.job
+	return &job, nil
+}
+
+func (u *streamingOCRUsecase) SubscribeJob(string) (<-chan domain.OCRJobEvent, func()) {
+	return u.events, func() {}
+}
+
+func streamRouter(uc domain.IOCRJobUseCase, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := handler.NewOCRJobHandler(uc, nil, nil, nil, nil, nil, 1)
+	h.StreamPollInterval = 10 * time.Millisecond
+	r := gin.New()
+	r.GET("/ocr/:id/events", func(c *gin.Context) { c.Set("user_id", userID) }, h.StreamOCRJob)
+	return r
+}
+
+func TestStreamOCRJobSendsEventsUntilFinal(t *testing.T) {
+	uc := &streamingOCRUsecase{
+		job:    domain.OCRJob{ID: "j1", UserID: "u1", Status: domain.OCRProcessing, Phase: domain.PhaseOCRExtraction, Progress: 20},
+		events: make(chan domain.OCRJobEvent, 4),
+	}
+	uc.events <- domain.OCRJobEvent{JobID: "j1", Status: domain.OCRProcessing, Phase: domain.PhaseAIStructuring, Progress: 50, PhaseChanged: true}
+	go func() {
+		// completion written by another instance is picked up by polling
+		time.Sleep(30 * time.Millisecond)
+		uc.mu.Lock()
+		uc.job.Status, uc.job.Phase, uc.job.Progress = domain.OCRCompleted, domain.PhaseCompleted, 100
+		uc.job.Results = &domain.OCRJobResult{ParseResultID: "p1"}
+		uc.mu.Unlock()
+	}()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ocr/j1/events", nil)
+	streamRouter(uc, "u1").ServeHTTP(w, req)
+
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("content type %q", ct)
+	}
+	body := w.Body.String()
+	first := strings.Index(body, `"phase":"ocr_extraction"`)
+	second := strings.Index(body, `"phase":"ai_structuring"`)
+	final := strings.Index(body, "event:completed")
+	if first < 0 || second < first || final < second {
+		t.Fatalf("events missing or out of order:\n%s", body)
+	}
+	if !strings.Contains(body[final:], `"parse_result_id":"p1"`) || !strings.Contains(body[final:], `"final":true`) {
+		t.Fatalf("final event lacks the result:\n%s", body[final:])
+	}
+}
+
+func TestStreamOCRJobRejectsOtherUsers(t *testing.T) {
+	uc := &streamingOCRUsecase{job: domain.OCRJob{ID: "j1", UserID: "u1", Status: domain.OCRPending}, events: make(chan domain.OCRJobEvent)}
+	w := httptest.NewRecorder()
+	streamRouter(uc, "u2").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ocr/j1/events", nil))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	streamRouter(uc, "u1").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ocr/missing/events", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+}
@@ -0,0 +1,129 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	handler "github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+// managedRestaurants answers which restaurant each manager runs.
+type managedRestaurants struct {
+	domain.IRestaurantUsecase
+	byManager map[string]*domain.Restaurant
+}
+
+func (u *managedRestaurants) GetRestaurantByManagerId(_ context.Context, manager string) (*domain.Restaurant, error) {
+	if rest, ok := u.byManager[manager]; ok {
+		return rest, nil
+	}
+	return nil, domain.ErrRestaurantNotFound
+}
+
+func signedIn(userID string, role domain.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", string(role))
+	}
+}
+
+func postForm(r http.Handler, path string, form url.Values) int {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestUploadIntoAMenuNeedsItsRestaurant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	menus := &publishedMenuUsecase{menu: &domain.Menu{ID: "m1", RestaurantID: "r1"}}
+	rests := &managedRestaurants{byManager: map[string]*domain.Restaurant{
+		"manager-1": {ID: "r1", Slug: "cafe", ManagerID: "manager-1"},
+		"manager-2": {ID: "r2", Slug: "bistro", ManagerID: "manager-2"},
+	}}
+	h := handler.NewOCRJobHandler(nil, menus, rests, nil, nil, nil, 1)
+
+	for _, tc := range []struct {
+		user string
+		role domain.UserRole
+		want int
+	}{
+		{"manager-2", domain.RoleManager, http.StatusForbidden},
+		{"nobody", domain.RoleCustomer, http.StatusForbidden},
+		// allowed through to the file checks, which fail without a file
+		{"manager-1", domain.RoleManager, http.StatusBadRequest},
+		{"someone", domain.RoleAdmin, http.StatusBadRequest},
+	} {
+		r := gin.New()
+		r.POST("/ocr/upload", signedIn(tc.user, tc.role), h.UploadMenu)
+		if code := postForm(r, "/ocr/upload", url.Values{"menu_id": {"m1"}}); code != tc.want {
+			t.Errorf("%s (%s): status %d, want %d", tc.user, tc.role, code, tc.want)
+		}
+	}
+}
+
+func TestUploadNeedsAManagedRestaurant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rests := &managedRestaurants{byManager: map[string]*domain.Restaurant{"manager-1": {ID: "r1", ManagerID: "manager-1"}}}
+	h := handler.NewOCRJobHandler(nil, nil, rests, nil, nil, nil, 1)
+
+	for _, tc := range []struct {
+		user string
+		role domain.UserRole
+		want int
+	}{
+		{"nobody", domain.RoleCustomer, http.StatusForbidden},
+		{"someone", domain.RoleAdmin, http.StatusForbidden},
+		// allowed through to the file checks, which fail without a file
+		{"manager-1", domain.RoleManager, http.StatusBadRequest},
+	} {
+		r := gin.New()
+		r.POST("/ocr/upload", signedIn(tc.user, tc.role), h.UploadMenu)
+		if code := postForm(r, "/ocr/upload?restaurant_id=r1", url.Values{}); code != tc.want {
+			t.Errorf("%s (%s): status %d, want %d", tc.user, tc.role, code, tc.want)
+		}
+	}
+}
+
+// mergeResultUsecase serves one parse result uploaded in merge mode.
+type mergeResultUsecase struct {
+	domain.IAIParseResultUseCase
+	result *domain.AIParseResult
+}
+
+func (u *mergeResultUsecase) GetAIParseResultByID(id string) (*domain.AIParseResult, error) {
+	if id != u.result.ID {
+		return nil, domain.ErrNotFound
+	}
+	return u.result, nil
+}
+
+func (u *mergeResultUsecase) PreviewMerge(string) (*domain.MenuChangeSet, error) {
+	return &domain.MenuChangeSet{}, nil
+}
+
+func TestPreviewMergeRechecksTheRestaurant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	results := &mergeResultUsecase{result: &domain.AIParseResult{ID: "p1", UserID: "manager-1", RestaurantID: "r1", TargetMenuID: "m1"}}
+	rests := &managedRestaurants{byManager: map[string]*domain.Restaurant{}}
+	h := handler.NewAIParseResultHandler(results, rests)
+	r := gin.New()
+	r.GET("/parse-results/:id/merge-preview", signedIn("manager-1", domain.RoleManager), h.PreviewMerge)
+
+	// the uploader no longer manages the restaurant of the target menu
+	rests.byManager["manager-1"] = &domain.Restaurant{ID: "r2", ManagerID: "manager-1"}
+	if code, _ := serveJSON(t, r, http.MethodGet, "/parse-results/p1/merge-preview", ""); code != http.StatusForbidden {
+		t.Fatalf("former manager: status %d, want 403", code)
+	}
+	rests.byManager["manager-1"] = &domain.Restaurant{ID: "r1", ManagerID: "manager-1"}
+	if code, _ := serveJSON(t, r, http.MethodGet, "/parse-results/p1/merge-preview", ""); code != http.StatusOK {
+		t.Fatalf("manager: status %d, want 200", code)
+	}
+}
@@ -0,0 +1,508 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	handler "github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/handlers"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// memQRRepo keeps QR codes in memory, in creation order.
+type memQRRepo struct {
+	domain.IQRCodeRepository
+	mu    sync.Mutex
+	codes []*domain.QRCode
+}
+
+func (r *memQRRepo) Create(_ context.Context, qr *domain.QRCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *qr
+	r.codes = append(r.codes, &stored)
+	return nil
+}
+
+func (r *memQRRepo) GetByID(_ context.Context, id string) (*domain.QRCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, qr := range r.codes {
+		if qr.ID == id && !qr.IsDeleted {
+			found := *qr
+			return &found, nil
+		}
+	}
+	return nil, domain.ErrQRCodeNotFound
+}
+
+func (r *memQRRepo) List(_ context.Context, restaurantSlugs []string, menuID string) ([]*domain.QRCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*domain.QRCode
+	for i := len(r.codes) - 1; i >= 0; i-- {
+		qr := r.codes[i]
+		if slices.Contains(restaurantSlugs, qr.RestaurantID) && !qr.IsDeleted && (menuID == "" || qr.MenuID == menuID) {
+			found := *qr
+			out = append(out, &found)
+		}
+	}
+	return out, nil
+}
+
+func (r *memQRRepo) Update(_ context.Context, qr *domain.QRCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.codes {
+		if stored.ID == qr.ID && !stored.IsDeleted {
+			stored.Label, stored.IsActive = qr.Label, qr.IsActive
+			return nil
+		}
+	}
+	return domain.ErrQRCodeNotFound
+}
+
+func (r *memQRRepo) UpdateActivation(_ context.Context, restaurantID string, isActive bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.codes {
+		if stored.RestaurantID == restaurantID && !stored.IsDeleted {
+			stored.IsActive = isActive
+		}
+	}
+	return nil
+}
+
+func (r *memQRRepo) GetByRestaurantId(_ context.Context, restaurantID string) (*domain.QRCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.codes {
+		if stored.RestaurantID == restaurantID && !stored.IsDeleted {
+			found := *stored
+			return &found, nil
+		}
+	}
+	return nil, domain.ErrQRCodeNotFound
+}
+
+func (r *memQRRepo) Delete(_ context.Context, restaurantID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.codes {
+		if stored.RestaurantID == restaurantID {
+			stored.IsDeleted = true
+		}
+	}
+	return nil
+}
+
+func (r *memQRRepo) RecordScan(_ context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.codes {
+		if stored.ID == id {
+			stored.ScanCount++
+			stored.LastScannedAt = &at
+		}
+	}
+	return nil
+}
+
+func (r *memQRRepo) DeleteByID(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.codes {
+		if stored.ID == id && !stored.IsDeleted {
+			stored.IsDeleted = true
+			return nil
+		}
+	}
+	return domain.ErrQRCodeNotFound
+}
+
+// publishedMenuUsecase serves a single published menu.
+type publishedMenuUsecase struct {
+	domain.IMenuUseCase
+	menu *domain.Menu
+}
+
+func (u *publishedMenuUsecase) GetByID(id string) (*domain.Menu, error) {
+	if id != u.menu.ID {
+		return nil, domain.ErrNotFound
+	}
+	return u.menu, nil
+}
+
+func (u *publishedMenuUsecase) IncrementMenuViewCount(string) error { return nil }
+
+type slugRestaurantUsecase struct {
+	domain.IRestaurantUsecase
+	rest *domain.Restaurant
+}
+
+func (u *slugRestaurantUsecase) GetRestaurantBySlug(_ context.Context, slug string) (*domain.Restaurant, error) {
+	if slug != u.rest.Slug {
+		return nil, domain.ErrRestaurantNotFound
+	}
+	return u.rest, nil
+}
+
+type recordingViews struct {
+	mu     sync.Mutex
+	events []domain.ViewEvent
+}
+
+func (v *recordingViews) LogView(event *domain.ViewEvent) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.events = append(v.events, *event)
+	return nil
+}
+
+// qrRouter serves the QR routes to a signed-in user with the given role.
+func qrRouter(qrUc domain.IQRCodeUseCase, rests domain.IRestaurantUsecase, userID string, role domain.UserRole, menuHandler *handler.MenuHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := handler.NewQRCodeHandler(qrUc, nil, rests)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", string(role))
+	})
+	r.GET("/qr-code/:restaurant_slug", h.GetQRCode)
+	r.PATCH("/qr-code/:restaurant_slug/:status", h.UpdateQRCodeStatus)
+	r.DELETE("/qr-code/:restaurant_slug", h.DeleteQRCode)
+	r.GET("/qr-code/:restaurant_slug/codes", h.ListQRCodes)
+	r.GET("/qr-code/:restaurant_slug/codes/:id", h.GetQRCodeByID)
+	r.PATCH("/qr-code/:restaurant_slug/codes/:id", h.UpdateQRCode)
+	r.DELETE("/qr-code/:restaurant_slug/codes/:id", h.DeleteQRCodeByID)
+	if menuHandler != nil {
+		r.GET("/public/menus/:restaurant_slug/:id", menuHandler.PublicGetPublishedMenuByID)
+	}
+	return r
+}
+
+func serveJSON(t *testing.T, r http.Handler, method, path, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var out map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("%s %s: decode %q: %v", method, path, w.Body.String(), err)
+	}
+	return w.Code, out
+}
+
+func TestQRCodesPerTableAndLocation(t *testing.T) {
+	repo := &memQRRepo{}
+	uc := usecase.NewQRCodeUseCase(repo, nil, nil, nil, time.Second)
+	for _, qr := range []*domain.QRCode{
+		{ID: "qr-table-12", RestaurantID: "cafe", MenuID: "m1", Label: " Table 12 ", Table: "12"},
+		{ID: "qr-patio", RestaurantID: "cafe", MenuID: "m1", Label: "Patio", Location: "patio"},
+		{ID: "qr-flyer", RestaurantID: "cafe", MenuID: "m2", Label: "Flyer", Location: "flyer"},
+		{ID: "qr-other", RestaurantID: "other", MenuID: "m9", Label: "Table 1", Table: "1"},
+	} {
+		if err := uc.CreateQRCode(qr); err != nil {
+			t.Fatalf("create %s: %v", qr.ID, err)
+		}
+	}
+	rests := &slugRestaurantUsecase{rest: &domain.Restaurant{ID: "r1", Slug: "cafe", ManagerID: "manager-1"}}
+	r := qrRouter(uc, rests, "manager-1", domain.RoleManager, nil)
+
+	code, body := serveJSON(t, r, http.MethodGet, "/qr-code/cafe/codes", "")
+	codes := body["data"].(map[string]any)["qr_codes"].([]any)
+	if code != http.StatusOK || len(codes) != 3 {
+		t.Fatalf("list: status %d, %d codes, want 200 and 3", code, len(codes))
+	}
+	if first := codes[0].(map[string]any); first["qr_code_id"] != "qr-flyer" || first["location"] != "flyer" {
+		t.Fatalf("codes should be newest first with their placement, got %v", first)
+	}
+	_, body = serveJSON(t, r, http.MethodGet, "/qr-code/cafe/codes?menu_id=m1", "")
+	if n := len(body["data"].(map[string]any)["qr_codes"].([]any)); n != 2 {
+		t.Fatalf("menu m1 should have 2 codes, got %d", n)
+	}
+
+	_, body = serveJSON(t, r, http.MethodGet, "/qr-code/cafe/codes/qr-table-12", "")
+	if qr := body["data"].(map[string]any)["qr_code"].(map[string]any); qr["label"] != "Table 12" || qr["table"] != "12" {
+		t.Fatalf("label should be trimmed and the table kept, got %v", qr)
+	}
+	if code, _ := serveJSON(t, r, http.MethodGet, "/qr-code/cafe/codes/qr-other", ""); code != http.StatusNotFound {
+		t.Fatalf("a code of another restaurant should be 404, got %d", code)
+	}
+
+	code, body = serveJSON(t, r, http.MethodPatch, "/qr-code/cafe/codes/qr-patio", `{"label":"Patio left","is_active":false}`)
+	if qr := body["data"].(map[string]any)["qr_code"].(map[string]any); code != http.StatusOK || qr["label"] != "Patio left" || qr["is_active"] != false || qr["location"] != "patio" {
+		t.Fatalf("update: status %d, %v", code, qr)
+	}
+	if code, _ := serveJSON(t, r, http.MethodPatch, "/qr-code/cafe/true", ""); code != http.StatusOK {
+		t.Fatalf("the restaurant-wide status route should still match, got %d", code)
+	}
+
+	if code, _ := serveJSON(t, r, http.MethodDelete, "/qr-code/cafe/codes/qr-table-12", ""); code != http.StatusOK {
+		t.Fatalf("delete: status %d", code)
+	}
+	if code, _ := serveJSON(t, r, http.MethodGet, "/qr-code/cafe/codes/qr-table-12", ""); code != http.StatusNotFound {
+		t.Fatalf("a deleted code should be 404, got %d", code)
+	}
+}
+
+func TestQRCodesNeedTheRestaurantsManager(t *testing.T) {
+	uc := usecase.NewQRCodeUseCase(&memQRRepo{}, nil, nil, nil, time.Second)
+	_ = uc.CreateQRCode(&domain.QRCode{ID: "qr1", RestaurantID: "cafe", MenuID: "m1", Table: "1"})
+	rests := &slugRestaurantUsecase{rest: &domain.Restaurant{ID: "r1", Slug: "cafe", ManagerID: "manager-1"}}
+
+	stranger := qrRouter(uc, rests, "manager-2", domain.RoleManager, nil)
+	for _, req := range []struct{ method, path, body string }{
+		{http.MethodGet, "/qr-code/cafe/codes", ""},
+		{http.MethodGet, "/qr-code/cafe/codes/qr1", ""},
+		{http.MethodPatch, "/qr-code/cafe/codes/qr1", `{"is_active":false}`},
+		{http.MethodDelete, "/qr-code/cafe/codes/qr1", ""},
+		{http.MethodGet, "/qr-code/cafe", ""},
+		{http.MethodPatch, "/qr-code/cafe/false", ""},
+		{http.MethodDelete, "/qr-code/cafe", ""},
+	} {
+		if code, _ := serveJSON(t, stranger, req.method, req.path, req.body); code != http.StatusForbidden {
+			t.Errorf("%s %s by another manager: status %d, want 403", req.method, req.path, code)
+		}
+	}
+	if qr, _ := uc.GetQRCode("qr1"); qr == nil || !qr.IsActive {
+		t.Fatalf("another manager changed the code: %+v", qr)
+	}
+	if code, _ := serveJSON(t, stranger, http.MethodGet, "/qr-code/missing/codes", ""); code != http.StatusNotFound {
+		t.Fatalf("unknown restaurant: status %d, want 404", code)
+	}
+
+	for _, role := range []domain.UserRole{domain.RoleOwner, domain.RoleAdmin} {
+		if code, _ := serveJSON(t, qrRouter(uc, rests, "someone", role, nil), http.MethodGet, "/qr-code/cafe/codes/qr1", ""); code != http.StatusOK {
+			t.Errorf("%s: status %d, want 200", role, code)
+		}
+	}
+}
+
+func TestQRCodesFollowRestaurantRenames(t *testing.T) {
+	uc := usecase.NewQRCodeUseCase(&memQRRepo{}, nil, nil, nil, time.Second)
+	_ = uc.CreateQRCode(&domain.QRCode{ID: "qr-old", RestaurantID: "cafe", MenuID: "m1", Table: "1"})
+	_ = uc.CreateQRCode(&domain.QRCode{ID: "qr-new", RestaurantID: "cafe-addis", MenuID: "m1", Table: "2"})
+	_ = uc.CreateQRCode(&domain.QRCode{ID: "qr-other", RestaurantID: "bistro", MenuID: "m9", Table: "3"})
+	rests := &slugRestaurantUsecase{rest: &domain.Restaurant{ID: "r1", Slug: "cafe-addis", PreviousSlugs: []string{"cafe"}, ManagerID: "manager-1"}}
+	r := qrRouter(uc, rests, "manager-1", domain.RoleManager, nil)
+
+	code, body := serveJSON(t, r, http.MethodGet, "/qr-code/cafe-addis/codes", "")
+	codes := body["data"].(map[string]any)["qr_codes"].([]any)
+	if code != http.StatusOK || len(codes) != 2 {
+		t.Fatalf("list: status %d, %d codes, want 200 and the 2 codes of both slugs", code, len(codes))
+	}
+	if code, _ := serveJSON(t, r, http.MethodPatch, "/qr-code/cafe-addis/codes/qr-old", `{"label":"Table 1"}`); code != http.StatusOK {
+		t.Fatalf("a code printed before the rename: status %d, want 200", code)
+	}
+	if code, _ := serveJSON(t, r, http.MethodGet, "/qr-code/cafe-addis/codes/qr-other", ""); code != http.StatusNotFound {
+		t.Fatalf("a code of another restaurant: status %d, want 404", code)
+	}
+
+	if code, _ := serveJSON(t, r, http.MethodPatch, "/qr-code/cafe-addis/false", ""); code != http.StatusOK {
+		t.Fatalf("deactivate all: status %d", code)
+	}
+	for _, id := range []string{"qr-old", "qr-new"} {
+		if qr, _ := uc.GetQRCode(id); qr == nil || qr.IsActive {
+			t.Fatalf("%s should be deactivated with the restaurant: %+v", id, qr)
+		}
+	}
+	if qr, _ := uc.GetQRCode("qr-other"); qr == nil || !qr.IsActive {
+		t.Fatalf("the code of another restaurant was deactivated: %+v", qr)
+	}
+	if code, _ := serveJSON(t, r, http.MethodDelete, "/qr-code/cafe-addis", ""); code != http.StatusOK {
+		t.Fatalf("delete all: status %d", code)
+	}
+	if code, _ := serveJSON(t, r, http.MethodGet, "/qr-code/cafe-addis/codes", ""); code != http.StatusOK {
+		t.Fatalf("list after delete: status %d", code)
+	} else if qr, _ := uc.GetQRCode("qr-old"); qr != nil {
+		t.Fatalf("a code of the earlier slug survived the delete: %+v", qr)
+	}
+}
+
+func TestPublicMenuCarriesQRPlacement(t *testing.T) {
+	if got, want := services.QRMenuURL("https://menu.example/user/cafe/lunch", "qr1", domain.QRPlacement{Label: "Table 12", Table: "12", Location: "patio"}),
+		"https://menu.example/user/cafe/lunch?location=patio&qr=qr1&table=12"; got != want {
+		t.Fatalf("QRMenuURL = %q, want %q", got, want)
+	}
+
+	repo := &memQRRepo{}
+	qrUc := usecase.NewQRCodeUseCase(repo, nil, nil, nil, time.Second)
+	_ = qrUc.CreateQRCode(&domain.QRCode{ID: "qr1", RestaurantID: "cafe", MenuID: "m1", Label: "Table 12", Table: "12", Location: "patio"})
+	_ = qrUc.CreateQRCode(&domain.QRCode{ID: "qr-other-menu", RestaurantID: "cafe", MenuID: "m2", Table: "3"})
+	_ = qrUc.CreateQRCode(&domain.QRCode{ID: "qr-expired", RestaurantID: "cafe", MenuID: "m1", Table: "4", ExpiresAt: time.Now().Add(-time.Hour)})
+
+	views := &recordingViews{}
+	menus := &publishedMenuUsecase{menu: &domain.Menu{ID: "m1", RestaurantID: "r1", RestaurantSlug: "cafe", Slug: "lunch", Name: "Lunch", IsPublished: true}}
+	rests := &slugRestaurantUsecase{rest: &domain.Restaurant{ID: "r1", Slug: "cafe"}}
+	r := qrRouter(qrUc, rests, "", domain.RoleCustomer, handler.NewMenuHandler(menus, qrUc, rests, nil, views))
+
+	code, body := serveJSON(t, r, http.MethodGet, "/public/menus/cafe/m1?qr=qr1&table=99", "")
+	if code != http.StatusOK {
+		t.Fatalf("status %d: %v", code, body)
+	}
+	placement, _ := body["data"].(map[string]any)["qr_code"].(map[string]any)
+	if placement["qr_code_id"] != "qr1" || placement["table"] != "12" || placement["location"] != "patio" || placement["label"] != "Table 12" {
+		t.Fatalf("placement should come from the stored code, got %v", placement)
+	}
+	if len(views.events) != 1 || views.events[0].QRCodeID != "qr1" || views.events[0].Table != "12" || views.events[0].Location != "patio" {
+		t.Fatalf("the scan should be logged with its placement, got %+v", views.events)
+	}
+
+	for _, qr := range []string{"qr-other-menu", "qr-expired", "missing"} {
+		_, body = serveJSON(t, r, http.MethodGet, "/public/menus/cafe/m1?qr="+qr, "")
+		if _, ok := body["data"].(map[string]any)["qr_code"]; ok {
+			t.Fatalf("%s should not tag the menu", qr)
+		}
+	}
+	if len(views.events) != 1 {
+		t.Fatalf("ignored codes should not log scans, got %d views", len(views.events))
+	}
+}
+
+type memQRScanRepo struct {
+	mu    sync.Mutex
+	scans []domain.QRScan
+}
+
+func (r *memQRScanRepo) Log(_ context.Context, scan *domain.QRScan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scans = append(r.scans, *scan)
+	return nil
+}
+
+type scanMenuRepo struct {
+	domain.IMenuRepository
+	menus []*domain.Menu
+}
+
+func (r *scanMenuRepo) GetByID(_ context.Context, id string) (*domain.Menu, error) {
+	for _, m := range r.menus {
+		if m.ID == id {
+			return m, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *scanMenuRepo) GetByRestaurantID(_ context.Context, restaurantID string) ([]*domain.Menu, error) {
+	var out []*domain.Menu
+	for _, m := range r.menus {
+		if m.RestaurantID == restaurantID {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// renamedRestaurantRepo holds one restaurant whose slug has changed.
+type renamedRestaurantRepo struct {
+	domain.IRestaurantRepo
+	rest *domain.Restaurant
+}
+
+func (r *renamedRestaurantRepo) GetBySlug(_ context.Context, slug string) (*domain.Restaurant, error) {
+	if slug == r.rest.Slug {
+		return r.rest, nil
+	}
+	return nil, domain.ErrRestaurantNotFound
+}
+
+func (r *renamedRestaurantRepo) GetByOldSlug(_ context.Context, slug string) (*domain.Restaurant, error) {
+	for _, old := range r.rest.PreviousSlugs {
+		if old == slug {
+			return r.rest, nil
+		}
+	}
+	return nil, domain.ErrRestaurantNotFound
+}
+
+func TestQRScanRedirectsToActiveMenu(t *testing.T) {
+	t.Setenv("FRONTEND_URL", "https://menu.example")
+	if got := services.QRScanURL("https://api.example/", "qr1"); got != "https://api.example/q/qr1" {
+		t.Fatalf("QRScanURL = %q", got)
+	}
+
+	repo := &memQRRepo{}
+	scans := &memQRScanRepo{}
+	menus := &scanMenuRepo{menus: []*domain.Menu{
+		{ID: "m1", RestaurantID: "r1", Slug: "breakfast"}, // unpublished since the code was printed
+		{ID: "m2", RestaurantID: "r1", Slug: "all-day", IsPublished: true},
+	}}
+	rests := &renamedRestaurantRepo{rest: &domain.Restaurant{ID: "r1", Slug: "cafe-addis", PreviousSlugs: []string{"cafe"}}}
+	uc := usecase.NewQRCodeUseCase(repo, scans, menus, rests, time.Second)
+	_ = uc.CreateQRCode(&domain.QRCode{ID: "qr1", RestaurantID: "cafe", MenuID: "m1", Label: "Table 12", Table: "12"})
+	_ = uc.CreateQRCode(&domain.QRCode{ID: "qr-expired", RestaurantID: "cafe", MenuID: "m2", ExpiresAt: time.Now().Add(-time.Minute)})
+
+	gin.SetMode(gin.TestMode)
+	h := handler.NewQRCodeHandler(uc, nil, nil)
+	r := gin.New()
+	r.GET("/q/:id", h.ScanQRCode)
+	scan := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/q/"+id, nil)
+		req.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148 Safari/604.1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := scan("qr1")
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "https://menu.example/user/cafe-addis/all-day?qr=qr1&table=12" {
+		t.Fatalf("scan: status %d, location %q", w.Code, w.Header().Get("Location"))
+	}
+	if w.Header().Get("Cache-Control") != "no-store" {
+		t.Fatalf("the redirect should not be cached")
+	}
+	if len(scans.scans) != 1 || scans.scans[0].QRCodeID != "qr1" || scans.scans[0].MenuID != "m2" || scans.scans[0].RestaurantID != "r1" ||
+		scans.scans[0].Device != domain.DeviceIOS || scans.scans[0].Table != "12" || scans.scans[0].ScannedAt.IsZero() {
+		t.Fatalf("scan log = %+v", scans.scans)
+	}
+	if repo.codes[0].ScanCount != 1 || repo.codes[0].LastScannedAt == nil {
+		t.Fatalf("the code should count its scan, got %d", repo.codes[0].ScanCount)
+	}
+
+	menus.menus[1].IsPublished = false
+	if w := scan("qr1"); w.Header().Get("Location") != "https://menu.example/user/cafe-addis?qr=qr1&table=12" {
+		t.Fatalf("without an active menu the restaurant page should open, got %q", w.Header().Get("Location"))
+	}
+
+	if w := scan("qr-expired"); w.Code != http.StatusGone || !strings.Contains(w.Body.String(), "qr_code_expired") {
+		t.Fatalf("expired code: status %d, %s", w.Code, w.Body.String())
+	}
+	_ = uc.UpdateQRCode(&domain.QRCode{ID: "qr1", Label: "Table 12", IsActive: false})
+	if w := scan("qr1"); w.Code != http.StatusGone || !strings.Contains(w.Body.String(), "qr_code_inactive") {
+		t.Fatalf("inactive code: status %d, %s", w.Code, w.Body.String())
+	}
+	if w := scan("missing"); w.Code != http.StatusNotFound {
+		t.Fatalf("unknown code: status %d", w.Code)
+	}
+	if len(scans.scans) != 2 {
+		t.Fatalf("only redirected scans are logged, got %d", len(scans.scans))
+	}
+}
+
+func TestCoarseUserAgent(t *testing.T) {
+	for ua, want := range map[string]string{
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Chrome/126.0 Mobile Safari/537.36": domain.DeviceAndroid,
+		"Mozilla/5.0 (iPad; CPU OS 17_0 like Mac OS X) AppleWebKit/605.1.15":                            domain.DeviceIOS,
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/126.0 Safari/537.36":       domain.DeviceDesktop,
+		"WhatsApp/2.23 link preview": domain.DeviceBot,
+		"Googlebot/2.1":              domain.DeviceBot,
+		"":                           domain.DeviceOther,
+	} {
+		if got := domain.CoarseUserAgent(ua); got != want {
+			t.Errorf("CoarseUserAgent(%q) = %q, want %q", ua, got, want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	handler "github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+type fakeImageSearch struct{ calls int }
+
+func (f *fakeImageSearch) SearchFoodImages(context.Context, string, string, int) ([]services.PhotoMatch, error) {
+	f.calls++
+	return nil, nil
+}
+
+func (f *fakeImageSearch) Enabled() bool { return true }
+
+// quotaUsage allows searches until limit and records them.
+type quotaUsage struct {
+	used, limit int64
+	recorded    []domain.UsageEntry
+}
+
+func (u *quotaUsage) Record(entries ...domain.UsageEntry) {
+	for _, e := range entries {
+		u.used += e.Quantity
+	}
+	u.recorded = append(u.recorded, entries...)
+}
+
+func (u *quotaUsage) CheckQuota(_, _ string, kind domain.UsageKind, requested int64) error {
+	if u.used+requested > u.limit {
+		return &domain.QuotaExceededError{Scope: "user", Kind: kind, Used: u.used, Requested: requested, Limit: u.limit, ResetsAt: time.Now().Add(time.Hour)}
+	}
+	return nil
+}
+
+func (u *quotaUsage) Report(domain.UsageReportFilter) (*domain.UsageReport, error) { return nil, nil }
+
+func TestImageSearchQuota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	google := &fakeImageSearch{}
+	usage := &quotaUsage{limit: 1}
+	rests := &managedRestaurants{byManager: map[string]*domain.Restaurant{"u1": {ID: "r1", ManagerID: "u1"}}}
+	h := handler.NewImageSearchHandler(google, nil, nil, nil, nil, usage, rests)
+	r := gin.New()
+	r.GET("/images/search", func(c *gin.Context) { c.Set("user_id", "u1") }, h.Search)
+
+	// the restaurant the client names is ignored
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/images/search?item=tibs&restaurant_id=r9", nil))
+	if w.Code != http.StatusOK || len(usage.recorded) != 1 || usage.recorded[0].RestaurantID != "r1" || usage.recorded[0].UserID != "u1" {
+		t.Fatalf("first search: %d, recorded %+v", w.Code, usage.recorded)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/images/search?item=tibs", nil))
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Fatal("missing Retry-After")
+	}
+	var body struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details struct {
+			Scope string `json:"scope"`
+			Limit int64  `json:"limit"`
+		} `json:"details"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	if body.Code != "usage_quota_exceeded" || body.Details.Scope != "user" || body.Details.Limit != 1 || body.Message == "" {
+		t.Fatalf("unexpected error body %s", w.Body.String())
+	}
+	if google.calls != 1 {
+		t.Fatalf("search ran over quota: %d calls", google.calls)
+	}
+}
+
+func TestImageSearchNeedsAManagedRestaurant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	google := &fakeImageSearch{}
+	usage := &quotaUsage{limit: 10}
+	h := handler.NewImageSearchHandler(google, nil, nil, nil, nil, usage, &managedRestaurants{})
+	r := gin.New()
+	r.GET("/images/search", func(c *gin.Context) { c.Set("user_id", "u1") }, h.Search)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/images/search?item=tibs&restaurant_id=r1", nil))
+	if w.Code != http.StatusForbidden || google.calls != 0 || len(usage.recorded) != 0 {
+		t.Fatalf("search without a restaurant: %d, %d calls, recorded %+v", w.Code, google.calls, usage.recorded)
+	}
+}
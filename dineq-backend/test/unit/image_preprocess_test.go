@@ -0,0 +1,198 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+	"github.com/disintegration/imaging"
+)
+
+// menuPage draws dim "text lines": dashes of dark gray on light gray paper,
+// with a wide empty margin around them.
+func menuPage(w, h int) *image.NRGBA {
+	img := imaging.New(w, h, color.NRGBA{170, 170, 170, 255})
+	ink := color.NRGBA{110, 110, 110, 255}
+	for y := h / 5; y < h*4/5; y += h / 20 {
+		for x := w / 5; x < w*4/5; x++ {
+			if (x/12)%4 == 3 {
+				continue // word gap
+			}
+			for dy := 0; dy < h/60+1; dy++ {
+				img.Set(x, y+dy, ink)
+			}
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decodePNG(t *testing.T, data []byte) image.Image {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func TestPreprocessImageDownscalesAndStretchesContrast(t *testing.T) {
+	out, report, err := services.PreprocessImage(encodePNG(t, menuPage(1200, 900)), services.PreprocessOptions{MaxDimension: 600})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.OriginalWidth != 1200 || report.Width != 600 || report.Height != 450 || report.Scale != 0.5 {
+		t.Fatalf("not downscaled: %+v", report)
+	}
+	if !report.ContrastStretched || report.SkewDegrees != 0 || report.Cropped {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	lo, hi := uint32(0xffff), uint32(0)
+	img := decodePNG(t, out)
+	for y := 0; y < img.Bounds().Dy(); y += 3 {
+		for x := 0; x < img.Bounds().Dx(); x += 3 {
+			r, _, _, _ := img.At(x, y).RGBA()
+			lo, hi = min(lo, r), max(hi, r)
+		}
+	}
+	if lo > 0x1000 || hi < 0xf000 {
+		t.Fatalf("contrast not stretched: %x..%x", lo, hi)
+	}
+}
+
+func TestPreprocessImageStraightensSkewedText(t *testing.T) {
+	for _, angle := range []float64{3, -4.5} {
+		skewed := imaging.Rotate(menuPage(800, 800), angle, color.NRGBA{170, 170, 170, 255})
+		_, report, err := services.PreprocessImage(encodePNG(t, skewed), services.PreprocessOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(report.SkewDegrees+angle) > 0.3 {
+			t.Errorf("text rotated by %v°: corrected by %v°", angle, report.SkewDegrees)
+		}
+	}
+	_, report, _ := services.PreprocessImage(encodePNG(t, menuPage(800, 800)), services.PreprocessOptions{})
+	if report.SkewDegrees != 0 {
+		t.Errorf("straight page rotated by %v°", report.SkewDegrees)
+	}
+}
+
+func TestPreprocessImageCropsMargins(t *testing.T) {
+	_, report, err := services.PreprocessImage(encodePNG(t, menuPage(1000, 1000)), services.PreprocessOptions{Crop: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Cropped || report.Width > 700 || report.Height > 700 || report.Width < 600 {
+		t.Fatalf("margins not cropped: %+v", report)
+	}
+}
+
+// withOrientation inserts an EXIF APP1 segment with the orientation tag
+// after the JPEG SOI marker.
+func withOrientation(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1) // one IFD entry
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	seg := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(seg)+2))
+	app1 = append(app1, seg...)
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), app1...), data[2:]...)
+}
+
+func TestPreprocessImageAppliesEXIFOrientation(t *testing.T) {
+	// a landscape frame the camera says was held rotated 90°
+	_, report, err := services.PreprocessImage(withOrientation(t, menuPage(400, 300), 6), services.PreprocessOptions{MaxSkewDegrees: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Orientation != 6 || report.Width != 300 || report.Height != 400 {
+		t.Fatalf("orientation not applied: %+v", report)
+	}
+	if _, _, err := services.PreprocessImage([]byte("not an image"), services.PreprocessOptions{}); err == nil {
+		t.Fatal("expected a decode error")
+	}
+}
+
+func TestOCRAccuracy(t *testing.T) {
+	if got := services.OCRAccuracy("Doro Wat  450\nTibs 380", "doro wat 450 tibs 380"); got != 1 {
+		t.Fatalf("whitespace and case should not count: %v", got)
+	}
+	if got := services.OCRAccuracy("Tibs 380", "Tlbs 38O"); got != 0.75 {
+		t.Fatalf("two of eight characters wrong: %v", got)
+	}
+	if got := services.OCRAccuracy("Tibs", "completely unrelated text"); got != 0 {
+		t.Fatalf("expected clamp at 0, got %v", got)
+	}
+}
+
+// recordingPreprocessor processes every image but broken.png.
+type recordingPreprocessor struct{}
+
+func (recordingPreprocessor) Preprocess(_ context.Context, url string) (string, string, *domain.ImagePreprocessReport, error) {
+	if url == "broken.png" {
+		return "", "", nil, image.ErrFormat
+	}
+	return "processed-" + url, "processed/" + url, &domain.ImagePreprocessReport{SkewDegrees: 2}, nil
+}
+
+func TestOCRJobReadsPreprocessedImages(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	ocr := &flakyPageOCR{
+		texts: map[string]string{"processed-p1.png": "Doro Wat\n450", "broken.png": "Tibs\n380"},
+		reads: map[string]int{},
+	}
+	uc := usecase.NewOCRJobUseCase(repo, newMemParseResultRepo(), ocr, recordingPreprocessor{}, &echoAI{}, nil, nil, nil, nil, nil, time.Second)
+	job := &domain.OCRJob{ImageURL: "p1.png", Pages: []domain.OCRPage{
+		{Number: 1, ImageURL: "p1.png", Status: domain.OCRPagePending},
+		{Number: 2, ImageURL: "broken.png", Status: domain.OCRPagePending},
+	}}
+	if err := uc.CreateOCRJob(job); err != nil {
+		t.Fatal(err)
+	}
+	uc.ProcessJob(job.ID)
+	got, _ := repo.GetByID(context.Background(), job.ID)
+	if got.Status != domain.OCRCompleted {
+		t.Fatalf("job status %q, error %q", got.Status, got.Error)
+	}
+	if got.ProcessedImageURL != "processed-p1.png" || got.ImageURL != "p1.png" {
+		t.Fatalf("processed image not recorded next to the original: %q %q", got.ImageURL, got.ProcessedImageURL)
+	}
+	if got.Pages[0].Preprocess == nil || got.Pages[1].ProcessedImageURL != "" {
+		t.Fatalf("unexpected pages %+v", got.Pages)
+	}
+	if ocr.reads["processed-p1.png"] != 1 || ocr.reads["broken.png"] != 1 || ocr.reads["p1.png"] != 0 {
+		t.Fatalf("OCR should read processed images and fall back to originals: %v", ocr.reads)
+	}
+	if got.PhaseHistory[1].Name != domain.PhasePreprocessing || got.PhaseHistory[1].Status != "done" {
+		t.Fatalf("preprocessing phase missing: %+v", got.PhaseHistory)
+	}
+}
@@ -0,0 +1,54 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+func TestItemAvailability(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	if !(&domain.Item{}).IsAvailableAt(now) {
+		t.Fatalf("items are available by default")
+	}
+	if (&domain.Item{Unavailable: true}).IsAvailableAt(now) {
+		t.Fatalf("86'd item without an end time must stay unavailable")
+	}
+	timed := &domain.Item{Unavailable: true, UnavailableUntil: &later}
+	if timed.IsAvailableAt(now) {
+		t.Fatalf("item should be unavailable before unavailable_until")
+	}
+	if !timed.IsAvailableAt(later) {
+		t.Fatalf("item should come back at unavailable_until")
+	}
+}
+
+func TestItemResponseAvailability(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	res := dto.ItemToResponse(&domain.Item{Unavailable: true, UnavailableUntil: &future})
+	if res.IsAvailable || res.UnavailableUntil == nil {
+		t.Fatalf("expected sold out item with end time, got %+v", res)
+	}
+	res = dto.ItemToResponse(&domain.Item{Unavailable: true, UnavailableUntil: &past})
+	if !res.IsAvailable || res.UnavailableUntil != nil {
+		t.Fatalf("expired sold out period should read as available, got %+v", res)
+	}
+}
+
+func TestItemAvailabilityOfAnotherRestaurantsMenuIsNotFound(t *testing.T) {
+	// storedMenuRepo has no SetItemAvailability, so reaching the write panics
+	menus := &storedMenuRepo{menu: &domain.Menu{ID: "m1", RestaurantID: "r1", Items: []domain.Item{{ID: "i1", Name: "Shiro"}}}}
+	uc := usecase.NewMenuUseCase(menus, nil, nil, nil, services.QRService{}, nil, nil, nil, time.Second)
+
+	if _, err := uc.SetItemAvailability("m1", "i1", &domain.Restaurant{ID: "r2"}, false, nil); err != domain.ErrNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+func TestItemPriceRange(t *testing.T) {
+	item := &domain.Item{
+		Price: 100,
+		ModifierGroups: []domain.ModifierGroup{
+			{Name: "Size", MinSelect: 1, MaxSelect: 1, Options: []domain.ModifierOption{
+				{Name: "Regular", PriceDelta: 0},
+				{Name: "Large", PriceDelta: 40},
+			}},
+			{Name: "Side", Translations: domain.Translations{"am": "ጎን"}, MinSelect: 1, MaxSelect: 1, Options: []domain.ModifierOption{
+				{Name: "Injera", PriceDelta: 0},
+				{Name: "Rice", PriceDelta: 10},
+			}},
+			{Name: "Extras", MinSelect: 0, MaxSelect: 2, Options: []domain.ModifierOption{
+				{Name: "Egg", PriceDelta: 15},
+				{Name: "Cheese", PriceDelta: 20},
+				{Name: "Avocado", PriceDelta: 25},
+			}},
+		},
+	}
+	low, high := item.PriceRange()
+	if low != 100 {
+		t.Fatalf("low: got %v want 100", low)
+	}
+	// large + rice + the two dearest extras
+	if high != 100+40+10+25+20 {
+		t.Fatalf("high: got %v want 195", high)
+	}
+
+	plain := &domain.Item{Price: 80}
+	if low, high := plain.PriceRange(); low != 80 || high != 80 {
+		t.Fatalf("item without modifiers: got %v-%v", low, high)
+	}
+}
+
+func TestValidateModifierGroups(t *testing.T) {
+	option := []domain.ModifierOption{{Name: "Regular"}}
+	bad := [][]domain.ModifierGroup{
+		{{Options: option}},
+		{{Name: "Size"}},
+		{{Name: "Size", MinSelect: 2, MaxSelect: 1, Options: option}},
+		{{Name: "Size", MinSelect: 2, Options: option}},
+		{{Name: "Size", Options: []domain.ModifierOption{{PriceDelta: 5}}}},
+	}
+	for i, groups := range bad {
+		if err := domain.ValidateModifierGroups(groups); !errors.Is(err, domain.ErrInvalidModifierGroups) {
+			t.Errorf("case %d: expected ErrInvalidModifierGroups, got %v", i, err)
+		}
+	}
+	ok := []domain.ModifierGroup{{Translations: domain.Translations{"am": "መጠን"}, MinSelect: 1, MaxSelect: 0, Options: option}}
+	if err := domain.ValidateModifierGroups(ok); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+func TestDiffMenusItemsAndTabs(t *testing.T) {
+	from := &domain.Menu{
+		Name: "Lunch Menu",
+		Items: []domain.Item{
+			{ID: "a", Slug: "tibs", Name: "Tibs", Price: 250, Currency: "ETB", TabTags: []string{"Mains"}},
+			{ID: "b", Slug: "kitfo", Name: "Kitfo", Price: 300, Currency: "ETB", TabTags: []string{"Mains"}},
+			{ID: "c", Slug: "shai", Name: "Shai", Price: 20, Currency: "ETB", TabTags: []string{"Drinks"}},
+		},
+	}
+	to := &domain.Menu{
+		Name: "Lunch Menu",
+		Items: []domain.Item{
+			{ID: "a", Slug: "tibs", Name: "Tibs", Price: 280, Currency: "ETB", TabTags: []string{"Mains"}},
+			{ID: "b", Slug: "kitfo", Name: "Kitfo", Price: 300, Currency: "ETB", TabTags: []string{"Mains"}},
+			{ID: "c", Slug: "shai", Name: "Shai", Price: 20, Currency: "ETB", TabTags: []string{"Drinks"}, IsDeleted: true},
+			{Slug: "baklava", Name: "Baklava", Price: 90, Currency: "ETB", TabTags: []string{"Desserts"}},
+		},
+	}
+
+	diff := usecase.DiffMenus(from, to)
+	if diff.NameChanged {
+		t.Fatalf("name should be unchanged")
+	}
+	if len(diff.ItemsAdded) != 1 || diff.ItemsAdded[0].Slug != "baklava" {
+		t.Fatalf("unexpected added items %#v", diff.ItemsAdded)
+	}
+	if len(diff.ItemsRemoved) != 1 || diff.ItemsRemoved[0].ID != "c" {
+		t.Fatalf("unexpected removed items %#v", diff.ItemsRemoved)
+	}
+	if len(diff.ItemsRepriced) != 1 || diff.ItemsRepriced[0].OldPrice != 250 || diff.ItemsRepriced[0].NewPrice != 280 {
+		t.Fatalf("unexpected repriced items %#v", diff.ItemsRepriced)
+	}
+	if len(diff.TabsAdded) != 1 || diff.TabsAdded[0] != "Desserts" {
+		t.Fatalf("unexpected tabs added %#v", diff.TabsAdded)
+	}
+	if len(diff.TabsRemoved) != 1 || diff.TabsRemoved[0] != "Drinks" {
+		t.Fatalf("unexpected tabs removed %#v", diff.TabsRemoved)
+	}
+}
+
+func TestDiffMenusIdentical(t *testing.T) {
+	menu := &domain.Menu{Name: "Menu", Items: []domain.Item{{ID: "a", Price: 10}}}
+	diff := usecase.DiffMenus(menu, menu)
+	if diff.NameChanged || len(diff.ItemsAdded)+len(diff.ItemsRemoved)+len(diff.ItemsRepriced) != 0 {
+		t.Fatalf("expected empty diff, got %#v", diff)
+	}
+}
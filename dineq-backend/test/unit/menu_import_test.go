@@ -0,0 +1,165 @@
+package unit
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database/mapper"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestMenuImportCSVReport(t *testing.T) {
+	csv := "Tab,Category,Name,Price,Currency,Ingredients\n" +
+		"Food,Mains,Tibs,\"1,250.50\",,beef; onion\n" +
+		"Food,Mains,Shiro,abc,ETB,\n" +
+		"\n" +
+		"Drinks,Hot,Tibs,40,ETB,\n" +
+		"Drinks,Hot,Macchiato,35,XYZ,\n" +
+		"Drinks,Cold,Juice,60,usd,\n"
+	imp, err := services.ParseMenuImport(domain.MenuImportCSV, []byte(csv))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(imp.Rows) != 5 {
+		t.Fatalf("expected blank line skipped, got %d rows", len(imp.Rows))
+	}
+
+	tabs, items, report := usecase.BuildImportedMenu(imp, "ETB")
+	if report.TotalRows != 5 || report.ValidRows != 2 {
+		t.Fatalf("unexpected counts: total %d valid %d", report.TotalRows, report.ValidRows)
+	}
+	want := map[int]string{3: "price", 5: "slug", 6: "currency"}
+	if len(report.Issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), report.Issues)
+	}
+	for _, is := range report.Issues {
+		if want[is.Row] != is.Field {
+			t.Errorf("unexpected issue %+v", is)
+		}
+	}
+
+	if len(items) != 2 || items[0].Price != 1250.50 || items[0].Currency != "ETB" || items[1].Currency != "USD" {
+		t.Fatalf("unexpected items %+v", items)
+	}
+	if len(items[0].Ingredients) != 2 || items[0].TabTags[0] != "Food" {
+		t.Fatalf("ingredients or tab not mapped: %+v", items[0])
+	}
+	if len(tabs) != 2 || tabs[1].Name != "Drinks" || tabs[1].Categories[0].Name != "Cold" {
+		t.Fatalf("unexpected tabs %+v", tabs)
+	}
+}
+
+func TestMenuImportJSON(t *testing.T) {
+	doc := `{"name":"Lunch","tabs":[{"name":"Food","categories":[{"name":"Mains","items":[
+		{"name":"Tibs","price":250},{"name":"Kitfo","price":"0"}]}]}]}`
+	imp, err := services.ParseMenuImport(domain.MenuImportJSON, []byte(doc))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if imp.Name != "Lunch" || len(imp.Rows) != 2 || imp.Rows[1].Row != 2 || imp.Rows[0].Category != "Mains" {
+		t.Fatalf("unexpected import %+v", imp)
+	}
+	_, _, report := usecase.BuildImportedMenu(imp, "")
+	if len(report.Issues) != 1 || report.Issues[0].Row != 2 || report.Issues[0].Field != "price" {
+		t.Fatalf("expected zero price rejected, got %+v", report.Issues)
+	}
+}
+
+// xlsxWorkbook zips a minimal workbook around the given sheetData rows.
+func xlsxWorkbook(t *testing.T, rows string) []byte {
+	t.Helper()
+	files := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Menu" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/menu.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":       `<sst><si><t>name</t></si><si><t>price</t></si><si><r><t>Ti</t></r><r><t>bs</t></r></si></sst>`,
+		"xl/worksheets/menu.xml":     `<worksheet><sheetData>` + rows + `</sheetData></worksheet>`,
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(body))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestMenuImportXLSX(t *testing.T) {
+	// rows 3 and 4 are empty and therefore missing from the sheet
+	data := xlsxWorkbook(t, `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>`+
+		`<row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2"><v>120</v></c></row>`+
+		`<row r="5"><c r="A5" t="inlineStr"><is><t>Shiro</t></is></c><c r="C5"><v>0</v></c></row>`)
+
+	imp, err := services.ParseMenuImport(domain.MenuImportXLSX, data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(imp.Rows) != 2 || imp.Rows[0].Name != "Tibs" || imp.Rows[0].Price != "120" || imp.Rows[0].Row != 2 {
+		t.Fatalf("unexpected rows %+v", imp.Rows)
+	}
+	_, _, report := usecase.BuildImportedMenu(imp, "")
+	if len(report.Issues) != 1 || report.Issues[0].Row != 5 {
+		t.Fatalf("expected the zero price reported on sheet row 5, got %+v", report.Issues)
+	}
+}
+
+func TestMenuImportXLSXColumnLimit(t *testing.T) {
+	data := xlsxWorkbook(t, `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="ZZZZZZZZZZZZ1" t="s"><v>1</v></c></row>`)
+	if _, err := services.ParseMenuImport(domain.MenuImportXLSX, data); err == nil {
+		t.Fatal("expected a cell past the last sheet column to be rejected")
+	}
+}
+
+func TestMenuImportStructureSurvivesStorage(t *testing.T) {
+	csv := "Tab,Tab_am,Category,Category_am,Name,Price\n" +
+		"Food,ምግብ,Mains,ዋና,Tibs,250\n" +
+		"Food,ምግብ,Sides,,Salad,80\n" +
+		"Drinks,,Hot,,Macchiato,35\n"
+	imp, err := services.ParseMenuImport(domain.MenuImportCSV, []byte(csv))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	_, items, report := usecase.BuildImportedMenu(imp, "ETB")
+	if report.HasIssues() {
+		t.Fatalf("unexpected issues %+v", report.Issues)
+	}
+
+	// store and load the menu the way the repository does; only items persist
+	raw, err := bson.Marshal(mapper.NewMenuDBFromDomain(&domain.Menu{Name: "Lunch", Items: items}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored mapper.MenuDB
+	if err := bson.Unmarshal(raw, &stored); err != nil {
+		t.Fatal(err)
+	}
+	tabs := mapper.ToDomainMenu(&stored).Sections()
+
+	if len(tabs) != 2 || tabs[0].Name != "Food" || tabs[1].Name != "Drinks" {
+		t.Fatalf("unexpected tabs %+v", tabs)
+	}
+	food := tabs[0]
+	if food.Translations[domain.LangAmharic] != "ምግብ" || len(food.Categories) != 2 {
+		t.Fatalf("food tab lost its translation or categories: %+v", food)
+	}
+	mains, sides := food.Categories[0], food.Categories[1]
+	if mains.Name != "Mains" || mains.Translations[domain.LangAmharic] != "ዋና" || len(mains.Items) != 1 || mains.Items[0].Name != "Tibs" {
+		t.Fatalf("unexpected mains %+v", mains)
+	}
+	if sides.Name != "Sides" || len(sides.Items) != 1 || sides.Items[0].Name != "Salad" {
+		t.Fatalf("unexpected sides %+v", sides)
+	}
+	if cats := tabs[1].Categories; len(cats) != 1 || cats[0].Name != "Hot" {
+		t.Fatalf("unexpected drinks categories %+v", cats)
+	}
+}
@@ -0,0 +1,186 @@
+package unit
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+// storedMenuRepo holds one menu; Update assigns IDs to new items, bumps the
+// version and keeps the items when given none, as the Mongo repository would.
+type storedMenuRepo struct {
+	domain.IMenuRepository
+	menu   *domain.Menu
+	nextID int
+}
+
+func (r *storedMenuRepo) GetByID(_ context.Context, id string) (*domain.Menu, error) {
+	if r.menu == nil || r.menu.ID != id {
+		return nil, domain.ErrNotFound
+	}
+	cp := *r.menu
+	cp.Items = slices.Clone(r.menu.Items)
+	return &cp, nil
+}
+
+func (r *storedMenuRepo) Update(_ context.Context, id string, menu *domain.Menu) (*domain.Menu, error) {
+	cp := *menu
+	cp.Items = slices.Clone(menu.Items)
+	if menu.Items == nil { // nil leaves the stored items alone, an empty list clears them
+		cp.Items = r.menu.Items
+	}
+	for i := range cp.Items {
+		if cp.Items[i].ID == "" {
+			r.nextID++
+			cp.Items[i].ID = "new-" + strconv.Itoa(r.nextID)
+		}
+	}
+	cp.Version = r.menu.Version + 1
+	r.menu = &cp
+	return r.GetByID(context.Background(), id)
+}
+
+func mergeFixture() *domain.Menu {
+	return &domain.Menu{
+		ID:           "m1",
+		RestaurantID: "r1",
+		Slug:         "lunch-menu-0a1b2c3d",
+		Items: []domain.Item{
+			{ID: "i1", Name: "Doro Wat", Slug: "doro-wat-1a2b3c4d", Price: 450, ReviewIds: []string{"rev1"}, ViewCount: 12, AverageRating: 4.5},
+			{ID: "i2", Name: "Shiro", Slug: "shiro-5e6f7a8b", Price: 200, Translations: map[string]domain.ItemTranslation{domain.LangAmharic: {Name: "ሽሮ"}}},
+			{ID: "i3", Name: "Tibs Special", Slug: "tibs-special-9c0d1e2f", Price: 380, Image: []string{"tibs.jpg"}},
+			{ID: "i4", Name: "Kitfo", Slug: "kitfo-3a4b5c6d", Price: 500},
+			{ID: "i5", Name: "Beyaynetu", Slug: "beyaynetu-7e8f9a0b", Price: 300},
+		},
+	}
+}
+
+func mergeParseResult() *domain.AIParseResult {
+	item := func(id string, status domain.ParsedItemStatus, it domain.Item) domain.ParsedItem {
+		return domain.ParsedItem{ID: id, Tab: "Food", Category: "Mains", Item: it, Status: status}
+	}
+	return &domain.AIParseResult{
+		ID:           "p1",
+		RestaurantID: "r1",
+		TargetMenuID: "m1",
+		Status:       domain.ParseResultPendingReview,
+		Items: []domain.ParsedItem{
+			item("a", domain.ParsedItemAccepted, domain.Item{Name: "Doro Wat", Price: 480}),
+			item("b", domain.ParsedItemAccepted, domain.Item{Name: "Shero", Price: 200, Translations: map[string]domain.ItemTranslation{domain.LangAmharic: {Name: "ሽሮ"}}}),
+			item("c", domain.ParsedItemEdited, domain.Item{Name: "Tibbs Special", Price: 380}),
+			item("d", domain.ParsedItemAccepted, domain.Item{Name: "Firfir", Price: 150}),
+			// rejected as misread, but Kitfo is still on the photo
+			item("e", domain.ParsedItemRejected, domain.Item{Name: "Kitfo", Price: 5}),
+		},
+	}
+}
+
+func TestPlanMenuMergeMatchesItems(t *testing.T) {
+	changes := usecase.PlanMenuMerge(mergeFixture(), mergeParseResult())
+	if len(changes.PriceChanged) != 1 {
+		t.Fatalf("price changes %+v", changes.PriceChanged)
+	}
+	if pc := changes.PriceChanged[0]; pc.ItemID != "i1" || pc.OldPrice != 450 || pc.NewPrice != 480 || pc.MatchedBy != domain.MergeMatchSlug {
+		t.Fatalf("unexpected price change %+v", pc)
+	}
+	if changes.Unchanged != 2 {
+		t.Fatalf("expected Shiro (Amharic name) and Tibs Special (fuzzy) unchanged, got %d", changes.Unchanged)
+	}
+	if len(changes.Added) != 1 || changes.Added[0].Name != "Firfir" || changes.Added[0].ParsedItemID != "d" {
+		t.Fatalf("added %+v", changes.Added)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0].ItemID != "i5" {
+		t.Fatalf("only Beyaynetu should be removed, got %+v", changes.Removed)
+	}
+}
+
+func TestApproveParseResultMergesIntoExistingMenu(t *testing.T) {
+	menus := &storedMenuRepo{menu: mergeFixture()}
+	menuUc := usecase.NewMenuUseCase(menus, nil, nil, nil, services.QRService{}, nil, nil, nil, time.Second)
+	parsed := newMemParseResultRepo()
+	result := mergeParseResult()
+	if err := parsed.Create(context.Background(), result); err != nil {
+		t.Fatal(err)
+	}
+	uc := usecase.NewAIParseResultUseCase(parsed, menuUc, time.Second)
+
+	if _, err := uc.ApproveParseResult(result.ID, &domain.Menu{RestaurantID: "other", CreatedBy: "u1"}, false); err != domain.ErrForbidden {
+		t.Fatalf("merge into another restaurant's menu: %v", err)
+	}
+	menu := &domain.Menu{RestaurantID: "r1", CreatedBy: "u1"}
+	changes, err := uc.ApproveParseResult(result.ID, menu, false)
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if changes == nil || len(changes.Added) != 1 || len(changes.Removed) != 1 {
+		t.Fatalf("unexpected change set %+v", changes)
+	}
+	if menu.ID != "m1" || len(menu.Items) != 5 {
+		t.Fatalf("expected the merged menu back with 5 items, got %+v", menu)
+	}
+	byID := map[string]domain.Item{}
+	for _, it := range menu.Items {
+		byID[it.ID] = it
+	}
+	doro := byID["i1"]
+	if doro.Price != 480 || doro.ViewCount != 12 || doro.AverageRating != 4.5 || !slices.Equal(doro.ReviewIds, []string{"rev1"}) {
+		t.Fatalf("matched item lost its history or price: %+v", doro)
+	}
+	if byID["i3"].Name != "Tibs Special" || !slices.Equal(byID["i3"].Image, []string{"tibs.jpg"}) {
+		t.Fatalf("fuzzy-matched item changed: %+v", byID["i3"])
+	}
+	if _, ok := byID["i4"]; !ok {
+		t.Fatal("item matched by a rejected parse was removed")
+	}
+	if _, ok := byID["i5"]; ok {
+		t.Fatal("item missing from the photo was kept")
+	}
+	added := byID["new-1"]
+	if added.Name != "Firfir" || added.MenuSlug != "lunch-menu-0a1b2c3d" || added.TabTags[0] != "Food" {
+		t.Fatalf("new item %+v", added)
+	}
+	stored, _ := parsed.GetByID(context.Background(), result.ID)
+	if stored.MenuID != "m1" || stored.Status != domain.ParseResultApproved {
+		t.Fatalf("stored result %+v", stored)
+	}
+}
+
+func TestApproveParseResultMergeKeepsRemoved(t *testing.T) {
+	menus := &storedMenuRepo{menu: mergeFixture()}
+	menuUc := usecase.NewMenuUseCase(menus, nil, nil, nil, services.QRService{}, nil, nil, nil, time.Second)
+	parsed := newMemParseResultRepo()
+	result := mergeParseResult()
+	if err := parsed.Create(context.Background(), result); err != nil {
+		t.Fatal(err)
+	}
+	uc := usecase.NewAIParseResultUseCase(parsed, menuUc, time.Second)
+	changes, err := uc.ApproveParseResult(result.ID, &domain.Menu{RestaurantID: "r1", CreatedBy: "u1"}, true)
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if len(changes.Removed) != 0 || len(menus.menu.Items) != 6 {
+		t.Fatalf("expected nothing removed, got %+v and %d items", changes.Removed, len(menus.menu.Items))
+	}
+}
+
+func TestPreviewMergeNeedsTheTargetMenusRestaurant(t *testing.T) {
+	menus := &storedMenuRepo{menu: mergeFixture()}
+	menuUc := usecase.NewMenuUseCase(menus, nil, nil, nil, services.QRService{}, nil, nil, nil, time.Second)
+	parsed := newMemParseResultRepo()
+	result := mergeParseResult()
+	result.RestaurantID = "other"
+	if err := parsed.Create(context.Background(), result); err != nil {
+		t.Fatal(err)
+	}
+	uc := usecase.NewAIParseResultUseCase(parsed, menuUc, time.Second)
+
+	if _, err := uc.PreviewMerge(result.ID); err != domain.ErrForbidden {
+		t.Fatalf("preview against another restaurant's menu: %v", err)
+	}
+}
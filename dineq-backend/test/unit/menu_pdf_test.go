@@ -0,0 +1,211 @@
+package unit
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+)
+
+func TestMenuSectionsFromTabTags(t *testing.T) {
+	menu := &domain.Menu{Items: []domain.Item{
+		{Name: "Tibs", TabTags: []string{"Food"}, Translations: map[string]domain.ItemTranslation{"am": {TabTags: []string{"ምግብ"}}}},
+		{Name: "Old", TabTags: []string{"Food"}, IsDeleted: true},
+		{Name: "Buna", TabTags: []string{"Drinks"}},
+		{Name: "Kitfo", TabTags: []string{"food"}},
+		{Name: "Bread"},
+	}}
+	tabs := menu.Sections()
+	if len(tabs) != 3 {
+		t.Fatalf("expected 3 tabs, got %+v", tabs)
+	}
+	if tabs[0].Name != "Food" || tabs[0].Translations["am"] != "ምግብ" || len(tabs[0].Categories[0].Items) != 2 {
+		t.Fatalf("unexpected first tab %+v", tabs[0])
+	}
+	if tabs[2].Name != "Menu" {
+		t.Fatalf("untagged items should go to a default tab, got %q", tabs[2].Name)
+	}
+}
+
+func TestRenderMenuPDF(t *testing.T) {
+	menu := &domain.Menu{ID: "m1", Name: "Dinner Menu", Slug: "dinner-menu-1234"}
+	for i := 0; i < 80; i++ {
+		menu.Items = append(menu.Items, domain.Item{
+			Name:        fmt.Sprintf("Dish %d", i),
+			Description: "Slow cooked beef with onion, rosemary and a long enough description to wrap onto a second line of the column.",
+			Price:       float64(100 + i),
+			TabTags:     []string{"Mains"},
+			Translations: map[string]domain.ItemTranslation{
+				"am": {Name: "ምግብ", Description: "በሽንኩርት የተጠበሰ ስጋ"},
+			},
+		})
+	}
+	rest := &domain.Restaurant{Slug: "cafe", RestaurantName: "Cafe", PrimaryColor: "#112233", DefaultCurrency: "ETB"}
+
+	out, err := services.NewMenuPDFService(&services.QRService{}).RenderMenuPDF(context.Background(), menu, rest, domain.MenuPDFOptions{})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if texts := pdfTexts(t, out); !slices.ContainsFunc(texts, func(txt pdfText) bool { return txt.text == "Dish 0" && txt.x == "40" }) {
+		t.Fatalf("item name not drawn in the first column: %v", texts)
+	}
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+	count := regexp.MustCompile(`/Type /Pages /Kids \[[^\]]*\] /Count (\d+)`).FindSubmatch(out)
+	if count == nil {
+		t.Fatal("page tree not found")
+	}
+	if n, _ := strconv.Atoi(string(count[1])); n < 2 {
+		t.Fatalf("expected the long menu to paginate, got %d page(s)", n)
+	}
+
+	// every xref entry must point at the start of its object
+	start := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(out)
+	xref, _ := strconv.Atoi(string(start[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		want := fmt.Sprintf("%d 0 obj", i+1)
+		if !bytes.HasPrefix(out[off:], []byte(want)) {
+			t.Fatalf("xref entry %d points at %q", i+1, out[off:off+12])
+		}
+	}
+}
+
+func TestRenderMenuPDFAmharicColumn(t *testing.T) {
+	if err := services.CheckPDFFonts(); err != nil {
+		t.Fatal(err)
+	}
+	menu := &domain.Menu{ID: "m1", Name: "Lunch", Slug: "lunch", Items: []domain.Item{{
+		Name: "Tibs", Description: "Sauteed beef", Price: 250, TabTags: []string{"Mains"},
+		Translations: map[string]domain.ItemTranslation{"am": {Name: "ጥብስ", Description: "የተጠበሰ ስጋ"}},
+	}}}
+	rest := &domain.Restaurant{Slug: "cafe", RestaurantName: "Cafe", DefaultCurrency: "ETB"}
+	out, err := services.NewMenuPDFService(nil).RenderMenuPDF(context.Background(), menu, rest, domain.MenuPDFOptions{IncludeAmharic: true})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+
+	texts := pdfTexts(t, out)
+	find := func(s string) pdfText {
+		for _, txt := range texts {
+			if txt.text == s {
+				return txt
+			}
+		}
+		t.Fatalf("%q not drawn; texts: %v", s, texts)
+		return pdfText{}
+	}
+	name, amName, amDesc := find("Tibs"), find("ጥብስ"), find("የተጠበሰ ስጋ")
+	// names are bold; without a bold Ethiopic cut they use the regular one
+	if !slices.Contains(amName.fonts, "NotoSansEthiopic-Regular") || !slices.Contains(amDesc.fonts, "NotoSansEthiopic-Regular") {
+		t.Fatalf("Amharic should be drawn with the Ethiopic faces, got %v and %v", amName.fonts, amDesc.fonts)
+	}
+	nameX, _ := strconv.ParseFloat(name.x, 64)
+	amX, _ := strconv.ParseFloat(amName.x, 64)
+	if amDesc.x != amName.x || amX <= nameX+100 {
+		t.Fatalf("Amharic should be a column right of the English one, got x %s and %s next to %s", amName.x, amDesc.x, name.x)
+	}
+}
+
+// pdfText is a string drawn by one text object, decoded through the
+// ToUnicode maps of its fonts.
+type pdfText struct {
+	text  string
+	x     string
+	fonts []string
+}
+
+var (
+	pdfObjectRe  = regexp.MustCompile(`(?s)(\d+) 0 obj\n(.*?)\nendobj\n`)
+	pdfStreamRe  = regexp.MustCompile(`(?s)^<<(.*?) /Length (\d+) >>\nstream\n`)
+	pdfFontRefRe = regexp.MustCompile(`/(F\d+) (\d+) 0 R`)
+	pdfTextObjRe = regexp.MustCompile(`BT \S+ \S+ \S+ rg (\S+) \S+ Td\n((?:/F\d+ \S+ Tf <[0-9A-F]*> Tj\n)+)ET`)
+	pdfRunRe     = regexp.MustCompile(`/(F\d+) \S+ Tf <([0-9A-F]*)> Tj`)
+	pdfBfCharRe  = regexp.MustCompile(`<([0-9A-F]{4})> <([0-9A-F]+)>`)
+)
+
+// pdfTexts decodes every text object in the page content streams.
+func pdfTexts(t *testing.T, out []byte) []pdfText {
+	t.Helper()
+	dicts := map[string]string{}
+	streams := map[string][]byte{}
+	for _, m := range pdfObjectRe.FindAllSubmatch(out, -1) {
+		id, body := string(m[1]), m[2]
+		dicts[id] = string(body)
+		sm := pdfStreamRe.FindSubmatchIndex(body)
+		if sm == nil {
+			continue
+		}
+		n, _ := strconv.Atoi(string(body[sm[4]:sm[5]]))
+		data := body[sm[1] : sm[1]+n]
+		if strings.Contains(string(body[sm[2]:sm[3]]), "/FlateDecode") {
+			zr, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("object %s: %v", id, err)
+			}
+			if data, err = io.ReadAll(zr); err != nil {
+				t.Fatalf("object %s: %v", id, err)
+			}
+		}
+		streams[id] = data
+	}
+
+	// resource name -> base font and glyph -> text
+	baseFonts := map[string]string{}
+	glyphs := map[string]map[string]string{}
+	var contents [][]byte
+	for _, dict := range dicts {
+		if !strings.Contains(dict, "/Type /Page ") {
+			continue
+		}
+		fontDict := dict[strings.Index(dict, "/Font <<"):]
+		fontDict = fontDict[:strings.Index(fontDict, ">>")]
+		for _, ref := range pdfFontRefRe.FindAllStringSubmatch(fontDict, -1) {
+			font := dicts[ref[2]]
+			baseFonts[ref[1]] = regexp.MustCompile(`/BaseFont /(\S+)`).FindStringSubmatch(font)[1]
+			cmap := streams[regexp.MustCompile(`/ToUnicode (\d+) 0 R`).FindStringSubmatch(font)[1]]
+			glyphs[ref[1]] = map[string]string{}
+			for _, bf := range pdfBfCharRe.FindAllSubmatch(cmap, -1) {
+				var units []uint16
+				for i := 0; i+4 <= len(bf[2]); i += 4 {
+					u, _ := strconv.ParseUint(string(bf[2][i:i+4]), 16, 16)
+					units = append(units, uint16(u))
+				}
+				glyphs[ref[1]][string(bf[1])] = string(utf16.Decode(units))
+			}
+		}
+		contents = append(contents, streams[regexp.MustCompile(`/Contents (\d+) 0 R`).FindStringSubmatch(dict)[1]])
+	}
+
+	var texts []pdfText
+	for _, content := range contents {
+		for _, obj := range pdfTextObjRe.FindAllSubmatch(content, -1) {
+			txt := pdfText{x: string(obj[1])}
+			var b strings.Builder
+			for _, run := range pdfRunRe.FindAllSubmatch(obj[2], -1) {
+				font := string(run[1])
+				if !slices.Contains(txt.fonts, baseFonts[font]) {
+					txt.fonts = append(txt.fonts, baseFonts[font])
+				}
+				for i := 0; i+4 <= len(run[2]); i += 4 {
+					b.WriteString(glyphs[font][string(run[2][i:i+4])])
+				}
+			}
+			txt.text = b.String()
+			texts = append(texts, txt)
+		}
+	}
+	return texts
+}
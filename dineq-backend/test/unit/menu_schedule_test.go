@@ -0,0 +1,75 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+)
+
+func TestMenuScheduleWindows(t *testing.T) {
+	loc, err := time.LoadLocation(domain.DefaultRestaurantTimezone)
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	breakfast := &domain.Menu{Schedule: &domain.MenuSchedule{Windows: []domain.MenuActivationWindow{
+		{Days: []string{"monday", "tue", "Wednesday", "thu", "fri"}, StartTime: "06:30", EndTime: "11:00"},
+	}}}
+	lateNight := &domain.Menu{Schedule: &domain.MenuSchedule{Windows: []domain.MenuActivationWindow{
+		{StartTime: "22:00", EndTime: "02:00"},
+	}}}
+	fasting := &domain.Menu{Schedule: &domain.MenuSchedule{Windows: []domain.MenuActivationWindow{
+		{StartDate: "2026-02-16", EndDate: "2026-04-11"},
+	}}}
+
+	cases := []struct {
+		name string
+		menu *domain.Menu
+		at   time.Time
+		want bool
+	}{
+		{"breakfast weekday morning", breakfast, time.Date(2026, 3, 2, 7, 0, 0, 0, loc), true},
+		{"breakfast weekday noon", breakfast, time.Date(2026, 3, 2, 12, 0, 0, 0, loc), false},
+		{"breakfast saturday", breakfast, time.Date(2026, 3, 7, 7, 0, 0, 0, loc), false},
+		{"breakfast evaluated in restaurant tz", breakfast, time.Date(2026, 3, 2, 4, 0, 0, 0, time.UTC), true},
+		{"late night before midnight", lateNight, time.Date(2026, 3, 2, 23, 30, 0, 0, loc), true},
+		{"late night after midnight", lateNight, time.Date(2026, 3, 3, 1, 30, 0, 0, loc), true},
+		{"late night afternoon", lateNight, time.Date(2026, 3, 3, 15, 0, 0, 0, loc), false},
+		{"fasting inside range", fasting, time.Date(2026, 4, 11, 20, 0, 0, 0, loc), true},
+		{"fasting after range", fasting, time.Date(2026, 4, 12, 8, 0, 0, 0, loc), false},
+		{"no schedule", &domain.Menu{}, time.Now(), true},
+	}
+	for _, tc := range cases {
+		if got := tc.menu.IsActiveAt(tc.at, loc); got != tc.want {
+			t.Errorf("%s: got %v want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestMenuScheduleUnpublishAtHidesMenu(t *testing.T) {
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	menu := &domain.Menu{Schedule: &domain.MenuSchedule{UnpublishAt: &cutoff}}
+	if !menu.IsActiveAt(cutoff.Add(-time.Minute), time.UTC) {
+		t.Fatalf("menu should be active before unpublish_at")
+	}
+	if menu.IsActiveAt(cutoff, time.UTC) {
+		t.Fatalf("menu should be hidden from unpublish_at on")
+	}
+}
+
+func TestMenuScheduleValidate(t *testing.T) {
+	bad := []domain.MenuSchedule{
+		{Windows: []domain.MenuActivationWindow{{Days: []string{"someday"}}}},
+		{Windows: []domain.MenuActivationWindow{{StartTime: "7am"}}},
+		{Windows: []domain.MenuActivationWindow{{StartDate: "2026-05-01", EndDate: "2026-04-01"}}},
+	}
+	for i := range bad {
+		if err := bad[i].Validate(); err == nil {
+			t.Errorf("schedule %d should be invalid", i)
+		}
+	}
+	good := domain.MenuSchedule{Windows: []domain.MenuActivationWindow{{Days: []string{"Sun"}, StartTime: "18:00", EndTime: "24:00"}}}
+	if err := good.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+// blockingScheduleUseCase reports each scheduler run and holds it until ctx ends,
+// so a second loop would show up as a second concurrent run.
+type blockingScheduleUseCase struct {
+	domain.IMenuUseCase
+	ctx  context.Context
+	runs chan struct{}
+}
+
+func (u *blockingScheduleUseCase) ApplyScheduledChanges(time.Time) (int, error) {
+	u.runs <- struct{}{}
+	<-u.ctx.Done()
+	return 0, nil
+}
+
+func (u *blockingScheduleUseCase) RestoreExpiredAvailability(time.Time) (int, error) {
+	return 0, nil
+}
+
+func TestStartMenuSchedulerRunsOneLoop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uc := &blockingScheduleUseCase{ctx: ctx, runs: make(chan struct{}, 2)}
+	// a loop of an earlier test may still be winding down, so retry until ours runs
+	deadline := time.After(time.Second)
+	for started := false; !started; {
+		usecase.StartMenuScheduler(ctx, uc, 5*time.Millisecond)
+		select {
+		case <-uc.runs:
+			started = true
+		case <-time.After(20 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("scheduler never ran")
+		}
+	}
+
+	usecase.StartMenuScheduler(ctx, uc, 5*time.Millisecond)
+	select {
+	case <-uc.runs:
+		t.Fatal("second start launched another scheduler loop")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// dueMenuRepo serves one menu with a due schedule and records which scheduled
+// times the use case clears. It has no SetSchedule, so writing back the whole
+// schedule it loaded panics.
+type dueMenuRepo struct {
+	domain.IMenuRepository
+	menu               *domain.Menu
+	unpublished        bool
+	clearedPublishAt   *time.Time
+	clearedUnpublishAt *time.Time
+}
+
+func (r *dueMenuRepo) FindScheduleDue(context.Context, time.Time) ([]*domain.Menu, error) {
+	return []*domain.Menu{r.menu}, nil
+}
+
+func (r *dueMenuRepo) Unpublish(context.Context, string, string) error {
+	r.unpublished = true
+	return nil
+}
+
+func (r *dueMenuRepo) ClearScheduledTransitions(_ context.Context, _ string, publishAt, unpublishAt *time.Time) error {
+	r.clearedPublishAt, r.clearedUnpublishAt = publishAt, unpublishAt
+	return nil
+}
+
+func TestApplyScheduledChangesClearsOnlyWhatFired(t *testing.T) {
+	now := time.Now()
+	unpublishAt := now.Add(-time.Minute)
+	publishAt := now.Add(time.Hour)
+	menus := &dueMenuRepo{menu: &domain.Menu{ID: "m1", IsPublished: true, Schedule: &domain.MenuSchedule{
+		Windows:     []domain.MenuActivationWindow{{Days: []string{"mon"}, StartTime: "08:00", EndTime: "11:00"}},
+		PublishAt:   &publishAt,
+		UnpublishAt: &unpublishAt,
+	}}}
+	uc := usecase.NewMenuUseCase(menus, nil, nil, nil, services.QRService{}, nil, nil, nil, time.Second)
+
+	changed, err := uc.ApplyScheduledChanges(now)
+	if err != nil || changed != 1 || !menus.unpublished {
+		t.Fatalf("changed %d, unpublished %v, err %v", changed, menus.unpublished, err)
+	}
+	if menus.clearedPublishAt != nil {
+		t.Fatalf("the pending publish time was cleared: %v", menus.clearedPublishAt)
+	}
+	if menus.clearedUnpublishAt == nil || !menus.clearedUnpublishAt.Equal(unpublishAt) {
+		t.Fatalf("expected the fired unpublish time to be cleared by its old value, got %v", menus.clearedUnpublishAt)
+	}
+}
+
+func TestScheduleAndUnpublishOfAnotherRestaurantsMenuAreNotFound(t *testing.T) {
+	// storedMenuRepo has no SetSchedule or Unpublish, so reaching the write panics
+	menus := &storedMenuRepo{menu: &domain.Menu{ID: "m1", RestaurantID: "r1", IsPublished: true}}
+	uc := usecase.NewMenuUseCase(menus, nil, nil, nil, services.QRService{}, nil, nil, nil, time.Second)
+	other := &domain.Restaurant{ID: "r2"}
+
+	if err := uc.SetSchedule("m1", other, &domain.MenuSchedule{}); err != domain.ErrNotFound {
+		t.Fatalf("set schedule: %v", err)
+	}
+	if err := uc.UnpublishMenu("m1", "u2", other); err != domain.ErrNotFound {
+		t.Fatalf("unpublish: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+func TestFakeMenuStructurerIsDeterministic(t *testing.T) {
+	text := "Breakfast\nFirfir .... 180\nGenfo 150 ETB\nLunch\nShiro Wat - 220.50\nOpen daily"
+	s, err := services.NewMenuStructurer(context.Background(), services.AIProviderConfig{Provider: "fake"})
+	if err != nil {
+		t.Fatalf("fake provider: %v", err)
+	}
+	first, err := s.StructureMenu(context.Background(), text)
+	if err != nil {
+		t.Fatalf("structure: %v", err)
+	}
+	second, _ := s.StructureMenu(context.Background(), text)
+	if first.RawJSON != second.RawJSON {
+		t.Fatal("fake structurer is not deterministic")
+	}
+	tabs := first.Menu.Tabs
+	if len(tabs) != 2 || tabs[0].Name != "Breakfast" || tabs[1].Name != "Lunch" {
+		t.Fatalf("unexpected tabs %+v", tabs)
+	}
+	var items []domain.Item
+	for _, cat := range tabs[0].Categories {
+		items = append(items, cat.Items...)
+	}
+	if len(items) != 2 || items[0].Name != "Firfir" || items[0].Price != 180 || items[1].Name != "Genfo" {
+		t.Fatalf("unexpected breakfast items %+v", items)
+	}
+	if shiro := tabs[1].Categories[0].Items[0]; shiro.Name != "Shiro Wat" || shiro.Price != 220.5 {
+		t.Fatalf("unexpected lunch item %+v", shiro)
+	}
+	if first.Usage.Provider != "fake" || first.Usage.TotalTokens == 0 {
+		t.Fatalf("unexpected usage %+v", first.Usage)
+	}
+}
+
+func TestOpenAICompatServiceStructuresMenu(t *testing.T) {
+	var gotAuth, gotModel string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		var req struct {
+			Model string `json:"model"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		gotModel = req.Model
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": "```json\n{\"menuItems\":[{\"name\":\"Tibs\",\"nameAmharic\":\"ጥብስ\",\"price\":350}]}\n```"}}},
+			"usage":   map[string]int{"prompt_tokens": 900, "completion_tokens": 40, "total_tokens": 940},
+		})
+	}))
+	defer srv.Close()
+
+	s, err := services.NewMenuStructurer(context.Background(), services.AIProviderConfig{Provider: "openai", BaseURL: srv.URL + "/v1/", APIKey: "secret", Model: "llama3"})
+	if err != nil {
+		t.Fatalf("openai provider: %v", err)
+	}
+	res, err := s.StructureMenu(context.Background(), "Tibs 350")
+	if err != nil {
+		t.Fatalf("structure: %v", err)
+	}
+	if gotAuth != "Bearer secret" || gotModel != "llama3" {
+		t.Fatalf("request auth %q model %q", gotAuth, gotModel)
+	}
+	item := res.Menu.Tabs[0].Categories[0].Items[0]
+	if item.Name != "Tibs" || item.Price != 350 || item.Translation(domain.LangAmharic).Name != "ጥብስ" {
+		t.Fatalf("unexpected item %+v", item)
+	}
+	want := domain.AIUsage{Provider: "openai", Model: "llama3", PromptTokens: 900, CompletionTokens: 40, TotalTokens: 940}
+	if res.Usage != want {
+		t.Fatalf("usage %+v, want %+v", res.Usage, want)
+	}
+	if _, ok := s.(domain.ITranslator); !ok {
+		t.Fatal("OpenAI-compatible backend should also translate")
+	}
+}
+
+func TestNewMenuStructurerRejectsIncompleteConfig(t *testing.T) {
+	for _, cfg := range []services.AIProviderConfig{
+		{Provider: "claude-local"},
+		{Provider: "openai", Model: "llama3"},
+		{Provider: "openai", BaseURL: "http://localhost:11434/v1"},
+		{Provider: "gemini"},
+	} {
+		if s, err := services.NewMenuStructurer(context.Background(), cfg); err == nil || s != nil {
+			t.Fatalf("expected error for %+v", cfg)
+		}
+	}
+}
+
+// brokenStructurer fails like a model that is down.
+type brokenStructurer struct{}
+
+func (brokenStructurer) Name() string { return "broken" }
+
+func (brokenStructurer) StructureMenu(context.Context, string) (*domain.StructuredMenu, error) {
+	return nil, errors.New("model offline")
+}
+
+func TestOCRJobRecordsFailedAICall(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	ocr := &services.FixtureOCRService{Texts: map[string]string{"default": "Kitfo 400"}}
+	uc := usecase.NewOCRJobUseCase(repo, newMemParseResultRepo(), ocr, nil, brokenStructurer{}, nil, nil, nil, nil, nil, time.Second)
+	job := &domain.OCRJob{ImageURL: "menu.jpg"}
+	if err := uc.CreateOCRJob(job); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	uc.ProcessJob(job.ID)
+
+	got, _ := repo.GetByID(context.Background(), job.ID)
+	if got.Status != domain.OCRFailed || got.Error != "model offline" {
+		t.Fatalf("job status %q error %q", got.Status, got.Error)
+	}
+	if len(got.AICalls) != 1 || got.AICalls[0].Provider != "broken" || got.AICalls[0].Error != "model offline" || got.AICalls[0].Stage != domain.PhaseAIStructuring {
+		t.Fatalf("unexpected AI calls %+v", got.AICalls)
+	}
+}
@@ -0,0 +1,107 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+// fakeTranslator "translates" by prefixing the target language and records
+// what it was sent.
+type fakeTranslator struct {
+	calls [][]string
+	err   error
+	drop  bool // drop placeholders, as a careless model would
+}
+
+func (f *fakeTranslator) TranslateBatch(_ context.Context, texts []string, target string) ([]string, error) {
+	f.calls = append(f.calls, texts)
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make([]string, len(texts))
+	for i, s := range texts {
+		if f.drop {
+			s = strings.ReplaceAll(s, "{{0}}", "")
+		}
+		out[i] = target + ":" + s
+	}
+	return out, nil
+}
+
+func TestMenuTranslatorBatchesAndKeepsGlossary(t *testing.T) {
+	items := []domain.Item{
+		{Name: "Special Kitfo", Description: "Minced beef with kitfo spice", TabTags: []string{"Mains"}},
+		{Name: "Tibs", Description: "Fried beef", TabTags: []string{"Mains"}},
+		{Name: "Coffee", TabTags: []string{"Drinks"}, Translations: map[string]domain.ItemTranslation{"am": {Name: "ቡና"}}},
+	}
+	fake := &fakeTranslator{}
+	report, err := usecase.NewMenuTranslator(fake, 3).Translate(context.Background(), items, []string{"am", "en", "am-ET"}, []string{"kitfo", "Tibs"})
+	if err != nil {
+		t.Fatalf("translate: %v", err)
+	}
+	if len(report.Languages) != 1 || report.Languages[0] != "am" {
+		t.Fatalf("expected only Amharic, got %v", report.Languages)
+	}
+	// unique texts: Special Kitfo, Minced beef..., Mains, Fried beef, Drinks; "Tibs" is kept verbatim
+	sent := 0
+	for _, call := range fake.calls {
+		if len(call) > 3 {
+			t.Fatalf("batch larger than 3: %v", call)
+		}
+		sent += len(call)
+		for _, s := range call {
+			if strings.Contains(strings.ToLower(s), "kitfo") || s == "Tibs" {
+				t.Fatalf("glossary term sent to provider: %q", s)
+			}
+		}
+	}
+	if sent != 5 || report.Requests != 2 {
+		t.Fatalf("expected 5 unique texts in 2 requests, got %d in %d", sent, report.Requests)
+	}
+
+	kitfo := items[0].Translation("am")
+	if kitfo.Name != "am:Special Kitfo" || kitfo.Description != "am:Minced beef with kitfo spice" {
+		t.Fatalf("glossary terms not restored: %+v", kitfo)
+	}
+	if !kitfo.IsMachineTranslated(domain.FieldName) || kitfo.TabTags[0] != "am:Mains" {
+		t.Fatalf("fields not marked machine translated: %+v", kitfo)
+	}
+	tibs := items[1].Translation("am")
+	if tibs.Name != "Tibs" || tibs.IsMachineTranslated(domain.FieldName) {
+		t.Fatalf("glossary dish name should be kept as written and not flagged: %+v", tibs)
+	}
+	coffee := items[2].Translation("am")
+	if coffee.Name != "ቡና" || coffee.IsMachineTranslated(domain.FieldName) || coffee.TabTags[0] != "am:Drinks" {
+		t.Fatalf("existing translation overwritten: %+v", coffee)
+	}
+	if report.Translated != 7 {
+		t.Fatalf("expected 7 fields translated, got %d", report.Translated)
+	}
+}
+
+func TestMenuTranslatorSkipsLostPlaceholders(t *testing.T) {
+	items := []domain.Item{{Name: "Kitfo combo"}}
+	report, err := usecase.NewMenuTranslator(&fakeTranslator{drop: true}, 0).Translate(context.Background(), items, []string{"om"}, []string{"kitfo"})
+	if err != nil {
+		t.Fatalf("translate: %v", err)
+	}
+	if report.Failed != 1 || len(items[0].Translations) != 0 {
+		t.Fatalf("mangled output should be discarded, got %+v %+v", report, items[0].Translations)
+	}
+}
+
+func TestMenuTranslatorProviderError(t *testing.T) {
+	items := []domain.Item{{Name: "Tibs"}}
+	_, err := usecase.NewMenuTranslator(&fakeTranslator{err: errors.New("quota")}, 0).Translate(context.Background(), items, []string{"ti"}, nil)
+	if !errors.Is(err, domain.ErrTranslationUnavailable) {
+		t.Fatalf("expected ErrTranslationUnavailable, got %v", err)
+	}
+	if _, err := usecase.NewMenuTranslator(nil, 0).Translate(context.Background(), items, []string{"ti"}, nil); !errors.Is(err, domain.ErrTranslationUnavailable) {
+		t.Fatalf("missing provider should be unavailable, got %v", err)
+	}
+}
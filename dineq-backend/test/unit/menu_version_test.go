@@ -0,0 +1,115 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+type memVersionRepo struct {
+	domain.IMenuVersionRepository
+	versions []*domain.MenuVersion
+}
+
+func (r *memVersionRepo) Create(_ context.Context, v *domain.MenuVersion) error {
+	r.versions = append(r.versions, v)
+	return nil
+}
+
+func (r *memVersionRepo) GetByMenuIDAndVersion(_ context.Context, menuID string, version int) (*domain.MenuVersion, error) {
+	for _, v := range r.versions {
+		if v.MenuID == menuID && v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// racingMenuRepo lets another edit land right after each update, as a
+// concurrent request would.
+type racingMenuRepo struct {
+	*storedMenuRepo
+}
+
+func (r *racingMenuRepo) Update(ctx context.Context, id string, menu *domain.Menu) (*domain.Menu, error) {
+	saved, err := r.storedMenuRepo.Update(ctx, id, menu)
+	if err != nil {
+		return nil, err
+	}
+	other := *r.menu
+	other.Name = "Edited concurrently"
+	if _, err := r.storedMenuRepo.Update(ctx, id, &other); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func TestRecordVersionSnapshotsTheWrittenMenu(t *testing.T) {
+	menus := &racingMenuRepo{&storedMenuRepo{menu: &domain.Menu{ID: "m1", Name: "Lunch", Version: 3}}}
+	versions := &memVersionRepo{}
+	uc := usecase.NewMenuUseCase(menus, versions, nil, nil, services.QRService{}, nil, nil, nil, time.Second)
+
+	if err := uc.UpdateMenu("m1", "u1", &domain.Menu{Name: "Dinner"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if len(versions.versions) != 1 {
+		t.Fatalf("expected one version, got %d", len(versions.versions))
+	}
+	if v := versions.versions[0]; v.Version != 4 || v.Snapshot.Name != "Dinner" {
+		t.Fatalf("version %d recorded %q, want version 4 recording its own write", v.Version, v.Snapshot.Name)
+	}
+}
+
+func TestRollbackMenuToEmptyItems(t *testing.T) {
+	menus := &storedMenuRepo{menu: &domain.Menu{ID: "m1", Name: "Lunch", Version: 2, Items: []domain.Item{{ID: "i1", Name: "Shiro"}}}}
+	versions := &memVersionRepo{versions: []*domain.MenuVersion{
+		{MenuID: "m1", Version: 1, Action: domain.MenuVersionCreate, Snapshot: domain.Menu{ID: "m1", Name: "Lunch", Version: 1}},
+	}}
+	uc := usecase.NewMenuUseCase(menus, versions, nil, nil, services.QRService{}, nil, nil, nil, time.Second)
+
+	if err := uc.RollbackMenu("m1", 1, "u1", &domain.Restaurant{ID: "r1"}); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if len(menus.menu.Items) != 0 {
+		t.Fatalf("rolling back to an empty menu kept the items: %+v", menus.menu.Items)
+	}
+	last := versions.versions[len(versions.versions)-1]
+	if last.Action != domain.MenuVersionRollback || last.Version != 3 || len(last.Snapshot.Items) != 0 {
+		t.Fatalf("unexpected rollback version %+v", last)
+	}
+}
+
+func TestMenuVersionsOfAnotherRestaurantAreNotFound(t *testing.T) {
+	menus := &storedMenuRepo{menu: &domain.Menu{ID: "m1", RestaurantID: "r1", Name: "Lunch", Version: 2, Items: []domain.Item{{ID: "i1", Name: "Shiro"}}}}
+	versions := &memVersionRepo{versions: []*domain.MenuVersion{
+		{MenuID: "m1", Version: 1, Action: domain.MenuVersionCreate, Snapshot: domain.Menu{ID: "m1", Name: "Lunch", Version: 1}},
+		{MenuID: "m1", Version: 2, Action: domain.MenuVersionUpdate, Snapshot: domain.Menu{ID: "m1", Name: "Lunch", Version: 2}},
+	}}
+	uc := usecase.NewMenuUseCase(menus, versions, nil, nil, services.QRService{}, nil, nil, nil, time.Second)
+	other := &domain.Restaurant{ID: "r2"}
+
+	if _, err := uc.ListVersions("m1", other); err != domain.ErrNotFound {
+		t.Fatalf("list versions: %v", err)
+	}
+	if _, err := uc.GetVersion("m1", other, 1); err != domain.ErrNotFound {
+		t.Fatalf("get version: %v", err)
+	}
+	if _, err := uc.DiffVersions("m1", other, 1, 2); err != domain.ErrNotFound {
+		t.Fatalf("diff versions: %v", err)
+	}
+	if err := uc.RollbackMenu("m1", 1, "u2", other); err != domain.ErrNotFound {
+		t.Fatalf("rollback: %v", err)
+	}
+	if len(versions.versions) != 2 || len(menus.menu.Items) != 1 {
+		t.Fatalf("a cross-restaurant rollback changed the menu: %+v", menus.menu)
+	}
+
+	own := &domain.Restaurant{ID: "r1"}
+	if _, err := uc.GetVersion("m1", own, 1); err != nil {
+		t.Fatalf("own restaurant: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	"github.com/gorilla/websocket"
+)
+
+// connectClient registers a WebSocket client of userID subscribed to the given
+// restaurants and returns the client side of the connection.
+func connectClient(t *testing.T, svc services.NotificationService, userID string, restaurants ...string) *websocket.Conn {
+	t.Helper()
+	registered := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		svc.RegisterClient(userID, conn, restaurants...)
+		close(registered)
+	}))
+	t.Cleanup(srv.Close)
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	<-registered
+	return client
+}
+
+func TestBroadcastReachesOnlyRestaurantSubscribers(t *testing.T) {
+	svc := services.NewNotificationService()
+	viewer := connectClient(t, svc, "viewer", "cafe-addis")
+	other := connectClient(t, svc, "other", "pizza-place")
+
+	if err := svc.Broadcast(context.Background(), "cafe-addis", &domain.Notification{Message: "Tibs is sold out", Type: domain.ItemAvailability}); err != nil {
+		t.Fatalf("broadcast: %v", err)
+	}
+
+	_ = viewer.SetReadDeadline(time.Now().Add(time.Second))
+	if _, msg, err := viewer.ReadMessage(); err != nil || !strings.Contains(string(msg), "Tibs is sold out") {
+		t.Fatalf("subscriber did not get the event: %q, %v", msg, err)
+	}
+	_ = other.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, msg, err := other.ReadMessage(); err == nil {
+		t.Fatalf("client of another restaurant got %q", msg)
+	}
+}
@@ -0,0 +1,134 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+	"github.com/disintegration/imaging"
+)
+
+func pageHashes(t *testing.T, data []byte) domain.OCRPage {
+	t.Helper()
+	sha, phash, err := services.ImageHashes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return domain.OCRPage{SHA256: sha, PerceptualHash: phash}
+}
+
+func TestImageHashesMatchReencodedPhotos(t *testing.T) {
+	page := menuPage(1200, 1600)
+	original := pageHashes(t, encodePNG(t, page))
+
+	// the same photo, shrunk and recompressed as messaging apps do
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, imaging.Resize(page, 600, 0, imaging.Lanczos), &jpeg.Options{Quality: 60}); err != nil {
+		t.Fatal(err)
+	}
+	resent := pageHashes(t, buf.Bytes())
+	if resent.SHA256 == original.SHA256 {
+		t.Fatal("different files share a SHA-256")
+	}
+	if d := services.PerceptualHashDistance(original.PerceptualHash, resent.PerceptualHash); d < 0 || d > services.SimilarImageBits {
+		t.Fatalf("re-encoded photo is %d bits away", d)
+	}
+
+	// another page of the same menu: shorter sections in two columns
+	other := imaging.New(1200, 1600, color.NRGBA{170, 170, 170, 255})
+	for y := 1600 / 6; y < 1600*5/6; y += 1600 / 24 {
+		for x := 1200 / 6; x < 1200*5/6; x++ {
+			if (x/12)%4 == 3 || (x > 500 && x < 700) {
+				continue
+			}
+			for dy := 0; dy <= 1600/60; dy++ {
+				other.Set(x, y+dy, color.NRGBA{110, 110, 110, 255})
+			}
+		}
+	}
+	for _, img := range []image.Image{other, imaging.Rotate90(menuPage(1600, 1200))} {
+		h := pageHashes(t, encodePNG(t, img))
+		if d := services.PerceptualHashDistance(original.PerceptualHash, h.PerceptualHash); d <= services.SimilarImageBits {
+			t.Fatalf("a different page is only %d bits away", d)
+		}
+	}
+	if services.PerceptualHashDistance(original.PerceptualHash, "") != -1 {
+		t.Fatal("missing hash should not compare")
+	}
+}
+
+func TestFindDuplicateOCRJob(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	parsed := newMemParseResultRepo()
+	uc := usecase.NewOCRJobUseCase(repo, parsed, failingOCR{}, nil, nil, nil, nil, nil, nil, nil, time.Second)
+	ctx := context.Background()
+
+	page := pageHashes(t, encodePNG(t, menuPage(800, 1000)))
+	similar := page
+	similar.SHA256 = "another-file"
+	similar.PerceptualHash = flipBit(page.PerceptualHash)
+	completed := func(restaurant string, age time.Duration, pages ...domain.OCRPage) *domain.OCRJob {
+		result := &domain.AIParseResult{RestaurantID: restaurant, Status: domain.ParseResultPendingReview}
+		_ = parsed.Create(ctx, result)
+		job := &domain.OCRJob{RestaurantID: restaurant, Status: domain.OCRCompleted, CreatedAt: time.Now().Add(-age), Pages: pages,
+			Results: &domain.OCRJobResult{ParseResultID: result.ID}}
+		_ = repo.Create(ctx, job)
+		return job
+	}
+	near := completed("r1", time.Hour, similar)
+	exact := completed("r1", 2*time.Hour, page)
+	completed("r2", time.Hour, page)       // another restaurant
+	completed("r1", time.Hour, page, page) // another page count
+	completed("r1", 90*24*time.Hour, page) // too old
+	upload := &domain.OCRJob{RestaurantID: "r1", Pages: []domain.OCRPage{page}}
+	since := time.Now().Add(-30 * 24 * time.Hour)
+
+	dup, err := uc.FindDuplicate(upload, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dup == nil || dup.Job.ID != exact.ID || !dup.Exact || dup.ParseResult.ID != exact.Results.ParseResultID {
+		t.Fatalf("expected the exact match, got %+v", dup)
+	}
+
+	// once its result is discarded the near duplicate is offered
+	_ = parsed.Delete(ctx, exact.Results.ParseResultID)
+	dup, _ = uc.FindDuplicate(upload, since)
+	if dup == nil || dup.Job.ID != near.ID || dup.Exact || dup.Distance != 1 {
+		t.Fatalf("expected the similar match, got %+v", dup)
+	}
+
+	// without a restaurant only the uploader's own jobs are searched
+	mine := completed("", time.Hour, page)
+	mine.UserID = "u1"
+	_ = repo.Update(ctx, mine.ID, mine)
+	if dup, _ = uc.FindDuplicate(&domain.OCRJob{UserID: "u2", Pages: []domain.OCRPage{page}}, since); dup != nil {
+		t.Fatalf("matched another user's upload: %+v", dup)
+	}
+	if dup, _ = uc.FindDuplicate(&domain.OCRJob{UserID: "u1", Pages: []domain.OCRPage{page}}, since); dup == nil || dup.Job.ID != mine.ID {
+		t.Fatalf("expected the user's own upload, got %+v", dup)
+	}
+	if dup, _ = uc.FindDuplicate(&domain.OCRJob{Pages: []domain.OCRPage{page}}, since); dup != nil {
+		t.Fatalf("an unscoped upload matched %+v", dup)
+	}
+
+	// merge uploads only match uploads for the same menu
+	upload.TargetMenuID = "menu-1"
+	if dup, _ = uc.FindDuplicate(upload, since); dup != nil {
+		t.Fatalf("merge upload matched %+v", dup)
+	}
+}
+
+func flipBit(phash string) string {
+	b, _ := hex.DecodeString(phash)
+	b[0] ^= 1
+	return hex.EncodeToString(b)
+}
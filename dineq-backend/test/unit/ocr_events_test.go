@@ -0,0 +1,137 @@
+package unit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+// pushRecorder records the notifications pushed to each user's socket.
+type pushRecorder struct {
+	domain.INotificationUseCase
+	mu     sync.Mutex
+	pushed map[string][]*domain.Notification
+}
+
+func (p *pushRecorder) Push(_ context.Context, userID string, n *domain.Notification) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pushed[userID] = append(p.pushed[userID], n)
+	return nil
+}
+
+func TestOCRJobPublishesProgressEvents(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	ocr := &flakyPageOCR{texts: map[string]string{"p1.png": "Doro Wat\n450"}, reads: map[string]int{}}
+	notifier := &pushRecorder{pushed: map[string][]*domain.Notification{}}
+	uc := usecase.NewOCRJobUseCase(repo, newMemParseResultRepo(), ocr, nil, &echoAI{}, nil, notifier, nil, nil, nil, time.Second)
+
+	job := &domain.OCRJob{UserID: "u1", ImageURL: "p1.png"}
+	if err := uc.CreateOCRJob(job); err != nil {
+		t.Fatal(err)
+	}
+	events, cancel := uc.SubscribeJob(job.ID)
+	defer cancel()
+	uc.ProcessJob(job.ID)
+
+	var got []domain.OCRJobEvent
+	for len(events) > 0 {
+		got = append(got, <-events)
+	}
+	if len(got) < 5 {
+		t.Fatalf("expected an event per update, got %d", len(got))
+	}
+	var phases []string
+	for i, ev := range got {
+		if ev.JobID != job.ID {
+			t.Fatalf("event for another job: %+v", ev)
+		}
+		if i > 0 && ev.Progress < got[i-1].Progress {
+			t.Fatalf("progress went back: %d after %d", ev.Progress, got[i-1].Progress)
+		}
+		if ev.PhaseChanged {
+			phases = append(phases, ev.Phase)
+		}
+		if ev.Final != (i == len(got)-1) {
+			t.Fatalf("only the last event should be final: %+v", ev)
+		}
+	}
+	want := []string{domain.PhaseReceived, domain.PhaseOCRExtraction, domain.PhaseAIStructuring, domain.PhaseMenuPersist, domain.PhaseCompleted}
+	if len(phases) != len(want) {
+		t.Fatalf("phase transitions %v, want %v", phases, want)
+	}
+	for i := range want {
+		if phases[i] != want[i] {
+			t.Fatalf("phase transitions %v, want %v", phases, want)
+		}
+	}
+	last := got[len(got)-1]
+	if last.Status != domain.OCRCompleted || last.Progress != 100 || last.ParseResultID == "" {
+		t.Fatalf("unexpected final event %+v", last)
+	}
+
+	pushed := notifier.pushed["u1"]
+	if len(pushed) != len(got) || pushed[0].Type != domain.OCRProgress {
+		t.Fatalf("expected every event pushed to the uploader, got %d of %d", len(pushed), len(got))
+	}
+	if ev, ok := pushed[len(pushed)-1].Data["job"].(domain.OCRJobEvent); !ok || !ev.Final {
+		t.Fatalf("last pushed notification %+v", pushed[len(pushed)-1])
+	}
+}
+
+func TestOCRJobFailureIsPublishedAsFinalEvent(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	uc := usecase.NewOCRJobUseCase(repo, newMemParseResultRepo(), failingOCR{}, nil, &echoAI{}, nil, nil, nil, nil, nil, time.Second)
+	job := &domain.OCRJob{UserID: "u1", ImageURL: "p1.png"}
+	if err := uc.CreateOCRJob(job); err != nil {
+		t.Fatal(err)
+	}
+	events, cancel := uc.SubscribeJob(job.ID)
+	uc.ProcessJob(job.ID)
+	var last domain.OCRJobEvent
+	for len(events) > 0 {
+		last = <-events
+	}
+	cancel()
+	if !last.Final || last.Status != domain.OCRFailed || last.Error == "" {
+		t.Fatalf("expected a final failed event, got %+v", last)
+	}
+	// cancelling twice is harmless
+	cancel()
+}
+
+func TestOCRJobETAIsReestimated(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	ocr := &slowOCR{delay: 30 * time.Millisecond}
+	uc := usecase.NewOCRJobUseCase(repo, newMemParseResultRepo(), ocr, nil, &echoAI{}, nil, nil, nil, nil, nil, time.Second)
+	job := &domain.OCRJob{UserID: "u1", ImageURL: "p1.png"}
+	if err := uc.CreateOCRJob(job); err != nil {
+		t.Fatal(err)
+	}
+	initial := job.EstimatedCompletion
+	events, cancel := uc.SubscribeJob(job.ID)
+	defer cancel()
+	uc.ProcessJob(job.ID)
+	for len(events) > 0 {
+		ev := <-events
+		if ev.Phase == domain.PhaseAIStructuring && !ev.Final {
+			// a run this fast finishes long before the two-minute guess
+			if !ev.EstimatedCompletion.Before(initial) {
+				t.Fatalf("ETA not updated: %v, initially %v", ev.EstimatedCompletion, initial)
+			}
+			return
+		}
+	}
+	t.Fatal("no AI structuring event")
+}
+
+type slowOCR struct{ delay time.Duration }
+
+func (o *slowOCR) ExtractText(_ context.Context, _ string) (*domain.OCRDocument, error) {
+	time.Sleep(o.delay)
+	return &domain.OCRDocument{Text: "Tibs\n380", Provider: "slow"}, nil
+}
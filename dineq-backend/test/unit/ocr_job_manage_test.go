@@ -0,0 +1,246 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+// blockingOCR holds every read until its context ends and reports why.
+type blockingOCR struct {
+	started chan struct{}
+	ended   chan error
+}
+
+func (o *blockingOCR) ExtractText(ctx context.Context, _ string) (*domain.OCRDocument, error) {
+	o.started <- struct{}{}
+	<-ctx.Done()
+	o.ended <- context.Cause(ctx)
+	return nil, ctx.Err()
+}
+
+// recordingStorage records deletions and fails those listed in fail.
+type recordingStorage struct {
+	mu      sync.Mutex
+	deleted []string
+	fail    map[string]bool
+}
+
+func (s *recordingStorage) UploadFile(_ context.Context, name string, _ []byte, folder string) (string, string, error) {
+	return folder + "/" + name, folder + "/" + name, nil
+}
+
+func (s *recordingStorage) DeleteFile(_ context.Context, publicID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail[publicID] {
+		return errors.New("storage unavailable")
+	}
+	s.deleted = append(s.deleted, publicID)
+	return nil
+}
+
+func TestCancelPendingOCRJob(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	uc := usecase.NewOCRJobUseCase(repo, newMemParseResultRepo(), failingOCR{}, nil, &echoAI{}, nil, nil, nil, nil, nil, time.Second)
+	job := &domain.OCRJob{ImageURL: "menu.png"}
+	if err := uc.CreateOCRJob(job); err != nil {
+		t.Fatal(err)
+	}
+	got, err := uc.CancelJob(job.ID)
+	if err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	if got.Status != domain.OCRCancelled || got.Error != usecase.OCRCancelReason {
+		t.Fatalf("unexpected job %q %q", got.Status, got.Error)
+	}
+	if _, err := uc.CancelJob(job.ID); !errors.Is(err, domain.ErrOCRJobNotCancellable) {
+		t.Fatalf("second cancel should be rejected, got %v", err)
+	}
+	// a claimed job that was cancelled meanwhile is not run
+	uc.ProcessJob(job.ID)
+	if stored, _ := repo.GetByID(context.Background(), job.ID); stored.Status != domain.OCRCancelled {
+		t.Fatalf("cancelled job was processed: %q", stored.Status)
+	}
+}
+
+func TestCancelRunningOCRJobStopsPipeline(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	ocr := &blockingOCR{started: make(chan struct{}, 1), ended: make(chan error, 1)}
+	uc := usecase.NewOCRJobUseCase(repo, newMemParseResultRepo(), ocr, nil, &echoAI{}, nil, nil, nil, nil, nil, time.Minute)
+	job := &domain.OCRJob{ImageURL: "menu.png"}
+	if err := uc.CreateOCRJob(job); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		uc.ProcessJob(job.ID)
+		close(done)
+	}()
+	<-ocr.started
+	if _, err := uc.CancelJob(job.ID); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job kept running after cancel")
+	}
+	if cause := <-ocr.ended; cause == nil || cause.Error() != usecase.OCRCancelReason {
+		t.Fatalf("OCR context not cancelled by the user: %v", cause)
+	}
+	got, _ := repo.GetByID(context.Background(), job.ID)
+	if got.Status != domain.OCRCancelled || got.Error != usecase.OCRCancelReason {
+		t.Fatalf("unexpected job %q %q", got.Status, got.Error)
+	}
+	for _, ph := range got.PhaseHistory {
+		if ph.Status == "running" {
+			t.Fatalf("phase %q left running", ph.Name)
+		}
+	}
+
+	// a cancelled job can be retried
+	retried, err := uc.RetryJob(job.ID)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if retried.Status != domain.OCRPending || retried.Error != "" {
+		t.Fatalf("retried job %q %q", retried.Status, retried.Error)
+	}
+}
+
+func TestListOCRJobsFiltersAndPages(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	base := time.Now().Add(-time.Hour)
+	add := func(restaurant, user string, status domain.OCRJobStatus, minute int) {
+		_ = repo.Create(context.Background(), &domain.OCRJob{
+			RestaurantID: restaurant, UserID: user, Status: status, CreatedAt: base.Add(time.Duration(minute) * time.Minute),
+		})
+	}
+	add("r1", "u1", domain.OCRCompleted, 1)
+	add("r1", "u1", domain.OCRFailed, 2)
+	add("r1", "u2", domain.OCRCompleted, 3)
+	add("r2", "u1", domain.OCRCompleted, 4)
+	add("r1", "u1", domain.OCRPending, 5)
+	uc := usecase.NewOCRJobUseCase(repo, nil, failingOCR{}, nil, nil, nil, nil, nil, nil, nil, time.Second)
+
+	jobs, total, err := uc.ListJobs(domain.OCRJobFilter{RestaurantID: "r1", PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 4 || len(jobs) != 2 || jobs[0].ID != "5" || jobs[1].ID != "3" {
+		t.Fatalf("first page of r1: total %d, jobs %+v", total, jobs)
+	}
+	jobs, _, _ = uc.ListJobs(domain.OCRJobFilter{RestaurantID: "r1", Page: 2, PageSize: 2})
+	if len(jobs) != 2 || jobs[0].ID != "2" || jobs[1].ID != "1" {
+		t.Fatalf("second page of r1: %+v", jobs)
+	}
+	jobs, total, _ = uc.ListJobs(domain.OCRJobFilter{UserID: "u1", Statuses: []domain.OCRJobStatus{domain.OCRCompleted, domain.OCRFailed}})
+	if total != 3 || jobs[0].ID != "4" {
+		t.Fatalf("finished jobs of u1: total %d, jobs %+v", total, jobs)
+	}
+}
+
+func TestPurgeExpiredOCRImages(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	now := time.Now()
+	add := func(status domain.OCRJobStatus, age time.Duration, publicID string) string {
+		job := &domain.OCRJob{Status: status, UpdatedAt: now.Add(-age), ImageURL: "https://img/" + publicID,
+			Pages: []domain.OCRPage{{Number: 1, ImagePublicID: publicID, ProcessedPublicID: publicID + "-processed"}}}
+		_ = repo.Create(context.Background(), job)
+		return job.ID
+	}
+	oldDone := add(domain.OCRCompleted, 40*24*time.Hour, "old-done")
+	recentDone := add(domain.OCRCompleted, 5*24*time.Hour, "recent-done")
+	oldFailed := add(domain.OCRFailed, 10*24*time.Hour, "old-failed")
+	running := add(domain.OCRProcessing, 40*24*time.Hour, "running")
+	stuck := add(domain.OCRCancelled, 10*24*time.Hour, "stuck")
+
+	storage := &recordingStorage{fail: map[string]bool{"stuck": true}}
+	uc := usecase.NewOCRJobUseCase(repo, nil, failingOCR{}, nil, nil, nil, nil, storage, nil, nil, time.Second)
+	n, err := uc.PurgeExpiredImages(now.Add(-30*24*time.Hour), now.Add(-7*24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 purged jobs, got %d (deleted %v)", n, storage.deleted)
+	}
+	for _, id := range []string{oldDone, oldFailed} {
+		if job, _ := repo.GetByID(context.Background(), id); job.ImagesPurgedAt == nil || job.ImageURL != "" {
+			t.Fatalf("job %s not marked purged", id)
+		}
+	}
+	for _, id := range []string{recentDone, running, stuck} {
+		if job, _ := repo.GetByID(context.Background(), id); job.ImagesPurgedAt != nil {
+			t.Fatalf("job %s purged too early", id)
+		}
+	}
+	if len(storage.deleted) != 5 {
+		t.Fatalf("unexpected deletions %v", storage.deleted)
+	}
+
+	// the failed deletion is tried again on the next run
+	delete(storage.fail, "stuck")
+	if n, _ := uc.PurgeExpiredImages(now.Add(-30*24*time.Hour), now.Add(-7*24*time.Hour)); n != 1 {
+		t.Fatalf("expected the stuck job to be purged on retry, got %d", n)
+	}
+	if _, err := uc.RetryJob(stuck); err == nil {
+		t.Fatal("a job without images should not be retried")
+	}
+}
+
+// gatedOCR blocks until released and then returns text.
+type gatedOCR struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (o *gatedOCR) ExtractText(ctx context.Context, _ string) (*domain.OCRDocument, error) {
+	o.started <- struct{}{}
+	<-o.release
+	return &domain.OCRDocument{Text: "Tibs 250", Provider: "test"}, nil
+}
+
+func TestOCRJobWithLostLeaseDoesNotOverwriteNewOwner(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	ocr := &gatedOCR{started: make(chan struct{}, 1), release: make(chan struct{})}
+	uc := usecase.NewOCRJobUseCase(repo, newMemParseResultRepo(), ocr, nil, &echoAI{}, nil, nil, nil, nil, nil, time.Minute)
+	job := &domain.OCRJob{ImageURL: "menu.png"}
+	if err := uc.CreateOCRJob(job); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err := repo.Claim(ctx, "worker-a", time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		uc.ProcessJob(job.ID)
+		close(done)
+	}()
+	<-ocr.started
+
+	// worker-a stalls past its lease and the job moves to worker-b
+	if _, _, err := repo.RequeueExpired(ctx, time.Now().Add(time.Hour), 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Claim(ctx, "worker-b", time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	close(ocr.release)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("run kept going after losing its lease")
+	}
+
+	got, _ := repo.GetByID(ctx, job.ID)
+	if got.Status != domain.OCRProcessing || got.LeaseOwner != "worker-b" || got.ResultText != "" {
+		t.Fatalf("stale worker overwrote the job: status %q owner %q text %q", got.Status, got.LeaseOwner, got.ResultText)
+	}
+}
@@ -0,0 +1,126 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+// flakyPageOCR fails the pages listed in failOnce the first time they are read.
+type flakyPageOCR struct {
+	mu       sync.Mutex
+	texts    map[string]string
+	failOnce map[string]bool
+	reads    map[string]int
+}
+
+func (o *flakyPageOCR) ExtractText(_ context.Context, url string) (*domain.OCRDocument, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.reads[url]++
+	if o.failOnce[url] {
+		delete(o.failOnce, url)
+		return nil, errors.New("blurry page")
+	}
+	return &domain.OCRDocument{Text: o.texts[url], Provider: "flaky"}, nil
+}
+
+func TestCombinePageMenus(t *testing.T) {
+	item := func(name string) domain.Item { return domain.Item{Name: name, Price: 100} }
+	pages := []*domain.Menu{
+		{Tabs: []domain.Tab{{Name: "Food", Categories: []domain.Category{{Name: "Mains", Items: []domain.Item{item("Doro Wat")}}}}}},
+		// no heading at the top of page 2: the Food section runs on
+		{Tabs: []domain.Tab{
+			{Name: "General", Categories: []domain.Category{{Name: "Mains", Items: []domain.Item{item("Tibs")}}}},
+			{Name: "Drinks", Categories: []domain.Category{{Name: "Hot", Items: []domain.Item{item("Buna")}}}},
+		}},
+		{Tabs: []domain.Tab{{Name: "food", Categories: []domain.Category{{Name: "Sides", Items: []domain.Item{item("Salad")}}}}}},
+	}
+	menu := usecase.CombinePageMenus(pages)
+	if len(menu.Tabs) != 2 || menu.Tabs[0].Name != "Food" || menu.Tabs[1].Name != "Drinks" {
+		t.Fatalf("unexpected tabs %+v", menu.Tabs)
+	}
+	food := menu.Tabs[0]
+	if len(food.Categories) != 2 || len(food.Categories[0].Items) != 2 || food.Categories[0].Items[1].Name != "Tibs" || food.Categories[1].Items[0].Name != "Salad" {
+		t.Fatalf("food tab not combined across pages: %+v", food)
+	}
+	// pages are not modified
+	if len(pages[0].Tabs[0].Categories[0].Items) != 1 {
+		t.Fatal("first page menu was modified")
+	}
+}
+
+func TestMultiPageOCRJobRetriesOnlyFailedPages(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	parsed := newMemParseResultRepo()
+	ocr := &flakyPageOCR{
+		texts:    map[string]string{"p1.png": "Doro Wat\n450", "p2.png": "Tibs\n380"},
+		failOnce: map[string]bool{"p2.png": true},
+		reads:    map[string]int{},
+	}
+	ai := &echoAI{}
+	uc := usecase.NewOCRJobUseCase(repo, parsed, ocr, nil, ai, nil, nil, nil, nil, nil, time.Second)
+
+	job := &domain.OCRJob{ImageURL: "p1.png", Pages: []domain.OCRPage{
+		{Number: 1, ImageURL: "p1.png", Status: domain.OCRPagePending},
+		{Number: 2, ImageURL: "p2.png", Status: domain.OCRPagePending},
+	}}
+	if err := uc.CreateOCRJob(job); err != nil {
+		t.Fatal(err)
+	}
+	uc.ProcessJob(job.ID)
+	got, _ := repo.GetByID(context.Background(), job.ID)
+	if got.Status != domain.OCRFailed || !strings.HasPrefix(got.Error, "page 2:") {
+		t.Fatalf("expected page 2 failure, got %q %q", got.Status, got.Error)
+	}
+	if got.Pages[0].Status != domain.OCRPageDone || got.Pages[1].Status != domain.OCRPageFailed {
+		t.Fatalf("unexpected page states %+v", got.Pages)
+	}
+
+	if _, err := uc.RetryJob(job.ID); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	uc.ProcessJob(job.ID)
+	got, _ = repo.GetByID(context.Background(), job.ID)
+	if got.Status != domain.OCRCompleted {
+		t.Fatalf("job status %q, error %q", got.Status, got.Error)
+	}
+	if ocr.reads["p1.png"] != 1 || ocr.reads["p2.png"] != 2 {
+		t.Fatalf("page 1 should be read once and page 2 twice: %v", ocr.reads)
+	}
+	if got.Pages[1].Attempts != 2 {
+		t.Fatalf("page 2 attempts %d", got.Pages[1].Attempts)
+	}
+	phases := map[string]string{}
+	for _, ph := range got.PhaseHistory {
+		phases[ph.Name] = ph.Status
+	}
+	if phases[domain.OCRPagePhase(1)] != "done" || phases[domain.OCRPagePhase(2)] != "done" {
+		t.Fatalf("page phases missing: %+v", got.PhaseHistory)
+	}
+	text := got.Results.ExtractedText
+	if !strings.Contains(text, "--- Page 1 ---\nDoro Wat") || !strings.Contains(text, "--- Page 2 ---\nTibs") {
+		t.Fatalf("extracted text %q", text)
+	}
+
+	result, err := parsed.GetByID(context.Background(), got.Results.ParseResultID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Items) != 2 || result.Items[0].Item.Name != "Doro Wat" || result.Items[1].Item.Name != "Tibs" {
+		t.Fatalf("pages not combined into one parse result: %+v", result.Items)
+	}
+	var pageCalls []int
+	for _, call := range got.AICalls {
+		pageCalls = append(pageCalls, call.Page)
+	}
+	if len(pageCalls) != 2 || pageCalls[0] != 1 || pageCalls[1] != 2 {
+		t.Fatalf("expected one structuring call per page, got %v", pageCalls)
+	}
+}
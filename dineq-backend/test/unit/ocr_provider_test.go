@@ -0,0 +1,86 @@
+package unit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+// echoAI structures OCR text into a one-item menu named after the first line.
+type echoAI struct{ seen string }
+
+func (a *echoAI) Name() string { return "echo" }
+
+func (a *echoAI) StructureMenu(_ context.Context, ocrText string) (*domain.StructuredMenu, error) {
+	a.seen = ocrText
+	name := strings.SplitN(ocrText, "\n", 2)[0]
+	menu := &domain.Menu{Tabs: []domain.Tab{{Categories: []domain.Category{{Items: []domain.Item{{Name: name}}}}}}}
+	return &domain.StructuredMenu{Menu: menu, RawJSON: `{"menuItems":[]}`, Usage: domain.AIUsage{Provider: "echo", TotalTokens: 7}}, nil
+}
+
+func TestOCRJobRunsWithFixtureProvider(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	parsed := newMemParseResultRepo()
+	ai := &echoAI{}
+	ocr := &services.FixtureOCRService{Texts: map[string]string{"lunch": "Doro Wat\n450 ETB"}}
+	uc := usecase.NewOCRJobUseCase(repo, parsed, ocr, nil, ai, nil, nil, nil, nil, nil, time.Second)
+
+	job := &domain.OCRJob{ImageURL: "https://cdn.example.com/uploads/lunch.jpg?v=2"}
+	if err := uc.CreateOCRJob(job); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	uc.ProcessJob(job.ID)
+
+	got, _ := repo.GetByID(context.Background(), job.ID)
+	if got.Status != domain.OCRCompleted {
+		t.Fatalf("job status %q, error %q", got.Status, got.Error)
+	}
+	if got.Results == nil || got.Results.OCRProvider != services.OCRProviderFixture {
+		t.Fatalf("unexpected results %+v", got.Results)
+	}
+	if len(got.AICalls) != 1 || got.AICalls[0].Provider != "echo" || got.AICalls[0].TotalTokens != 7 {
+		t.Fatalf("unexpected AI calls %+v", got.AICalls)
+	}
+	if got.Results.RawAIJSON != `{"menuItems":[]}` {
+		t.Fatalf("raw AI JSON not kept: %q", got.Results.RawAIJSON)
+	}
+	if ai.seen != "Doro Wat\n450 ETB" {
+		t.Fatalf("AI stage got %q", ai.seen)
+	}
+	result, err := parsed.GetByID(context.Background(), got.Results.ParseResultID)
+	if err != nil {
+		t.Fatalf("parse result not stored: %v", err)
+	}
+	if result.Status != domain.ParseResultPendingReview || len(result.Items) != 1 || result.Items[0].Item.Name != "Doro Wat" {
+		t.Fatalf("parse result not built from OCR text: %+v", result)
+	}
+}
+
+func TestNewOCRProvider(t *testing.T) {
+	if _, err := services.NewOCRProvider(services.OCRProviderConfig{Provider: "abbyy"}); err == nil {
+		t.Fatal("expected error for unknown provider")
+	}
+	if _, err := services.NewOCRProvider(services.OCRProviderConfig{Provider: "fixture"}); err == nil {
+		t.Fatal("expected error for fixture provider without a directory")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "default.txt"), []byte("Shiro 200"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ocr, err := services.NewOCRProvider(services.OCRProviderConfig{Provider: "Fixture", FixtureDir: dir})
+	if err != nil {
+		t.Fatalf("fixture provider: %v", err)
+	}
+	doc, err := ocr.ExtractText(context.Background(), "/tmp/unknown-menu.png")
+	if err != nil || doc.Text != "Shiro 200" {
+		t.Fatalf("expected default fixture, got %+v, %v", doc, err)
+	}
+}
@@ -0,0 +1,283 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+// memOCRJobRepo is an in-memory queue with the lease semantics of the Mongo repository.
+type memOCRJobRepo struct {
+	mu     sync.Mutex
+	jobs   map[string]*domain.OCRJob
+	nextID int
+	claims map[string]string // job id -> worker
+}
+
+func newMemOCRJobRepo() *memOCRJobRepo {
+	return &memOCRJobRepo{jobs: map[string]*domain.OCRJob{}, claims: map[string]string{}}
+}
+
+func (r *memOCRJobRepo) Create(_ context.Context, job *domain.OCRJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	job.ID = strconv.Itoa(r.nextID)
+	cp := *job
+	r.jobs[job.ID] = &cp
+	return nil
+}
+
+func (r *memOCRJobRepo) Update(_ context.Context, id string, job *domain.OCRJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// like the Mongo filter: a cancelled job stays cancelled until retried, and
+	// a job read under a lease is only written while that lease is held
+	if job.Status != domain.OCRPending && job.Status != domain.OCRCancelled {
+		if r.jobs[id].Status == domain.OCRCancelled {
+			return nil
+		}
+		if job.LeaseOwner != "" && r.jobs[id].LeaseOwner != job.LeaseOwner {
+			return domain.ErrOCRJobLeaseLost
+		}
+	}
+	cp := *job
+	cp.LeaseOwner, cp.LeaseExpiresAt, cp.Attempts = r.jobs[id].LeaseOwner, r.jobs[id].LeaseExpiresAt, r.jobs[id].Attempts
+	r.jobs[id] = &cp
+	return nil
+}
+
+func (r *memOCRJobRepo) UpdateStatus(_ context.Context, id, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[id].Status = domain.OCRJobStatus(status)
+	return nil
+}
+
+func (r *memOCRJobRepo) GetByID(_ context.Context, id string) (*domain.OCRJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	cp := *job
+	return &cp, nil
+}
+
+func (r *memOCRJobRepo) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.jobs, id)
+	return nil
+}
+
+func (r *memOCRJobRepo) Claim(_ context.Context, workerID string, leaseUntil time.Time) (*domain.OCRJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ids []string
+	for id, job := range r.jobs {
+		if job.Status == domain.OCRPending {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	sort.Strings(ids)
+	job := r.jobs[ids[0]]
+	job.Status, job.LeaseOwner, job.LeaseExpiresAt = domain.OCRProcessing, workerID, &leaseUntil
+	job.Attempts++
+	r.claims[job.ID] = workerID
+	cp := *job
+	return &cp, nil
+}
+
+func (r *memOCRJobRepo) ExtendLease(_ context.Context, id, workerID string, leaseUntil time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.jobs[id]
+	if job.Status != domain.OCRProcessing || job.LeaseOwner != workerID {
+		return domain.ErrOCRJobLeaseLost
+	}
+	job.LeaseExpiresAt = &leaseUntil
+	return nil
+}
+
+func (r *memOCRJobRepo) RequeueExpired(_ context.Context, now time.Time, maxAttempts int) (int64, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var requeued, failed int64
+	for _, job := range r.jobs {
+		if job.Status != domain.OCRProcessing || (job.LeaseExpiresAt != nil && !job.LeaseExpiresAt.Before(now)) {
+			continue
+		}
+		job.LeaseOwner, job.LeaseExpiresAt = "", nil
+		if job.Attempts >= maxAttempts {
+			job.Status = domain.OCRFailed
+			failed++
+		} else {
+			job.Status = domain.OCRPending
+			requeued++
+		}
+	}
+	return requeued, failed, nil
+}
+
+func (r *memOCRJobRepo) CountByStatus(_ context.Context) (map[domain.OCRJobStatus]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := map[domain.OCRJobStatus]int64{}
+	for _, job := range r.jobs {
+		counts[job.Status]++
+	}
+	return counts, nil
+}
+
+func (r *memOCRJobRepo) UnreadPages(_ context.Context, restaurantID, userID string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for _, job := range r.jobs {
+		if (job.Status != domain.OCRPending && job.Status != domain.OCRProcessing) ||
+			(restaurantID != "" && job.RestaurantID != restaurantID) || (userID != "" && job.UserID != userID) {
+			continue
+		}
+		if len(job.Pages) == 0 {
+			n++
+		}
+		for _, p := range job.Pages {
+			if p.Status != domain.OCRPageDone {
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+func (r *memOCRJobRepo) List(_ context.Context, f domain.OCRJobFilter) ([]*domain.OCRJob, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f = f.Normalize()
+	var matched []*domain.OCRJob
+	for _, job := range r.jobs {
+		if (f.RestaurantID != "" && job.RestaurantID != f.RestaurantID) || (f.UserID != "" && job.UserID != f.UserID) {
+			continue
+		}
+		if (len(f.Statuses) > 0 && !slices.Contains(f.Statuses, job.Status)) || job.CreatedAt.Before(f.CreatedAfter) {
+			continue
+		}
+		cp := *job
+		matched = append(matched, &cp)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].CreatedAt.After(matched[j].CreatedAt) })
+	from := min((f.Page-1)*f.PageSize, len(matched))
+	to := min(from+f.PageSize, len(matched))
+	return matched[from:to], int64(len(matched)), nil
+}
+
+func (r *memOCRJobRepo) Cancel(_ context.Context, id, reason string, at time.Time) (*domain.OCRJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	if job.Status != domain.OCRPending && job.Status != domain.OCRProcessing {
+		return nil, domain.ErrOCRJobNotCancellable
+	}
+	job.Status, job.Error, job.UpdatedAt, job.CompletedAt = domain.OCRCancelled, reason, at, &at
+	job.LeaseOwner, job.LeaseExpiresAt = "", nil
+	cp := *job
+	return &cp, nil
+}
+
+func (r *memOCRJobRepo) ListImagesToPurge(_ context.Context, completedBefore, failedBefore time.Time, limit int) ([]*domain.OCRJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*domain.OCRJob
+	for _, job := range r.jobs {
+		if job.ImagesPurgedAt != nil || len(out) == limit {
+			continue
+		}
+		expired := job.Status == domain.OCRCompleted && job.UpdatedAt.Before(completedBefore)
+		expired = expired || ((job.Status == domain.OCRFailed || job.Status == domain.OCRCancelled) && job.UpdatedAt.Before(failedBefore))
+		if expired {
+			cp := *job
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (r *memOCRJobRepo) MarkImagesPurged(_ context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.jobs[id]
+	job.ImagesPurgedAt, job.ImageURL = &at, ""
+	return nil
+}
+
+func (r *memOCRJobRepo) GetUserFCMToken(string) string { return "" }
+
+// failingOCR makes every job fail fast at the OCR stage.
+type failingOCR struct{}
+
+func (failingOCR) ExtractText(context.Context, string) (*domain.OCRDocument, error) {
+	return nil, errors.New("ocr unavailable")
+}
+
+func TestOCRWorkerPoolRecoversAndDrainsQueue(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	// left processing without a lease by a crashed process, and one that already crashed workers twice
+	_ = repo.Create(context.Background(), &domain.OCRJob{Status: domain.OCRProcessing})
+	_ = repo.Create(context.Background(), &domain.OCRJob{Status: domain.OCRProcessing, Attempts: 2})
+
+	uc := usecase.NewOCRJobUseCase(repo, nil, failingOCR{}, nil, nil, nil, nil, nil, nil, nil, time.Second)
+	for i := 0; i < 3; i++ {
+		if err := uc.CreateOCRJob(&domain.OCRJob{ImageURL: "https://example.com/menu.jpg"}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uc.StartWorkers(ctx, domain.OCRWorkerConfig{Workers: 2, Lease: time.Minute, PollInterval: 10 * time.Millisecond, MaxAttempts: 2})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats, err := uc.QueueStats()
+		if err != nil {
+			t.Fatalf("stats: %v", err)
+		}
+		if stats.Failed == 5 {
+			if len(stats.Workers) != 2 {
+				t.Fatalf("expected 2 workers, got %+v", stats.Workers)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("queue not drained: %+v", stats)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if _, ok := repo.claims["1"]; !ok {
+		t.Fatal("orphaned job was not requeued and processed")
+	}
+	if _, ok := repo.claims["2"]; ok {
+		t.Fatal("job over its attempt limit should fail without another run")
+	}
+	if repo.jobs["1"].Error != "ocr unavailable" {
+		t.Fatalf("unexpected job error %q", repo.jobs["1"].Error)
+	}
+}
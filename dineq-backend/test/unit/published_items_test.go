@@ -0,0 +1,206 @@
+package unit
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/bootstrap"
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	database "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/repositories"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// NOTE: like the review cascade test this hits the Mongo instance configured by
+// environment variables and skips without one.
+func TestPublicItemReadsUseThePublishedCopy(t *testing.T) {
+	app, err := bootstrap.InitApp()
+	if err != nil {
+		t.Skipf("init app failed: %v", err)
+	}
+	defer app.CloseDBConnection()
+	env := app.Env
+	if env.DB_Name == "" || env.DB_Uri == "" {
+		t.Skip("missing DB env")
+	}
+	db := app.Mongo.Database(env.DB_Name)
+	menuColl := os.Getenv("MENU_COLLECTION")
+	if menuColl == "" {
+		menuColl = "menus"
+	}
+	ctx := context.Background()
+	menus := repositories.NewMenuRepository(db, menuColl)
+	items := repositories.NewItemRepository(db, env.ItemCollection)
+
+	suffix := bson.NewObjectID().Hex()
+	itemID := bson.NewObjectID().Hex()
+	live := &domain.Menu{Name: "Lunch", Slug: "lunch-" + suffix, Items: []domain.Item{{ID: itemID, Name: "Shiro", Slug: "shiro", Price: 200}}}
+	if err := menus.Create(ctx, live); err != nil {
+		t.Fatalf("create menu: %v", err)
+	}
+	if _, err := menus.Publish(ctx, live.ID, live.Version, &domain.PublishedMenu{Name: live.Name, Items: live.Items, Version: live.Version + 1}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	// the draft moves on: Shiro is repriced and a new item is added
+	draft, err := menus.GetByID(ctx, live.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	draft.Items[0].Price = 999
+	draft.Items = append(draft.Items, domain.Item{ID: bson.NewObjectID().Hex(), Name: "Secret Special", Slug: "secret-special", Price: 50})
+	if _, err := menus.Update(ctx, live.ID, draft); err != nil {
+		t.Fatalf("update draft: %v", err)
+	}
+	neverPublished := &domain.Menu{Name: "Dinner", Slug: "dinner-" + suffix, Items: []domain.Item{{ID: bson.NewObjectID().Hex(), Name: "Kitfo", Slug: "kitfo", Price: 500}}}
+	if err := menus.Create(ctx, neverPublished); err != nil {
+		t.Fatalf("create menu: %v", err)
+	}
+	defer func() {
+		_ = menus.Delete(ctx, live.ID)
+		_ = menus.Delete(ctx, neverPublished.ID)
+	}()
+
+	got, err := items.GetItems(ctx, live.Slug)
+	if err != nil {
+		t.Fatalf("get items: %v", err)
+	}
+	if len(got) != 1 || got[0].Price != 200 {
+		t.Fatalf("expected only the published Shiro at 200, got %+v", got)
+	}
+	item, err := items.GetPublishedItem(ctx, live.Slug, itemID)
+	if err != nil || item.Price != 200 {
+		t.Fatalf("published item: %+v, %v", item, err)
+	}
+	found, total, err := items.SearchItems(ctx, domain.ItemFilter{MenuSlug: live.Slug, Query: "special"})
+	if err != nil || total != 0 || len(found) != 0 {
+		t.Fatalf("search found draft-only items: %+v, %d, %v", found, total, err)
+	}
+
+	if got, err := items.GetItems(ctx, neverPublished.Slug); err != nil || len(got) != 0 {
+		t.Fatalf("never published menu exposed items: %+v, %v", got, err)
+	}
+	if _, err := items.GetPublishedItem(ctx, neverPublished.Slug, neverPublished.Items[0].ID); err != domain.ErrNotFound {
+		t.Fatalf("never published item: %v", err)
+	}
+}
+
+// pipelineDB records the aggregation pipelines the item repository sends,
+// so the public reads can be checked without a Mongo instance.
+type pipelineDB struct {
+	database.Database
+	runs []aggregateRun
+}
+
+type aggregateRun struct {
+	coll     string
+	pipeline []bson.D
+}
+
+func (d *pipelineDB) Collection(name string) database.Collection {
+	return &pipelineColl{db: d, name: name}
+}
+
+type pipelineColl struct {
+	database.Collection
+	db   *pipelineDB
+	name string
+}
+
+func (c *pipelineColl) Indexes() database.IndexView { return noIndexes{} }
+
+func (c *pipelineColl) AggregatePipeline(_ context.Context, pipeline []bson.D, _ ...options.Lister[options.AggregateOptions]) (database.Cursor, error) {
+	c.db.runs = append(c.db.runs, aggregateRun{coll: c.name, pipeline: pipeline})
+	return emptyCursor{}, nil
+}
+
+type noIndexes struct{ database.IndexView }
+
+func (noIndexes) CreateOne(context.Context, database.IndexModel, ...options.Lister[options.CreateIndexesOptions]) (string, error) {
+	return "", nil
+}
+
+type emptyCursor struct{ database.Cursor }
+
+func (emptyCursor) Close(context.Context) error    { return nil }
+func (emptyCursor) All(context.Context, any) error { return nil }
+
+func TestPublicItemReadsServeLegacyAndStandaloneItems(t *testing.T) {
+	t.Setenv("MENU_COLLECTION", "")
+	db := &pipelineDB{}
+	items := repositories.NewItemRepository(db, "items")
+	ctx := context.Background()
+
+	if _, err := items.GetItems(ctx, "lunch"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := items.GetPublishedItem(ctx, "lunch", bson.NewObjectID().Hex()); err != domain.ErrNotFound {
+		t.Fatalf("expected not found from an empty cursor, got %v", err)
+	}
+	if _, _, err := items.SearchItems(ctx, domain.ItemFilter{MenuSlug: "lunch"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(db.runs) != 3 {
+		t.Fatalf("expected 3 aggregations, got %d", len(db.runs))
+	}
+	for _, run := range db.runs {
+		if run.coll != "menus" {
+			t.Fatalf("public reads must start from the menus, got %q", run.coll)
+		}
+		doc := bson.A{}
+		for _, stage := range run.pipeline {
+			doc = append(doc, stage)
+		}
+		raw, err := bson.MarshalExtJSON(bson.D{{Key: "pipeline", Value: doc}}, false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		js := string(raw)
+		if strings.Contains(js, `"$type"`) {
+			t.Fatalf("menus without a published copy must not be filtered out: %s", js)
+		}
+		if !strings.Contains(js, `"$ifNull":["$published.items","$items"]`) {
+			t.Fatalf("expected the stored items as fallback for the published copy: %s", js)
+		}
+		if !strings.Contains(js, `"isPublished":true`) {
+			t.Fatalf("expected only live menus to be read: %s", js)
+		}
+
+		var union bson.M
+		for _, stage := range run.pipeline {
+			if stage[0].Key == "$unionWith" {
+				union = stage[0].Value.(bson.M)
+			}
+		}
+		if union == nil || union["coll"] != "items" {
+			t.Fatalf("expected the standalone items to be unioned in: %s", js)
+		}
+		standalone, err := bson.MarshalExtJSON(bson.D{{Key: "pipeline", Value: union["pipeline"]}}, false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{`"menuSlug":"lunch"`, `"from":"menus"`, `"isPublished":true`, `"slug":"lunch"`} {
+			if !strings.Contains(string(standalone), want) {
+				t.Fatalf("standalone items must belong to the live menu (missing %s): %s", want, standalone)
+			}
+		}
+	}
+}
+
+func TestPreviewDraftOfAnotherRestaurantsMenuIsNotFound(t *testing.T) {
+	menus := &storedMenuRepo{menu: &domain.Menu{ID: "m1", RestaurantID: "r1", Name: "Lunch", Items: []domain.Item{{ID: "i1", Name: "Secret Special"}}}}
+	uc := usecase.NewMenuUseCase(menus, nil, nil, nil, services.QRService{}, nil, nil, nil, time.Second)
+
+	if _, err := uc.PreviewDraft("m1", &domain.Restaurant{ID: "r2"}); err != domain.ErrNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	preview, err := uc.PreviewDraft("m1", &domain.Restaurant{ID: "r1"})
+	if err != nil || len(preview.Items) != 1 {
+		t.Fatalf("own preview: %+v, %v", preview, err)
+	}
+}
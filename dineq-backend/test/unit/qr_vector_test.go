@@ -0,0 +1,201 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"image/color"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	"github.com/disintegration/imaging"
+)
+
+// printRequest asks for a fully customised code; the logo is a data URL so
+// rendering needs no network.
+func printRequest(t *testing.T, format string) *domain.QRCodeRequest {
+	t.Helper()
+	logo := "data:image/png;base64," + base64.StdEncoding.EncodeToString(encodePNG(t, imaging.New(40, 20, color.NRGBA{200, 30, 30, 128})))
+	return &domain.QRCodeRequest{
+		Format:       format,
+		Size:         300,
+		IncludeLabel: true,
+		Placement:    domain.QRPlacement{Label: "Table 12"},
+		Customization: &domain.QRCodeCustomization{
+			BackgroundColor:   "#fffdf0",
+			ForegroundColor:   "#112233",
+			GradientFrom:      "#112233",
+			GradientTo:        "#aa3300",
+			GradientDirection: "vertical",
+			Margin:            20,
+			Logo:              logo,
+			LabelColor:        "#aa3300",
+			LabelFontSize:     24,
+			LabelFontURL:      "https://fonts.invalid/never-fetched.ttf",
+		},
+	}
+}
+
+func TestRenderQRCodeSVG(t *testing.T) {
+	out, fontApplied, err := services.RenderQRCode("https://dineq.example/q/abc", printRequest(t, "SVG"))
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if fontApplied {
+		t.Fatal("vector output must not load the remote label font")
+	}
+	if err := xml.Unmarshal(out, new(struct{ XMLName xml.Name })); err != nil {
+		t.Fatalf("output is not well-formed XML: %v", err)
+	}
+	svg := string(out)
+	for _, want := range []string{
+		`viewBox="0 0 340 `,
+		`<rect width="340"`,
+		`fill="#fffdf0"`,
+		`<linearGradient id="qr-gradient" gradientUnits="userSpaceOnUse" x1="0" y1="0" x2="0" y2="340">`,
+		`stop-color="#aa3300"`,
+		`fill="url(#qr-gradient)" shape-rendering="crispEdges"`,
+		`xlink:href="data:image/png;base64,`,
+		`<path fill="#aa3300" d="M`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("svg missing %q", want)
+		}
+	}
+	if strings.Contains(svg, "<text") {
+		t.Error("label should be drawn as outlines, not text")
+	}
+	// the logo keeps its own 40x20 pixels, centred on the code
+	if !strings.Contains(svg, `<image x="150" y="160" width="40" height="20"`) {
+		t.Error("logo not centred at native size")
+	}
+}
+
+func TestRenderQRCodePDF(t *testing.T) {
+	out, _, err := services.RenderQRCode("https://dineq.example/q/abc", printRequest(t, "pdf"))
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+	for _, want := range []string{
+		"/MediaBox [0 0 340 ",
+		"/ShadingType 2",
+		"/C0 [0.067 0.133 0.2] /C1 [0.667 0.2 0]",
+		"/SMask ",
+		"/Subtype /CIDFontType2",
+	} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("pdf missing %q", want)
+		}
+	}
+	if bytes.Contains(out, []byte("/DCTDecode")) {
+		t.Error("logo should be embedded losslessly")
+	}
+	// only fonts the label uses are embedded
+	if n := bytes.Count(out, []byte("/Subtype /Type0")); n != 1 {
+		t.Errorf("expected 1 embedded font, got %d", n)
+	}
+}
+
+func TestRenderQRCodeDefaultsToPNG(t *testing.T) {
+	out, _, err := services.RenderQRCode("https://dineq.example/q/abc", &domain.QRCodeRequest{Format: "bmp"})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	img := decodePNG(t, out)
+	if img.Bounds().Dx() != 256 {
+		t.Fatalf("expected default size 256, got %d", img.Bounds().Dx())
+	}
+}
+
+const testSVGLogo = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10"><circle cx="5" cy="5" r="4" fill="#c00"/></svg>`
+
+func TestRenderQRCodeLogoSources(t *testing.T) {
+	withLogo := func(format, logo string) *domain.QRCodeRequest {
+		return &domain.QRCodeRequest{Format: format, Size: 200, Customization: &domain.QRCodeCustomization{Logo: logo}}
+	}
+
+	// logos come from clients, so files on the server are never read
+	local := filepath.Join(t.TempDir(), "logo.png")
+	if err := os.WriteFile(local, encodePNG(t, imaging.New(10, 10, color.Black)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []string{"png", "svg", "pdf"} {
+		for _, logo := range []string{local, "file://" + local, "data:image/png;base64,not base64!"} {
+			if _, _, err := services.RenderQRCode("https://dineq.example/q/abc", withLogo(format, logo)); !errors.Is(err, domain.ErrInvalidQRLogo) {
+				t.Errorf("%s logo %q: expected ErrInvalidQRLogo, got %v", format, logo, err)
+			}
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/logo.svg" {
+			w.Header().Set("Content-Type", "image/svg+xml")
+			_, _ = w.Write([]byte(testSVGLogo))
+			return
+		}
+		_, _ = w.Write(encodePNG(t, imaging.New(30, 30, color.NRGBA{0, 0, 200, 255})))
+	}))
+	defer srv.Close()
+
+	out, _, err := services.RenderQRCode("https://dineq.example/q/abc", withLogo("pdf", srv.URL+"/logo.png"))
+	if err != nil || !bytes.Contains(out, []byte("/Subtype /Image /Width 30 /Height 30")) {
+		t.Fatalf("a PNG logo URL should be embedded in the PDF (err %v)", err)
+	}
+
+	svgData := "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(testSVGLogo))
+	for _, logo := range []string{srv.URL + "/logo.svg", svgData} {
+		if _, _, err := services.RenderQRCode("https://dineq.example/q/abc", withLogo("pdf", logo)); err != domain.ErrUnsupportedQRLogo {
+			t.Errorf("SVG logo %.40s in a PDF: expected ErrUnsupportedQRLogo, got %v", logo, err)
+		}
+		out, _, err := services.RenderQRCode("https://dineq.example/q/abc", withLogo("svg", logo))
+		if err != nil || !bytes.Contains(out, []byte(`xlink:href="data:image/svg+xml;base64,`)) {
+			t.Errorf("SVG logo %.40s should stay a vector in SVG output (err %v)", logo, err)
+		}
+	}
+}
+
+func TestRenderQRCodeAmharicLabel(t *testing.T) {
+	const label = "ጠረጴዛ 12"
+	request := func(format string) *domain.QRCodeRequest {
+		return &domain.QRCodeRequest{Format: format, Size: 300, IncludeLabel: true, Placement: domain.QRPlacement{Label: label}}
+	}
+
+	out, _, err := services.RenderQRCode("https://dineq.example/q/abc", request("pdf"))
+	if err != nil {
+		t.Fatalf("render pdf: %v", err)
+	}
+	var drawn *pdfText
+	for _, txt := range pdfTexts(t, out) {
+		if txt.text == label {
+			drawn = &txt
+		}
+	}
+	if drawn == nil || !slices.Contains(drawn.fonts, "NotoSansEthiopic-Regular") {
+		t.Fatalf("label should be drawn with the Ethiopic font, got %+v", drawn)
+	}
+
+	// SVG labels are outlines: the Ethiopic glyphs add path data beyond the
+	// digits alone
+	out, _, err = services.RenderQRCode("https://dineq.example/q/abc", request("svg"))
+	if err != nil {
+		t.Fatalf("render svg: %v", err)
+	}
+	digits, _, err := services.RenderQRCode("https://dineq.example/q/abc", &domain.QRCodeRequest{Format: "svg", Size: 300, IncludeLabel: true, Placement: domain.QRPlacement{Label: "12"}})
+	if err != nil {
+		t.Fatalf("render svg: %v", err)
+	}
+	if len(out) <= len(digits)+200 {
+		t.Fatalf("Amharic label outlines missing: %d bytes against %d for the digits", len(out), len(digits))
+	}
+}
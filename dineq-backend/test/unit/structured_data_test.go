@@ -0,0 +1,94 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
+)
+
+func TestRestaurantToJSONLD(t *testing.T) {
+	about := "Family kitchen"
+	r := &domain.Restaurant{
+		Slug:            "cafe",
+		RestaurantName:  "Cafe",
+		About:           &about,
+		DefaultCurrency: "ETB",
+		AverageRating:   4.5,
+		Location:        &domain.Address{Type: "Point", Coordinates: [2]float64{38.76, 9.03}},
+		Schedule: []domain.Schedule{
+			{Day: "monday", IsOpen: true, StartTime: "08:00", EndTime: "22:00"},
+			{Day: "sunday", IsOpen: false},
+			{Day: "Tue", IsOpen: true, StartTime: "09:00", EndTime: "17:00"},
+		},
+		SpecialDays: []domain.SpecialDay{{Date: "2026-01-07", IsOpen: false}},
+	}
+	ld := dto.RestaurantToJSONLD(r, "https://example.com/user/cafe", "", domain.LangEnglish)
+
+	if ld.Geo == nil || ld.Geo.Latitude != 9.03 || ld.Geo.Longitude != 38.76 {
+		t.Fatalf("coordinates are [lng, lat], got %+v", ld.Geo)
+	}
+	if ld.AggregateRating == nil || ld.AggregateRating.RatingValue != 4.5 {
+		t.Fatalf("missing rating: %+v", ld.AggregateRating)
+	}
+	if len(ld.OpeningHours) != 3 {
+		t.Fatalf("expected 2 open days and 1 closed special day, got %+v", ld.OpeningHours)
+	}
+	if ld.OpeningHours[1].DayOfWeek != "https://schema.org/Tuesday" {
+		t.Fatalf("short day names should map, got %q", ld.OpeningHours[1].DayOfWeek)
+	}
+	closed := ld.OpeningHours[2]
+	if closed.ValidFrom != "2026-01-07" || closed.Opens != "00:00" || closed.Closes != "00:00" {
+		t.Fatalf("unexpected special day %+v", closed)
+	}
+}
+
+func TestMenuToJSONLDOffers(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	menu := &domain.Menu{Name: "Lunch", Items: []domain.Item{
+		{Name: "Tibs", Price: 250, TabTags: []string{"Food"}, Translations: map[string]domain.ItemTranslation{"am": {Name: "ጥብስ"}}},
+		{Name: "Coffee", Price: 40, Currency: "USD", TabTags: []string{"Drinks"}, Unavailable: true,
+			ModifierGroups: []domain.ModifierGroup{{Name: "Size", MinSelect: 1, MaxSelect: 1, Options: []domain.ModifierOption{
+				{Name: "Small"}, {Name: "Large", PriceDelta: 15},
+			}}}},
+	}}
+	ld := dto.MenuToJSONLD(menu, &domain.Restaurant{DefaultCurrency: "ETB"}, "https://example.com/m", domain.LangEnglish, now)
+
+	if len(ld.Sections) != 2 || len(ld.InLanguage) != 2 {
+		t.Fatalf("unexpected menu %+v", ld)
+	}
+	tibs := ld.Sections[0].Items[0]
+	if tibs.Offers.Type != "Offer" || *tibs.Offers.Price != 250 || tibs.Offers.PriceCurrency != "ETB" || tibs.AlternateName != "ጥብስ" {
+		t.Fatalf("unexpected item %+v", tibs)
+	}
+	coffee := ld.Sections[1].Items[0].Offers
+	if coffee.Type != "AggregateOffer" || *coffee.LowPrice != 40 || *coffee.HighPrice != 55 || coffee.PriceCurrency != "USD" {
+		t.Fatalf("unexpected offer %+v", coffee)
+	}
+	if coffee.Availability != "https://schema.org/OutOfStock" {
+		t.Fatalf("sold out item should be out of stock, got %q", coffee.Availability)
+	}
+}
+
+func TestRenderOpenGraphHTMLEscapesScript(t *testing.T) {
+	ld := map[string]string{"name": "</script><script>alert(1)</script>"}
+	page, err := dto.RenderOpenGraphHTML(dto.OpenGraph{Type: "website", Title: `A "quoted" <title>`, URL: "https://example.com"}, ld)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if bytes.Count(page, []byte("</script>")) != 1 {
+		t.Fatalf("menu text closed the script block:\n%s", page)
+	}
+	if !bytes.Contains(page, []byte(`content="A &#34;quoted&#34; &lt;title&gt;"`)) {
+		t.Fatalf("og:title not escaped:\n%s", page)
+	}
+	start := bytes.Index(page, []byte(`ld+json">`)) + len(`ld+json">`)
+	end := bytes.Index(page, []byte("</script>"))
+	var back map[string]string
+	if err := json.Unmarshal(page[start:end], &back); err != nil || back["name"] != ld["name"] {
+		t.Fatalf("embedded JSON-LD not valid: %v %q", err, page[start:end])
+	}
+}
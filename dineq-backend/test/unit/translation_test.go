@@ -0,0 +1,85 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	"github.com/RealEskalate/G6-MenuMate/internal/infrastructure/database/mapper"
+	"github.com/RealEskalate/G6-MenuMate/internal/interfaces/http/dto"
+)
+
+func TestNegotiateLanguage(t *testing.T) {
+	cases := []struct {
+		explicit, accept, fallback, want string
+	}{
+		{"om", "am-ET", "English", "om"},
+		{"fr", "ti;q=0.4, am-ET;q=0.9, fr", "English", "am"},
+		{"", "fr-FR, de", "Amharic", "am"},
+		{"", "", "", "en"},
+		{"", "am;q=0", "Tigrinya", "ti"},
+	}
+	for _, tc := range cases {
+		if got := domain.NegotiateLanguage(tc.explicit, tc.accept, tc.fallback); got != tc.want {
+			t.Errorf("NegotiateLanguage(%q, %q, %q) = %q, want %q", tc.explicit, tc.accept, tc.fallback, got, tc.want)
+		}
+	}
+}
+
+func TestItemLocalizedFallsBackPerField(t *testing.T) {
+	item := &domain.Item{Name: "Tibs", Description: "Fried beef", TabTags: []string{"Mains"}}
+	item.SetTranslation(domain.LangOromo, domain.ItemTranslation{Name: "Xibsii"})
+
+	om := item.Localized(domain.LangOromo)
+	if om.Name != "Xibsii" || om.Description != "Fried beef" || om.TabTags[0] != "Mains" {
+		t.Fatalf("unexpected localized text %+v", om)
+	}
+	if ti := item.Localized(domain.LangTigrinya); ti.Name != "Tibs" {
+		t.Fatalf("untranslated language should use the base name, got %q", ti.Name)
+	}
+	item.SetTranslation(domain.LangOromo, domain.ItemTranslation{})
+	if len(item.Translations) != 0 {
+		t.Fatalf("empty translation should be removed, got %+v", item.Translations)
+	}
+}
+
+func TestLegacyAmharicFieldsMigrate(t *testing.T) {
+	legacy := &mapper.ItemDB{Name: "Tibs", NameAm: "ጥብስ", TabTagsAm: []string{"ዋና"},
+		ModifierGroups: []mapper.ModifierGroupDB{{Name: "Size", NameAm: "መጠን"}}}
+	item := mapper.ToDomainItem(legacy)
+	if am := item.Translation(domain.LangAmharic); am.Name != "ጥብስ" || am.TabTags[0] != "ዋና" {
+		t.Fatalf("legacy fields not migrated: %+v", item.Translations)
+	}
+	if item.ModifierGroups[0].Translations["am"] != "መጠን" {
+		t.Fatalf("legacy modifier name not migrated: %+v", item.ModifierGroups[0])
+	}
+
+	// once written with translations, stale legacy fields are ignored
+	stored := mapper.ToItemDBForUpdate(&domain.Item{Name: "Tibs"})
+	stored.NameAm = "ጥብስ"
+	if got := mapper.ToDomainItem(stored).Translations; got != nil {
+		t.Fatalf("cleared translations came back from legacy fields: %+v", got)
+	}
+}
+
+func TestItemRequestAmharicShorthand(t *testing.T) {
+	req := &dto.ItemRequest{
+		Name:   "Tibs",
+		NameAm: "ጥብስ",
+		Translations: map[string]dto.ItemTranslationDTO{
+			"am":    {Name: "ignored", Description: "የተጠበሰ ስጋ"},
+			"ti-ER": {Name: "ጥብሲ"},
+		},
+	}
+	item := dto.RequestToItem(req)
+	if am := item.Translation("am"); am.Name != "ጥብስ" || am.Description != "የተጠበሰ ስጋ" {
+		t.Fatalf("name_am should win over translations.am: %+v", am)
+	}
+	if item.Translation("ti").Name != "ጥብሲ" {
+		t.Fatalf("language key not normalized: %+v", item.Translations)
+	}
+
+	res := dto.LocalizedItemResponse(item, "ti")
+	if res.Name != "ጥብሲ" || res.NameAm != "ጥብስ" || res.Language != "ti" {
+		t.Fatalf("unexpected localized response name=%q name_am=%q lang=%q", res.Name, res.NameAm, res.Language)
+	}
+}
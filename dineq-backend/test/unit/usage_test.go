@@ -0,0 +1,190 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+// memUsageRepo is an in-memory usage ledger.
+type memUsageRepo struct {
+	mu      sync.Mutex
+	entries []domain.UsageEntry
+}
+
+func (r *memUsageRepo) Insert(_ context.Context, entries []domain.UsageEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entries...)
+	return nil
+}
+
+func (r *memUsageRepo) Totals(_ context.Context, restaurantID, userID string, since time.Time) (domain.UsageTotals, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var t domain.UsageTotals
+	for _, e := range r.entries {
+		if e.CreatedAt.Before(since) || (restaurantID != "" && e.RestaurantID != restaurantID) || (userID != "" && e.UserID != userID) {
+			continue
+		}
+		t.Add(e.Kind, e.Quantity)
+	}
+	return t, nil
+}
+
+func (r *memUsageRepo) Report(_ context.Context, f domain.UsageReportFilter) ([]domain.UsageReportRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var rows []domain.UsageReportRow
+	index := map[string]int{}
+	for _, e := range r.entries {
+		if e.CreatedAt.Before(f.From) || !e.CreatedAt.Before(f.To) {
+			continue
+		}
+		key := e.RestaurantID
+		if f.GroupBy == domain.UsageByUser {
+			key = e.UserID
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(rows)
+			index[key] = i
+			row := domain.UsageReportRow{RestaurantID: key}
+			if f.GroupBy == domain.UsageByUser {
+				row = domain.UsageReportRow{UserID: key}
+			}
+			rows = append(rows, row)
+		}
+		rows[i].Add(e.Kind, e.Quantity)
+	}
+	return rows, nil
+}
+
+func TestUsageQuotas(t *testing.T) {
+	repo := &memUsageRepo{}
+	uc := usecase.NewUsageUseCase(repo, nil, domain.UsageQuotas{
+		Restaurant: domain.UsageQuota{OCRPages: 10, Tokens: 1000},
+		User:       domain.UsageQuota{ImageSearches: 2},
+	}, time.Second)
+	lastMonth := time.Now().AddDate(0, -1, 0)
+	uc.Record(
+		domain.UsageEntry{RestaurantID: "r1", UserID: "u1", Kind: domain.UsageOCRPages, Quantity: 8},
+		domain.UsageEntry{RestaurantID: "r1", UserID: "u1", Kind: domain.UsageOCRPages, Quantity: 50, CreatedAt: lastMonth},
+		domain.UsageEntry{RestaurantID: "r1", UserID: "u1", Kind: domain.UsageTokensIn, Quantity: 600},
+		domain.UsageEntry{RestaurantID: "r1", UserID: "u2", Kind: domain.UsageTokensOut, Quantity: 400},
+		domain.UsageEntry{RestaurantID: "r2", UserID: "u1", Kind: domain.UsageImageSearches, Quantity: 2},
+		domain.UsageEntry{RestaurantID: "r1", UserID: "u1", Kind: domain.UsageTokensOut, Quantity: 0},
+	)
+	if len(repo.entries) != 5 {
+		t.Fatalf("zero quantities should not be recorded: %d entries", len(repo.entries))
+	}
+
+	if err := uc.CheckQuota("r1", "u1", domain.UsageOCRPages, 2); err != nil {
+		t.Fatalf("2 more pages fit the quota: %v", err)
+	}
+	err := uc.CheckQuota("r1", "u1", domain.UsageOCRPages, 3)
+	var qe *domain.QuotaExceededError
+	if !errors.As(err, &qe) || !errors.Is(err, domain.ErrUsageQuotaExceeded) {
+		t.Fatalf("expected a quota error, got %v", err)
+	}
+	if qe.Scope != "restaurant" || qe.Used != 8 || qe.Limit != 10 || qe.ResetsAt.Day() != 1 || !qe.ResetsAt.After(time.Now()) {
+		t.Fatalf("unexpected quota error %+v", qe)
+	}
+	// tokens in and out together, from every user of the restaurant
+	if err := uc.CheckQuota("r1", "u3", domain.UsageTokens, 0); !errors.Is(err, domain.ErrUsageQuotaExceeded) {
+		t.Fatalf("token quota used up, got %v", err)
+	}
+	if err := uc.CheckQuota("r2", "u2", domain.UsageTokens, 0); err != nil {
+		t.Fatalf("r2 has no token usage: %v", err)
+	}
+	// image searches are capped per user across restaurants
+	err = uc.CheckQuota("r1", "u1", domain.UsageImageSearches, 1)
+	if !errors.As(err, &qe) || qe.Scope != "user" {
+		t.Fatalf("expected the user image search quota, got %v", err)
+	}
+
+	report, err := uc.Report(domain.UsageReportFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Rows) != 2 || report.Rows[0].RestaurantID != "r1" || report.Rows[0].OCRPages != 8 || report.Rows[0].TokensOut != 400 {
+		t.Fatalf("unexpected report rows %+v", report.Rows)
+	}
+	if report.Total.ImageSearches != 2 || report.Total.OCRPages != 8 || report.Quotas.Restaurant.OCRPages != 10 {
+		t.Fatalf("unexpected report totals %+v", report)
+	}
+	byUser, _ := uc.Report(domain.UsageReportFilter{From: lastMonth.Add(-time.Hour), GroupBy: domain.UsageByUser})
+	if len(byUser.Rows) != 2 || byUser.Rows[0].UserID != "u1" || byUser.Rows[0].OCRPages != 58 {
+		t.Fatalf("unexpected per-user report %+v", byUser.Rows)
+	}
+	if _, err := uc.Report(domain.UsageReportFilter{GroupBy: "menu"}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("unknown grouping should be rejected, got %v", err)
+	}
+}
+
+func TestOCRJobRecordsUsage(t *testing.T) {
+	repo := newMemOCRJobRepo()
+	ledger := &memUsageRepo{}
+	usage := usecase.NewUsageUseCase(ledger, nil, domain.UsageQuotas{}, time.Second)
+	ocr := &flakyPageOCR{texts: map[string]string{"p1.png": "Doro Wat\n450", "p2.png": "Tibs\n380"}, failOnce: map[string]bool{}, reads: map[string]int{}}
+	uc := usecase.NewOCRJobUseCase(repo, newMemParseResultRepo(), ocr, nil, &echoAI{}, nil, nil, nil, usage, nil, time.Second)
+	job := &domain.OCRJob{RestaurantID: "r1", UserID: "u1", ImageURL: "p1.png", Pages: []domain.OCRPage{
+		{Number: 1, ImageURL: "p1.png", Status: domain.OCRPagePending},
+		{Number: 2, ImageURL: "p2.png", Status: domain.OCRPagePending},
+	}}
+	if err := uc.CreateOCRJob(job); err != nil {
+		t.Fatal(err)
+	}
+	uc.ProcessJob(job.ID)
+
+	totals, _ := ledger.Totals(context.Background(), "r1", "u1", time.Time{})
+	// two pages read, and echoAI reports 7 tokens per structuring call
+	if totals.OCRPages != 2 || totals.TokensIn != 14 {
+		t.Fatalf("unexpected usage %+v", totals)
+	}
+	for _, e := range ledger.entries {
+		if e.OCRJobID != job.ID || e.Provider == "" {
+			t.Fatalf("entry without job or provider: %+v", e)
+		}
+	}
+}
+
+func TestOCRPageQuotaCountsUnfinishedJobs(t *testing.T) {
+	ledger := &memUsageRepo{}
+	jobs := newMemOCRJobRepo()
+	uc := usecase.NewUsageUseCase(ledger, jobs, domain.UsageQuotas{Restaurant: domain.UsageQuota{OCRPages: 10}}, time.Second)
+	uc.Record(domain.UsageEntry{RestaurantID: "r1", UserID: "u1", Kind: domain.UsageOCRPages, Quantity: 4})
+	ctx := context.Background()
+	pages := func(statuses ...string) []domain.OCRPage {
+		out := make([]domain.OCRPage, len(statuses))
+		for i, s := range statuses {
+			out[i] = domain.OCRPage{Number: i + 1, Status: s}
+		}
+		return out
+	}
+	// queued: 3 unread; running: 1 of 2 read (and already in the ledger); done: not counted
+	for _, job := range []*domain.OCRJob{
+		{RestaurantID: "r1", UserID: "u1", Status: domain.OCRPending, Pages: pages(domain.OCRPagePending, domain.OCRPagePending, domain.OCRPagePending)},
+		{RestaurantID: "r1", UserID: "u2", Status: domain.OCRProcessing, Pages: pages(domain.OCRPageDone, domain.OCRPagePending)},
+		{RestaurantID: "r1", UserID: "u1", Status: domain.OCRCompleted, Pages: pages(domain.OCRPageDone)},
+		{RestaurantID: "r2", UserID: "u1", Status: domain.OCRPending, Pages: pages(domain.OCRPagePending)},
+	} {
+		if err := jobs.Create(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := uc.CheckQuota("r1", "u1", domain.UsageOCRPages, 2); err != nil {
+		t.Fatalf("4 recorded and 4 unread pages leave room for 2: %v", err)
+	}
+	err := uc.CheckQuota("r1", "u1", domain.UsageOCRPages, 3)
+	var qe *domain.QuotaExceededError
+	if !errors.As(err, &qe) || qe.Used != 8 {
+		t.Fatalf("expected the unread pages counted as used, got %v", err)
+	}
+}
@@ -0,0 +1,405 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RealEskalate/G6-MenuMate/internal/domain"
+	services "github.com/RealEskalate/G6-MenuMate/internal/infrastructure/service"
+	usecase "github.com/RealEskalate/G6-MenuMate/internal/usecases"
+)
+
+// memWebhookRepo keeps subscriptions and deliveries in memory.
+type memWebhookRepo struct {
+	mu         sync.Mutex
+	subs       map[string]*domain.WebhookSubscription
+	deliveries map[string]*domain.WebhookDelivery
+	nextID     int
+}
+
+func newMemWebhookRepo() *memWebhookRepo {
+	return &memWebhookRepo{subs: map[string]*domain.WebhookSubscription{}, deliveries: map[string]*domain.WebhookDelivery{}}
+}
+
+func (r *memWebhookRepo) id() string {
+	r.nextID++
+	return strconv.Itoa(r.nextID)
+}
+
+func (r *memWebhookRepo) CreateSubscription(_ context.Context, sub *domain.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sub.ID = r.id()
+	cp := *sub
+	r.subs[sub.ID] = &cp
+	return nil
+}
+
+func (r *memWebhookRepo) GetSubscription(_ context.Context, id string) (*domain.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sub, ok := r.subs[id]
+	if !ok {
+		return nil, domain.ErrWebhookNotFound
+	}
+	cp := *sub
+	return &cp, nil
+}
+
+func (r *memWebhookRepo) ListSubscriptions(_ context.Context, restaurantID string) ([]*domain.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*domain.WebhookSubscription
+	for _, sub := range r.subs {
+		if sub.RestaurantID == restaurantID {
+			cp := *sub
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (r *memWebhookRepo) UpdateSubscription(_ context.Context, sub *domain.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.subs[sub.ID]; !ok {
+		return domain.ErrWebhookNotFound
+	}
+	cp := *sub
+	r.subs[sub.ID] = &cp
+	return nil
+}
+
+func (r *memWebhookRepo) DeleteSubscription(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subs, id)
+	return nil
+}
+
+func (r *memWebhookRepo) CreateDelivery(_ context.Context, d *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d.ID = r.id()
+	cp := *d
+	r.deliveries[d.ID] = &cp
+	return nil
+}
+
+func (r *memWebhookRepo) GetDelivery(_ context.Context, id string) (*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.deliveries[id]
+	if !ok {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+	cp := *d
+	return &cp, nil
+}
+
+func (r *memWebhookRepo) ListDeliveries(_ context.Context, subscriptionID string, page, pageSize int) ([]*domain.WebhookDelivery, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*domain.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.SubscriptionID == subscriptionID {
+			cp := *d
+			out = append(out, &cp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	total := int64(len(out))
+	start := min((page-1)*pageSize, len(out))
+	return out[start:min(start+pageSize, len(out))], total, nil
+}
+
+func (r *memWebhookRepo) ClaimDelivery(_ context.Context, now, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range r.deliveries {
+		if d.Status == domain.WebhookPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			d.NextAttemptAt = &leaseUntil
+			cp := *d
+			return &cp, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *memWebhookRepo) UpdateDelivery(_ context.Context, d *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *d
+	r.deliveries[d.ID] = &cp
+	return nil
+}
+
+func (r *memWebhookRepo) all() []*domain.WebhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*domain.WebhookDelivery
+	for _, d := range r.deliveries {
+		cp := *d
+		out = append(out, &cp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// waitForDelivery polls the delivery until it is no longer pending.
+func waitForDelivery(t *testing.T, repo *memWebhookRepo, id string) *domain.WebhookDelivery {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		d, _ := repo.GetDelivery(context.Background(), id)
+		if d.Status != domain.WebhookPending {
+			return d
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("delivery %s still pending", id)
+	return nil
+}
+
+// flakyReceiver answers 500 to the first fail requests and checks every
+// signature it gets.
+type flakyReceiver struct {
+	t      *testing.T
+	secret string
+	fail   int32
+	calls  atomic.Int32
+	bodies chan []byte
+}
+
+func (f *flakyReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	ts, _ := strconv.ParseInt(r.Header.Get(services.WebhookTimestampHeader), 10, 64)
+	if got := r.Header.Get(services.WebhookSignatureHeader); got != "sha256="+services.SignWebhook(f.secret, ts, body) {
+		f.t.Errorf("bad signature %q", got)
+	}
+	if f.calls.Add(1) <= f.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	f.bodies <- body
+}
+
+// localReceivers lets the tests post to httptest servers.
+var localReceivers = services.WebhookTargets{AllowHTTP: true, AllowPrivate: true}
+
+func TestWebhookURLMustBePublicHTTPS(t *testing.T) {
+	uc := usecase.NewWebhookUseCase(newMemWebhookRepo(), services.NewWebhookSender(services.WebhookTargets{}), time.Second)
+	for _, u := range []string{
+		"http://93.184.216.34/hook",
+		"https://127.0.0.1/hook",
+		"https://localhost:8443/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://10.1.2.3/hook",
+		"https://192.168.0.10/hook",
+		"https://[::1]/hook",
+		"https://[fe80::1]/hook",
+		"https://[::ffff:127.0.0.1]/hook",
+		"https://100.64.0.1/hook",
+	} {
+		sub := &domain.WebhookSubscription{RestaurantID: "r1", URL: u, Events: []domain.WebhookEvent{domain.WebhookMenuPublished}}
+		if err := uc.CreateSubscription(sub); err != domain.ErrInvalidWebhookURL {
+			t.Errorf("%s: expected invalid URL, got %v", u, err)
+		}
+	}
+	if err := uc.CreateSubscription(&domain.WebhookSubscription{RestaurantID: "r1", URL: "https://93.184.216.34/hook", Events: []domain.WebhookEvent{domain.WebhookMenuPublished}}); err != nil {
+		t.Fatalf("public https receiver rejected: %v", err)
+	}
+
+	dev := usecase.NewWebhookUseCase(newMemWebhookRepo(), services.NewWebhookSender(services.WebhookTargets{AllowHTTP: true}), time.Second)
+	if err := dev.CreateSubscription(&domain.WebhookSubscription{RestaurantID: "r1", URL: "http://93.184.216.34/hook", Events: []domain.WebhookEvent{domain.WebhookMenuPublished}}); err != nil {
+		t.Fatalf("http receiver rejected in development: %v", err)
+	}
+	if err := dev.CreateSubscription(&domain.WebhookSubscription{RestaurantID: "r1", URL: "http://127.0.0.1/hook", Events: []domain.WebhookEvent{domain.WebhookMenuPublished}}); err != domain.ErrInvalidWebhookURL {
+		t.Fatalf("expected loopback rejected in development too, got %v", err)
+	}
+}
+
+// A URL that passed registration may resolve elsewhere later; the address is
+// checked again when the request connects.
+func TestWebhookSenderRefusesPrivateAddressWhenConnecting(t *testing.T) {
+	var hit atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { hit.Store(true) }))
+	defer srv.Close()
+	sender := services.NewWebhookSender(services.WebhookTargets{AllowHTTP: true})
+	if _, err := sender.Send(context.Background(), services.WebhookRequest{URL: srv.URL, Secret: "s", Event: "menu.published", Body: []byte("{}")}); err == nil {
+		t.Fatal("expected the loopback receiver refused")
+	}
+	if hit.Load() {
+		t.Fatal("request reached the loopback receiver")
+	}
+}
+
+func TestWebhookRetriesUntilDelivered(t *testing.T) {
+	repo := newMemWebhookRepo()
+	uc := usecase.NewWebhookUseCase(repo, services.NewWebhookSender(localReceivers), time.Second)
+	receiver := &flakyReceiver{t: t, fail: 2, bodies: make(chan []byte, 4)}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	sub := &domain.WebhookSubscription{RestaurantID: "r1", URL: srv.URL, Events: []domain.WebhookEvent{domain.WebhookOCRCompleted, domain.WebhookOCRCompleted}, Active: true}
+	if err := uc.CreateSubscription(sub); err != nil {
+		t.Fatal(err)
+	}
+	receiver.secret = sub.Secret
+	if len(sub.Events) != 1 || len(sub.Secret) < 32 {
+		t.Fatalf("unexpected subscription %+v", sub)
+	}
+	// no delivery for other events or restaurants, or for paused webhooks
+	paused := &domain.WebhookSubscription{RestaurantID: "r1", URL: srv.URL, Events: []domain.WebhookEvent{domain.WebhookOCRCompleted}}
+	_ = uc.CreateSubscription(paused)
+	uc.Publish("r1", domain.WebhookMenuUpdated, nil)
+	uc.Publish("r2", domain.WebhookOCRCompleted, nil)
+	uc.Publish("r1", domain.WebhookOCRCompleted, map[string]string{"job_id": "j1"})
+
+	deliveries := repo.all()
+	if len(deliveries) != 1 || deliveries[0].SubscriptionID != sub.ID {
+		t.Fatalf("expected one delivery, got %+v", deliveries)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uc.StartDispatcher(ctx, domain.WebhookConfig{MaxAttempts: 5, Backoff: 10 * time.Millisecond, PollInterval: 5 * time.Millisecond})
+
+	d := waitForDelivery(t, repo, deliveries[0].ID)
+	if d.Status != domain.WebhookDelivered || len(d.Attempts) != 3 || d.Attempts[0].StatusCode != 500 || d.Attempts[2].StatusCode != 200 {
+		t.Fatalf("unexpected delivery %+v", d)
+	}
+	var payload domain.WebhookPayload
+	if err := json.Unmarshal(<-receiver.bodies, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.ID != d.EventID || payload.Event != domain.WebhookOCRCompleted || payload.RestaurantID != "r1" {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+}
+
+func TestWebhookGivesUpAndRedelivers(t *testing.T) {
+	repo := newMemWebhookRepo()
+	uc := usecase.NewWebhookUseCase(repo, services.NewWebhookSender(localReceivers), time.Second)
+	receiver := &flakyReceiver{t: t, fail: 2, bodies: make(chan []byte, 4)}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+	sub := &domain.WebhookSubscription{RestaurantID: "r1", URL: srv.URL, Events: []domain.WebhookEvent{domain.WebhookMenuPublished}, Active: true}
+	if err := uc.CreateSubscription(sub); err != nil {
+		t.Fatal(err)
+	}
+	receiver.secret = sub.Secret
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uc.StartDispatcher(ctx, domain.WebhookConfig{MaxAttempts: 2, Backoff: 5 * time.Millisecond, PollInterval: 5 * time.Millisecond})
+
+	uc.Publish("r1", domain.WebhookMenuPublished, map[string]string{"menu_id": "m1"})
+	failed := waitForDelivery(t, repo, repo.all()[0].ID)
+	if failed.Status != domain.WebhookFailed || len(failed.Attempts) != 2 || failed.Attempts[1].Error == "" {
+		t.Fatalf("expected failure after 2 attempts, got %+v", failed)
+	}
+
+	// once the receiver is back a manual redelivery gets through
+	again, err := uc.Redeliver(failed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again = waitForDelivery(t, repo, again.ID)
+	if again.Status != domain.WebhookDelivered || len(again.Attempts) != 1 || again.EventID != failed.EventID || again.Payload != failed.Payload || again.RedeliveryOf != failed.ID {
+		t.Fatalf("unexpected redelivery %+v", again)
+	}
+	if orig, _ := uc.GetDelivery(failed.ID); orig.Status != domain.WebhookFailed || len(orig.Attempts) != 2 {
+		t.Fatalf("redelivery changed the original log %+v", orig)
+	}
+
+	if err := uc.CreateSubscription(&domain.WebhookSubscription{RestaurantID: "r1", URL: "ftp://example.com", Events: []domain.WebhookEvent{domain.WebhookMenuPublished}}); err != domain.ErrInvalidWebhookURL {
+		t.Fatalf("expected invalid URL, got %v", err)
+	}
+	if err := uc.CreateSubscription(&domain.WebhookSubscription{RestaurantID: "r1", URL: srv.URL, Events: []domain.WebhookEvent{"menu.deleted"}}); err != domain.ErrInvalidInput {
+		t.Fatalf("expected unknown event rejected, got %v", err)
+	}
+}
+
+// recordingWebhooks keeps the events published to it.
+type recordingWebhooks struct {
+	mu     sync.Mutex
+	events []domain.WebhookEvent
+	data   []any
+}
+
+func (r *recordingWebhooks) Publish(restaurantID string, event domain.WebhookEvent, data any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	r.data = append(r.data, data)
+}
+
+func TestOCRJobRaisesWebhooks(t *testing.T) {
+	hooks := &recordingWebhooks{}
+	repo := newMemOCRJobRepo()
+	ocr := &services.FixtureOCRService{Texts: map[string]string{"lunch": "Doro Wat\n450 ETB"}}
+	uc := usecase.NewOCRJobUseCase(repo, newMemParseResultRepo(), ocr, nil, &echoAI{}, nil, nil, nil, nil, hooks, time.Second)
+	done := &domain.OCRJob{RestaurantID: "r1", ImageURL: "https://cdn.example.com/lunch.jpg"}
+	_ = uc.CreateOCRJob(done)
+	uc.ProcessJob(done.ID)
+
+	failing := usecase.NewOCRJobUseCase(repo, newMemParseResultRepo(), failingOCR{}, nil, &echoAI{}, nil, nil, nil, nil, hooks, time.Second)
+	failed := &domain.OCRJob{RestaurantID: "r1", ImageURL: "https://cdn.example.com/dinner.jpg"}
+	_ = failing.CreateOCRJob(failed)
+	failing.ProcessJob(failed.ID)
+
+	if len(hooks.events) != 2 || hooks.events[0] != domain.WebhookOCRCompleted || hooks.events[1] != domain.WebhookOCRFailed {
+		t.Fatalf("unexpected events %v", hooks.events)
+	}
+	body, _ := json.Marshal(hooks.data[0])
+	var data struct {
+		JobID         string `json:"job_id"`
+		ParseResultID string `json:"parse_result_id"`
+	}
+	_ = json.Unmarshal(body, &data)
+	if data.JobID != done.ID || data.ParseResultID == "" {
+		t.Fatalf("unexpected ocr.completed data %s", body)
+	}
+}
+
+// publishingMenuRepo adds publishing to storedMenuRepo.
+type publishingMenuRepo struct{ *storedMenuRepo }
+
+func (r publishingMenuRepo) Publish(ctx context.Context, id string, _ int, published *domain.PublishedMenu) (*domain.Menu, error) {
+	r.menu.Published, r.menu.IsPublished, r.menu.Version = published, true, published.Version
+	return r.GetByID(ctx, id)
+}
+
+func TestMenuChangesRaiseWebhooks(t *testing.T) {
+	hooks := &recordingWebhooks{}
+	menus := publishingMenuRepo{&storedMenuRepo{menu: mergeFixture()}}
+	uc := usecase.NewMenuUseCase(menus, nil, nil, nil, services.QRService{}, nil, nil, hooks, time.Second)
+	if err := uc.MergeMenuItems("m1", "u1", []domain.Item{{Name: "Firfir", Price: 150}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.PublishMenu("m1", "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks.events) != 2 || hooks.events[0] != domain.WebhookMenuUpdated || hooks.events[1] != domain.WebhookMenuPublished {
+		t.Fatalf("unexpected events %v", hooks.events)
+	}
+	body, _ := json.Marshal(hooks.data[1])
+	var data struct {
+		MenuID           string `json:"menu_id"`
+		PublishedVersion int    `json:"published_version"`
+		ChangedBy        string `json:"changed_by"`
+	}
+	_ = json.Unmarshal(body, &data)
+	// the merge is version 1, so publishing it makes version 2
+	if data.MenuID != "m1" || data.PublishedVersion != 2 || data.ChangedBy != "u1" {
+		t.Fatalf("unexpected menu.published data %s", body)
+	}
+}